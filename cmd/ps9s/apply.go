@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/manifest"
+)
+
+// runApply implements `ps9s apply MANIFEST [--plan] [--yes]`
+func runApply(args []string) int {
+	fs := flag.NewFlagSet("apply", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: ps9s apply MANIFEST [--profile P] [--region R] [--plan] [--yes] [--json-errors]\n")
+		fs.PrintDefaults()
+	}
+	common := addCommonFlags(fs)
+	planOnly := fs.Bool("plan", false, "print the plan and exit without writing")
+	yes := fs.Bool("yes", false, "apply without asking for confirmation")
+
+	path, err := parseWithPositional(fs, args)
+	if err != nil {
+		return exitUsage
+	}
+	if path == "" {
+		fs.Usage()
+		return exitUsage
+	}
+	if *planOnly && *yes {
+		return usageError(os.Stderr, "--plan and --yes cannot be combined", *common.jsonErrors)
+	}
+
+	m, err := manifest.Load(path)
+	if err != nil {
+		return usageError(os.Stderr, err.Error(), *common.jsonErrors)
+	}
+
+	ctx := context.Background()
+	client, err := newWriteClient(ctx, common, false)
+	if err != nil {
+		return reportError(os.Stderr, err, *common.jsonErrors)
+	}
+
+	changes, err := manifest.Plan(ctx, client, m)
+	if err != nil {
+		return reportError(os.Stderr, fmt.Errorf("failed to plan: %w", err), *common.jsonErrors)
+	}
+	pending := writePlan(os.Stdout, changes)
+	if pending == 0 || *planOnly {
+		return exitOK
+	}
+	if client.ReadOnly() {
+		return reportError(os.Stderr, fmt.Errorf("cannot apply %d changes: %w", pending, aws.ErrReadOnly), *common.jsonErrors)
+	}
+	if !*yes && !confirm(os.Stdin, os.Stdout, fmt.Sprintf("Apply %d changes to %s? Type yes to confirm: ", pending, *common.profile)) {
+		fmt.Fprintln(os.Stderr, "Apply cancelled")
+		return exitError
+	}
+
+	applied, err := manifest.Apply(ctx, client, changes)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Applied %d of %d changes\n", applied, pending)
+		return reportError(os.Stderr, err, *common.jsonErrors)
+	}
+	fmt.Printf("Applied %d changes\n", applied)
+	return exitOK
+}
+
+// writePlan prints one line per manifest parameter and a summary, and
+// returns the number of changes. Values are never printed.
+func writePlan(w io.Writer, changes []manifest.Change) int {
+	var create, update, unchanged int
+	for _, c := range changes {
+		p := c.Parameter
+		switch c.Action {
+		case manifest.ActionCreate:
+			create++
+			fmt.Fprintf(w, "+ %s (%s)\n", p.Name, p.Type)
+		case manifest.ActionUpdate, manifest.ActionTag:
+			update++
+			fmt.Fprintf(w, "~ %s (%s)\n", p.Name, strings.Join(c.Reasons, ", "))
+		default:
+			unchanged++
+		}
+	}
+	fmt.Fprintf(w, "\nPlan: %d to create, %d to update, %d unchanged\n", create, update, unchanged)
+	return create + update
+}
+
+// confirm asks prompt on w and reports whether the answer read from r is yes
+func confirm(r io.Reader, w io.Writer, prompt string) bool {
+	fmt.Fprint(w, prompt)
+	answer, _ := bufio.NewReader(r).ReadString('\n')
+	return strings.TrimSpace(answer) == "yes"
+}
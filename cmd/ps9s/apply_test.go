@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ilia/ps9s/internal/manifest"
+)
+
+func TestWritePlan(t *testing.T) {
+	changes := []manifest.Change{
+		{Parameter: manifest.Parameter{Name: "/a", Type: "SecureString", Value: "s3cret"}, Action: manifest.ActionCreate},
+		{Parameter: manifest.Parameter{Name: "/b", Type: "String", Value: "v"}, Action: manifest.ActionUpdate, Reasons: []string{"value", "tags"}},
+		{Parameter: manifest.Parameter{Name: "/c", Type: "String"}, Action: manifest.ActionUnchanged},
+	}
+	var buf bytes.Buffer
+	if n := writePlan(&buf, changes); n != 2 {
+		t.Fatalf("expected 2 changes, got %d", n)
+	}
+	out := buf.String()
+	if strings.Contains(out, "s3cret") {
+		t.Fatal("the plan must not print values")
+	}
+	for _, want := range []string{"+ /a (SecureString)", "~ /b (value, tags)", "Plan: 1 to create, 1 to update, 1 unchanged"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("plan is missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	var out bytes.Buffer
+	if !confirm(strings.NewReader("yes\n"), &out, "? ") {
+		t.Fatal("yes should confirm")
+	}
+	if confirm(strings.NewReader("y\n"), &out, "? ") || confirm(strings.NewReader(""), &out, "? ") {
+		t.Fatal("only yes should confirm")
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/export"
+)
+
+// runAudit implements `ps9s audit [--prefix PREFIX] [-o csv|json] [--file FILE]`
+func runAudit(args []string) int {
+	fs := flag.NewFlagSet("audit", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: ps9s audit [--profile P] [--region R] [--prefix PREFIX] [-o csv|json] [--file FILE] [--json-errors]\n")
+		fs.PrintDefaults()
+	}
+	common := addCommonFlags(fs)
+	prefix := fs.String("prefix", "", "only include parameters whose names begin with PREFIX")
+	output := fs.String("o", "csv", "output format: csv or json")
+	file := fs.String("file", "", "write to FILE instead of stdout")
+
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	format := export.Format(*output)
+	if format != export.FormatCSV && format != export.FormatJSON {
+		return usageError(os.Stderr, fmt.Sprintf("unsupported output format %q", *output), *common.jsonErrors)
+	}
+
+	ctx := context.Background()
+	client, err := aws.NewClientWithRegion(ctx, *common.profile, *common.region)
+	if err != nil {
+		return reportError(os.Stderr, err, *common.jsonErrors)
+	}
+	client.SetPathPrefix(*prefix)
+
+	records, err := auditRecords(ctx, client)
+	if err != nil {
+		return reportError(os.Stderr, err, *common.jsonErrors)
+	}
+
+	var w io.Writer = os.Stdout
+	if *file != "" {
+		f, err := os.Create(*file)
+		if err != nil {
+			return reportError(os.Stderr, fmt.Errorf("failed to create audit file: %w", err), *common.jsonErrors)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := export.WriteAudit(w, format, records); err != nil {
+		return reportError(os.Stderr, fmt.Errorf("failed to write audit: %w", err), *common.jsonErrors)
+	}
+	return exitOK
+}
+
+// auditRecords lists the SecureStrings of client's account with their tags
+func auditRecords(ctx context.Context, client *aws.Client) ([]export.AuditRecord, error) {
+	params, err := client.ListParameters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var records []export.AuditRecord
+	for _, p := range params {
+		// Shares are audited by the accounts owning them
+		if p.Type != "SecureString" || aws.IsShared(p.Name) {
+			continue
+		}
+		tags, err := client.ListTags(ctx, p.Name)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, export.AuditRecord{Parameter: p, Tags: tags})
+	}
+	return records, nil
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/config"
+)
+
+// doctorClient is what `ps9s doctor` checks on each profile's client
+type doctorClient interface {
+	CheckReachable(ctx context.Context) error
+	ResolveCredentials(ctx context.Context) (time.Time, error)
+	CallerIdentity(ctx context.Context) (aws.Identity, error)
+	CheckListAccess(ctx context.Context) error
+}
+
+// Checks of a profile, in the order they run; each needs the previous to pass
+const (
+	checkReachable = iota
+	checkCredentials
+	checkIdentity
+	checkList
+	checkCount
+)
+
+// errNoRegion fails a profile without a region to check
+var errNoRegion = errors.New("no region configured")
+
+// checkNames head the result table
+var checkNames = [checkCount]string{"REACHABLE", "CREDENTIALS", "IDENTITY", "SSM LIST"}
+
+// doctorCheck is the outcome of one check; ran is false when an earlier
+// check failed
+type doctorCheck struct {
+	ran    bool
+	err    error
+	detail string // Shown instead of "ok" on success
+}
+
+// doctorResult holds the checks of one profile
+type doctorResult struct {
+	profile string
+	region  string
+	checks  [checkCount]doctorCheck
+}
+
+// ok reports whether every check of the profile passed
+func (r doctorResult) ok() bool {
+	for _, c := range r.checks {
+		if !c.ran || c.err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// runDoctor implements `ps9s doctor [--profile P1,P2] [--region R]`
+func runDoctor(args []string) int {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: ps9s doctor [--profile P1,P2,...] [--region R] [--timeout D]\n")
+		fs.PrintDefaults()
+	}
+	profileFlag := fs.String("profile", "", "profiles to check (default: every profile ps9s shows)")
+	region := fs.String("region", "", "region to check (default: each profile's remembered or configured region)")
+	timeout := fs.Duration("timeout", 20*time.Second, "time allowed for the checks of each profile")
+
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	settings, err := config.LoadSettings()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to load settings: %v\n", err)
+		return exitError
+	}
+	var profiles []string
+	if *profileFlag != "" {
+		for _, p := range strings.Split(*profileFlag, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				profiles = append(profiles, p)
+			}
+		}
+	} else {
+		profiles, err = config.GetProfilesFromAWSConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitError
+		}
+		profiles = settings.ManagedProfiles(profiles)
+	}
+	if len(profiles) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no AWS profiles to check\n")
+		return exitError
+	}
+
+	mapping, err := config.LoadRegionMapping()
+	if err != nil {
+		mapping = &config.RegionMapping{ProfileRegions: make(map[string]string)}
+	}
+
+	results := make([]doctorResult, len(profiles))
+	var wg sync.WaitGroup
+	for i, profile := range profiles {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := profileRegion(profile, *region, mapping, settings)
+			ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+			defer cancel()
+			results[i] = diagnose(ctx, profile, r, func() (doctorClient, error) {
+				return aws.NewClientWithRegion(ctx, profile, r)
+			})
+		}()
+	}
+	wg.Wait()
+
+	if !writeDoctor(os.Stdout, results) {
+		return exitError
+	}
+	return exitOK
+}
+
+// profileRegion picks the region to check profile in: the flag, the region
+// ps9s remembers for it, its AWS config region, then default_region
+func profileRegion(profile, override string, mapping *config.RegionMapping, settings *config.Settings) string {
+	if override != "" {
+		return override
+	}
+	if r := mapping.ProfileRegions[profile]; r != "" {
+		return r
+	}
+	if r, _ := config.GetProfileRegion(profile); r != "" {
+		return r
+	}
+	return settings.DefaultRegion
+}
+
+// diagnose runs the checks of one profile, stopping at the first failure
+func diagnose(ctx context.Context, profile, region string, newClient func() (doctorClient, error)) doctorResult {
+	res := doctorResult{profile: profile, region: region}
+	if region == "" {
+		res.checks[checkReachable] = doctorCheck{ran: true, err: errNoRegion}
+		return res
+	}
+	client, err := newClient()
+	if err != nil {
+		// The profile's configuration could not be loaded
+		res.checks[checkCredentials] = doctorCheck{ran: true, err: err}
+		return res
+	}
+
+	steps := [checkCount]func() (string, error){
+		checkReachable: func() (string, error) {
+			return "", client.CheckReachable(ctx)
+		},
+		checkCredentials: func() (string, error) {
+			expires, err := client.ResolveCredentials(ctx)
+			if err != nil || expires.IsZero() {
+				return "", err
+			}
+			left := time.Until(expires).Round(time.Minute)
+			return "ok, expires in " + strings.TrimSuffix(left.String(), "0s"), nil
+		},
+		checkIdentity: func() (string, error) {
+			id, err := client.CallerIdentity(ctx)
+			return id.ARN, err
+		},
+		checkList: func() (string, error) {
+			return "", client.CheckListAccess(ctx)
+		},
+	}
+	for i, step := range steps {
+		detail, err := step()
+		res.checks[i] = doctorCheck{ran: true, err: err, detail: detail}
+		if err != nil {
+			break
+		}
+	}
+	return res
+}
+
+// writeDoctor prints a table of the results and the failures with hints,
+// and reports whether every check passed
+func writeDoctor(w io.Writer, results []doctorResult) bool {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "PROFILE\tREGION\t%s\n", strings.Join(checkNames[:], "\t"))
+	for _, r := range results {
+		cells := []string{r.profile, dash(r.region)}
+		for _, c := range r.checks {
+			switch {
+			case !c.ran:
+				cells = append(cells, "-")
+			case c.err != nil:
+				cells = append(cells, "FAIL")
+			case c.detail != "":
+				cells = append(cells, c.detail)
+			default:
+				cells = append(cells, "ok")
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	tw.Flush()
+
+	healthy := true
+	for _, r := range results {
+		if r.ok() {
+			continue
+		}
+		if healthy {
+			fmt.Fprintln(w)
+			healthy = false
+		}
+		for i, c := range r.checks {
+			if c.err == nil {
+				continue
+			}
+			fmt.Fprintf(w, "%s: %s: %v\n", r.profile, strings.ToLower(checkNames[i]), c.err)
+			if hint := doctorHint(i, r.profile, c.err); hint != "" {
+				fmt.Fprintf(w, "  hint: %s\n", hint)
+			}
+		}
+	}
+	return healthy
+}
+
+// doctorHint suggests a fix for the failure of check
+func doctorHint(check int, profile string, err error) string {
+	msg := strings.ToLower(err.Error())
+	switch {
+	case errors.Is(err, errNoRegion):
+		return "set region for the profile in ~/.aws/config, pass --region, or pick one in ps9s"
+	case strings.Contains(msg, "sso"):
+		return fmt.Sprintf("run `aws sso login --profile %s`", profile)
+	case aws.ErrorCode(err) == "ExpiredToken" || aws.ErrorCode(err) == "ExpiredTokenException":
+		return "the session token has expired; refresh the profile's credentials"
+	case check == checkReachable:
+		return "check the region name and that the network, proxy or VPN lets HTTPS through to AWS"
+	case check == checkCredentials:
+		return "check the profile's credential settings (source_profile, role_arn, credential_process, keys)"
+	case check == checkList && aws.IsAccessDenied(err):
+		return "the identity needs ssm:DescribeParameters; `ps9s iam-policy` prints a policy ps9s works with"
+	}
+	return ""
+}
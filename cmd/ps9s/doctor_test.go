@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go"
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+// fakeDoctorClient fails the list check with listErr
+type fakeDoctorClient struct {
+	listErr error
+}
+
+func (fakeDoctorClient) CheckReachable(context.Context) error { return nil }
+
+func (fakeDoctorClient) ResolveCredentials(context.Context) (time.Time, error) {
+	return time.Time{}, nil
+}
+
+func (fakeDoctorClient) CallerIdentity(context.Context) (aws.Identity, error) {
+	return aws.Identity{Account: "123456789012", ARN: "arn:aws:iam::123456789012:user/dev"}, nil
+}
+
+func (c fakeDoctorClient) CheckListAccess(context.Context) error { return c.listErr }
+
+func TestDiagnoseAndWriteDoctor(t *testing.T) {
+	ctx := context.Background()
+	denied := &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "not authorized to perform ssm:DescribeParameters"}
+	results := []doctorResult{
+		diagnose(ctx, "dev", "eu-west-1", func() (doctorClient, error) { return fakeDoctorClient{}, nil }),
+		diagnose(ctx, "prod", "us-east-1", func() (doctorClient, error) { return fakeDoctorClient{listErr: denied}, nil }),
+		diagnose(ctx, "broken", "eu-west-1", func() (doctorClient, error) { return nil, errors.New("failed to load AWS config") }),
+		diagnose(ctx, "noregion", "", nil),
+	}
+	if !results[0].ok() || results[1].ok() {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	var buf bytes.Buffer
+	if writeDoctor(&buf, results) {
+		t.Fatal("expected failures to be reported")
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"PROFILE", "SSM LIST",
+		"arn:aws:iam::123456789012:user/dev",
+		"prod: ssm list: ", "ps9s iam-policy",
+		"broken: credentials: failed to load AWS config",
+		"noregion: reachable: no region configured", "pass --region",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "dev: ") {
+		t.Fatalf("healthy profiles must not be listed as failures:\n%s", out)
+	}
+
+	buf.Reset()
+	if !writeDoctor(&buf, results[:1]) {
+		t.Fatalf("expected a healthy profile to pass:\n%s", buf.String())
+	}
+}
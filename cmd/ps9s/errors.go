@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+// Exit codes for non-interactive subcommands
+const (
+	exitOK           = 0
+	exitError        = 1 // Unclassified failure (network, throttling, ...)
+	exitUsage        = 2 // Bad flags or arguments
+	exitNotFound     = 3 // Parameter does not exist
+	exitAccessDenied = 4 // IAM/KMS denial or invalid credentials
+	exitValidation   = 5 // Request rejected by AWS as invalid
+)
+
+// commonFlags are shared by every subcommand
+type commonFlags struct {
+	profile    *string
+	region     *string
+	jsonErrors *bool
+}
+
+// addCommonFlags registers the shared flags on fs
+func addCommonFlags(fs *flag.FlagSet) commonFlags {
+	return commonFlags{
+		profile:    fs.String("profile", "default", "AWS profile (default honours AWS_PROFILE)"),
+		region:     fs.String("region", "", "AWS region (default from the profile)"),
+		jsonErrors: fs.Bool("json-errors", false, "print errors to stderr as JSON"),
+	}
+}
+
+// errorOutput is the JSON shape printed with --json-errors
+type errorOutput struct {
+	Error struct {
+		Code     string `json:"code"`
+		AWSCode  string `json:"aws_code,omitempty"`
+		Message  string `json:"message"`
+		ExitCode int    `json:"exit_code"`
+	} `json:"error"`
+}
+
+// classifyError maps err to an exit code and a stable machine-readable code
+func classifyError(err error) (int, string) {
+	switch {
+	case aws.IsValidation(err):
+		return exitValidation, "validation_error"
+	case aws.IsNotFound(err):
+		return exitNotFound, "not_found"
+	case aws.IsAccessDenied(err):
+		return exitAccessDenied, "access_denied"
+	}
+	return exitError, "error"
+}
+
+// reportError prints err to w (as JSON when requested) and returns the exit code
+func reportError(w io.Writer, err error, asJSON bool) int {
+	code, name := classifyError(err)
+	if !asJSON {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return code
+	}
+
+	var out errorOutput
+	out.Error.Code = name
+	out.Error.AWSCode = aws.ErrorCode(err)
+	out.Error.Message = err.Error()
+	out.Error.ExitCode = code
+	_ = json.NewEncoder(w).Encode(out)
+	return code
+}
+
+// usageError reports a bad invocation and returns exitUsage
+func usageError(w io.Writer, msg string, asJSON bool) int {
+	if !asJSON {
+		fmt.Fprintf(w, "Error: %s\n", msg)
+		return exitUsage
+	}
+	var out errorOutput
+	out.Error.Code = "usage"
+	out.Error.Message = msg
+	out.Error.ExitCode = exitUsage
+	_ = json.NewEncoder(w).Encode(out)
+	return exitUsage
+}
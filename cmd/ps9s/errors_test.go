@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{fmt.Errorf("failed: %w", &smithy.GenericAPIError{Code: "ParameterNotFound"}), exitNotFound},
+		{fmt.Errorf("failed: %w", &smithy.GenericAPIError{Code: "AccessDeniedException"}), exitAccessDenied},
+		{fmt.Errorf("failed: %w", &smithy.GenericAPIError{Code: "ValidationException"}), exitValidation},
+		{fmt.Errorf("connection reset"), exitError},
+	}
+	for _, tt := range tests {
+		if got, _ := classifyError(tt.err); got != tt.want {
+			t.Errorf("classifyError(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestReportError_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	err := fmt.Errorf("failed to get parameter /x: %w", &smithy.GenericAPIError{Code: "ParameterNotFound", Message: "nope"})
+
+	code := reportError(&buf, err, true)
+	if code != exitNotFound {
+		t.Fatalf("exit code = %d, want %d", code, exitNotFound)
+	}
+
+	var out errorOutput
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	if out.Error.Code != "not_found" || out.Error.AWSCode != "ParameterNotFound" || out.Error.ExitCode != exitNotFound {
+		t.Fatalf("unexpected error JSON: %+v", out.Error)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+// parameterOutput is the JSON shape printed by `get -o json`
+type parameterOutput struct {
+	Name             string    `json:"name"`
+	Type             string    `json:"type"`
+	Value            string    `json:"value"`
+	Version          int64     `json:"version"`
+	LastModifiedDate time.Time `json:"last_modified_date"`
+	ARN              string    `json:"arn,omitempty"`
+	DataType         string    `json:"data_type,omitempty"`
+}
+
+// runGet implements `ps9s get NAME [--raw | -o json]`
+func runGet(args []string) int {
+	fs := flag.NewFlagSet("get", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: ps9s get NAME [--profile P] [--region R] [--raw | -o json] [--json-errors]\n")
+		fs.PrintDefaults()
+	}
+	common := addCommonFlags(fs)
+	raw := fs.Bool("raw", false, "print only the decrypted value, with no trailing newline")
+	output := fs.String("o", "", "output format: json")
+
+	name, err := parseWithPositional(fs, args)
+	if err != nil {
+		return exitUsage
+	}
+	if name == "" {
+		fs.Usage()
+		return exitUsage
+	}
+	if *raw && *output != "" {
+		return usageError(os.Stderr, "--raw and -o cannot be combined", *common.jsonErrors)
+	}
+	if *output != "" && *output != "json" {
+		return usageError(os.Stderr, fmt.Sprintf("unsupported output format %q", *output), *common.jsonErrors)
+	}
+
+	ctx := context.Background()
+	client, err := aws.NewClientWithRegion(ctx, *common.profile, *common.region)
+	if err != nil {
+		return reportError(os.Stderr, err, *common.jsonErrors)
+	}
+
+	param, err := client.GetParameter(ctx, name)
+	if err != nil {
+		return reportError(os.Stderr, err, *common.jsonErrors)
+	}
+
+	format := "text"
+	if *raw {
+		format = "raw"
+	} else if *output != "" {
+		format = *output
+	}
+	if err := writeParameter(os.Stdout, param, format); err != nil {
+		return reportError(os.Stderr, err, *common.jsonErrors)
+	}
+	return exitOK
+}
+
+// parseWithPositional parses flags that may appear before or after a single
+// positional argument (`get NAME --raw` as well as `get --raw NAME`)
+func parseWithPositional(fs *flag.FlagSet, args []string) (string, error) {
+	if err := fs.Parse(args); err != nil {
+		return "", err
+	}
+	if fs.NArg() == 0 {
+		return "", nil
+	}
+	positional := fs.Arg(0)
+	if err := fs.Parse(fs.Args()[1:]); err != nil {
+		return "", err
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(fs.Output(), "unexpected argument %q\n", fs.Arg(0))
+		return "", fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+	return positional, nil
+}
+
+// writeParameter prints param in the given format: raw, json or text
+func writeParameter(w io.Writer, param *aws.Parameter, format string) error {
+	switch format {
+	case "raw":
+		_, err := io.WriteString(w, param.Value)
+		return err
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(parameterOutput{
+			Name:             param.Name,
+			Type:             param.Type,
+			Value:            param.Value,
+			Version:          param.Version,
+			LastModifiedDate: param.LastModifiedDate,
+			ARN:              param.ARN,
+			DataType:         param.DataType,
+		})
+	default:
+		_, err := fmt.Fprintf(w, "Name:     %s\nType:     %s\nVersion:  %d\nModified: %s\n\n%s\n",
+			param.Name, param.Type, param.Version,
+			param.LastModifiedDate.Local().Format("2006-01-02 15:04:05"), param.Value)
+		return err
+	}
+}
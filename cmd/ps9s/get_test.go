@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+func TestWriteParameter_Raw(t *testing.T) {
+	var buf bytes.Buffer
+	p := &aws.Parameter{Name: "/app/db", Type: "SecureString", Value: "s3cret"}
+	if err := writeParameter(&buf, p, "raw"); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "s3cret" {
+		t.Fatalf("raw output should be the bare value, got %q", buf.String())
+	}
+}
+
+func TestWriteParameter_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	p := &aws.Parameter{Name: "/app/db", Type: "String", Value: "v", Version: 4}
+	if err := writeParameter(&buf, p, "json"); err != nil {
+		t.Fatal(err)
+	}
+	var out parameterOutput
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if out.Name != "/app/db" || out.Value != "v" || out.Version != 4 {
+		t.Fatalf("unexpected JSON output: %+v", out)
+	}
+}
+
+func TestParseWithPositional_FlagsAfterName(t *testing.T) {
+	fs := flag.NewFlagSet("get", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	raw := fs.Bool("raw", false, "")
+
+	name, err := parseWithPositional(fs, []string{"/app/db", "--raw"})
+	if err != nil || name != "/app/db" || !*raw {
+		t.Fatalf("got name=%q raw=%v err=%v", name, *raw, err)
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+// listOutput is the JSON shape of each parameter printed by `list -o json`.
+// Values are never included.
+type listOutput struct {
+	Profile          string    `json:"profile"`
+	Region           string    `json:"region,omitempty"`
+	Name             string    `json:"name"`
+	Type             string    `json:"type"`
+	Version          int64     `json:"version"`
+	Tier             string    `json:"tier,omitempty"`
+	DataType         string    `json:"data_type,omitempty"`
+	KMSKeyID         string    `json:"kms_key_id,omitempty"`
+	Description      string    `json:"description,omitempty"`
+	ARN              string    `json:"arn,omitempty"`
+	LastModifiedDate time.Time `json:"last_modified_date"`
+	LastModifiedUser string    `json:"last_modified_user,omitempty"`
+	Shared           bool      `json:"shared,omitempty"`
+}
+
+// listedParameter is a parameter with the profile and region it was listed from
+type listedParameter struct {
+	profile string
+	region  string
+	param   *aws.Parameter
+}
+
+// runList implements `ps9s list [--profile P1,P2] [--prefix PREFIX] [-o json|table|names]`
+func runList(args []string) int {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: ps9s list [--profile P1,P2,...] [--region R] [--prefix PREFIX] [-o json|table|names] [--json-errors]\n")
+		fs.PrintDefaults()
+	}
+	common := addCommonFlags(fs)
+	prefix := fs.String("prefix", "", "only include parameters whose names begin with PREFIX")
+	output := fs.String("o", "table", "output format: json, table or names")
+
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	switch *output {
+	case "json", "table", "names":
+	default:
+		return usageError(os.Stderr, fmt.Sprintf("unsupported output format %q", *output), *common.jsonErrors)
+	}
+
+	var profiles []string
+	for _, p := range strings.Split(*common.profile, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			profiles = append(profiles, p)
+		}
+	}
+	if len(profiles) == 0 {
+		return usageError(os.Stderr, "--profile must name at least one profile", *common.jsonErrors)
+	}
+
+	ctx := context.Background()
+	var listed []listedParameter
+	for _, profile := range profiles {
+		client, err := aws.NewClientWithRegion(ctx, profile, *common.region)
+		if err != nil {
+			return reportError(os.Stderr, err, *common.jsonErrors)
+		}
+		client.SetPathPrefix(*prefix)
+
+		params, err := client.ListParameters(ctx)
+		if err != nil {
+			return reportError(os.Stderr, fmt.Errorf("failed to list parameters for profile %s: %w", profile, err), *common.jsonErrors)
+		}
+		for _, p := range params {
+			listed = append(listed, listedParameter{profile: profile, region: client.Region(), param: p})
+		}
+	}
+
+	if err := writeList(os.Stdout, listed, *output, len(profiles) > 1); err != nil {
+		return reportError(os.Stderr, err, *common.jsonErrors)
+	}
+	return exitOK
+}
+
+// writeList prints listed in format. With several profiles, names output is
+// prefixed with the profile ("prod:/app/db") so the lines stay unambiguous.
+func writeList(w io.Writer, listed []listedParameter, format string, multiProfile bool) error {
+	switch format {
+	case "json":
+		out := make([]listOutput, len(listed))
+		for i, l := range listed {
+			p := l.param
+			out[i] = listOutput{
+				Profile:          l.profile,
+				Region:           l.region,
+				Name:             p.Name,
+				Type:             p.Type,
+				Version:          p.Version,
+				Tier:             p.Tier,
+				DataType:         p.DataType,
+				KMSKeyID:         p.KeyID,
+				Description:      p.Description,
+				ARN:              p.ARN,
+				LastModifiedDate: p.LastModifiedDate,
+				LastModifiedUser: p.LastModifiedUser,
+				Shared:           aws.IsShared(p.Name),
+			}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+
+	case "names":
+		for _, l := range listed {
+			name := l.param.Name
+			if multiProfile {
+				name = l.profile + ":" + name
+			}
+			if _, err := fmt.Fprintln(w, name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if multiProfile {
+		fmt.Fprint(tw, "PROFILE\t")
+	}
+	fmt.Fprintln(tw, "NAME\tTYPE\tVERSION\tTIER\tLAST MODIFIED\tUSER")
+	for _, l := range listed {
+		p := l.param
+		if multiProfile {
+			fmt.Fprintf(tw, "%s\t", l.profile)
+		}
+		modified := "-"
+		if !p.LastModifiedDate.IsZero() {
+			modified = p.LastModifiedDate.Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\n", p.Name, p.Type, p.Version, dash(p.Tier), modified, dash(p.LastModifiedUser))
+	}
+	return tw.Flush()
+}
+
+// dash returns s, or "-" when it is empty, for table cells
+func dash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
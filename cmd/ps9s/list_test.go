@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+func testListed() []listedParameter {
+	return []listedParameter{
+		{profile: "dev", param: &aws.Parameter{Name: "/app/db", Type: "SecureString", Value: "s3cret", Version: 3, KeyID: "alias/aws/ssm", Tier: "Standard"}},
+		{profile: "prod", param: &aws.Parameter{Name: "/app/host", Type: "String", Value: "db.internal", Version: 1}},
+	}
+}
+
+func TestWriteList_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeList(&buf, testListed(), "json", true); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "s3cret") {
+		t.Fatal("list output must not include values")
+	}
+	var out []listOutput
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(out) != 2 || out[0].Profile != "dev" || out[0].KMSKeyID != "alias/aws/ssm" || out[0].Version != 3 || out[1].Profile != "prod" {
+		t.Fatalf("unexpected JSON output: %+v", out)
+	}
+}
+
+func TestWriteList_Names(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeList(&buf, testListed(), "names", true); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "dev:/app/db\nprod:/app/host\n" {
+		t.Fatalf("unexpected names output: %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := writeList(&buf, testListed()[:1], "names", false); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "/app/db\n" {
+		t.Fatalf("single profile names should not be prefixed, got %q", buf.String())
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -8,20 +9,69 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/ilia/ps9s/internal/aws"
 	"github.com/ilia/ps9s/internal/config"
+	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/terraform"
 	"github.com/ilia/ps9s/internal/ui"
 )
 
 func main() {
+	// Non-interactive subcommands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "get":
+			os.Exit(runGet(os.Args[2:]))
+		case "iam-policy":
+			os.Exit(runIAMPolicy(os.Args[2:]))
+		case "audit":
+			os.Exit(runAudit(os.Args[2:]))
+		case "list":
+			os.Exit(runList(os.Args[2:]))
+		case "put":
+			os.Exit(runPut(os.Args[2:]))
+		case "apply":
+			os.Exit(runApply(os.Args[2:]))
+		case "doctor":
+			os.Exit(runDoctor(os.Args[2:]))
+		}
+	}
+
 	debug := flag.Bool("debug", false, "enable debug logging to file")
+	dryRun := flag.Bool("dry-run", false, "preview writes instead of sending them to AWS")
+	last := flag.Bool("last", false, "open the most recent profile/region, skipping the selectors")
+	ascii := flag.Bool("ascii", false, "draw markers, borders and spinners with plain ASCII")
+	demo := flag.Bool("demo", false, "use built-in sample parameters instead of AWS (no credentials needed)")
+	noAltScreen := flag.Bool("no-alt-screen", false, "draw in the normal screen, so the last view stays in the scrollback after exit")
+	noMouse := flag.Bool("no-mouse", false, "leave the mouse to the terminal for text selection (ctrl+x toggles)")
+	setup := flag.Bool("setup", false, "run the setup wizard that picks profiles and default regions")
+	pprofPort := flag.Int("pprof", 0, "serve net/http/pprof and message counts on this localhost port")
+	flag.Usage = usage
 	flag.Parse()
 
+	if *pprofPort != 0 {
+		servePprof(*pprofPort)
+	}
+
 	if *debug {
 		ui.EnableDebugLogging()
 	}
 
-	profiles, err := config.GetProfilesFromAWSConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	var profiles []string
+	var err error
+	if *demo {
+		// Keep demo sessions away from the real settings and recents
+		dir, err := os.MkdirTemp("", "ps9s-demo")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer os.RemoveAll(dir)
+		os.Setenv("XDG_CONFIG_HOME", dir)
+		profiles = aws.DemoProfiles
+	} else {
+		profiles, err = config.GetProfilesFromAWSConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
 	}
 	if len(profiles) == 0 {
 		fmt.Fprintf(os.Stderr, "Error: no AWS profiles available\n")
@@ -29,6 +79,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Offer the setup wizard on first run, when there is a terminal to run it in
+	if !*demo && (*setup || (config.FirstRun() && isTerminal(os.Stdin))) {
+		if err := ui.RunSetup(profiles); errors.Is(err, ui.ErrSetupCancelled) {
+			os.Exit(0)
+		} else if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: setup failed: %v\n", err)
+		}
+	}
+
 	// Load region mapping from config
 	regionMapping, err := config.LoadRegionMapping()
 	if err != nil {
@@ -39,16 +98,61 @@ func main() {
 		}
 	}
 
+	// Load user settings from config; defaults could silently drop read_only,
+	// so invalid settings stop ps9s instead
+	settings, err := config.LoadSettings()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to load settings: %v\n", err)
+		os.Exit(1)
+	}
+	if err := styles.ApplyTheme(settings.Theme); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if *ascii || settings.ASCII {
+		styles.SetASCII(true)
+	}
+	styles.SetReduceMotion(settings.ReduceMotion)
+	profiles = settings.ManagedProfiles(profiles)
+
 	// Initialize root model with empty client pool
 	// Clients will be created after region selection
 	clientPool := make(map[string]*aws.Client)
 	model := ui.NewModel(profiles, clientPool, regionMapping)
+	model.SetDemo(*demo)
+	model.SetDryRun(*dryRun)
+	model.SetMouse(!*noMouse)
+	model.ApplySettings(settings)
+	if len(settings.TerraformState) > 0 {
+		resources, err := terraform.Load(settings.TerraformState)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		model.SetTerraform(resources)
+	}
+	if *last || settings.OpenLast {
+		if !model.OpenLastContext() {
+			fmt.Fprintf(os.Stderr, "Warning: no recent profile/region to open\n")
+		}
+	}
 
-	// Start Bubble Tea program with alt screen
-	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	// Start Bubble Tea program, by default with alt screen
+	var opts []tea.ProgramOption
+	if !*noAltScreen {
+		opts = append(opts, tea.WithAltScreen())
+	}
+	if !*noMouse {
+		opts = append(opts, tea.WithMouseCellMotion())
+	}
+	p := tea.NewProgram(model, opts...)
 
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// isTerminal reports whether f is an interactive terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
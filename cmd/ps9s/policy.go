@@ -0,0 +1,30 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+// runIAMPolicy implements `ps9s iam-policy [--write] [--prefix PREFIX]`
+func runIAMPolicy(args []string) int {
+	fs := flag.NewFlagSet("iam-policy", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: ps9s iam-policy [--write] [--prefix PREFIX]\n")
+		fs.PrintDefaults()
+	}
+	write := fs.Bool("write", false, "include the permissions to create and edit parameters and tags")
+	prefix := fs.String("prefix", "", "only allow parameters whose names begin with PREFIX, e.g. /app/")
+
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	fmt.Println(aws.IAMPolicy(*write, *prefix).JSON())
+	return exitOK
+}
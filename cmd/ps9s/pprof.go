@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	_ "net/http/pprof" // Registers the /debug/pprof handlers
+	"os"
+
+	"github.com/ilia/ps9s/internal/ui"
+)
+
+// hiddenFlags are left out of the usage message; they are meant for
+// contributors rather than users
+var hiddenFlags = map[string]bool{"pprof": true}
+
+// usage prints the flags except the hidden ones
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage of %s:\n", os.Args[0])
+	visible := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	flag.VisitAll(func(f *flag.Flag) {
+		if !hiddenFlags[f.Name] {
+			visible.Var(f.Value, f.Name, f.Usage)
+		}
+	})
+	visible.SetOutput(out)
+	visible.PrintDefaults()
+}
+
+// servePprof serves net/http/pprof and the message counts (/debug/vars) on
+// localhost:port for profiling rendering and memory on large accounts
+func servePprof(port int) {
+	ui.EnableMessageCounts()
+	addr := fmt.Sprintf("localhost:%d", port)
+	go func() {
+		if err := http.ListenAndServe(addr, nil); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: pprof server stopped: %v\n", err)
+		}
+	}()
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/config"
+)
+
+// runPut implements `ps9s put NAME [--type T] [--key-id K] [--value @FILE] [--no-overwrite]`.
+// The value comes from stdin or a file, never from the command line, so it
+// stays out of shell history and process listings.
+func runPut(args []string) int {
+	fs := flag.NewFlagSet("put", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: ps9s put NAME [--profile P] [--region R] [--type String|StringList|SecureString] [--key-id K] [--value @FILE] [--no-overwrite] [--dry-run] [--json-errors] < value\n")
+		fs.PrintDefaults()
+	}
+	common := addCommonFlags(fs)
+	paramType := fs.String("type", "String", "parameter type: String, StringList or SecureString")
+	keyID := fs.String("key-id", "", "KMS key ID, ARN or alias for SecureString (default alias/aws/ssm)")
+	source := fs.String("value", "-", "where to read the value: - for stdin or @FILE for a file")
+	noOverwrite := fs.Bool("no-overwrite", false, "fail if the parameter already exists")
+	dryRun := fs.Bool("dry-run", false, "print the write instead of sending it to AWS")
+
+	name, err := parseWithPositional(fs, args)
+	if err != nil {
+		return exitUsage
+	}
+	if name == "" {
+		fs.Usage()
+		return exitUsage
+	}
+	switch *paramType {
+	case "String", "StringList", "SecureString":
+	default:
+		return usageError(os.Stderr, fmt.Sprintf("unsupported parameter type %q", *paramType), *common.jsonErrors)
+	}
+	if *keyID != "" && *paramType != "SecureString" {
+		return usageError(os.Stderr, "--key-id only applies to SecureString parameters", *common.jsonErrors)
+	}
+
+	if *source == "-" && isTerminal(os.Stdin) {
+		fmt.Fprintln(os.Stderr, "Reading the value from stdin, end with ctrl+d")
+	}
+	value, err := readValue(*source, os.Stdin)
+	if err != nil {
+		return usageError(os.Stderr, err.Error(), *common.jsonErrors)
+	}
+
+	ctx := context.Background()
+	client, err := newWriteClient(ctx, common, *dryRun)
+	if err != nil {
+		return reportError(os.Stderr, err, *common.jsonErrors)
+	}
+
+	switch {
+	case *noOverwrite:
+		err = client.CreateParameter(ctx, name, value, *paramType, *keyID, "", nil)
+	case *paramType == "SecureString":
+		err = client.PutSecureParameter(ctx, name, value, *keyID)
+	default:
+		err = client.PutParameter(ctx, name, value, *paramType)
+	}
+	var dryRunErr *aws.DryRunError
+	if errors.As(err, &dryRunErr) {
+		fmt.Fprintf(os.Stderr, "Dry run: %s %s (%s) was not sent\n", dryRunErr.Request.Operation, name, *paramType)
+		return exitOK
+	}
+	if err != nil {
+		return reportError(os.Stderr, err, *common.jsonErrors)
+	}
+	return exitOK
+}
+
+// newWriteClient creates the client of a subcommand that writes, honouring
+// read_only and PS9S_READONLY from the settings
+func newWriteClient(ctx context.Context, common commonFlags, dryRun bool) (*aws.Client, error) {
+	settings, err := config.LoadSettings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load settings: %w", err)
+	}
+	client, err := aws.NewClientWithRegion(ctx, *common.profile, *common.region)
+	if err != nil {
+		return nil, err
+	}
+	client.SetReadOnly(settings.ReadOnly)
+	client.SetDryRun(dryRun)
+	return client, nil
+}
+
+// readValue reads a parameter value from stdin ("-") or a file ("@FILE").
+// One trailing newline is dropped, as `echo` and most editors add it.
+func readValue(source string, stdin io.Reader) (string, error) {
+	var data []byte
+	var err error
+	switch {
+	case source == "-":
+		if data, err = io.ReadAll(stdin); err != nil {
+			return "", fmt.Errorf("failed to read value from stdin: %w", err)
+		}
+	case strings.HasPrefix(source, "@") && len(source) > 1:
+		if data, err = os.ReadFile(source[1:]); err != nil {
+			return "", fmt.Errorf("failed to read value file: %w", err)
+		}
+	default:
+		return "", fmt.Errorf("--value must be - or @FILE, so values stay out of shell history")
+	}
+
+	value := string(data)
+	if strings.HasSuffix(value, "\n") {
+		value = strings.TrimSuffix(strings.TrimSuffix(value, "\n"), "\r")
+	}
+	if value == "" {
+		return "", fmt.Errorf("the value is empty")
+	}
+	return value, nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+func TestReadValue(t *testing.T) {
+	v, err := readValue("-", strings.NewReader("s3cret\n"))
+	if err != nil || v != "s3cret" {
+		t.Fatalf("stdin: got %q, %v", v, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "value")
+	if err := os.WriteFile(path, []byte("line 1\nline 2\r\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	v, err = readValue("@"+path, nil)
+	if err != nil || v != "line 1\nline 2" {
+		t.Fatalf("file: got %q, %v", v, err)
+	}
+
+	if _, err := readValue("s3cret", nil); err == nil {
+		t.Fatal("a literal value should be rejected")
+	}
+	if _, err := readValue("-", strings.NewReader("\n")); err == nil {
+		t.Fatal("an empty value should be rejected")
+	}
+}
+
+func TestNewWriteClient_ReadOnly(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("PS9S_READONLY", "1")
+
+	fs := flag.NewFlagSet("put", flag.ContinueOnError)
+	common := addCommonFlags(fs)
+	if err := fs.Parse([]string{"--region", "eu-west-1"}); err != nil {
+		t.Fatal(err)
+	}
+	client, err := newWriteClient(context.Background(), common, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.PutParameter(context.Background(), "/app/x", "v", "String"); !errors.Is(err, aws.ErrReadOnly) {
+		t.Fatalf("expected PS9S_READONLY to refuse the write, got %v", err)
+	}
+
+	t.Setenv("PS9S_READONLY", "maybe")
+	if _, err := newWriteClient(context.Background(), common, false); err == nil {
+		t.Fatal("invalid settings should fail instead of allowing writes")
+	}
+}
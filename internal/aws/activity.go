@@ -0,0 +1,213 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// APIActivity is a snapshot of SSM calls made by all clients
+type APIActivity struct {
+	InFlight  int64
+	Completed int64
+	Retries   int64
+	Retrying  *RetryStatus // Latest call being retried, nil when none
+}
+
+// RetryStatus describes a call that failed with a retryable error
+type RetryStatus struct {
+	Attempt     int       // Attempt being made, starting at 2
+	MaxAttempts int       // Attempts allowed before the call fails
+	Until       time.Time // When the attempt is sent after the backoff
+}
+
+// activity counts calls across every client so the UI can show one indicator
+var activity struct {
+	inFlight  atomic.Int64
+	completed atomic.Int64
+	retries   atomic.Int64
+	retrying  atomic.Pointer[RetryStatus]
+}
+
+// callStarted receives when a call starts, holding at most one signal so
+// calls never wait for the UI
+var callStarted = make(chan struct{}, 1)
+
+// CallStarted returns a channel that receives when an API call starts, so the
+// UI only refreshes its indicator while calls are running
+func CallStarted() <-chan struct{} {
+	return callStarted
+}
+
+// Activity returns the current API call counters
+func Activity() APIActivity {
+	return APIActivity{
+		InFlight:  activity.inFlight.Load(),
+		Completed: activity.completed.Load(),
+		Retries:   activity.retries.Load(),
+		Retrying:  activity.retrying.Load(),
+	}
+}
+
+// callLogSize is the number of recent calls kept for the API log
+const callLogSize = 200
+
+// APICall is a finished AWS API call, as shown in the API log
+type APICall struct {
+	Service   string // e.g. "SSM", "appconfig"
+	Operation string // e.g. "GetParameter"
+	Region    string
+	Start     time.Time
+	Duration  time.Duration
+	Attempts  int
+	Status    string // "OK", or the error code of a failed call
+	Err       error
+}
+
+// callLog keeps the most recent calls of every client in a ring buffer
+var callLog struct {
+	sync.Mutex
+	calls [callLogSize]APICall
+	next  int
+	count int
+}
+
+// logCall adds a finished call to the API log
+func logCall(call APICall) {
+	call.Status = "OK"
+	if call.Err != nil {
+		call.Status = "error"
+		var apiErr smithy.APIError
+		if errors.As(call.Err, &apiErr) {
+			call.Status = apiErr.ErrorCode()
+		} else if errors.Is(call.Err, context.Canceled) {
+			call.Status = "canceled"
+		}
+	}
+
+	callLog.Lock()
+	defer callLog.Unlock()
+	callLog.calls[callLog.next] = call
+	callLog.next = (callLog.next + 1) % callLogSize
+	callLog.count = min(callLog.count+1, callLogSize)
+}
+
+// RecentCalls returns up to n of the most recent API calls, newest first
+func RecentCalls(n int) []APICall {
+	callLog.Lock()
+	defer callLog.Unlock()
+	n = min(n, callLog.count)
+	calls := make([]APICall, n)
+	for i := range calls {
+		calls[i] = callLog.calls[(callLog.next-1-i+callLogSize)%callLogSize]
+	}
+	return calls
+}
+
+// operationState follows one operation across its attempts
+type operationState struct {
+	attempts int
+	retry    *RetryStatus // Status published for this operation's latest retry
+}
+
+// operationKey stores the operationState in the request context
+type operationKey struct{}
+
+// trackActivity registers middleware that counts operations and their retry attempts
+func trackActivity(stack *middleware.Stack) error {
+	err := stack.Initialize.Add(middleware.InitializeMiddlewareFunc("ps9sTrackActivity",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			activity.inFlight.Add(1)
+			select {
+			case callStarted <- struct{}{}:
+			default:
+			}
+			op := &operationState{}
+			start := time.Now()
+			defer func() {
+				activity.inFlight.Add(-1)
+				activity.completed.Add(1)
+				// Leave the status alone if another call has started retrying since
+				if op.retry != nil {
+					activity.retrying.CompareAndSwap(op.retry, nil)
+				}
+			}()
+			ctx = context.WithValue(ctx, operationKey{}, op)
+			out, metadata, err := next.HandleInitialize(ctx, in)
+			logCall(APICall{
+				Service:   awsmiddleware.GetServiceID(ctx),
+				Operation: awsmiddleware.GetOperationName(ctx),
+				Region:    awsmiddleware.GetRegion(ctx),
+				Start:     start,
+				Duration:  time.Since(start),
+				Attempts:  max(op.attempts, 1),
+				Err:       err,
+			})
+			return out, metadata, err
+		}), middleware.Before)
+	if err != nil {
+		return err
+	}
+
+	// Runs once per attempt because it sits inside the retry loop
+	return stack.Finalize.Insert(middleware.FinalizeMiddlewareFunc("ps9sCountRetries",
+		func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			if op, ok := ctx.Value(operationKey{}).(*operationState); ok {
+				if op.attempts > 0 {
+					activity.retries.Add(1)
+					// The retryer published the status just before the backoff
+					op.retry = activity.retrying.Load()
+				}
+				op.attempts++
+			}
+			return next.HandleFinalize(ctx, in)
+		}), "Retry", middleware.After)
+}
+
+// retryTracker wraps the SDK retryer to publish the backoff of each retry, so
+// the UI can show that a throttled call is waiting rather than hung
+type retryTracker struct {
+	aws.Retryer
+	maxAttempts int // Overrides the retryer's limit when set, as RetryMaxAttempts does
+}
+
+// trackRetries wraps r; maxAttempts is the client's RetryMaxAttempts option
+func trackRetries(r aws.Retryer, maxAttempts int) aws.Retryer {
+	if r == nil {
+		return nil
+	}
+	return retryTracker{Retryer: r, maxAttempts: maxAttempts}
+}
+
+// GetAttemptToken keeps the wrapped retryer's rate limiting
+func (r retryTracker) GetAttemptToken(ctx context.Context) (func(error) error, error) {
+	if v2, ok := r.Retryer.(aws.RetryerV2); ok {
+		return v2.GetAttemptToken(ctx)
+	}
+	return r.Retryer.GetInitialToken(), nil
+}
+
+// RetryDelay publishes the upcoming attempt and its backoff
+func (r retryTracker) RetryDelay(attempt int, opErr error) (time.Duration, error) {
+	delay, err := r.Retryer.RetryDelay(attempt, opErr)
+	if err != nil {
+		return delay, err
+	}
+	maxAttempts := r.maxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = r.Retryer.MaxAttempts()
+	}
+	activity.retrying.Store(&RetryStatus{
+		Attempt:     attempt + 1,
+		MaxAttempts: maxAttempts,
+		Until:       time.Now().Add(delay),
+	})
+	return delay, nil
+}
@@ -0,0 +1,101 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go"
+)
+
+func TestRetryTracker_PublishesBackoff(t *testing.T) {
+	defer activity.retrying.Store(nil)
+
+	standard := retry.NewStandard(func(o *retry.StandardOptions) {
+		o.MaxAttempts = 5
+		o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) {
+			return 4 * time.Second, nil
+		})
+	})
+	r := trackRetries(standard, 0)
+
+	before := time.Now()
+	delay, err := r.RetryDelay(1, errors.New("throttled"))
+	if err != nil || delay != 4*time.Second {
+		t.Fatalf("expected the wrapped 4s delay, got %v, %v", delay, err)
+	}
+
+	s := Activity().Retrying
+	if s == nil {
+		t.Fatal("expected a retry status")
+	}
+	if s.Attempt != 2 || s.MaxAttempts != 5 {
+		t.Fatalf("expected attempt 2/5, got %d/%d", s.Attempt, s.MaxAttempts)
+	}
+	if s.Until.Before(before.Add(4 * time.Second)) {
+		t.Fatalf("expected the retry to wait 4s, until %v", s.Until)
+	}
+
+	// RetryMaxAttempts overrides the retryer's own limit
+	if _, err := trackRetries(standard, 3).RetryDelay(2, errors.New("throttled")); err != nil {
+		t.Fatal(err)
+	}
+	if s := Activity().Retrying; s.Attempt != 3 || s.MaxAttempts != 3 {
+		t.Fatalf("expected attempt 3/3, got %d/%d", s.Attempt, s.MaxAttempts)
+	}
+}
+
+func TestRecentCalls(t *testing.T) {
+	for i := range callLogSize + 5 {
+		logCall(APICall{Operation: "GetParameter", Attempts: i})
+	}
+	logCall(APICall{Operation: "PutParameter", Err: &smithy.GenericAPIError{Code: "AccessDeniedException"}})
+
+	calls := RecentCalls(3)
+	if len(calls) != 3 {
+		t.Fatalf("expected 3 calls, got %d", len(calls))
+	}
+	if calls[0].Operation != "PutParameter" || calls[0].Status != "AccessDeniedException" {
+		t.Errorf("expected the failed PutParameter first, got %s %s", calls[0].Operation, calls[0].Status)
+	}
+	if calls[1].Status != "OK" || calls[1].Attempts != callLogSize+4 || calls[2].Attempts != callLogSize+3 {
+		t.Errorf("expected the latest GetParameter calls newest first, got %+v", calls[1:])
+	}
+	if n := len(RecentCalls(callLogSize * 2)); n != callLogSize {
+		t.Errorf("expected the log to keep %d calls, got %d", callLogSize, n)
+	}
+}
+
+func TestCallStarted_SignalsEachCall(t *testing.T) {
+	isolateAWSConfig(t)
+	t.Setenv("AWS_MAX_ATTEMPTS", "1")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		w.Write([]byte(`{"Parameter":{"Name":"/app/key","Value":"v","Type":"String"}}`))
+	}))
+	defer srv.Close()
+	t.Setenv("AWS_ENDPOINT_URL_SSM", srv.URL)
+
+	c, err := NewClientWithRegion(context.Background(), "default", "us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Drop a signal left by earlier calls
+	select {
+	case <-CallStarted():
+	default:
+	}
+
+	if _, err := c.GetParameter(context.Background(), "/app/key"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-CallStarted():
+	default:
+		t.Fatal("expected the call to signal that it started")
+	}
+}
@@ -0,0 +1,249 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+)
+
+// ErrAppConfigUnavailable is returned by the AppConfig methods of clients
+// without AWS credentials, such as demo clients
+var ErrAppConfigUnavailable = errors.New("AppConfig is not available for this client")
+
+// AppConfigApplication is an AppConfig application
+type AppConfigApplication struct {
+	ID          string
+	Name        string
+	Description string
+}
+
+// AppConfigEnvironment is a deployment target of an application
+type AppConfigEnvironment struct {
+	ID          string
+	Name        string
+	Description string
+	State       string
+}
+
+// AppConfigProfile is a configuration profile of an application
+type AppConfigProfile struct {
+	ID          string
+	Name        string
+	Description string
+	LocationURI string // "hosted", or e.g. ssm-parameter://NAME
+	Type        string
+}
+
+// Hosted reports whether the profile's content is stored in AppConfig
+func (p AppConfigProfile) Hosted() bool {
+	return p.LocationURI == "hosted"
+}
+
+// AppConfigVersion is a hosted configuration version; Content is only set by
+// GetHostedVersion
+type AppConfigVersion struct {
+	Number      int32
+	Description string
+	ContentType string
+	Label       string
+	Content     string
+}
+
+// AppConfigDeployment is the latest deployment of a configuration profile to an environment
+type AppConfigDeployment struct {
+	Number               int32
+	ConfigurationName    string
+	ConfigurationVersion string
+	State                string
+}
+
+// ListApplications returns the AppConfig applications in the client's region
+func (c *Client) ListApplications(ctx context.Context) ([]AppConfigApplication, error) {
+	if c.appConfig == nil {
+		return nil, ErrAppConfigUnavailable
+	}
+	var apps []AppConfigApplication
+	pages := appconfig.NewListApplicationsPaginator(c.appConfig, &appconfig.ListApplicationsInput{})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list AppConfig applications: %w", err)
+		}
+		for _, a := range page.Items {
+			apps = append(apps, AppConfigApplication{
+				ID:          aws.ToString(a.Id),
+				Name:        aws.ToString(a.Name),
+				Description: aws.ToString(a.Description),
+			})
+		}
+	}
+	return apps, nil
+}
+
+// ListEnvironments returns the environments of an application
+func (c *Client) ListEnvironments(ctx context.Context, appID string) ([]AppConfigEnvironment, error) {
+	if c.appConfig == nil {
+		return nil, ErrAppConfigUnavailable
+	}
+	var envs []AppConfigEnvironment
+	pages := appconfig.NewListEnvironmentsPaginator(c.appConfig, &appconfig.ListEnvironmentsInput{ApplicationId: aws.String(appID)})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list AppConfig environments: %w", err)
+		}
+		for _, e := range page.Items {
+			envs = append(envs, AppConfigEnvironment{
+				ID:          aws.ToString(e.Id),
+				Name:        aws.ToString(e.Name),
+				Description: aws.ToString(e.Description),
+				State:       string(e.State),
+			})
+		}
+	}
+	return envs, nil
+}
+
+// ListConfigurationProfiles returns the configuration profiles of an application
+func (c *Client) ListConfigurationProfiles(ctx context.Context, appID string) ([]AppConfigProfile, error) {
+	if c.appConfig == nil {
+		return nil, ErrAppConfigUnavailable
+	}
+	var profiles []AppConfigProfile
+	pages := appconfig.NewListConfigurationProfilesPaginator(c.appConfig, &appconfig.ListConfigurationProfilesInput{ApplicationId: aws.String(appID)})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list AppConfig configuration profiles: %w", err)
+		}
+		for _, p := range page.Items {
+			profiles = append(profiles, AppConfigProfile{
+				ID:          aws.ToString(p.Id),
+				Name:        aws.ToString(p.Name),
+				LocationURI: aws.ToString(p.LocationUri),
+				Type:        aws.ToString(p.Type),
+			})
+		}
+	}
+	return profiles, nil
+}
+
+// LatestDeployments returns the most recent deployment of each configuration
+// profile to an environment, by configuration profile name
+func (c *Client) LatestDeployments(ctx context.Context, appID, envID string) (map[string]AppConfigDeployment, error) {
+	if c.appConfig == nil {
+		return nil, ErrAppConfigUnavailable
+	}
+	latest := make(map[string]AppConfigDeployment)
+	pages := appconfig.NewListDeploymentsPaginator(c.appConfig, &appconfig.ListDeploymentsInput{
+		ApplicationId: aws.String(appID),
+		EnvironmentId: aws.String(envID),
+	})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list AppConfig deployments: %w", err)
+		}
+		for _, d := range page.Items {
+			name := aws.ToString(d.ConfigurationName)
+			if prev, ok := latest[name]; !ok || d.DeploymentNumber > prev.Number {
+				latest[name] = AppConfigDeployment{
+					Number:               d.DeploymentNumber,
+					ConfigurationName:    name,
+					ConfigurationVersion: aws.ToString(d.ConfigurationVersion),
+					State:                string(d.State),
+				}
+			}
+		}
+	}
+	return latest, nil
+}
+
+// ListHostedVersions returns the hosted versions of a configuration profile, newest first
+func (c *Client) ListHostedVersions(ctx context.Context, appID, profileID string) ([]AppConfigVersion, error) {
+	if c.appConfig == nil {
+		return nil, ErrAppConfigUnavailable
+	}
+	var versions []AppConfigVersion
+	pages := appconfig.NewListHostedConfigurationVersionsPaginator(c.appConfig, &appconfig.ListHostedConfigurationVersionsInput{
+		ApplicationId:          aws.String(appID),
+		ConfigurationProfileId: aws.String(profileID),
+	})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list hosted configuration versions: %w", err)
+		}
+		for _, v := range page.Items {
+			versions = append(versions, AppConfigVersion{
+				Number:      v.VersionNumber,
+				Description: aws.ToString(v.Description),
+				ContentType: aws.ToString(v.ContentType),
+				Label:       aws.ToString(v.VersionLabel),
+			})
+		}
+	}
+	return versions, nil
+}
+
+// GetHostedVersion returns a hosted configuration version with its content
+func (c *Client) GetHostedVersion(ctx context.Context, appID, profileID string, number int32) (*AppConfigVersion, error) {
+	if c.appConfig == nil {
+		return nil, ErrAppConfigUnavailable
+	}
+	out, err := c.appConfig.GetHostedConfigurationVersion(ctx, &appconfig.GetHostedConfigurationVersionInput{
+		ApplicationId:          aws.String(appID),
+		ConfigurationProfileId: aws.String(profileID),
+		VersionNumber:          aws.Int32(number),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hosted configuration version %d: %w", number, err)
+	}
+	return &AppConfigVersion{
+		Number:      number,
+		Description: aws.ToString(out.Description),
+		ContentType: aws.ToString(out.ContentType),
+		Label:       aws.ToString(out.VersionLabel),
+		Content:     string(out.Content),
+	}, nil
+}
+
+// CreateHostedVersion stores content as a new hosted version of a
+// configuration profile; name labels the request in dry-run previews. latest
+// is the newest version the content was based on, so a version created
+// meanwhile by someone else fails the request.
+func (c *Client) CreateHostedVersion(ctx context.Context, appID, profileID, name string, latest int32, content, contentType string) (*AppConfigVersion, error) {
+	if c.appConfig == nil {
+		return nil, ErrAppConfigUnavailable
+	}
+	if err := c.checkWrite(WriteRequest{
+		Operation: "CreateHostedConfigurationVersion",
+		Name:      name,
+		Value:     content,
+		Type:      contentType,
+	}); err != nil {
+		return nil, err
+	}
+
+	input := &appconfig.CreateHostedConfigurationVersionInput{
+		ApplicationId:          aws.String(appID),
+		ConfigurationProfileId: aws.String(profileID),
+		Content:                []byte(content),
+		ContentType:            aws.String(contentType),
+	}
+	if latest > 0 {
+		input.LatestVersionNumber = aws.Int32(latest)
+	}
+	out, err := c.appConfig.CreateHostedConfigurationVersion(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hosted configuration version: %w", err)
+	}
+	return &AppConfigVersion{
+		Number:      out.VersionNumber,
+		ContentType: contentType,
+		Content:     content,
+	}, nil
+}
@@ -0,0 +1,94 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAppConfig_ListsPagesAndCreatesVersions(t *testing.T) {
+	isolateAWSConfig(t)
+
+	var created string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Authorization"), "/appconfig/aws4_request") {
+			t.Errorf("expected a request signed for appconfig, got %q", r.Header.Get("Authorization"))
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/applications":
+			if r.URL.Query().Get("next_token") == "" {
+				w.Write([]byte(`{"Items":[{"Id":"a1","Name":"checkout"}],"NextToken":"t2"}`))
+			} else {
+				w.Write([]byte(`{"Items":[{"Id":"a2","Name":"search"}]}`))
+			}
+		case r.Method == http.MethodGet && r.URL.Path == "/applications/a1/configurationprofiles/p1/hostedconfigurationversions/3":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"feature":true}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/applications/a1/configurationprofiles/p1/hostedconfigurationversions":
+			if got := r.Header.Get("Latest-Version-Number"); got != "3" {
+				t.Errorf("expected Latest-Version-Number 3, got %q", got)
+			}
+			body, _ := io.ReadAll(r.Body)
+			created = string(body)
+			w.Header().Set("Version-Number", "4")
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.Header().Set("X-Amzn-ErrorType", "ResourceNotFoundException:http://internal.amazon.com/")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"Message":"not found"}`))
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("AWS_ENDPOINT_URL", srv.URL)
+
+	c, err := NewClientWithRegion(context.Background(), "default", "us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	apps, err := c.ListApplications(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != 2 || apps[0].Name != "checkout" || apps[1].Name != "search" {
+		t.Fatalf("expected both pages of applications, got %+v", apps)
+	}
+
+	v, err := c.GetHostedVersion(ctx, "a1", "p1", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Content != `{"feature":true}` || v.ContentType != "application/json" {
+		t.Fatalf("unexpected version %+v", v)
+	}
+
+	created4, err := c.CreateHostedVersion(ctx, "a1", "p1", "checkout/flags", 3, `{"feature":false}`, "application/json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created4.Number != 4 || created != `{"feature":false}` {
+		t.Fatalf("expected version 4 with the new content, got %+v (sent %q)", created4, created)
+	}
+
+	_, err = c.ListEnvironments(ctx, "missing")
+	if ErrorCode(err) != "ResourceNotFoundException" {
+		t.Fatalf("expected ResourceNotFoundException, got %v", err)
+	}
+
+	c.SetReadOnly(true)
+	if _, err := c.CreateHostedVersion(ctx, "a1", "p1", "checkout/flags", 4, "{}", "application/json"); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly, got %v", err)
+	}
+}
+
+func TestAppConfig_UnavailableForDemoClients(t *testing.T) {
+	c := NewDemoClient("demo", "us-east-1")
+	if _, err := c.ListApplications(context.Background()); !errors.Is(err, ErrAppConfigUnavailable) {
+		t.Fatalf("expected ErrAppConfigUnavailable, got %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+// breakerThreshold is the number of consecutive credential failures after
+// which a profile is marked degraded and its calls fail without reaching AWS
+const breakerThreshold = 3
+
+// DegradedError is returned instead of calling AWS while a profile is degraded
+type DegradedError struct {
+	Profile string
+	Err     error // Last credential failure
+}
+
+func (e *DegradedError) Error() string {
+	return fmt.Sprintf("profile %s is degraded after %d credential failures (press R on the profile selector to retry): %v",
+		e.Profile, breakerThreshold, e.Err)
+}
+
+func (e *DegradedError) Unwrap() error {
+	return e.Err
+}
+
+// breakers holds the consecutive credential failures of each profile, shared
+// by every client of the profile
+var breakers = struct {
+	sync.Mutex
+	failures map[string]int
+	lastErr  map[string]error
+}{
+	failures: make(map[string]int),
+	lastErr:  make(map[string]error),
+}
+
+// ProfileDegraded reports whether the profile's calls are being refused, and
+// the credential failure that caused it
+func ProfileDegraded(profile string) (bool, error) {
+	breakers.Lock()
+	defer breakers.Unlock()
+	if breakers.failures[profile] < breakerThreshold {
+		return false, nil
+	}
+	return true, breakers.lastErr[profile]
+}
+
+// ResetProfile lets a degraded profile call AWS again, resolving its
+// credentials anew
+func ResetProfile(profile string) {
+	invalidateCredentials(profile)
+	breakers.Lock()
+	defer breakers.Unlock()
+	delete(breakers.failures, profile)
+	delete(breakers.lastErr, profile)
+}
+
+// recordCall updates the profile's breaker with the outcome of a call
+func recordCall(profile string, err error, identified bool) {
+	breakers.Lock()
+	defer breakers.Unlock()
+	switch {
+	case err == nil:
+		delete(breakers.failures, profile)
+		delete(breakers.lastErr, profile)
+	case isCredentialFailure(err):
+		breakers.failures[profile]++
+		breakers.lastErr[profile] = err
+	case identified:
+		// AWS accepted the credentials and rejected the request for another reason
+		delete(breakers.failures, profile)
+		delete(breakers.lastErr, profile)
+	}
+}
+
+// isCredentialFailure reports whether err means the profile's credentials
+// are unusable. Other errors raised before a request is sent, such as input
+// validation, say nothing about the credentials.
+func isCredentialFailure(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var credErr *credentialsError
+	if errors.As(err, &credErr) {
+		// Resolving the credentials failed (expired SSO session, missing keys, ...)
+		return true
+	}
+	switch ErrorCode(err) {
+	case "UnrecognizedClientException", "InvalidClientTokenId", "ExpiredTokenException",
+		"ExpiredToken", "InvalidSignatureException":
+		return true
+	}
+	return false
+}
+
+// identifiedKey stores a flag in the request context that is set once the
+// credentials of an attempt were resolved
+type identifiedKey struct{}
+
+// profileBreaker returns middleware that refuses calls while profile is
+// degraded and counts its credential failures
+func profileBreaker(profile string) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		err := stack.Initialize.Add(middleware.InitializeMiddlewareFunc("ps9sProfileBreaker",
+			func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+				if degraded, lastErr := ProfileDegraded(profile); degraded {
+					return middleware.InitializeOutput{}, middleware.Metadata{}, &DegradedError{Profile: profile, Err: lastErr}
+				}
+				identified := new(bool)
+				out, metadata, err := next.HandleInitialize(context.WithValue(ctx, identifiedKey{}, identified), in)
+				recordCall(profile, err, *identified)
+				return out, metadata, err
+			}), middleware.Before)
+		if err != nil {
+			return err
+		}
+
+		return stack.Finalize.Insert(middleware.FinalizeMiddlewareFunc("ps9sIdentified",
+			func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+				if identified, ok := ctx.Value(identifiedKey{}).(*bool); ok {
+					*identified = true
+				}
+				return next.HandleFinalize(ctx, in)
+			}), "GetIdentity", middleware.After)
+	}
+}
@@ -0,0 +1,126 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestProfileBreaker_OpensAfterCredentialFailures(t *testing.T) {
+	isolateAWSConfig(t)
+	t.Setenv("AWS_MAX_ATTEMPTS", "1")
+	defer ResetProfile("default")
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"__type":"UnrecognizedClientException","message":"The security token included in the request is invalid."}`))
+	}))
+	defer srv.Close()
+	t.Setenv("AWS_ENDPOINT_URL_SSM", srv.URL)
+
+	c, err := NewClientWithRegion(context.Background(), "default", "us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < breakerThreshold; i++ {
+		if _, err := c.GetParameter(context.Background(), "/app/key"); err == nil {
+			t.Fatal("expected the call to fail")
+		}
+	}
+	if degraded, _ := ProfileDegraded("default"); !degraded {
+		t.Fatal("expected the profile to be degraded")
+	}
+
+	// Further calls fail without reaching AWS
+	sent := requests.Load()
+	_, err = c.GetParameter(context.Background(), "/app/key")
+	var degradedErr *DegradedError
+	if !errors.As(err, &degradedErr) {
+		t.Fatalf("expected DegradedError, got %v", err)
+	}
+	if requests.Load() != sent {
+		t.Fatal("expected no request while degraded")
+	}
+
+	ResetProfile("default")
+	c.GetParameter(context.Background(), "/app/key")
+	if requests.Load() != sent+1 {
+		t.Fatal("expected a request after resetting the profile")
+	}
+}
+
+func TestRecordCall_RequestErrorsResetFailures(t *testing.T) {
+	defer ResetProfile("p")
+	noCredentials := &credentialsError{err: errors.New("no credentials")}
+
+	recordCall("p", noCredentials, false)
+	recordCall("p", noCredentials, false)
+	// A request rejected after the credentials resolved proves they work
+	recordCall("p", errors.New("ParameterNotFound"), true)
+	recordCall("p", noCredentials, false)
+
+	if degraded, _ := ProfileDegraded("p"); degraded {
+		t.Fatal("expected failures to restart after a non-credential error")
+	}
+
+	// Canceled calls say nothing about the credentials
+	recordCall("p", context.Canceled, false)
+	recordCall("p", noCredentials, false)
+	if degraded, _ := ProfileDegraded("p"); degraded {
+		t.Fatal("expected cancellation not to count")
+	}
+	recordCall("p", noCredentials, false)
+	if degraded, err := ProfileDegraded("p"); !degraded || err == nil {
+		t.Fatal("expected the profile to be degraded with its last error")
+	}
+}
+
+func TestRecordCall_ValidationErrorsDoNotCount(t *testing.T) {
+	defer ResetProfile("p")
+
+	// Input validation fails before the credentials are resolved
+	for i := 0; i < breakerThreshold; i++ {
+		recordCall("p", errors.New("invalid input: Name is required"), false)
+	}
+	if degraded, _ := ProfileDegraded("p"); degraded {
+		t.Fatal("expected validation errors not to count as credential failures")
+	}
+}
+
+func TestProfileBreaker_CountsMissingCredentials(t *testing.T) {
+	isolateAWSConfig(t)
+	// A profile of its own, so no credentials cached by other tests are reused
+	configFile := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(configFile, []byte("[profile nokeys]\nregion = us-east-1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AWS_CONFIG_FILE", configFile)
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	os.Unsetenv("AWS_ACCESS_KEY_ID")
+	os.Unsetenv("AWS_SECRET_ACCESS_KEY")
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+	defer ResetProfile("nokeys")
+
+	c, err := NewClient(context.Background(), "nokeys")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < breakerThreshold; i++ {
+		if _, err := c.GetParameter(context.Background(), "/app/key"); err == nil {
+			t.Fatal("expected the call to fail without credentials")
+		}
+	}
+	if degraded, _ := ProfileDegraded("nokeys"); !degraded {
+		t.Fatal("expected missing credentials to degrade the profile")
+	}
+}
@@ -3,16 +3,59 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
+// defaultMaxResults is the largest page size DescribeParameters allows
+const defaultMaxResults = 50
+
+// Batched requests a client runs at once. The default stays well within the
+// standard Parameter Store throughput; accounts with the high-throughput
+// setting enabled allow many more transactions per second.
+const (
+	defaultConcurrency        = 1
+	highThroughputConcurrency = 8
+)
+
+// ssmAPI is the subset of the SSM client that Client uses, so an in-memory
+// backend can stand in for AWS in demo mode
+type ssmAPI interface {
+	DescribeParameters(context.Context, *ssm.DescribeParametersInput, ...func(*ssm.Options)) (*ssm.DescribeParametersOutput, error)
+	GetParameter(context.Context, *ssm.GetParameterInput, ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+	GetParameters(context.Context, *ssm.GetParametersInput, ...func(*ssm.Options)) (*ssm.GetParametersOutput, error)
+	GetParameterHistory(context.Context, *ssm.GetParameterHistoryInput, ...func(*ssm.Options)) (*ssm.GetParameterHistoryOutput, error)
+	GetParametersByPath(context.Context, *ssm.GetParametersByPathInput, ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error)
+	PutParameter(context.Context, *ssm.PutParameterInput, ...func(*ssm.Options)) (*ssm.PutParameterOutput, error)
+	DeleteParameters(context.Context, *ssm.DeleteParametersInput, ...func(*ssm.Options)) (*ssm.DeleteParametersOutput, error)
+	ListTagsForResource(context.Context, *ssm.ListTagsForResourceInput, ...func(*ssm.Options)) (*ssm.ListTagsForResourceOutput, error)
+	AddTagsToResource(context.Context, *ssm.AddTagsToResourceInput, ...func(*ssm.Options)) (*ssm.AddTagsToResourceOutput, error)
+	RemoveTagsFromResource(context.Context, *ssm.RemoveTagsFromResourceInput, ...func(*ssm.Options)) (*ssm.RemoveTagsFromResourceOutput, error)
+}
+
 // Client wraps AWS SSM client with profile information
 type Client struct {
-	ssmClient *ssm.Client
-	profile   string
+	ssmClient  ssmAPI
+	appConfig  *appconfig.Client   // nil without AWS credentials
+	events     *eventbridge.Client // nil without AWS credentials
+	sts        *sts.Client         // nil without AWS credentials
+	profile    string
+	region     string // Region the SDK resolved from the flag, environment or profile
+	dryRun     atomic.Bool
+	readOnly   atomic.Bool
+	highTPS    atomic.Bool
+	maxResults int32
+	sharedARNs []string
+	pathPrefix string // Only list parameters whose names begin with this
+	endpoint   string // Custom SSM endpoint from AWS_ENDPOINT_URL(_SSM) or the profile, if any
 }
 
 // NewClient creates an AWS SSM client for the specified profile
@@ -22,6 +65,13 @@ func NewClient(ctx context.Context, profile string) (*Client, error) {
 
 // NewClientWithRegion creates an AWS SSM client for the specified profile with optional region override
 func NewClientWithRegion(ctx context.Context, profile, region string) (*Client, error) {
+	return NewClientWithSessionDuration(ctx, profile, region, 0)
+}
+
+// NewClientWithSessionDuration creates an AWS SSM client whose assumed-role
+// credentials last sessionDuration (0 keeps the profile's duration_seconds or
+// the STS default of one hour)
+func NewClientWithSessionDuration(ctx context.Context, profile, region string, sessionDuration time.Duration) (*Client, error) {
 	var cfg aws.Config
 	var err error
 
@@ -38,15 +88,53 @@ func NewClientWithRegion(ctx context.Context, profile, region string) (*Client,
 		opts = append(opts, config.WithRegion(region))
 	}
 
+	if sessionDuration > 0 {
+		opts = append(opts, config.WithAssumeRoleCredentialOptions(func(o *stscreds.AssumeRoleOptions) {
+			o.Duration = sessionDuration
+		}))
+	}
+
 	// Load config with options
 	cfg, err = config.LoadDefaultConfig(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config for profile %s: %w", profile, err)
 	}
+	cfg.Credentials = sharedCredentials(profile, sessionDuration, cfg)
+
+	// The SDK resolves endpoint overrides (AWS_ENDPOINT_URL, AWS_ENDPOINT_URL_SSM,
+	// endpoint_url in the profile) the same way the AWS CLI does; keep the result
+	// so the UI can show when requests are not going to AWS
+	var endpoint string
+	ssmClient := ssm.NewFromConfig(cfg, func(o *ssm.Options) {
+		o.APIOptions = append(o.APIOptions, trackActivity, profileBreaker(profile))
+		o.Retryer = trackRetries(o.Retryer, o.RetryMaxAttempts)
+		if o.BaseEndpoint != nil {
+			endpoint = *o.BaseEndpoint
+		}
+	})
+
+	appConfig := appconfig.NewFromConfig(cfg, func(o *appconfig.Options) {
+		o.APIOptions = append(o.APIOptions, trackActivity)
+		o.Retryer = trackRetries(o.Retryer, o.RetryMaxAttempts)
+	})
+	events := eventbridge.NewFromConfig(cfg, func(o *eventbridge.Options) {
+		o.APIOptions = append(o.APIOptions, trackActivity)
+		o.Retryer = trackRetries(o.Retryer, o.RetryMaxAttempts)
+	})
+	stsClient := sts.NewFromConfig(cfg, func(o *sts.Options) {
+		o.APIOptions = append(o.APIOptions, trackActivity)
+		o.Retryer = trackRetries(o.Retryer, o.RetryMaxAttempts)
+	})
 
 	return &Client{
-		ssmClient: ssm.NewFromConfig(cfg),
-		profile:   profile,
+		ssmClient:  ssmClient,
+		appConfig:  appConfig,
+		events:     events,
+		sts:        stsClient,
+		profile:    profile,
+		region:     cfg.Region,
+		maxResults: defaultMaxResults,
+		endpoint:   endpoint,
 	}, nil
 }
 
@@ -74,3 +162,72 @@ func NewClientPoolWithRegion(ctx context.Context, profiles []string, region stri
 func (c *Client) Profile() string {
 	return c.profile
 }
+
+// Region returns the region requests are sent to, as resolved when the
+// client was created
+func (c *Client) Region() string {
+	return c.region
+}
+
+// Endpoint returns the custom SSM endpoint in use, or "" for the default AWS endpoint
+func (c *Client) Endpoint() string {
+	return c.endpoint
+}
+
+// SetDryRun toggles dry-run mode. While enabled, write methods return a
+// *DryRunError describing the request instead of calling AWS.
+func (c *Client) SetDryRun(on bool) {
+	c.dryRun.Store(on)
+}
+
+// DryRun reports whether dry-run mode is enabled
+func (c *Client) DryRun() bool {
+	return c.dryRun.Load()
+}
+
+// SetReadOnly enables or disables read-only mode; writes fail with ErrReadOnly while on
+func (c *Client) SetReadOnly(on bool) {
+	c.readOnly.Store(on)
+}
+
+// ReadOnly reports whether writes are disabled
+func (c *Client) ReadOnly() bool {
+	return c.readOnly.Load()
+}
+
+// SetPathPrefix limits ListParameters to names beginning with prefix ("" lists everything)
+func (c *Client) SetPathPrefix(prefix string) {
+	c.pathPrefix = prefix
+}
+
+// PathPrefix returns the listing prefix, or "" when unrestricted
+func (c *Client) PathPrefix() string {
+	return c.pathPrefix
+}
+
+// SetSharedARNs adds parameters shared from other accounts, by ARN, to listings
+func (c *Client) SetSharedARNs(arns []string) {
+	c.sharedARNs = arns
+}
+
+// SetHighThroughput raises the number of batched requests run at once, for
+// accounts with the high-throughput Parameter Store setting enabled
+func (c *Client) SetHighThroughput(on bool) {
+	c.highTPS.Store(on)
+}
+
+// Concurrency returns the number of batched requests to run at once
+func (c *Client) Concurrency() int {
+	if c.highTPS.Load() {
+		return highThroughputConcurrency
+	}
+	return defaultConcurrency
+}
+
+// SetMaxResults sets the page size for list calls; values outside 1-50 reset to the maximum
+func (c *Client) SetMaxResults(n int) {
+	if n < 1 || n > defaultMaxResults {
+		n = defaultMaxResults
+	}
+	c.maxResults = int32(n)
+}
@@ -0,0 +1,132 @@
+package aws
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// isolateAWSConfig points the SDK at an empty config and static credentials
+func isolateAWSConfig(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config")
+	if err := os.WriteFile(configFile, []byte("[default]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AWS_CONFIG_FILE", configFile)
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_PROFILE", "")
+	// Setenv restores any original values after the test; unset them so the
+	// SDK's LookupEnv sees no override
+	t.Setenv("AWS_ENDPOINT_URL", "")
+	t.Setenv("AWS_ENDPOINT_URL_SSM", "")
+	os.Unsetenv("AWS_ENDPOINT_URL")
+	os.Unsetenv("AWS_ENDPOINT_URL_SSM")
+}
+
+func TestNewClient_HonoursEndpointEnv(t *testing.T) {
+	isolateAWSConfig(t)
+
+	c, err := NewClientWithRegion(context.Background(), "default", "us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Endpoint() != "" {
+		t.Fatalf("expected default endpoint, got %q", c.Endpoint())
+	}
+
+	t.Setenv("AWS_ENDPOINT_URL", "http://localhost:4566")
+	c, err = NewClientWithRegion(context.Background(), "default", "us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Endpoint() != "http://localhost:4566" {
+		t.Fatalf("expected global endpoint override, got %q", c.Endpoint())
+	}
+
+	t.Setenv("AWS_ENDPOINT_URL_SSM", "http://localhost:4583")
+	c, err = NewClientWithRegion(context.Background(), "default", "us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Endpoint() != "http://localhost:4583" {
+		t.Fatalf("expected service-specific endpoint to win, got %q", c.Endpoint())
+	}
+}
+
+func TestNewClient_ResolvesRegion(t *testing.T) {
+	isolateAWSConfig(t)
+	t.Setenv("AWS_REGION", "eu-central-1")
+
+	c, err := NewClient(context.Background(), "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Region() != "eu-central-1" {
+		t.Fatalf("expected region from the environment, got %q", c.Region())
+	}
+
+	c, err = NewClientWithRegion(context.Background(), "default", "us-west-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Region() != "us-west-2" {
+		t.Fatalf("expected region override, got %q", c.Region())
+	}
+}
+
+func TestNewClient_SharesSSOCredentials(t *testing.T) {
+	isolateAWSConfig(t)
+	os.Unsetenv("AWS_ACCESS_KEY_ID")
+	os.Unsetenv("AWS_SECRET_ACCESS_KEY")
+
+	config := `
+[sso-session corp]
+sso_start_url = https://corp.awsapps.com/start
+sso_region = us-east-1
+
+[profile sso-app]
+sso_session = corp
+sso_account_id = 111122223333
+sso_role_name = Developer
+
+[profile sso-app-alias]
+sso_session = corp
+sso_account_id = 111122223333
+sso_role_name = Developer
+
+[profile sso-admin]
+sso_session = corp
+sso_account_id = 111122223333
+sso_role_name = Admin
+`
+	if err := os.WriteFile(os.Getenv("AWS_CONFIG_FILE"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	credentials := func(profile, region string) any {
+		t.Helper()
+		c, err := NewClientWithRegion(context.Background(), profile, region)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c.ssmClient.(*ssm.Client).Options().Credentials
+	}
+
+	app := credentials("sso-app", "us-east-1")
+	if credentials("sso-app", "eu-west-1") != app {
+		t.Error("expected clients of a profile in other regions to share credentials")
+	}
+	if credentials("sso-app-alias", "us-east-1") != app {
+		t.Error("expected profiles with the same SSO role to share credentials")
+	}
+	if credentials("sso-admin", "us-east-1") == app {
+		t.Error("expected a different SSO role to have its own credentials")
+	}
+}
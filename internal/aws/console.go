@@ -0,0 +1,24 @@
+package aws
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ConsoleURL returns the AWS console address of a parameter's detail page.
+// The console expects the name escaped twice, so slashes become %252F.
+func ConsoleURL(region, name string) string {
+	host := "console.aws.amazon.com"
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		host = "console.amazonaws.cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		host = "console.amazonaws-us-gov.com"
+	}
+	if region != "" {
+		host = region + "." + host
+	}
+	return fmt.Sprintf("https://%s/systems-manager/parameters/%s/description?region=%s&tab=Table",
+		host, url.PathEscape(url.PathEscape(name)), url.QueryEscape(region))
+}
@@ -0,0 +1,21 @@
+package aws
+
+import "testing"
+
+func TestConsoleURL(t *testing.T) {
+	tests := []struct {
+		region, name, want string
+	}{
+		{"us-east-1", "/app/prod/db",
+			"https://us-east-1.console.aws.amazon.com/systems-manager/parameters/%252Fapp%252Fprod%252Fdb/description?region=us-east-1&tab=Table"},
+		{"cn-north-1", "plain",
+			"https://cn-north-1.console.amazonaws.cn/systems-manager/parameters/plain/description?region=cn-north-1&tab=Table"},
+		{"us-gov-west-1", "/a b",
+			"https://us-gov-west-1.console.amazonaws-us-gov.com/systems-manager/parameters/%252Fa%2520b/description?region=us-gov-west-1&tab=Table"},
+	}
+	for _, tt := range tests {
+		if got := ConsoleURL(tt.region, tt.name); got != tt.want {
+			t.Errorf("ConsoleURL(%q, %q) =\n  %s\nwant\n  %s", tt.region, tt.name, got, tt.want)
+		}
+	}
+}
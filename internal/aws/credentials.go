@@ -0,0 +1,136 @@
+package aws
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+)
+
+// credentialsCaches shares resolved credentials between the clients of a
+// profile (other regions, other tabs), and between profiles signing in as the
+// same role through the same SSO session, so each is only resolved once
+var credentialsCaches = struct {
+	sync.Mutex
+	byKey     map[string]*aws.CredentialsCache
+	byProfile map[string][]string              // Keys used by each profile
+	sessions  map[string]*sync.Mutex           // Serializes SSO token use per sso-session
+	inner     map[string]*aws.CredentialsCache // SDK caches behind the shared ones
+}{
+	byKey:     make(map[string]*aws.CredentialsCache),
+	byProfile: make(map[string][]string),
+	sessions:  make(map[string]*sync.Mutex),
+	inner:     make(map[string]*aws.CredentialsCache),
+}
+
+// sharedCredentials returns the credentials provider clients of profile
+// share, built from the provider cfg resolved on first use
+func sharedCredentials(profile string, sessionDuration time.Duration, cfg aws.Config) aws.CredentialsProvider {
+	if cfg.Credentials == nil {
+		return nil
+	}
+
+	key := "profile/" + profile + "/" + sessionDuration.String()
+	session := ""
+	if sc, ok := sharedConfig(cfg); ok && sc.SSOSession != nil && sc.RoleARN == "" {
+		// Profiles signing in as the same SSO role are interchangeable
+		session = sc.SSOSession.Name
+		key = "sso/" + session + "/" + sc.SSOAccountID + "/" + sc.SSORoleName
+	}
+
+	credentialsCaches.Lock()
+	defer credentialsCaches.Unlock()
+
+	if !slices.Contains(credentialsCaches.byProfile[profile], key) {
+		credentialsCaches.byProfile[profile] = append(credentialsCaches.byProfile[profile], key)
+	}
+	if cache, ok := credentialsCaches.byKey[key]; ok {
+		return cache
+	}
+
+	var provider aws.CredentialsProvider = cfg.Credentials
+	if inner, ok := cfg.Credentials.(*aws.CredentialsCache); ok {
+		credentialsCaches.inner[key] = inner
+	}
+	if session != "" {
+		mu, ok := credentialsCaches.sessions[session]
+		if !ok {
+			mu = new(sync.Mutex)
+			credentialsCaches.sessions[session] = mu
+		}
+		provider = &sessionProvider{mu: mu, inner: provider}
+	}
+	cache := aws.NewCredentialsCache(&markedProvider{inner: provider})
+	credentialsCaches.byKey[key] = cache
+	return cache
+}
+
+// invalidateCredentials makes the next call of profile resolve its
+// credentials again, e.g. after signing in to SSO again
+func invalidateCredentials(profile string) {
+	credentialsCaches.Lock()
+	defer credentialsCaches.Unlock()
+	for _, key := range credentialsCaches.byProfile[profile] {
+		if inner, ok := credentialsCaches.inner[key]; ok {
+			inner.Invalidate()
+		}
+		if cache, ok := credentialsCaches.byKey[key]; ok {
+			cache.Invalidate()
+		}
+	}
+}
+
+// sharedConfig returns the shared config profile cfg was loaded from
+func sharedConfig(cfg aws.Config) (config.SharedConfig, bool) {
+	for _, source := range cfg.ConfigSources {
+		if sc, ok := source.(config.SharedConfig); ok {
+			return sc, true
+		}
+	}
+	return config.SharedConfig{}, false
+}
+
+// sessionProvider retrieves credentials one at a time per SSO session. When
+// the cached SSO token has expired, the first profile refreshes it and writes
+// it back to the token cache; the others then read the refreshed token instead
+// of each refreshing it independently.
+type sessionProvider struct {
+	mu    *sync.Mutex
+	inner aws.CredentialsProvider
+}
+
+func (p *sessionProvider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.inner.Retrieve(ctx)
+}
+
+// credentialsError is a failure to resolve a profile's credentials, told
+// apart from other errors raised before a request is sent
+type credentialsError struct {
+	err error
+}
+
+func (e *credentialsError) Error() string {
+	return e.err.Error()
+}
+
+func (e *credentialsError) Unwrap() error {
+	return e.err
+}
+
+// markedProvider wraps the errors of inner in a credentialsError
+type markedProvider struct {
+	inner aws.CredentialsProvider
+}
+
+func (p *markedProvider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	creds, err := p.inner.Retrieve(ctx)
+	if err != nil {
+		return creds, &credentialsError{err: err}
+	}
+	return creds, nil
+}
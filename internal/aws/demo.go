@@ -0,0 +1,423 @@
+package aws
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/aws/smithy-go"
+)
+
+// DemoProfiles are the profiles offered in demo mode
+var DemoProfiles = []string{"demo", "demo-staging"}
+
+// demoUser is recorded as the modifying user of demo writes
+const demoUser = "arn:aws:iam::123456789012:user/demo"
+
+// demoStores keeps one in-memory backend per profile and region, so edits
+// survive switching contexts within a session
+var demoStores = struct {
+	sync.Mutex
+	byContext map[string]*demoSSM
+}{byContext: make(map[string]*demoSSM)}
+
+// ResetDemo drops every demo backend, so the next NewDemoClient of each
+// context starts from the sample parameters again. Tests writing to demo
+// clients call it when they finish.
+func ResetDemo() {
+	demoStores.Lock()
+	defer demoStores.Unlock()
+	demoStores.byContext = make(map[string]*demoSSM)
+}
+
+// NewDemoClient returns a client backed by sample parameters held in memory
+// instead of AWS. Reads and writes behave like SSM, including dry-run and
+// read-only modes.
+func NewDemoClient(profile, region string) *Client {
+	demoStores.Lock()
+	defer demoStores.Unlock()
+
+	key := profile + "/" + region
+	store, ok := demoStores.byContext[key]
+	if !ok {
+		store = newDemoSSM(profile, region)
+		demoStores.byContext[key] = store
+	}
+
+	return &Client{
+		ssmClient:  store,
+		profile:    profile,
+		region:     region,
+		maxResults: defaultMaxResults,
+		endpoint:   "demo",
+	}
+}
+
+// demoParameter is one stored parameter with all of its versions, oldest first
+type demoParameter struct {
+	versions []types.ParameterHistory
+	tags     []types.Tag
+}
+
+func (p *demoParameter) latest() types.ParameterHistory {
+	return p.versions[len(p.versions)-1]
+}
+
+// demoSSM implements ssmAPI over an in-memory map
+type demoSSM struct {
+	mu     sync.Mutex
+	region string
+	params map[string]*demoParameter
+}
+
+// newDemoSSM seeds a backend with parameters for a few services and environments
+func newDemoSSM(profile, region string) *demoSSM {
+	s := &demoSSM{region: region, params: make(map[string]*demoParameter)}
+
+	env := "prod"
+	if strings.Contains(profile, "staging") {
+		env = "staging"
+	}
+	base := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	seed := func(name, typ, value, description string, age time.Duration, tags ...types.Tag) {
+		s.params[name] = &demoParameter{
+			versions: []types.ParameterHistory{{
+				Name:             aws.String(name),
+				Type:             types.ParameterType(typ),
+				Value:            aws.String(value),
+				Version:          1,
+				LastModifiedDate: aws.Time(base.Add(-age)),
+				LastModifiedUser: aws.String(demoUser),
+				Description:      aws.String(description),
+				DataType:         aws.String("text"),
+				Tier:             types.ParameterTierStandard,
+			}},
+			tags: tags,
+		}
+	}
+	team := func(v string) types.Tag { return types.Tag{Key: aws.String("team"), Value: aws.String(v)} }
+
+	for _, svc := range []string{"api", "worker"} {
+		prefix := "/" + svc + "/" + env + "/"
+		seed(prefix+"db/host", "String", svc+"-"+env+".cluster.internal", "Database host", 72*time.Hour, team("platform"))
+		seed(prefix+"db/port", "String", "5432", "Database port", 72*time.Hour, team("platform"))
+		seed(prefix+"db/password", "SecureString", "demo-"+svc+"-password", "Database password", 30*24*time.Hour, team("platform"))
+		seed(prefix+"log-level", "String", "info", "Log verbosity", 2*time.Hour)
+		seed(prefix+"feature-flags", "String", `{"new_checkout":true,"beta_search":false,"max_items":25}`, "Feature toggles", 5*time.Hour, team("product"))
+		seed(prefix+"allowed-origins", "StringList", "https://example.com,https://app.example.com", "CORS origins", 10*24*time.Hour)
+	}
+	seed("/shared/"+env+"/stripe/api-key", "SecureString", "sk_demo_4eC39HqLyjWDarjtT1zdp7dc", "Payment provider key", 90*24*time.Hour, team("payments"))
+	seed("/shared/"+env+"/smtp", "SecureString", `{"host":"smtp.example.com","user":"mailer","password":"demo"}`, "Outgoing mail", 45*24*time.Hour)
+	seed("/shared/"+env+"/region", "String", region, "Deployment region", 120*24*time.Hour)
+
+	// A parameter with history, so version browsing has something to show
+	logLevel := s.params["/api/"+env+"/log-level"]
+	for i, level := range []string{"debug", "warn"} {
+		v := logLevel.latest()
+		v.Version++
+		v.Value = aws.String(level)
+		v.LastModifiedDate = aws.Time(base.Add(-time.Duration(2-i) * time.Hour / 2))
+		logLevel.versions = append(logLevel.versions, v)
+	}
+	s.params["/shared/"+env+"/smtp"].versions[0].Tier = types.ParameterTierAdvanced
+
+	return s
+}
+
+func demoNotFound(name string) error {
+	return &types.ParameterNotFound{Message: aws.String(fmt.Sprintf("parameter %s not found", name))}
+}
+
+// demoValue returns v's value, standing in for ciphertext when a SecureString is not decrypted
+func demoValue(v types.ParameterHistory, decrypt *bool) *string {
+	if v.Type == types.ParameterTypeSecureString && !aws.ToBool(decrypt) {
+		return aws.String(base64.StdEncoding.EncodeToString([]byte("encrypted:" + aws.ToString(v.Value))))
+	}
+	return v.Value
+}
+
+func (s *demoSSM) arn(name string) *string {
+	return aws.String(fmt.Sprintf("arn:aws:ssm:%s:123456789012:parameter/%s", s.region, strings.TrimPrefix(name, "/")))
+}
+
+func (s *demoSSM) DescribeParameters(_ context.Context, in *ssm.DescribeParametersInput, _ ...func(*ssm.Options)) (*ssm.DescribeParametersOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Nothing is shared with the demo accounts
+	if aws.ToBool(in.Shared) {
+		return &ssm.DescribeParametersOutput{}, nil
+	}
+
+	var names []string
+	for name := range s.params {
+		keep := true
+		for _, f := range in.ParameterFilters {
+			if aws.ToString(f.Key) == "Name" && aws.ToString(f.Option) == "BeginsWith" && len(f.Values) > 0 {
+				keep = strings.HasPrefix(name, f.Values[0])
+			}
+			if aws.ToString(f.Key) == "Name" && aws.ToString(f.Option) == "Equals" {
+				keep = slices.Contains(f.Values, name)
+			}
+		}
+		if keep {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	start, _ := strconv.Atoi(aws.ToString(in.NextToken))
+	limit := int(aws.ToInt32(in.MaxResults))
+	if limit <= 0 {
+		limit = defaultMaxResults
+	}
+	end := min(start+limit, len(names))
+
+	out := &ssm.DescribeParametersOutput{}
+	for _, name := range names[start:end] {
+		v := s.params[name].latest()
+		out.Parameters = append(out.Parameters, types.ParameterMetadata{
+			Name:             v.Name,
+			ARN:              s.arn(name),
+			Type:             v.Type,
+			Version:          v.Version,
+			LastModifiedDate: v.LastModifiedDate,
+			LastModifiedUser: v.LastModifiedUser,
+			Description:      v.Description,
+			DataType:         v.DataType,
+			KeyId:            v.KeyId,
+			Tier:             v.Tier,
+		})
+	}
+	if end < len(names) {
+		out.NextToken = aws.String(strconv.Itoa(end))
+	}
+	return out, nil
+}
+
+func (s *demoSSM) GetParametersByPath(_ context.Context, in *ssm.GetParametersByPathInput, _ ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.TrimSuffix(aws.ToString(in.Path), "/") + "/"
+	var names []string
+	for name := range s.params {
+		rest, ok := strings.CutPrefix(name, path)
+		if ok && (aws.ToBool(in.Recursive) || !strings.Contains(rest, "/")) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	start, _ := strconv.Atoi(aws.ToString(in.NextToken))
+	limit := int(aws.ToInt32(in.MaxResults))
+	if limit <= 0 {
+		limit = 10
+	}
+	end := min(start+limit, len(names))
+
+	out := &ssm.GetParametersByPathOutput{}
+	for _, name := range names[start:end] {
+		out.Parameters = append(out.Parameters, s.parameter(s.params[name].latest(), in.WithDecryption))
+	}
+	if end < len(names) {
+		out.NextToken = aws.String(strconv.Itoa(end))
+	}
+	return out, nil
+}
+
+func (s *demoSSM) parameter(v types.ParameterHistory, decrypt *bool) types.Parameter {
+	return types.Parameter{
+		Name:             v.Name,
+		ARN:              s.arn(aws.ToString(v.Name)),
+		Type:             v.Type,
+		Value:            demoValue(v, decrypt),
+		Version:          v.Version,
+		LastModifiedDate: v.LastModifiedDate,
+		DataType:         v.DataType,
+	}
+}
+
+func (s *demoSSM) GetParameter(_ context.Context, in *ssm.GetParameterInput, _ ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := aws.ToString(in.Name)
+	p, ok := s.params[name]
+	if !ok {
+		return nil, demoNotFound(name)
+	}
+	param := s.parameter(p.latest(), in.WithDecryption)
+	return &ssm.GetParameterOutput{Parameter: &param}, nil
+}
+
+func (s *demoSSM) GetParameters(_ context.Context, in *ssm.GetParametersInput, _ ...func(*ssm.Options)) (*ssm.GetParametersOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := &ssm.GetParametersOutput{}
+	for _, name := range in.Names {
+		if p, ok := s.params[name]; ok {
+			out.Parameters = append(out.Parameters, s.parameter(p.latest(), in.WithDecryption))
+		} else {
+			out.InvalidParameters = append(out.InvalidParameters, name)
+		}
+	}
+	return out, nil
+}
+
+func (s *demoSSM) GetParameterHistory(_ context.Context, in *ssm.GetParameterHistoryInput, _ ...func(*ssm.Options)) (*ssm.GetParameterHistoryOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := aws.ToString(in.Name)
+	p, ok := s.params[name]
+	if !ok {
+		return nil, demoNotFound(name)
+	}
+	out := &ssm.GetParameterHistoryOutput{}
+	for _, v := range p.versions {
+		v.Value = demoValue(v, in.WithDecryption)
+		out.Parameters = append(out.Parameters, v)
+	}
+	return out, nil
+}
+
+func (s *demoSSM) PutParameter(_ context.Context, in *ssm.PutParameterInput, _ ...func(*ssm.Options)) (*ssm.PutParameterOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := aws.ToString(in.Name)
+	p, exists := s.params[name]
+	if exists && !aws.ToBool(in.Overwrite) {
+		return nil, &types.ParameterAlreadyExists{Message: aws.String(fmt.Sprintf("parameter %s already exists", name))}
+	}
+	if !exists && in.Type == "" {
+		return nil, &smithy.GenericAPIError{Code: "ValidationException", Message: "a type is required for new parameters"}
+	}
+
+	v := types.ParameterHistory{
+		Name:        in.Name,
+		Type:        in.Type,
+		Value:       in.Value,
+		Version:     1,
+		Description: in.Description,
+		DataType:    aws.String("text"),
+		KeyId:       in.KeyId,
+		Tier:        in.Tier,
+	}
+	if exists {
+		prev := p.latest()
+		v.Version = prev.Version + 1
+		if v.Type == "" {
+			v.Type = prev.Type
+		}
+		if v.Description == nil {
+			v.Description = prev.Description
+		}
+		if v.KeyId == nil && v.Type == prev.Type {
+			v.KeyId = prev.KeyId
+		}
+		if v.Tier == "" {
+			v.Tier = prev.Tier
+		}
+	} else {
+		p = &demoParameter{tags: in.Tags}
+		s.params[name] = p
+	}
+	if v.Tier == "" {
+		v.Tier = types.ParameterTierStandard
+	}
+	if v.Type == types.ParameterTypeSecureString && v.KeyId == nil {
+		v.KeyId = aws.String(DefaultKeyID)
+	}
+	v.LastModifiedDate = aws.Time(time.Now())
+	v.LastModifiedUser = aws.String(demoUser)
+	p.versions = append(p.versions, v)
+
+	return &ssm.PutParameterOutput{Version: v.Version, Tier: v.Tier}, nil
+}
+
+func (s *demoSSM) DeleteParameters(_ context.Context, in *ssm.DeleteParametersInput, _ ...func(*ssm.Options)) (*ssm.DeleteParametersOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := &ssm.DeleteParametersOutput{}
+	for _, name := range in.Names {
+		if _, ok := s.params[name]; ok {
+			delete(s.params, name)
+			out.DeletedParameters = append(out.DeletedParameters, name)
+		} else {
+			out.InvalidParameters = append(out.InvalidParameters, name)
+		}
+	}
+	return out, nil
+}
+
+func (s *demoSSM) ListTagsForResource(_ context.Context, in *ssm.ListTagsForResourceInput, _ ...func(*ssm.Options)) (*ssm.ListTagsForResourceOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := aws.ToString(in.ResourceId)
+	p, ok := s.params[name]
+	if !ok {
+		return nil, demoNotFound(name)
+	}
+	return &ssm.ListTagsForResourceOutput{TagList: append([]types.Tag(nil), p.tags...)}, nil
+}
+
+func (s *demoSSM) AddTagsToResource(_ context.Context, in *ssm.AddTagsToResourceInput, _ ...func(*ssm.Options)) (*ssm.AddTagsToResourceOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := aws.ToString(in.ResourceId)
+	p, ok := s.params[name]
+	if !ok {
+		return nil, demoNotFound(name)
+	}
+	for _, t := range in.Tags {
+		replaced := false
+		for i := range p.tags {
+			if aws.ToString(p.tags[i].Key) == aws.ToString(t.Key) {
+				p.tags[i] = t
+				replaced = true
+			}
+		}
+		if !replaced {
+			p.tags = append(p.tags, t)
+		}
+	}
+	return &ssm.AddTagsToResourceOutput{}, nil
+}
+
+func (s *demoSSM) RemoveTagsFromResource(_ context.Context, in *ssm.RemoveTagsFromResourceInput, _ ...func(*ssm.Options)) (*ssm.RemoveTagsFromResourceOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := aws.ToString(in.ResourceId)
+	p, ok := s.params[name]
+	if !ok {
+		return nil, demoNotFound(name)
+	}
+	remove := make(map[string]bool, len(in.TagKeys))
+	for _, k := range in.TagKeys {
+		remove[k] = true
+	}
+	kept := p.tags[:0]
+	for _, t := range p.tags {
+		if !remove[aws.ToString(t.Key)] {
+			kept = append(kept, t)
+		}
+	}
+	p.tags = kept
+	return &ssm.RemoveTagsFromResourceOutput{}, nil
+}
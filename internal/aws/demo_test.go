@@ -0,0 +1,115 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestDemoClient_ReadWrite(t *testing.T) {
+	t.Cleanup(ResetDemo)
+	ctx := context.Background()
+	c := NewDemoClient("demo-test", "eu-west-1")
+	c.SetMaxResults(5)
+
+	params, err := c.ListParameters(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(params) < 10 {
+		t.Fatalf("expected sample parameters across pages, got %d", len(params))
+	}
+
+	secret, err := c.GetParameter(ctx, "/api/prod/db/password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret.Value != "demo-api-password" {
+		t.Fatalf("expected decrypted value, got %q", secret.Value)
+	}
+
+	if err := c.CreateParameter(ctx, "/api/prod/db/password", "x", "SecureString", "", "", nil); err == nil {
+		t.Fatal("expected create of an existing name to fail")
+	}
+	if err := c.PutParameter(ctx, "/api/prod/log-level", "error", "String"); err != nil {
+		t.Fatal(err)
+	}
+	history, err := c.GetParameterHistory(ctx, "/api/prod/log-level")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 4 || history[0].Value != "error" {
+		t.Fatalf("expected new version on top of the history, got %d versions", len(history))
+	}
+
+	if _, err := c.GetParameter(ctx, "/missing"); !IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	// State is kept per context across clients
+	again := NewDemoClient("demo-test", "eu-west-1")
+	p, err := again.GetParameter(ctx, "/api/prod/log-level")
+	if err != nil || p.Value != "error" {
+		t.Fatalf("expected write to persist, got %v %v", p, err)
+	}
+
+	c.SetDryRun(true)
+	var dryRunErr *DryRunError
+	if err := c.PutParameter(ctx, "/api/prod/log-level", "info", "String"); !errors.As(err, &dryRunErr) {
+		t.Fatalf("expected dry run to apply to demo clients, got %v", err)
+	}
+}
+
+func TestDemoClient_Tags(t *testing.T) {
+	ctx := context.Background()
+	c := NewDemoClient("demo-tags", "us-east-1")
+
+	tags, err := c.ListTags(ctx, "/api/prod/db/host")
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated := append(tags, Tag{Key: "owner", Value: "alice"})
+	if err := c.UpdateTags(ctx, "/api/prod/db/host", tags, updated[1:]); err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.ListTags(ctx, "/api/prod/db/host")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Key != "owner" {
+		t.Fatalf("expected only the owner tag, got %v", got)
+	}
+}
+
+func TestGetParameters_ConcurrentBatchesKeepOrder(t *testing.T) {
+	t.Cleanup(ResetDemo)
+	c := NewDemoClient("demo", "test-concurrency")
+	c.SetHighThroughput(true)
+
+	var names []string
+	for i := 0; i < 35; i++ {
+		name := fmt.Sprintf("/load/p%02d", i)
+		if err := c.CreateParameter(context.Background(), name, fmt.Sprint(i), "String", "", "", nil); err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, name)
+	}
+	// Reverse the order and add a name that does not exist
+	slices.Reverse(names)
+	names = append(names, "/load/missing")
+
+	params, err := c.GetParameters(context.Background(), names, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(params) != 35 {
+		t.Fatalf("expected 35 parameters, got %d", len(params))
+	}
+	for i, p := range params {
+		if p.Name != names[i] {
+			t.Fatalf("expected %s at %d, got %s", names[i], i, p.Name)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+)
+
+// WriteRequest describes a write call as it would be sent to AWS
+type WriteRequest struct {
+	Operation string
+	Name      string
+	Names     []string // Every parameter of a batch request, such as DeleteParameters
+	Value     string
+	Type      string
+	Tier      string
+	KeyID     string
+	Overwrite bool
+	Tags      []Tag // Tags sent with the request; only keys for removals
+	// Description sent with the request, if any
+	Description string
+}
+
+// DryRunError is returned by write methods while dry-run mode is enabled
+type DryRunError struct {
+	Request WriteRequest
+}
+
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("dry run: %s %s was not sent", e.Request.Operation, e.Request.target())
+}
+
+// target describes what req writes: its parameter, or the number of
+// parameters of a batch request
+func (r WriteRequest) target() string {
+	if len(r.Names) > 0 {
+		return fmt.Sprintf("of %d parameters", len(r.Names))
+	}
+	return r.Name
+}
+
+// ErrReadOnly is returned by write methods while read-only mode is enabled
+var ErrReadOnly = errors.New("read-only mode: writes are disabled")
+
+// ErrShared is returned by write methods for parameters shared by another account
+var ErrShared = errors.New("parameters shared through AWS RAM are read-only")
+
+// checkWrite reports whether req may be sent: read-only mode and shared
+// parameters refuse it, dry-run mode turns it into a DryRunError, otherwise nil
+func (c *Client) checkWrite(req WriteRequest) error {
+	for _, name := range append([]string{req.Name}, req.Names...) {
+		if IsShared(name) {
+			return fmt.Errorf("cannot %s %s: %w", req.Operation, name, ErrShared)
+		}
+	}
+	if c.readOnly.Load() {
+		return fmt.Errorf("cannot %s %s: %w", req.Operation, req.target(), ErrReadOnly)
+	}
+	if c.DryRun() {
+		return &DryRunError{Request: req}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestWrites_ReadOnlyAndDryRun(t *testing.T) {
+	c := &Client{}
+
+	c.SetReadOnly(true)
+	c.SetDryRun(true)
+	if err := c.PutParameter(context.Background(), "/app/x", "v", "String"); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly to take precedence, got %v", err)
+	}
+
+	c.SetReadOnly(false)
+	var dryRunErr *DryRunError
+	if err := c.CreateParameter(context.Background(), "/app/x", "v", "String", "", "", nil); !errors.As(err, &dryRunErr) {
+		t.Fatalf("expected DryRunError, got %v", err)
+	}
+	if dryRunErr.Request.Overwrite {
+		t.Fatal("create should not overwrite")
+	}
+}
+
+func TestDeleteParameters_DryRunCoversBatch(t *testing.T) {
+	c := &Client{}
+	c.SetDryRun(true)
+
+	names := []string{"/app/a", "/app/b", "/app/c"}
+	var dryRunErr *DryRunError
+	if _, err := c.DeleteParameters(context.Background(), names); !errors.As(err, &dryRunErr) {
+		t.Fatalf("expected DryRunError, got %v", err)
+	}
+	if !slices.Equal(dryRunErr.Request.Names, names) {
+		t.Fatalf("expected every name in the request, got %v", dryRunErr.Request.Names)
+	}
+	if want := "dry run: DeleteParameters of 3 parameters was not sent"; dryRunErr.Error() != want {
+		t.Fatalf("unexpected message %q", dryRunErr.Error())
+	}
+}
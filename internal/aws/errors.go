@@ -0,0 +1,47 @@
+package aws
+
+import (
+	"errors"
+
+	"github.com/aws/smithy-go"
+)
+
+// ErrorCode returns the AWS error code carried by err, or "" for non-API errors
+func ErrorCode(err error) string {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode()
+	}
+	return ""
+}
+
+// IsNotFound reports whether err means the parameter (or version) does not exist
+func IsNotFound(err error) bool {
+	switch ErrorCode(err) {
+	case "ParameterNotFound", "ParameterVersionNotFound":
+		return true
+	}
+	return false
+}
+
+// IsAccessDenied reports whether err is an authorization or credentials failure,
+// including KMS denials when decrypting SecureStrings
+func IsAccessDenied(err error) bool {
+	switch ErrorCode(err) {
+	case "AccessDeniedException", "AccessDenied", "UnrecognizedClientException",
+		"InvalidClientTokenId", "ExpiredTokenException", "ExpiredToken":
+		return true
+	}
+	return false
+}
+
+// IsValidation reports whether AWS rejected the request as malformed
+func IsValidation(err error) bool {
+	switch ErrorCode(err) {
+	case "ValidationException", "ParameterPatternMismatchException",
+		"HierarchyLevelLimitExceededException", "HierarchyTypeMismatchException",
+		"InvalidKeyId", "UnsupportedParameterType", "InvalidAllowedPatternException":
+		return true
+	}
+	return false
+}
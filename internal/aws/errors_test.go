@@ -0,0 +1,27 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go"
+)
+
+func TestErrorClassification(t *testing.T) {
+	wrap := func(code string) error {
+		return fmt.Errorf("failed to get parameter /x: %w", &smithy.GenericAPIError{Code: code})
+	}
+
+	if !IsNotFound(wrap("ParameterNotFound")) {
+		t.Error("ParameterNotFound should be not found")
+	}
+	if !IsAccessDenied(wrap("AccessDeniedException")) {
+		t.Error("AccessDeniedException should be access denied")
+	}
+	if !IsValidation(wrap("ValidationException")) {
+		t.Error("ValidationException should be a validation error")
+	}
+	if IsNotFound(fmt.Errorf("plain error")) || ErrorCode(fmt.Errorf("plain error")) != "" {
+		t.Error("non-API errors should not be classified")
+	}
+}
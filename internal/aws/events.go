@@ -0,0 +1,142 @@
+package aws
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	eventtypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+)
+
+// changeRulePrefix starts the names of the rules ps9s creates
+const changeRulePrefix = "ps9s-changes-"
+
+// maxRuleName is the longest EventBridge rule name
+const maxRuleName = 64
+
+// ruleNameUnsafe matches characters EventBridge rule and target IDs don't allow
+var ruleNameUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// ErrEventsUnavailable is returned by the EventBridge methods of clients
+// without AWS credentials, such as demo clients
+var ErrEventsUnavailable = errors.New("EventBridge is not available for this client")
+
+// ChangeRule is an EventBridge rule forwarding Parameter Store change events
+type ChangeRule struct {
+	Name    string
+	Pattern string   // Event pattern JSON
+	State   string   // ENABLED or DISABLED, "" while the rule does not exist
+	Targets []string // Target ARNs
+}
+
+// Exists reports whether the rule was found in EventBridge
+func (r ChangeRule) Exists() bool {
+	return r.State != ""
+}
+
+// NewChangeRule describes the rule ps9s uses for changes of name, or of every
+// parameter under name when prefix is set
+func NewChangeRule(name string, prefix bool) ChangeRule {
+	match := any([]string{name})
+	if prefix {
+		match = []map[string]string{{"prefix": name}}
+	}
+	pattern, _ := json.MarshalIndent(map[string]any{
+		"source":      []string{"aws.ssm"},
+		"detail-type": []string{"Parameter Store Change"},
+		"detail":      map[string]any{"name": match},
+	}, "", "  ")
+
+	suffix := strings.Trim(ruleNameUnsafe.ReplaceAllString(name, "-"), "-")
+	if prefix {
+		suffix += "-all"
+	}
+	ruleName := changeRulePrefix + suffix
+	if len(ruleName) > maxRuleName {
+		// Keep the names of long paths unique with a hash of the full name
+		sum := sha256.Sum256([]byte(ruleName))
+		ruleName = ruleName[:maxRuleName-9] + "-" + hex.EncodeToString(sum[:4])
+	}
+	return ChangeRule{Name: ruleName, Pattern: string(pattern)}
+}
+
+// GetChangeRule looks up rule in EventBridge, filling in its state and targets
+func (c *Client) GetChangeRule(ctx context.Context, rule ChangeRule) (*ChangeRule, error) {
+	if c.events == nil {
+		return nil, ErrEventsUnavailable
+	}
+
+	described, err := c.events.DescribeRule(ctx, &eventbridge.DescribeRuleInput{Name: aws.String(rule.Name)})
+	if ErrorCode(err) == "ResourceNotFoundException" {
+		return &rule, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe rule %s: %w", rule.Name, err)
+	}
+	rule.State = string(described.State)
+	if described.EventPattern != nil {
+		rule.Pattern = aws.ToString(described.EventPattern)
+	}
+
+	targets, err := c.events.ListTargetsByRule(ctx, &eventbridge.ListTargetsByRuleInput{Rule: aws.String(rule.Name)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list targets of rule %s: %w", rule.Name, err)
+	}
+	rule.Targets = nil
+	for _, t := range targets.Targets {
+		rule.Targets = append(rule.Targets, aws.ToString(t.Arn))
+	}
+	return &rule, nil
+}
+
+// PutChangeRule creates or updates rule and adds topicARN as a target. The
+// topic's access policy must let events.amazonaws.com publish to it.
+func (c *Client) PutChangeRule(ctx context.Context, rule ChangeRule, topicARN string) error {
+	if c.events == nil {
+		return ErrEventsUnavailable
+	}
+	if !strings.HasPrefix(topicARN, "arn:") || !strings.Contains(topicARN, ":sns:") {
+		return fmt.Errorf("%q is not an SNS topic ARN", topicARN)
+	}
+	if err := c.checkWrite(WriteRequest{
+		Operation: "PutRule",
+		Name:      rule.Name,
+		Value:     rule.Pattern + "\n\ntarget: " + topicARN,
+	}); err != nil {
+		return err
+	}
+
+	_, err := c.events.PutRule(ctx, &eventbridge.PutRuleInput{
+		Name:         aws.String(rule.Name),
+		EventPattern: aws.String(rule.Pattern),
+		State:        eventtypes.RuleStateEnabled,
+		Description:  aws.String("Parameter Store changes, created by ps9s"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put rule %s: %w", rule.Name, err)
+	}
+
+	topic := topicARN[strings.LastIndexByte(topicARN, ':')+1:]
+	targetID := ruleNameUnsafe.ReplaceAllString(topic, "-")
+	if len(targetID) > maxRuleName {
+		targetID = targetID[:maxRuleName]
+	}
+	out, err := c.events.PutTargets(ctx, &eventbridge.PutTargetsInput{
+		Rule:    aws.String(rule.Name),
+		Targets: []eventtypes.Target{{Id: aws.String(targetID), Arn: aws.String(topicARN)}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add %s to rule %s: %w", topicARN, rule.Name, err)
+	}
+	if out.FailedEntryCount > 0 && len(out.FailedEntries) > 0 {
+		return fmt.Errorf("failed to add %s to rule %s: %s", topicARN, rule.Name, aws.ToString(out.FailedEntries[0].ErrorMessage))
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewChangeRule(t *testing.T) {
+	rule := NewChangeRule("/app/prod/db-url", false)
+	if rule.Name != "ps9s-changes-app-prod-db-url" {
+		t.Errorf("unexpected rule name %q", rule.Name)
+	}
+	var pattern struct {
+		Source []string `json:"source"`
+		Detail struct {
+			Name []any `json:"name"`
+		} `json:"detail"`
+	}
+	if err := json.Unmarshal([]byte(rule.Pattern), &pattern); err != nil {
+		t.Fatal(err)
+	}
+	if pattern.Source[0] != "aws.ssm" || pattern.Detail.Name[0] != "/app/prod/db-url" {
+		t.Errorf("unexpected pattern %s", rule.Pattern)
+	}
+
+	rule = NewChangeRule("/app/prod/", true)
+	if rule.Name != "ps9s-changes-app-prod-all" || !strings.Contains(rule.Pattern, `"prefix": "/app/prod/"`) {
+		t.Errorf("unexpected prefix rule %+v", rule)
+	}
+
+	long := NewChangeRule("/"+strings.Repeat("service/", 12), true)
+	if len(long.Name) > maxRuleName {
+		t.Errorf("rule name longer than %d: %q", maxRuleName, long.Name)
+	}
+}
+
+func TestPutChangeRule(t *testing.T) {
+	isolateAWSConfig(t)
+
+	var targets []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target := r.Header.Get("X-Amz-Target")
+		targets = append(targets, target)
+		body, _ := io.ReadAll(r.Body)
+		switch target {
+		case "AWSEvents.DescribeRule":
+			w.Header().Set("X-Amzn-ErrorType", "ResourceNotFoundException")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message":"Rule does not exist"}`))
+		case "AWSEvents.PutTargets":
+			if !strings.Contains(string(body), `"Id":"param-changes"`) {
+				t.Errorf("unexpected PutTargets body %s", body)
+			}
+			w.Write([]byte(`{"FailedEntryCount":0,"FailedEntries":[]}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("AWS_ENDPOINT_URL", srv.URL)
+
+	c, err := NewClientWithRegion(context.Background(), "default", "us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	rule, err := c.GetChangeRule(ctx, NewChangeRule("/app/", true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.Exists() {
+		t.Fatal("expected a missing rule")
+	}
+
+	if err := c.PutChangeRule(ctx, *rule, "not-an-arn"); err == nil {
+		t.Fatal("expected an error for an invalid topic ARN")
+	}
+	if err := c.PutChangeRule(ctx, *rule, "arn:aws:sns:us-east-1:111122223333:param-changes"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"AWSEvents.DescribeRule", "AWSEvents.PutRule", "AWSEvents.PutTargets"}
+	if strings.Join(targets, ",") != strings.Join(want, ",") {
+		t.Errorf("expected calls %v, got %v", want, targets)
+	}
+}
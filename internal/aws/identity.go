@@ -0,0 +1,89 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// ErrSTSUnavailable is returned by the credential checks of clients without
+// AWS credentials, such as demo clients
+var ErrSTSUnavailable = errors.New("STS is not available for this client")
+
+// Identity is the account and principal a client's credentials belong to
+type Identity struct {
+	Account string
+	ARN     string
+	UserID  string
+}
+
+// ResolveCredentials retrieves the client's credentials, as the first call of
+// a session would, and returns when they expire (zero if they don't)
+func (c *Client) ResolveCredentials(ctx context.Context) (time.Time, error) {
+	if c.sts == nil {
+		return time.Time{}, ErrSTSUnavailable
+	}
+	creds, err := c.sts.Options().Credentials.Retrieve(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !creds.CanExpire {
+		return time.Time{}, nil
+	}
+	return creds.Expires, nil
+}
+
+// CallerIdentity asks STS which account and principal the credentials belong to
+func (c *Client) CallerIdentity(ctx context.Context) (Identity, error) {
+	if c.sts == nil {
+		return Identity{}, ErrSTSUnavailable
+	}
+	out, err := c.sts.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return Identity{}, err
+	}
+	return Identity{Account: aws.ToString(out.Account), ARN: aws.ToString(out.Arn), UserID: aws.ToString(out.UserId)}, nil
+}
+
+// CheckListAccess lists a single parameter to check ssm:DescribeParameters
+func (c *Client) CheckListAccess(ctx context.Context) error {
+	_, err := c.ssmClient.DescribeParameters(ctx, &ssm.DescribeParametersInput{MaxResults: aws.Int32(1)})
+	return err
+}
+
+// CheckReachable connects to the SSM endpoint of the client's region, telling
+// network and DNS problems apart from credential ones
+func (c *Client) CheckReachable(ctx context.Context) error {
+	if c.sts == nil {
+		return nil
+	}
+	region := c.sts.Options().Region
+	host := "ssm." + region + ".amazonaws.com"
+	if strings.HasPrefix(region, "cn-") {
+		host += ".cn"
+	}
+	if c.endpoint != "" {
+		u, err := url.Parse(c.endpoint)
+		if err != nil {
+			return fmt.Errorf("invalid SSM endpoint %s: %w", c.endpoint, err)
+		}
+		host = u.Host
+	}
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, "443")
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", host, err)
+	}
+	return conn.Close()
+}
@@ -0,0 +1,68 @@
+package aws
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCallerIdentity(t *testing.T) {
+	isolateAWSConfig(t)
+
+	denied := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Authorization"), "/sts/aws4_request") {
+			t.Errorf("expected a request signed for sts, got %q", r.Header.Get("Authorization"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), "Action=GetCallerIdentity") {
+			t.Errorf("unexpected request body %q", body)
+		}
+		if denied {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`<ErrorResponse><Error><Type>Sender</Type><Code>InvalidClientTokenId</Code><Message>The security token included in the request is invalid.</Message></Error></ErrorResponse>`))
+			return
+		}
+		w.Write([]byte(`<GetCallerIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <GetCallerIdentityResult>
+    <Arn>arn:aws:sts::123456789012:assumed-role/Admin/dev</Arn>
+    <UserId>AROAEXAMPLE:dev</UserId>
+    <Account>123456789012</Account>
+  </GetCallerIdentityResult>
+</GetCallerIdentityResponse>`))
+	}))
+	defer srv.Close()
+	t.Setenv("AWS_ENDPOINT_URL", srv.URL)
+
+	c, err := NewClientWithRegion(context.Background(), "default", "us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	id, err := c.CallerIdentity(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.Account != "123456789012" || id.ARN != "arn:aws:sts::123456789012:assumed-role/Admin/dev" {
+		t.Fatalf("unexpected identity %+v", id)
+	}
+	if err := c.CheckReachable(ctx); err != nil {
+		t.Fatalf("expected the endpoint to be reachable: %v", err)
+	}
+
+	denied = true
+	if _, err := c.CallerIdentity(ctx); !IsAccessDenied(err) {
+		t.Fatalf("expected the XML error code to be recognised, got %v", err)
+	}
+}
+
+func TestCallerIdentity_DemoClient(t *testing.T) {
+	c := NewDemoClient("demo", "us-east-1")
+	if _, err := c.CallerIdentity(context.Background()); err != ErrSTSUnavailable {
+		t.Fatalf("expected ErrSTSUnavailable, got %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// placeholder matches values left over from bootstrapping, e.g. CHANGEME or TODO
+var placeholder = regexp.MustCompile(`(?i)(?:^|[^a-z0-9])(change[_\-. ]?me|to[_\- ]?do|tbd|fixme|placeholder|replace[_\-. ]?me|dummy|x{3,})(?:$|[^a-z0-9])`)
+
+// invisibleNames names invisible characters commonly pasted into values
+var invisibleNames = map[rune]string{
+	'\u00a0': "no-break space",
+	'\u00ad': "soft hyphen",
+	'\u200b': "zero width space",
+	'\u200c': "zero width non-joiner",
+	'\u200d': "zero width joiner",
+	'\u2060': "word joiner",
+	'\ufeff': "byte order mark",
+}
+
+// LintValue warns about characters that are easy to save by accident but
+// invisible in an editor: trailing whitespace and newlines, Windows line
+// endings and invisible Unicode characters
+func LintValue(value string) []string {
+	var warnings []string
+
+	if strings.Contains(value, "\r\n") {
+		warnings = append(warnings, "uses Windows line endings (CRLF)")
+	} else if strings.Contains(value, "\r") {
+		warnings = append(warnings, "contains carriage returns")
+	}
+
+	trimmed := strings.TrimRight(value, "\r\n")
+	if trimmed != value && trimmed != "" {
+		warnings = append(warnings, "ends with a newline")
+	}
+
+	var trailing []int
+	for i, line := range strings.Split(trimmed, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if line != strings.TrimRight(line, " \t") {
+			trailing = append(trailing, i+1)
+		}
+	}
+	switch {
+	case len(trailing) == 1 && !strings.Contains(trimmed, "\n"):
+		warnings = append(warnings, "ends with whitespace")
+	case len(trailing) > 0:
+		warnings = append(warnings, fmt.Sprintf("has trailing whitespace on %s", lineList(trailing)))
+	}
+
+	line, col := 1, 0
+	for _, r := range value {
+		col++
+		if r == '\n' {
+			line, col = line+1, 0
+			continue
+		}
+		if name, ok := invisibleName(r); ok {
+			warnings = append(warnings, fmt.Sprintf("contains an invisible %s (U+%04X) at line %d, column %d", name, r, line, col))
+		}
+	}
+	return warnings
+}
+
+// invisibleName describes r when it is an invisible character other than whitespace
+func invisibleName(r rune) (string, bool) {
+	if name, ok := invisibleNames[r]; ok {
+		return name, true
+	}
+	switch {
+	case r == '\t' || r == '\r':
+		return "", false
+	case unicode.Is(unicode.Cf, r):
+		return "format character", true
+	case unicode.IsControl(r):
+		return "control character", true
+	}
+	return "", false
+}
+
+// lineList renders line numbers, e.g. "line 3" or "lines 1, 4, 7 and 2 more"
+func lineList(lines []int) string {
+	if len(lines) == 1 {
+		return fmt.Sprintf("line %d", lines[0])
+	}
+	shown := lines[:min(3, len(lines))]
+	parts := make([]string, len(shown))
+	for i, l := range shown {
+		parts[i] = fmt.Sprint(l)
+	}
+	s := "lines " + strings.Join(parts, ", ")
+	if more := len(lines) - len(shown); more > 0 {
+		s += fmt.Sprintf(" and %d more", more)
+	}
+	return s
+}
+
+// PlaceholderReason reports why value looks like it was never filled in:
+// it is blank, a literal empty string or null, or an obvious placeholder
+func PlaceholderReason(value string) (string, bool) {
+	trimmed := strings.TrimSpace(value)
+	switch strings.ToLower(trimmed) {
+	case "":
+		return "blank value", true
+	case `""`, "''", "null", "none", "nil", "-":
+		return fmt.Sprintf("empty value %q", trimmed), true
+	}
+	if m := placeholder.FindStringSubmatch(trimmed); m != nil {
+		return fmt.Sprintf("placeholder %q", m[1]), true
+	}
+	return "", false
+}
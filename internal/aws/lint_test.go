@@ -0,0 +1,46 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLintValue(t *testing.T) {
+	tests := map[string][]string{
+		"clean":               nil,
+		"multi\nline":         nil,
+		"token \n":            {"ends with a newline", "ends with whitespace"},
+		"a\r\nb\r\n":          {"uses Windows line endings (CRLF)", "ends with a newline"},
+		"a \nb\nc\t":          {"has trailing whitespace on lines 1, 3"},
+		"pass\u200bword":      {"contains an invisible zero width space (U+200B) at line 1, column 5"},
+		"\ufeffkey=value":     {"contains an invisible byte order mark (U+FEFF) at line 1, column 1"},
+		"x\ny\u00a0":          {"contains an invisible no-break space (U+00A0) at line 2, column 2"},
+		"tabs\tinside\tvalue": nil,
+	}
+	for value, want := range tests {
+		if got := LintValue(value); !reflect.DeepEqual(got, want) {
+			t.Errorf("LintValue(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestPlaceholderReason(t *testing.T) {
+	tests := map[string]string{
+		"CHANGEME":                         `placeholder "CHANGEME"`,
+		"postgres://user:change_me@db/app": `placeholder "change_me"`,
+		"TODO: set the real key":           `placeholder "TODO"`,
+		"xxxx":                             `placeholder "xxxx"`,
+		"  ":                               "blank value",
+		`""`:                               `empty value "\"\""`,
+		"null":                             `empty value "null"`,
+		"db.internal":                      "",
+		"todos-service":                    "",
+		"box":                              "",
+	}
+	for value, want := range tests {
+		got, ok := PlaceholderReason(value)
+		if got != want || ok != (want != "") {
+			t.Errorf("PlaceholderReason(%q) = %q, %v, want %q", value, got, ok, want)
+		}
+	}
+}
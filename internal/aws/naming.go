@@ -0,0 +1,82 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Parameter Store naming limits
+const (
+	maxParameterNameLength = 1011
+	maxHierarchyDepth      = 15
+)
+
+// Parameter Store value size limits in bytes, by tier
+const (
+	MaxStandardValueSize = 4096
+	MaxAdvancedValueSize = 8192
+)
+
+// MaxValueSize returns the largest value a parameter of tier can hold;
+// Intelligent-Tiering switches to Advanced when a value needs it
+func MaxValueSize(tier string) int {
+	if tier == "Advanced" || tier == "Intelligent-Tiering" {
+		return MaxAdvancedValueSize
+	}
+	return MaxStandardValueSize
+}
+
+// ValidateParameterName checks name against the Parameter Store naming rules so
+// mistakes are caught before PutParameter rejects them
+func ValidateParameterName(name string) error {
+	if name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(name) > maxParameterNameLength {
+		return fmt.Errorf("name is %d characters, the maximum is %d", len(name), maxParameterNameLength)
+	}
+
+	for _, r := range name {
+		if !isParameterNameRune(r) {
+			if r == ' ' {
+				return fmt.Errorf("name must not contain spaces")
+			}
+			return fmt.Errorf("character %q is not allowed (use letters, digits, . _ - /)", r)
+		}
+	}
+
+	if strings.Contains(name, "/") {
+		if !strings.HasPrefix(name, "/") {
+			return fmt.Errorf("hierarchical names must start with /")
+		}
+		if strings.HasSuffix(name, "/") {
+			return fmt.Errorf("name must not end with /")
+		}
+		if strings.Contains(name, "//") {
+			return fmt.Errorf("name must not contain empty path segments (//)")
+		}
+		if depth := strings.Count(name, "/"); depth > maxHierarchyDepth {
+			return fmt.Errorf("name has %d levels, the maximum is %d", depth, maxHierarchyDepth)
+		}
+	}
+
+	first := strings.ToLower(strings.SplitN(strings.TrimPrefix(name, "/"), "/", 2)[0])
+	for _, reserved := range []string{"aws", "ssm"} {
+		if strings.HasPrefix(first, reserved) {
+			return fmt.Errorf("names beginning with %q are reserved", reserved)
+		}
+	}
+
+	return nil
+}
+
+// isParameterNameRune reports whether r may appear in a parameter name
+func isParameterNameRune(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	case r == '.', r == '_', r == '-', r == '/':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package aws
+
+import "testing"
+
+func TestValidateParameterName(t *testing.T) {
+	valid := []string{"app-config", "/app/prod/db.host", "/team_1/service-a/KEY"}
+	for _, name := range valid {
+		if err := ValidateParameterName(name); err != nil {
+			t.Errorf("%q should be valid, got %v", name, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"app/prod",       // hierarchy without leading slash
+		"/app/prod/",     // trailing slash
+		"/app//prod",     // empty segment
+		"/app/my key",    // space
+		"/app/p@ss",      // disallowed character
+		"/aws/reference", // reserved prefix
+		"/SSM/custom",    // reserved prefix, case-insensitive
+		"awsconfig",      // reserved prefix without hierarchy
+		"/1/2/3/4/5/6/7/8/9/10/11/12/13/14/15/16", // too deep
+	}
+	for _, name := range invalid {
+		if err := ValidateParameterName(name); err == nil {
+			t.Errorf("%q should be rejected", name)
+		}
+	}
+}
@@ -3,6 +3,9 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -19,18 +22,81 @@ type Parameter struct {
 	Version          int64
 	LastModifiedDate time.Time
 	DataType         string
+	KeyID            string // KMS key used for SecureString parameters
+	Tier             string // Standard, Advanced or Intelligent-Tiering
+	LastModifiedUser string
+	Description      string // Set from listings only
 }
 
-// ListParameters retrieves all parameters for the profile with pagination
+// ListParameters retrieves all parameters for the profile with pagination,
+// followed by the parameters other accounts share with it through AWS RAM
 func (c *Client) ListParameters(ctx context.Context) ([]*Parameter, error) {
+	parameters, err := c.describeParameters(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := c.describeParameters(ctx, true)
+	if err != nil && !IsValidation(err) {
+		// Endpoints that emulate SSM may not know the Shared option
+		return nil, err
+	}
+	parameters = append(parameters, shared...)
+	return append(parameters, c.configuredShared(ctx, parameters)...), nil
+}
+
+// configuredShared fetches the configured shared ARNs that are not listed yet.
+// They are best effort: ARNs from another region or without access are skipped.
+func (c *Client) configuredShared(ctx context.Context, listed []*Parameter) []*Parameter {
+	seen := make(map[string]bool, len(listed))
+	for _, p := range listed {
+		seen[p.Name] = true
+	}
+	var missing []string
+	for _, arn := range c.sharedARNs {
+		if !seen[arn] {
+			missing = append(missing, arn)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	var params []*Parameter
+	for _, arn := range missing {
+		found, err := c.GetParameters(ctx, []string{arn}, false)
+		if err != nil {
+			continue
+		}
+		for _, p := range found {
+			p.Value = ""
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// describeParameters lists the account's own parameters, or the shared ones.
+// Shared parameters are named by their ARN, which is how they are addressed.
+func (c *Client) describeParameters(ctx context.Context, shared bool) ([]*Parameter, error) {
 	var parameters []*Parameter
 	var nextToken *string
 
 	for {
 		input := &ssm.DescribeParametersInput{
-			MaxResults: aws.Int32(50), // Max allowed by AWS
+			MaxResults: aws.Int32(c.maxResults),
 			NextToken:  nextToken,
 		}
+		if shared {
+			input.Shared = aws.Bool(true)
+		}
+		if c.pathPrefix != "" {
+			input.ParameterFilters = []types.ParameterStringFilter{{
+				Key:    aws.String("Name"),
+				Option: aws.String("BeginsWith"),
+				Values: []string{c.pathPrefix},
+			}}
+		}
 
 		output, err := c.ssmClient.DescribeParameters(ctx, input)
 		if err != nil {
@@ -43,6 +109,7 @@ func (c *Client) ListParameters(ctx context.Context) ([]*Parameter, error) {
 				Type:             string(p.Type),
 				Version:          p.Version,
 				LastModifiedDate: aws.ToTime(p.LastModifiedDate),
+				Tier:             string(p.Tier),
 			}
 			if p.ARN != nil {
 				param.ARN = aws.ToString(p.ARN)
@@ -50,6 +117,16 @@ func (c *Client) ListParameters(ctx context.Context) ([]*Parameter, error) {
 			if p.DataType != nil {
 				param.DataType = aws.ToString(p.DataType)
 			}
+			if p.KeyId != nil {
+				param.KeyID = aws.ToString(p.KeyId)
+			}
+			if p.LastModifiedUser != nil {
+				param.LastModifiedUser = aws.ToString(p.LastModifiedUser)
+			}
+			param.Description = aws.ToString(p.Description)
+			if shared {
+				param.Name = param.ARN
+			}
 			parameters = append(parameters, param)
 		}
 
@@ -62,6 +139,20 @@ func (c *Client) ListParameters(ctx context.Context) ([]*Parameter, error) {
 	return parameters, nil
 }
 
+// IsShared reports whether name is the ARN of a parameter another account
+// shares through AWS RAM. Shared parameters can be read but not changed.
+func IsShared(name string) bool {
+	return strings.HasPrefix(name, "arn:")
+}
+
+// sharedName keeps the ARN a shared parameter was requested by as its name
+func sharedName(requested string, p *types.Parameter) string {
+	if IsShared(requested) {
+		return requested
+	}
+	return aws.ToString(p.Name)
+}
+
 // GetParameter retrieves a specific parameter with its value (decrypted if SecureString)
 func (c *Client) GetParameter(ctx context.Context, name string) (*Parameter, error) {
 	withDecryption := true
@@ -76,7 +167,7 @@ func (c *Client) GetParameter(ctx context.Context, name string) (*Parameter, err
 
 	p := output.Parameter
 	param := &Parameter{
-		Name:             aws.ToString(p.Name),
+		Name:             sharedName(name, p),
 		Type:             string(p.Type),
 		Value:            aws.ToString(p.Value),
 		ARN:              aws.ToString(p.ARN),
@@ -90,6 +181,226 @@ func (c *Client) GetParameter(ctx context.Context, name string) (*Parameter, err
 	return param, nil
 }
 
+// maxGetParametersNames is the most names GetParameters accepts per call
+const maxGetParametersNames = 10
+
+// GetParameters fetches names in batches, up to Concurrency batches at a time,
+// returning them in the order given. Names that no longer exist are left out.
+func (c *Client) GetParameters(ctx context.Context, names []string, withDecryption bool) ([]*Parameter, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	found := make(map[string]*Parameter, len(names))
+	requested := make(map[string]bool, len(names))
+	for _, name := range names {
+		requested[name] = true
+	}
+	slots := make(chan struct{}, c.Concurrency())
+	for start := 0; start < len(names); start += maxGetParametersNames {
+		batch := names[start:min(start+maxGetParametersNames, len(names))]
+		slots <- struct{}{}
+		if ctx.Err() != nil {
+			// An earlier batch failed
+			<-slots
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			output, err := c.ssmClient.GetParameters(ctx, &ssm.GetParametersInput{
+				Names:          batch,
+				WithDecryption: aws.Bool(withDecryption),
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to get parameters: %w", err)
+					cancel()
+				}
+				return
+			}
+			for _, p := range output.Parameters {
+				param := &Parameter{
+					Name:             aws.ToString(p.Name),
+					Type:             string(p.Type),
+					Value:            aws.ToString(p.Value),
+					ARN:              aws.ToString(p.ARN),
+					Version:          p.Version,
+					LastModifiedDate: aws.ToTime(p.LastModifiedDate),
+					DataType:         aws.ToString(p.DataType),
+				}
+				if !requested[param.Name] && requested[param.ARN] {
+					// Shared parameters were requested by ARN
+					param.Name = param.ARN
+				}
+				found[param.Name] = param
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	params := make([]*Parameter, 0, len(found))
+	for _, name := range names {
+		if p, ok := found[name]; ok {
+			params = append(params, p)
+		}
+	}
+	return params, nil
+}
+
+// GetParameterValues fetches the undecrypted values of names, keyed by name
+func (c *Client) GetParameterValues(ctx context.Context, names []string) (map[string]string, error) {
+	params, err := c.GetParameters(ctx, names, false)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(params))
+	for _, p := range params {
+		values[p.Name] = p.Value
+	}
+	return values, nil
+}
+
+// DefaultKeyID is the AWS managed key SecureStrings use when no key is given
+const DefaultKeyID = "alias/aws/ssm"
+
+// maxFilterValues is the most values a DescribeParameters filter accepts
+const maxFilterValues = 50
+
+// KeyIDs returns the KMS keys of the SecureString parameters among names,
+// keyed by name, as GetParameters does not report them
+func (c *Client) KeyIDs(ctx context.Context, names []string) (map[string]string, error) {
+	keys := make(map[string]string, len(names))
+	for start := 0; start < len(names); start += maxFilterValues {
+		batch := names[start:min(start+maxFilterValues, len(names))]
+		var nextToken *string
+		for {
+			output, err := c.ssmClient.DescribeParameters(ctx, &ssm.DescribeParametersInput{
+				ParameterFilters: []types.ParameterStringFilter{{
+					Key:    aws.String("Name"),
+					Option: aws.String("Equals"),
+					Values: batch,
+				}},
+				NextToken: nextToken,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to describe parameters: %w", err)
+			}
+			for _, p := range output.Parameters {
+				if p.KeyId != nil {
+					keys[aws.ToString(p.Name)] = aws.ToString(p.KeyId)
+				}
+			}
+			nextToken = output.NextToken
+			if nextToken == nil {
+				break
+			}
+		}
+	}
+	return keys, nil
+}
+
+// maxGetParametersByPathResults is the page size GetParametersByPath allows
+const maxGetParametersByPathResults = 10
+
+// GetParametersByPath lists the parameters under path (one level, or the
+// whole subtree when recursive), so a hierarchy can be browsed without
+// listing the account. Values are not decrypted and not kept.
+func (c *Client) GetParametersByPath(ctx context.Context, path string, recursive bool) ([]*Parameter, error) {
+	var params []*Parameter
+	var nextToken *string
+
+	for {
+		output, err := c.ssmClient.GetParametersByPath(ctx, &ssm.GetParametersByPathInput{
+			Path:       aws.String(path),
+			Recursive:  aws.Bool(recursive),
+			MaxResults: aws.Int32(maxGetParametersByPathResults),
+			NextToken:  nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parameters under %s: %w", path, err)
+		}
+
+		for _, p := range output.Parameters {
+			params = append(params, &Parameter{
+				Name:             aws.ToString(p.Name),
+				Type:             string(p.Type),
+				ARN:              aws.ToString(p.ARN),
+				Version:          p.Version,
+				LastModifiedDate: aws.ToTime(p.LastModifiedDate),
+				DataType:         aws.ToString(p.DataType),
+			})
+		}
+
+		nextToken = output.NextToken
+		if nextToken == nil {
+			break
+		}
+	}
+
+	sort.Slice(params, func(i, j int) bool {
+		return params[i].Name < params[j].Name
+	})
+	return params, nil
+}
+
+// GetParameterHistory retrieves all versions of a parameter (decrypted), newest first
+func (c *Client) GetParameterHistory(ctx context.Context, name string) ([]*Parameter, error) {
+	var versions []*Parameter
+	var nextToken *string
+
+	for {
+		output, err := c.ssmClient.GetParameterHistory(ctx, &ssm.GetParameterHistoryInput{
+			Name:           aws.String(name),
+			WithDecryption: aws.Bool(true),
+			MaxResults:     aws.Int32(50), // Max allowed by AWS
+			NextToken:      nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get history for parameter %s: %w", name, err)
+		}
+
+		for _, h := range output.Parameters {
+			versions = append(versions, &Parameter{
+				Name:             aws.ToString(h.Name),
+				Type:             string(h.Type),
+				Value:            aws.ToString(h.Value),
+				Version:          h.Version,
+				LastModifiedDate: aws.ToTime(h.LastModifiedDate),
+				LastModifiedUser: aws.ToString(h.LastModifiedUser),
+				DataType:         aws.ToString(h.DataType),
+				KeyID:            aws.ToString(h.KeyId),
+				Tier:             string(h.Tier),
+			})
+		}
+
+		nextToken = output.NextToken
+		if nextToken == nil {
+			break
+		}
+	}
+
+	// AWS returns oldest first
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Version > versions[j].Version
+	})
+
+	return versions, nil
+}
+
 // PutParameter updates a parameter's value
 func (c *Client) PutParameter(ctx context.Context, name, value, paramType string) error {
 	// Use Overwrite to update existing parameter
@@ -102,6 +413,16 @@ func (c *Client) PutParameter(ctx context.Context, name, value, paramType string
 		Overwrite: aws.Bool(overwrite),
 	}
 
+	if err := c.checkWrite(WriteRequest{
+		Operation: "PutParameter",
+		Name:      name,
+		Value:     value,
+		Type:      paramType,
+		Overwrite: overwrite,
+	}); err != nil {
+		return err
+	}
+
 	_, err := c.ssmClient.PutParameter(ctx, input)
 	if err != nil {
 		return fmt.Errorf("failed to put parameter %s: %w", name, err)
@@ -109,3 +430,154 @@ func (c *Client) PutParameter(ctx context.Context, name, value, paramType string
 
 	return nil
 }
+
+// PutSecureParameter overwrites a parameter as SecureString, encrypted with keyID
+// (an ID, ARN or alias). An empty keyID uses the account default alias/aws/ssm key.
+func (c *Client) PutSecureParameter(ctx context.Context, name, value, keyID string) error {
+	input := &ssm.PutParameterInput{
+		Name:      aws.String(name),
+		Value:     aws.String(value),
+		Type:      types.ParameterTypeSecureString,
+		Overwrite: aws.Bool(true),
+	}
+	if keyID != "" {
+		input.KeyId = aws.String(keyID)
+	}
+
+	if err := c.checkWrite(WriteRequest{
+		Operation: "PutParameter",
+		Name:      name,
+		Value:     value,
+		Type:      string(types.ParameterTypeSecureString),
+		KeyID:     keyID,
+		Overwrite: true,
+	}); err != nil {
+		return err
+	}
+
+	_, err := c.ssmClient.PutParameter(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to put secure parameter %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// CreateParameter creates a new parameter with optional description and tags, failing if the name already exists.
+// keyID selects the KMS key for SecureString parameters; empty uses the account default.
+func (c *Client) CreateParameter(ctx context.Context, name, value, paramType, keyID, description string, tags []Tag) error {
+	input := &ssm.PutParameterInput{
+		Name:      aws.String(name),
+		Value:     aws.String(value),
+		Type:      types.ParameterType(paramType),
+		Overwrite: aws.Bool(false),
+	}
+	if paramType != string(types.ParameterTypeSecureString) {
+		keyID = ""
+	}
+	if keyID != "" {
+		input.KeyId = aws.String(keyID)
+	}
+	if description != "" {
+		input.Description = aws.String(description)
+	}
+	if len(tags) > 0 {
+		input.Tags = sdkTags(tags)
+	}
+
+	if err := c.checkWrite(WriteRequest{
+		Operation:   "PutParameter",
+		Name:        name,
+		Value:       value,
+		Type:        paramType,
+		KeyID:       keyID,
+		Tags:        tags,
+		Description: description,
+	}); err != nil {
+		return err
+	}
+
+	_, err := c.ssmClient.PutParameter(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to create parameter %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// CopyParameter creates name as a copy of p with tags, keeping its value,
+// type, description, tier, data type and KMS key. It fails if name exists,
+// unless overwrite is set; an overwritten parameter keeps its own tags, since
+// PutParameter cannot tag existing parameters.
+func (c *Client) CopyParameter(ctx context.Context, p *Parameter, name string, tags []Tag, overwrite bool) error {
+	input := &ssm.PutParameterInput{
+		Name:      aws.String(name),
+		Value:     aws.String(p.Value),
+		Type:      types.ParameterType(p.Type),
+		Overwrite: aws.Bool(overwrite),
+	}
+	if p.Description != "" {
+		input.Description = aws.String(p.Description)
+	}
+	if p.Tier != "" {
+		input.Tier = types.ParameterTier(p.Tier)
+	}
+	if p.DataType != "" {
+		input.DataType = aws.String(p.DataType)
+	}
+	keyID := ""
+	if p.Type == string(types.ParameterTypeSecureString) && p.KeyID != "" {
+		keyID = p.KeyID
+		input.KeyId = aws.String(keyID)
+	}
+	if overwrite {
+		tags = nil
+	}
+	if len(tags) > 0 {
+		input.Tags = sdkTags(tags)
+	}
+
+	if err := c.checkWrite(WriteRequest{
+		Operation: "PutParameter",
+		Name:      name,
+		Value:     p.Value,
+		Type:      p.Type,
+		Tier:      p.Tier,
+		KeyID:     keyID,
+		Tags:      tags,
+	}); err != nil {
+		return err
+	}
+
+	if _, err := c.ssmClient.PutParameter(ctx, input); err != nil {
+		return fmt.Errorf("failed to copy parameter %s to %s: %w", p.Name, name, err)
+	}
+	return nil
+}
+
+// maxDeleteParametersNames is the most names DeleteParameters accepts per call
+const maxDeleteParametersNames = 10
+
+// DeleteParameters deletes names in batches and returns the names that were
+// deleted; names that no longer exist are skipped. The deleted names are
+// returned with the error when a later batch fails.
+func (c *Client) DeleteParameters(ctx context.Context, names []string) ([]string, error) {
+	// One request covers the batch, so a dry run previews every name
+	if err := c.checkWrite(WriteRequest{
+		Operation: "DeleteParameters",
+		Names:     names,
+	}); err != nil {
+		return nil, err
+	}
+
+	var deleted []string
+	for start := 0; start < len(names); start += maxDeleteParametersNames {
+		batch := names[start:min(start+maxDeleteParametersNames, len(names))]
+		output, err := c.ssmClient.DeleteParameters(ctx, &ssm.DeleteParametersInput{Names: batch})
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete parameters: %w", err)
+		}
+		deleted = append(deleted, output.DeletedParameters...)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,88 @@
+package aws
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// PolicyDocument is an IAM policy document
+type PolicyDocument struct {
+	Version   string            `json:"Version"`
+	Statement []PolicyStatement `json:"Statement"`
+}
+
+// PolicyStatement is one statement of an IAM policy document
+type PolicyStatement struct {
+	Sid       string                       `json:"Sid"`
+	Effect    string                       `json:"Effect"`
+	Action    []string                     `json:"Action"`
+	Resource  []string                     `json:"Resource"`
+	Condition map[string]map[string]string `json:"Condition,omitempty"`
+}
+
+// IAMPolicy returns the minimal policy ps9s needs: reading parameters and
+// their tags, plus changing them when write is set. A non-empty prefix
+// limits parameter access to names beginning with it, e.g. "/app/".
+func IAMPolicy(write bool, prefix string) PolicyDocument {
+	parameters := []string{"arn:aws:ssm:*:*:parameter/" + strings.TrimPrefix(prefix, "/") + "*"}
+	viaSSM := map[string]map[string]string{"StringLike": {"kms:ViaService": "ssm.*.amazonaws.com"}}
+
+	doc := PolicyDocument{
+		Version: "2012-10-17",
+		Statement: []PolicyStatement{
+			{
+				// DescribeParameters does not support resource-level permissions
+				Sid:      "ListParameters",
+				Effect:   "Allow",
+				Action:   []string{"ssm:DescribeParameters"},
+				Resource: []string{"*"},
+			},
+			{
+				Sid:    "ReadParameters",
+				Effect: "Allow",
+				Action: []string{
+					"ssm:GetParameter",
+					"ssm:GetParameters",
+					"ssm:GetParameterHistory",
+					"ssm:ListTagsForResource",
+				},
+				Resource: parameters,
+			},
+			{
+				Sid:       "DecryptSecureStrings",
+				Effect:    "Allow",
+				Action:    []string{"kms:Decrypt"},
+				Resource:  []string{"*"},
+				Condition: viaSSM,
+			},
+		},
+	}
+	if write {
+		doc.Statement = append(doc.Statement,
+			PolicyStatement{
+				Sid:    "WriteParameters",
+				Effect: "Allow",
+				Action: []string{
+					"ssm:PutParameter",
+					"ssm:AddTagsToResource",
+					"ssm:RemoveTagsFromResource",
+				},
+				Resource: parameters,
+			},
+			PolicyStatement{
+				Sid:       "EncryptSecureStrings",
+				Effect:    "Allow",
+				Action:    []string{"kms:Encrypt", "kms:GenerateDataKey"},
+				Resource:  []string{"*"},
+				Condition: viaSSM,
+			},
+		)
+	}
+	return doc
+}
+
+// JSON returns the document indented for pasting into the IAM console
+func (d PolicyDocument) JSON() string {
+	data, _ := json.MarshalIndent(d, "", "  ")
+	return string(data)
+}
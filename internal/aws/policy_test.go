@@ -0,0 +1,32 @@
+package aws
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestIAMPolicy(t *testing.T) {
+	read := IAMPolicy(false, "/app/")
+	for _, s := range read.Statement {
+		if slices.Contains(s.Action, "ssm:PutParameter") {
+			t.Fatalf("read-only policy allows %v", s.Action)
+		}
+	}
+	if got := read.Statement[1].Resource[0]; got != "arn:aws:ssm:*:*:parameter/app/*" {
+		t.Errorf("expected parameters under /app/, got %s", got)
+	}
+	if got := IAMPolicy(false, "").Statement[1].Resource[0]; got != "arn:aws:ssm:*:*:parameter/*" {
+		t.Errorf("expected every parameter without a prefix, got %s", got)
+	}
+
+	write := IAMPolicy(true, "")
+	if len(write.Statement) != len(read.Statement)+2 || !slices.Contains(write.Statement[3].Action, "ssm:PutParameter") {
+		t.Fatalf("expected write statements, got %+v", write.Statement)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(write.JSON()), &decoded); err != nil || decoded["Version"] != "2012-10-17" {
+		t.Fatalf("invalid policy JSON: %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package aws
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	// dynamicReference matches CloudFormation-style references such as
+	// {{resolve:ssm:/app/db-host}}, {{ssm:/app/db-host}} or {{ssm-secure:/app/key:3}}
+	dynamicReference = regexp.MustCompile(`\{\{\s*(?:resolve:)?ssm(?:-secure)?:([A-Za-z0-9_.\-/]+?)(?::\d+)?\s*\}\}`)
+	// parameterARN matches parameter ARNs, capturing the part after "parameter"
+	parameterARN = regexp.MustCompile(`arn:aws[a-z\-]*:ssm:[a-z0-9\-]*:\d*:parameter(/[A-Za-z0-9_.\-/]+)`)
+	// pathLike matches /path-like strings, counted only when such a parameter exists
+	pathLike = regexp.MustCompile(`(?:^|[\s"'=,:(\[{])(/[A-Za-z0-9_.\-]+(?:/[A-Za-z0-9_.\-]+)*)`)
+)
+
+// ParameterReferences returns the parameter names value refers to, in order of
+// appearance. Dynamic references and ARNs count even when the parameter does
+// not exist, so dangling references show up; plain paths only when known
+// reports the name as an existing parameter.
+func ParameterReferences(value string, known func(string) bool) []string {
+	var refs []string
+	add := func(name string) {
+		if !slices.Contains(refs, name) {
+			refs = append(refs, name)
+		}
+	}
+
+	for _, m := range dynamicReference.FindAllStringSubmatch(value, -1) {
+		add(m[1])
+	}
+	for _, m := range parameterARN.FindAllStringSubmatch(value, -1) {
+		// Names without a leading slash appear as parameter/NAME
+		if name := strings.TrimPrefix(m[1], "/"); !known(m[1]) && known(name) {
+			add(name)
+		} else {
+			add(m[1])
+		}
+	}
+	// Blank out explicit references so their paths are not matched twice
+	rest := parameterARN.ReplaceAllString(dynamicReference.ReplaceAllString(value, " "), " ")
+	for _, m := range pathLike.FindAllStringSubmatch(rest, -1) {
+		if name := strings.TrimRight(m[1], "."); known(name) {
+			add(name)
+		}
+	}
+	return refs
+}
+
+// ReferenceGraph links parameters to the parameters their values refer to
+type ReferenceGraph struct {
+	Refs         map[string][]string // Names each parameter refers to
+	ReferencedBy map[string][]string // Names of the parameters referring to each name, sorted
+}
+
+// BuildReferenceGraph finds the references between parameters from their
+// values, keyed by name; every key counts as an existing parameter
+func BuildReferenceGraph(values map[string]string) ReferenceGraph {
+	g := ReferenceGraph{
+		Refs:         make(map[string][]string),
+		ReferencedBy: make(map[string][]string),
+	}
+	known := func(name string) bool {
+		_, ok := values[name]
+		return ok
+	}
+	for name, value := range values {
+		for _, ref := range ParameterReferences(value, known) {
+			if ref == name {
+				continue
+			}
+			g.Refs[name] = append(g.Refs[name], ref)
+			g.ReferencedBy[ref] = append(g.ReferencedBy[ref], name)
+		}
+	}
+	for _, names := range g.ReferencedBy {
+		slices.Sort(names)
+	}
+	return g
+}
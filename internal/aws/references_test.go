@@ -0,0 +1,36 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParameterReferences(t *testing.T) {
+	known := func(name string) bool {
+		return name == "/app/db-host" || name == "/app/db-port" || name == "plain"
+	}
+	value := `{"host": "{{resolve:ssm:/app/db-host}}", "port": "/app/db-port",` +
+		` "shared": "arn:aws:ssm:eu-west-1:123456789012:parameter/platform/vpc-id",` +
+		` "legacy": "arn:aws:ssm:eu-west-1:123456789012:parameter/plain",` +
+		` "missing": "{{ssm:/app/gone:2}}", "url": "https://example.com/app/db-port", "dir": "/tmp/cache"}`
+
+	got := ParameterReferences(value, known)
+	want := []string{"/app/db-host", "/app/gone", "/platform/vpc-id", "plain", "/app/db-port"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParameterReferences() = %v, want %v", got, want)
+	}
+}
+
+func TestBuildReferenceGraph(t *testing.T) {
+	g := BuildReferenceGraph(map[string]string{
+		"/app/a":     "/shared/db",
+		"/app/b":     "{{ssm:/shared/db}} and /app/b",
+		"/shared/db": "db.internal",
+	})
+	if !reflect.DeepEqual(g.ReferencedBy["/shared/db"], []string{"/app/a", "/app/b"}) {
+		t.Errorf("unexpected referrers: %v", g.ReferencedBy["/shared/db"])
+	}
+	if !reflect.DeepEqual(g.Refs["/app/b"], []string{"/shared/db"}) {
+		t.Errorf("self-references should be skipped, got %v", g.Refs["/app/b"])
+	}
+}
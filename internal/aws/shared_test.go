@@ -0,0 +1,98 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+const sharedARN = "arn:aws:ssm:eu-west-1:210987654321:parameter/platform/vpc-id"
+
+// sharedSSM adds one parameter shared from another account to a demo backend
+type sharedSSM struct {
+	*demoSSM
+}
+
+func (s sharedSSM) DescribeParameters(ctx context.Context, in *ssm.DescribeParametersInput, opts ...func(*ssm.Options)) (*ssm.DescribeParametersOutput, error) {
+	if !aws.ToBool(in.Shared) {
+		return s.demoSSM.DescribeParameters(ctx, in, opts...)
+	}
+	return &ssm.DescribeParametersOutput{Parameters: []types.ParameterMetadata{{
+		Name: aws.String("/platform/vpc-id"),
+		ARN:  aws.String(sharedARN),
+		Type: types.ParameterTypeString,
+	}}}, nil
+}
+
+func (s sharedSSM) GetParameters(ctx context.Context, in *ssm.GetParametersInput, opts ...func(*ssm.Options)) (*ssm.GetParametersOutput, error) {
+	if len(in.Names) != 1 || in.Names[0] != sharedARN {
+		return s.demoSSM.GetParameters(ctx, in, opts...)
+	}
+	// AWS answers with the plain name for parameters requested by ARN
+	return &ssm.GetParametersOutput{Parameters: []types.Parameter{{
+		Name:  aws.String("/platform/vpc-id"),
+		ARN:   aws.String(sharedARN),
+		Type:  types.ParameterTypeString,
+		Value: aws.String("vpc-0abc"),
+	}}}, nil
+}
+
+func TestListParameters_IncludesShared(t *testing.T) {
+	c := &Client{ssmClient: sharedSSM{newDemoSSM("demo", "eu-west-1")}, maxResults: defaultMaxResults}
+
+	params, err := c.ListParameters(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := params[len(params)-1]
+	if last.Name != sharedARN || !IsShared(last.Name) {
+		t.Fatalf("expected the shared parameter last, named by ARN, got %q", last.Name)
+	}
+	for _, p := range params[:len(params)-1] {
+		if IsShared(p.Name) {
+			t.Fatalf("own parameter %q reported as shared", p.Name)
+		}
+	}
+
+	values, err := c.GetParameterValues(context.Background(), []string{sharedARN})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values[sharedARN] != "vpc-0abc" {
+		t.Fatalf("expected the shared value keyed by ARN, got %v", values)
+	}
+
+	if err := c.PutParameter(context.Background(), sharedARN, "vpc-1", "String"); !errors.Is(err, ErrShared) {
+		t.Fatalf("expected ErrShared, got %v", err)
+	}
+}
+
+// unlistedSSM shares sharedARN without it showing up in shared listings
+type unlistedSSM struct {
+	sharedSSM
+}
+
+func (s unlistedSSM) DescribeParameters(ctx context.Context, in *ssm.DescribeParametersInput, opts ...func(*ssm.Options)) (*ssm.DescribeParametersOutput, error) {
+	return s.demoSSM.DescribeParameters(ctx, in, opts...)
+}
+
+func TestListParameters_AddsConfiguredARNs(t *testing.T) {
+	c := &Client{ssmClient: unlistedSSM{sharedSSM{newDemoSSM("demo", "eu-west-1")}}, maxResults: defaultMaxResults}
+	c.SetSharedARNs([]string{sharedARN, "arn:aws:ssm:eu-west-1:210987654321:parameter/gone"})
+
+	params, err := c.ListParameters(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := params[len(params)-1]
+	if last.Name != sharedARN || last.Value != "" || last.Type != "String" {
+		t.Fatalf("expected the configured ARN listed last without its value, got %+v", last)
+	}
+	if IsShared(params[len(params)-2].Name) {
+		t.Fatal("expected the missing ARN to be skipped")
+	}
+}
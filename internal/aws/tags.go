@@ -0,0 +1,117 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// MaxTags is the most tags Parameter Store allows on one parameter
+const MaxTags = 50
+
+// Tag is a key/value pair attached to a parameter
+type Tag struct {
+	Key   string
+	Value string
+}
+
+// ListTags returns the tags of a parameter sorted by key
+func (c *Client) ListTags(ctx context.Context, name string) ([]Tag, error) {
+	output, err := c.ssmClient.ListTagsForResource(ctx, &ssm.ListTagsForResourceInput{
+		ResourceId:   aws.String(name),
+		ResourceType: types.ResourceTypeForTaggingParameter,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags for parameter %s: %w", name, err)
+	}
+
+	tags := make([]Tag, len(output.TagList))
+	for i, t := range output.TagList {
+		tags[i] = Tag{Key: aws.ToString(t.Key), Value: aws.ToString(t.Value)}
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Key < tags[j].Key })
+	return tags, nil
+}
+
+// UpdateTags changes the tags of a parameter from old to updated, adding or
+// overwriting changed tags and removing dropped keys
+func (c *Client) UpdateTags(ctx context.Context, name string, old, updated []Tag) error {
+	add, remove := DiffTags(old, updated)
+
+	if len(remove) > 0 {
+		removed := make([]Tag, len(remove))
+		for i, k := range remove {
+			removed[i] = Tag{Key: k}
+		}
+		if err := c.checkWrite(WriteRequest{
+			Operation: "RemoveTagsFromResource",
+			Name:      name,
+			Tags:      removed,
+		}); err != nil {
+			return err
+		}
+		_, err := c.ssmClient.RemoveTagsFromResource(ctx, &ssm.RemoveTagsFromResourceInput{
+			ResourceId:   aws.String(name),
+			ResourceType: types.ResourceTypeForTaggingParameter,
+			TagKeys:      remove,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove tags from parameter %s: %w", name, err)
+		}
+	}
+
+	if len(add) > 0 {
+		if err := c.checkWrite(WriteRequest{
+			Operation: "AddTagsToResource",
+			Name:      name,
+			Tags:      add,
+		}); err != nil {
+			return err
+		}
+		_, err := c.ssmClient.AddTagsToResource(ctx, &ssm.AddTagsToResourceInput{
+			ResourceId:   aws.String(name),
+			ResourceType: types.ResourceTypeForTaggingParameter,
+			Tags:         sdkTags(add),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add tags to parameter %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// DiffTags returns the tags in updated that are new or changed compared to old,
+// and the keys of old that updated no longer has
+func DiffTags(old, updated []Tag) (add []Tag, remove []string) {
+	before := make(map[string]string, len(old))
+	for _, t := range old {
+		before[t.Key] = t.Value
+	}
+	after := make(map[string]bool, len(updated))
+	for _, t := range updated {
+		after[t.Key] = true
+		if v, ok := before[t.Key]; !ok || v != t.Value {
+			add = append(add, t)
+		}
+	}
+	for _, t := range old {
+		if !after[t.Key] {
+			remove = append(remove, t.Key)
+		}
+	}
+	return add, remove
+}
+
+// sdkTags converts tags to the SDK type
+func sdkTags(tags []Tag) []types.Tag {
+	out := make([]types.Tag, len(tags))
+	for i, t := range tags {
+		out[i] = types.Tag{Key: aws.String(t.Key), Value: aws.String(t.Value)}
+	}
+	return out
+}
@@ -0,0 +1,39 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDiffTags(t *testing.T) {
+	old := []Tag{{"env", "prod"}, {"team", "core"}, {"owner", "ops"}}
+	updated := []Tag{{"env", "prod"}, {"team", "platform"}, {"cost", "42"}}
+
+	add, remove := DiffTags(old, updated)
+	if want := []Tag{{"team", "platform"}, {"cost", "42"}}; !reflect.DeepEqual(add, want) {
+		t.Errorf("add = %v, want %v", add, want)
+	}
+	if want := []string{"owner"}; !reflect.DeepEqual(remove, want) {
+		t.Errorf("remove = %v, want %v", remove, want)
+	}
+
+	if add, remove := DiffTags(old, old); add != nil || remove != nil {
+		t.Errorf("expected no changes, got add=%v remove=%v", add, remove)
+	}
+}
+
+func TestUpdateTags_DryRun(t *testing.T) {
+	c := &Client{}
+	c.SetDryRun(true)
+
+	var dryRunErr *DryRunError
+	err := c.UpdateTags(context.Background(), "/app/x", nil, []Tag{{"env", "prod"}})
+	if !errors.As(err, &dryRunErr) {
+		t.Fatalf("expected DryRunError, got %v", err)
+	}
+	if dryRunErr.Request.Operation != "AddTagsToResource" || len(dryRunErr.Request.Tags) != 1 {
+		t.Fatalf("unexpected request %+v", dryRunErr.Request)
+	}
+}
@@ -107,3 +107,203 @@ func parseAWSConfigProfiles(r io.Reader) []string {
 
 	return out
 }
+
+// GetProfileRegion returns the region configured for profile in AWS_CONFIG_FILE
+// or ~/.aws/config, or "" when the profile sets none
+func GetProfileRegion(profile string) (string, error) {
+	path, err := awsConfigPath()
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open AWS config file %q: %w", path, err)
+	}
+	defer f.Close()
+
+	return parseAWSConfigRegion(f, profile), nil
+}
+
+func parseAWSConfigRegion(r io.Reader, profile string) string {
+	want := "profile " + profile
+	if profile == "default" {
+		want = "default"
+	}
+
+	inSection := false
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+
+		// Strip INI-style comments.
+		if i := strings.IndexAny(line, "#;"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(line, "["), "]"))
+			inSection = section == want
+			continue
+		}
+		if !inSection {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if ok && strings.TrimSpace(key) == "region" {
+			return strings.TrimSpace(value)
+		}
+	}
+
+	return ""
+}
+
+// maxRoleChainDepth bounds source_profile links, matching the SDK's refusal
+// to follow cycles
+const maxRoleChainDepth = 10
+
+// RoleStep is one link of a profile's role chain
+type RoleStep struct {
+	Profile          string
+	RoleARN          string // Role assumed by the profile, "" for the base credentials
+	CredentialSource string // credential_source of a role profile without source_profile
+}
+
+// GetRoleChains returns the role chains of the profiles that assume a role,
+// formatted by FormatRoleChain. Profiles whose chain can't be resolved are left out.
+func GetRoleChains(profiles []string) (map[string]string, error) {
+	path, err := awsConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open AWS config file %q: %w", path, err)
+	}
+	defer f.Close()
+
+	sections := parseAWSConfigSections(f)
+	chains := make(map[string]string)
+	for _, p := range profiles {
+		if chain, err := roleChain(sections, p); err == nil && len(chain) > 0 {
+			chains[p] = FormatRoleChain(chain)
+		}
+	}
+	return chains, nil
+}
+
+// roleChain follows the source_profile links of profile through sections
+func roleChain(sections map[string]map[string]string, profile string) ([]RoleStep, error) {
+	var chain []RoleStep
+	seen := map[string]bool{}
+	for name := profile; ; {
+		keys := sections[name]
+		step := RoleStep{Profile: name, RoleARN: keys["role_arn"]}
+		if step.RoleARN == "" {
+			if len(chain) == 0 {
+				return nil, nil
+			}
+			chain = append(chain, step)
+			break
+		}
+		if seen[name] || len(chain) >= maxRoleChainDepth {
+			return nil, fmt.Errorf("profile %s has a source_profile cycle", profile)
+		}
+		seen[name] = true
+
+		source := keys["source_profile"]
+		if source == "" || source == name {
+			// The role is assumed with credentials from the environment or
+			// the profile's own static keys
+			step.CredentialSource = keys["credential_source"]
+			chain = append(chain, step)
+			break
+		}
+		chain = append(chain, step)
+		name = source
+	}
+
+	// Base credentials first, like the order the roles are assumed in
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain, nil
+}
+
+// FormatRoleChain renders a role chain as "base → role@account → ..."
+func FormatRoleChain(chain []RoleStep) string {
+	parts := make([]string, 0, len(chain)+1)
+	for i, step := range chain {
+		if step.RoleARN == "" {
+			parts = append(parts, step.Profile)
+			continue
+		}
+		if i == 0 && step.CredentialSource != "" {
+			parts = append(parts, step.CredentialSource)
+		}
+		parts = append(parts, roleLabel(step.RoleARN))
+	}
+	return strings.Join(parts, " → ")
+}
+
+// roleLabel shortens arn:aws:iam::123456789012:role/path/Name to Name@123456789012
+func roleLabel(arn string) string {
+	fields := strings.SplitN(arn, ":", 6)
+	if len(fields) != 6 || !strings.HasPrefix(fields[5], "role/") {
+		return arn
+	}
+	name := fields[5][strings.LastIndex(fields[5], "/")+1:]
+	return name + "@" + fields[4]
+}
+
+// parseAWSConfigSections returns the keys of every profile section, by profile name
+func parseAWSConfigSections(r io.Reader) map[string]map[string]string {
+	sections := map[string]map[string]string{}
+	var current map[string]string
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+
+		// Strip INI-style comments.
+		if i := strings.IndexAny(line, "#;"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(line, "["), "]"))
+			current = nil
+			name := ""
+			switch {
+			case section == "default":
+				name = "default"
+			case strings.HasPrefix(section, "profile "):
+				name = strings.TrimSpace(strings.TrimPrefix(section, "profile "))
+			}
+			if name != "" {
+				if sections[name] == nil {
+					sections[name] = map[string]string{}
+				}
+				current = sections[name]
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		if key, value, ok := strings.Cut(line, "="); ok {
+			current[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	return sections
+}
@@ -59,3 +59,86 @@ not a section
 		}
 	}
 }
+
+func TestParseAWSConfigRegion(t *testing.T) {
+	config := `
+[default]
+region = us-east-1
+
+[profile staging]
+output = json
+region=us-west-2 # inline comment
+
+[sso-session staging]
+region = eu-west-1
+
+[profile noregion]
+output = json
+`
+
+	tests := []struct {
+		profile string
+		want    string
+	}{
+		{"default", "us-east-1"},
+		{"staging", "us-west-2"},
+		{"noregion", ""},
+		{"missing", ""},
+	}
+	for _, tt := range tests {
+		if got := parseAWSConfigRegion(strings.NewReader(config), tt.profile); got != tt.want {
+			t.Errorf("parseAWSConfigRegion(%q) = %q, want %q", tt.profile, got, tt.want)
+		}
+	}
+}
+
+func TestRoleChain(t *testing.T) {
+	config := `
+[profile base]
+sso_session = corp
+
+[profile admin]
+role_arn = arn:aws:iam::111122223333:role/Admin
+source_profile = base
+
+[profile deployer]
+role_arn = arn:aws:iam::444455556666:role/ci/Deployer # inline comment
+source_profile = admin
+
+[profile ec2]
+role_arn = arn:aws:iam::111122223333:role/Reader
+credential_source = Ec2InstanceMetadata
+
+[profile loop-a]
+role_arn = arn:aws:iam::111122223333:role/A
+source_profile = loop-b
+
+[profile loop-b]
+role_arn = arn:aws:iam::111122223333:role/B
+source_profile = loop-a
+`
+	sections := parseAWSConfigSections(strings.NewReader(config))
+
+	tests := []struct {
+		profile string
+		want    string
+		wantErr bool
+	}{
+		{"base", "", false},
+		{"admin", "base → Admin@111122223333", false},
+		{"deployer", "base → Admin@111122223333 → Deployer@444455556666", false},
+		{"ec2", "Ec2InstanceMetadata → Reader@111122223333", false},
+		{"missing", "", false},
+		{"loop-a", "", true},
+	}
+	for _, tt := range tests {
+		chain, err := roleChain(sections, tt.profile)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("roleChain(%q) error = %v, wantErr %v", tt.profile, err, tt.wantErr)
+			continue
+		}
+		if got := FormatRoleChain(chain); got != tt.want {
+			t.Errorf("roleChain(%q) = %q, want %q", tt.profile, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// applyEnv overrides settings with PS9S_* environment variables, so CI and
+// throwaway shells can configure ps9s without a config directory
+func (s *Settings) applyEnv() error {
+	if err := envBool("PS9S_READONLY", &s.ReadOnly); err != nil {
+		return err
+	}
+	if err := envBool("PS9S_OPEN_LAST", &s.OpenLast); err != nil {
+		return err
+	}
+	if err := envBool("PS9S_ALWAYS_SHOW_PROFILES", &s.AlwaysShowProfiles); err != nil {
+		return err
+	}
+	if err := envBool("PS9S_SKIP_REGION_SELECTOR", &s.SkipRegionSelector); err != nil {
+		return err
+	}
+	if err := envBool("PS9S_SHOW_VALUES", &s.ShowValues); err != nil {
+		return err
+	}
+	if err := envBool("PS9S_HIGH_THROUGHPUT", &s.HighThroughput); err != nil {
+		return err
+	}
+	if err := envBool("PS9S_ASCII", &s.ASCII); err != nil {
+		return err
+	}
+	if err := envBool("PS9S_REDUCE_MOTION", &s.ReduceMotion); err != nil {
+		return err
+	}
+	if err := envBool("PS9S_CONCEAL_SECRETS", &s.ConcealSecrets); err != nil {
+		return err
+	}
+	if err := envInt("PS9S_MAX_RESULTS", &s.MaxResults); err != nil {
+		return err
+	}
+	if err := envInt("PS9S_LIST_PAGE_SIZE", &s.ListPageSize); err != nil {
+		return err
+	}
+	if err := envInt("PS9S_STALE_DAYS", &s.StaleDays); err != nil {
+		return err
+	}
+	envString("PS9S_DEFAULT_REGION", &s.DefaultRegion)
+	envString("PS9S_PATH_PREFIX", &s.PathPrefix)
+	envString("PS9S_THEME", &s.Theme)
+	envString("PS9S_LIST_MODE", &s.ListMode)
+	envString("PS9S_TIME_FORMAT", &s.TimeFormat)
+	envString("PS9S_TIMEZONE", &s.Timezone)
+	envString("PS9S_MIRROR_DIR", &s.MirrorDir)
+	envString("PS9S_NAMING_CONVENTION", &s.NamingConvention)
+	envString("PS9S_ENV_SEPARATOR", &s.EnvSeparator)
+	envString("PS9S_ENV_CASE", &s.EnvCase)
+	envString("PS9S_SNAPSHOTS", &s.Snapshots)
+	envList("PS9S_COLUMNS", &s.Columns)
+	envList("PS9S_PROFILES", &s.Profiles)
+	return nil
+}
+
+// envString sets *dst from a non-empty environment variable
+func envString(name string, dst *string) {
+	if v := os.Getenv(name); v != "" {
+		*dst = v
+	}
+}
+
+// envList sets *dst from a non-empty environment variable holding a
+// comma-separated list
+func envList(name string, dst *[]string) {
+	v := os.Getenv(name)
+	if v == "" {
+		return
+	}
+	*dst = nil
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*dst = append(*dst, item)
+		}
+	}
+}
+
+// envInt sets *dst from a non-empty environment variable holding an integer
+func envInt(name string, dst *int) error {
+	v := os.Getenv(name)
+	if v == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", name, v, err)
+	}
+	*dst = n
+	return nil
+}
+
+// envBool sets *dst from a non-empty environment variable holding a boolean (1, true, 0, false, ...)
+func envBool(name string, dst *bool) error {
+	v := os.Getenv(name)
+	if v == "" {
+		return nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", name, v, err)
+	}
+	*dst = b
+	return nil
+}
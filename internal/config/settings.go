@@ -0,0 +1,366 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Settings holds user preferences loaded from config.json
+type Settings struct {
+	// MaxResults is the page size requested from list APIs (1-50, 0 uses the AWS maximum)
+	MaxResults int `json:"max_results,omitempty"`
+	// HighThroughput runs more batched requests at once, for accounts with the
+	// high-throughput Parameter Store setting enabled
+	HighThroughput bool `json:"high_throughput,omitempty"`
+	// ListPageSize fixes the number of rows per page in the parameter list (0 fits the terminal)
+	ListPageSize int `json:"list_page_size,omitempty"`
+	// TimeFormat is the Go layout for absolute timestamps (default "2006-01-02 15:04")
+	TimeFormat string `json:"time_format,omitempty"`
+	// Timezone is the IANA zone for absolute timestamps (default: local time)
+	Timezone string `json:"timezone,omitempty"`
+	// ListMode is the parameter list density: "compact" (default) or "detailed"
+	ListMode string `json:"list_mode,omitempty"`
+	// ShowValues shows a truncated value column in the parameter list
+	ShowValues bool `json:"show_values,omitempty"`
+	// Columns are the metadata columns right of each name in the parameter
+	// list, in order (default modified and tier); see ListColumns
+	Columns []string `json:"columns,omitempty"`
+	// ReadOnly disables every write to AWS
+	ReadOnly bool `json:"read_only,omitempty"`
+	// DefaultRegion is preselected for profiles without a remembered region
+	DefaultRegion string `json:"default_region,omitempty"`
+	// PathPrefix limits listing to parameters whose names begin with it
+	PathPrefix string `json:"path_prefix,omitempty"`
+	// Theme is the color theme name ("default" or "high-contrast")
+	Theme string `json:"theme,omitempty"`
+	// ASCII draws markers, borders and spinners with plain ASCII characters
+	ASCII bool `json:"ascii,omitempty"`
+	// ReduceMotion replaces animated spinners with a static marker
+	ReduceMotion bool `json:"reduce_motion,omitempty"`
+	// OpenLast starts in the most recent profile/region instead of the selectors
+	OpenLast bool `json:"open_last,omitempty"`
+	// AlwaysShowProfiles shows the profile selector even when only one profile exists
+	AlwaysShowProfiles bool `json:"always_show_profiles,omitempty"`
+	// SkipRegionSelector opens the remembered region directly after selecting a profile
+	SkipRegionSelector bool `json:"skip_region_selector,omitempty"`
+	// StaleDays is the age in days from which the stale report flags a
+	// parameter as unmodified (default 180)
+	StaleDays int `json:"stale_days,omitempty"`
+	// MirrorDir is the git repository subtrees are mirrored into, one file per
+	// parameter, with changes made through ps9s committed to it
+	MirrorDir string `json:"mirror_dir,omitempty"`
+	// TerraformState are Terraform state or plan JSON files whose
+	// aws_ssm_parameter resources are marked as Terraform-managed
+	TerraformState []string `json:"terraform_state,omitempty"`
+	// NamingConvention is a regular expression new parameter names must
+	// match; existing parameters that do not are flagged
+	NamingConvention string `json:"naming_convention,omitempty"`
+	// SOPSAge and SOPSKMS are the age public keys and KMS key ARNs SOPS
+	// exports are encrypted for; sops' own configuration applies when unset
+	SOPSAge []string `json:"sops_age,omitempty"`
+	SOPSKMS []string `json:"sops_kms,omitempty"`
+	// SharedParameters are ARNs of parameters shared from other accounts to add
+	// to the list, since AWS only lists shares accepted through AWS RAM
+	SharedParameters []string `json:"shared_parameters,omitempty"`
+	// SessionDurations sets how long assumed-role credentials last, by profile
+	// (Go durations such as "4h", between 15m and 12h)
+	SessionDurations map[string]string `json:"session_durations,omitempty"`
+	// Favorites are pinned profile+region contexts shown on the profile selector
+	Favorites []RecentEntry `json:"favorites,omitempty"`
+	// Profiles limits the profile selector to these AWS profiles (default: all)
+	Profiles []string `json:"profiles,omitempty"`
+	// EnvSeparator joins nested JSON keys in the environment variable preview
+	// (default "__"), and EnvCase is their case: upper (default), lower or preserve
+	EnvSeparator string `json:"env_separator,omitempty"`
+	EnvCase      string `json:"env_case,omitempty"`
+	// ConcealSecrets masks the editor while editing SecureStrings (ctrl+r reveals)
+	ConcealSecrets bool `json:"conceal_secrets,omitempty"`
+	// Snapshots remembers viewed parameters to flag later changes: "hash"
+	// keeps a digest, "value" also an encrypted copy of the value to diff
+	Snapshots string `json:"snapshots,omitempty"`
+}
+
+// Limits of the STS AssumeRole DurationSeconds parameter
+const (
+	MinSessionDuration = 15 * time.Minute
+	MaxSessionDuration = 12 * time.Hour
+)
+
+// MaxFavorites is the number of favorites reachable with the alt+1-9 keys
+const MaxFavorites = 9
+
+// DefaultStaleDays is the stale report threshold when stale_days is not set
+const DefaultStaleDays = 180
+
+// List display modes
+const (
+	ListModeCompact  = "compact"
+	ListModeDetailed = "detailed"
+)
+
+// Cases of environment variable names in the JSON preview
+const (
+	EnvCaseUpper    = "upper"
+	EnvCaseLower    = "lower"
+	EnvCasePreserve = "preserve"
+)
+
+// Snapshot modes
+const (
+	SnapshotsHash  = "hash"
+	SnapshotsValue = "value"
+)
+
+// DefaultEnvSeparator joins nested JSON keys in environment variable names
+const DefaultEnvSeparator = "__"
+
+// ListColumns are the metadata columns the parameter list can show
+var ListColumns = []string{"type", "version", "tier", "modified", "user", "size"}
+
+// DefaultColumns are the parameter list columns when columns is not set
+var DefaultColumns = []string{"modified", "tier"}
+
+// LoadSettings loads settings from config.json, overridden by PS9S_* environment variables
+// Returns default settings if file doesn't exist
+func LoadSettings() (*Settings, error) {
+	settings, err := loadSettingsFile()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := settings.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// UpdateSettings applies change to the settings stored in config.json and saves them.
+// Environment overrides are not written back to the file.
+func UpdateSettings(change func(*Settings)) error {
+	settings, err := loadSettingsFile()
+	if err != nil {
+		return err
+	}
+	change(settings)
+	return SaveSettings(settings)
+}
+
+// SettingsPath returns the path of config.json
+func SettingsPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "config.json"), nil
+}
+
+// FirstRun reports whether the config directory is missing or empty. Users
+// upgrading from a release without config.json already have regions.json or
+// favorites there, so they are not sent through the setup wizard.
+func FirstRun() bool {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return false
+	}
+	entries, err := os.ReadDir(configDir)
+	if err != nil {
+		return os.IsNotExist(err)
+	}
+	return len(entries) == 0
+}
+
+// loadSettingsFile reads config.json without environment overrides
+func loadSettingsFile() (*Settings, error) {
+	configFile, err := SettingsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(configFile); os.IsNotExist(err) {
+		return &Settings{}, nil
+	}
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings file: %w", err)
+	}
+
+	var settings Settings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse settings file: %w", err)
+	}
+
+	return &settings, nil
+}
+
+// SaveSettings saves settings to config.json
+func SaveSettings(settings *Settings) error {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return err
+	}
+
+	// Create config directory if it doesn't exist
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	configFile := filepath.Join(configDir, "config.json")
+
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal settings: %w", err)
+	}
+
+	if err := os.WriteFile(configFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write settings file: %w", err)
+	}
+
+	return nil
+}
+
+// Validate checks settings values against the limits AWS and the UI accept
+func (s *Settings) Validate() error {
+	if s.MaxResults < 0 || s.MaxResults > 50 {
+		return fmt.Errorf("max_results must be between 1 and 50, got %d", s.MaxResults)
+	}
+	if s.ListPageSize < 0 {
+		return fmt.Errorf("list_page_size must not be negative, got %d", s.ListPageSize)
+	}
+	if s.StaleDays < 0 {
+		return fmt.Errorf("stale_days must not be negative, got %d", s.StaleDays)
+	}
+	if s.PathPrefix != "" && !strings.HasPrefix(s.PathPrefix, "/") {
+		return fmt.Errorf("path_prefix must start with /, got %q", s.PathPrefix)
+	}
+	if s.ListMode != "" && s.ListMode != ListModeCompact && s.ListMode != ListModeDetailed {
+		return fmt.Errorf("list_mode must be %q or %q, got %q", ListModeCompact, ListModeDetailed, s.ListMode)
+	}
+	switch s.EnvCase {
+	case "", EnvCaseUpper, EnvCaseLower, EnvCasePreserve:
+	default:
+		return fmt.Errorf("env_case must be %q, %q or %q, got %q", EnvCaseUpper, EnvCaseLower, EnvCasePreserve, s.EnvCase)
+	}
+	if s.Snapshots != "" && s.Snapshots != SnapshotsHash && s.Snapshots != SnapshotsValue {
+		return fmt.Errorf("snapshots must be %q or %q, got %q", SnapshotsHash, SnapshotsValue, s.Snapshots)
+	}
+	seen := make(map[string]bool, len(s.Columns))
+	for _, c := range s.Columns {
+		if !slices.Contains(ListColumns, c) {
+			return fmt.Errorf("columns must be among %s, got %q", strings.Join(ListColumns, ", "), c)
+		}
+		if seen[c] {
+			return fmt.Errorf("columns lists %q twice", c)
+		}
+		seen[c] = true
+	}
+	for _, arn := range s.SharedParameters {
+		if !strings.HasPrefix(arn, "arn:") || !strings.Contains(arn, ":parameter/") {
+			return fmt.Errorf("shared_parameters must be parameter ARNs, got %q", arn)
+		}
+	}
+	for profile, value := range s.SessionDurations {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid session_durations entry for %s: %w", profile, err)
+		}
+		if d < MinSessionDuration || d > MaxSessionDuration {
+			return fmt.Errorf("session_durations entry for %s must be between %s and %s, got %s",
+				profile, MinSessionDuration, MaxSessionDuration, value)
+		}
+	}
+	if len(s.Favorites) > MaxFavorites {
+		return fmt.Errorf("at most %d favorites are supported, got %d", MaxFavorites, len(s.Favorites))
+	}
+	for i, f := range s.Favorites {
+		if f.Profile == "" || f.Region == "" {
+			return fmt.Errorf("favorite %d needs both profile and region", i+1)
+		}
+	}
+	if _, err := s.Location(); err != nil {
+		return err
+	}
+	if _, err := s.NamingPattern(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ManagedProfiles returns the profiles of available that are configured to
+// be shown, or all of them when none are configured or none are available
+func (s *Settings) ManagedProfiles(available []string) []string {
+	if len(s.Profiles) == 0 {
+		return available
+	}
+	var managed []string
+	for _, p := range available {
+		if slices.Contains(s.Profiles, p) {
+			managed = append(managed, p)
+		}
+	}
+	if len(managed) == 0 {
+		return available
+	}
+	return managed
+}
+
+// SessionDuration returns the assumed-role session duration configured for
+// profile, or 0 to use the profile's own setting
+func (s *Settings) SessionDuration(profile string) time.Duration {
+	d, err := time.ParseDuration(s.SessionDurations[profile])
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// ListColumnNames returns the parameter list columns to show, in order
+func (s *Settings) ListColumnNames() []string {
+	if s.Columns == nil {
+		return DefaultColumns
+	}
+	return s.Columns
+}
+
+// StaleAfter returns the age in days from which parameters count as stale
+func (s *Settings) StaleAfter() int {
+	if s.StaleDays == 0 {
+		return DefaultStaleDays
+	}
+	return s.StaleDays
+}
+
+// NamingPattern returns the compiled naming convention, or nil when unset
+func (s *Settings) NamingPattern() (*regexp.Regexp, error) {
+	if s.NamingConvention == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(s.NamingConvention)
+	if err != nil {
+		return nil, fmt.Errorf("invalid naming_convention %q: %w", s.NamingConvention, err)
+	}
+	return re, nil
+}
+
+// Location returns the configured time zone, or local time when unset
+func (s *Settings) Location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", s.Timezone, err)
+	}
+	return loc, nil
+}
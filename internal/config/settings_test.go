@@ -0,0 +1,225 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestLoadSettings_MissingFileReturnsDefaults(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	s, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.MaxResults != 0 || s.ListPageSize != 0 {
+		t.Fatalf("expected zero-value defaults, got %+v", s)
+	}
+}
+
+func TestFirstRun(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", home)
+	if !FirstRun() {
+		t.Fatal("expected first run without a config directory")
+	}
+
+	dir := filepath.Join(home, "ps9s")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if !FirstRun() {
+		t.Fatal("expected first run with an empty config directory")
+	}
+
+	// An upgrade from a release without config.json keeps regions.json
+	if err := os.WriteFile(filepath.Join(dir, "regions.json"), []byte(`{"profile_regions":{}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if FirstRun() {
+		t.Fatal("expected no first run when the config directory has content")
+	}
+}
+
+func TestLoadSettings_RejectsOutOfRangeMaxResults(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "ps9s"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "ps9s", "config.json"), []byte(`{"max_results": 500}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadSettings(); err == nil {
+		t.Fatalf("expected error for max_results 500")
+	}
+}
+
+func TestSaveSettings_RoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if err := SaveSettings(&Settings{ListMode: ListModeDetailed, ListPageSize: 20}); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	s, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if s.ListMode != ListModeDetailed || s.ListPageSize != 20 {
+		t.Fatalf("settings not preserved: %+v", s)
+	}
+}
+
+func TestLoadSettings_EnvOverridesFile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := SaveSettings(&Settings{ListPageSize: 20, PathPrefix: "/file/"}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("PS9S_READONLY", "1")
+	t.Setenv("PS9S_PATH_PREFIX", "/ci/")
+	t.Setenv("PS9S_DEFAULT_REGION", "eu-west-1")
+
+	s, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if !s.ReadOnly || s.PathPrefix != "/ci/" || s.DefaultRegion != "eu-west-1" || s.ListPageSize != 20 {
+		t.Fatalf("unexpected merged settings: %+v", s)
+	}
+
+	// Saving a change must not persist the environment overrides
+	if err := UpdateSettings(func(s *Settings) { s.ListMode = ListModeDetailed }); err != nil {
+		t.Fatal(err)
+	}
+	file, err := loadSettingsFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.ReadOnly || file.PathPrefix != "/file/" || file.ListMode != ListModeDetailed {
+		t.Fatalf("env overrides leaked into config.json: %+v", file)
+	}
+}
+
+func TestLoadSettings_InvalidEnv(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("PS9S_READONLY", "maybe")
+
+	if _, err := LoadSettings(); err == nil {
+		t.Fatal("expected error for invalid PS9S_READONLY")
+	}
+}
+
+func TestValidate_Favorites(t *testing.T) {
+	s := &Settings{Favorites: []RecentEntry{{Profile: "prod"}}}
+	if err := s.Validate(); err == nil {
+		t.Fatalf("expected error for favorite without region")
+	}
+
+	s.Favorites = []RecentEntry{{Profile: "prod", Region: "eu-west-1"}}
+	if err := s.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidate_SharedParameters(t *testing.T) {
+	s := &Settings{SharedParameters: []string{"/platform/vpc-id"}}
+	if err := s.Validate(); err == nil {
+		t.Fatal("expected error for a name instead of an ARN")
+	}
+
+	s.SharedParameters = []string{"arn:aws:ssm:us-east-1:210987654321:parameter/platform/vpc-id"}
+	if err := s.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidate_Columns(t *testing.T) {
+	for _, columns := range [][]string{{"owner"}, {"tier", "tier"}} {
+		s := &Settings{Columns: columns}
+		if err := s.Validate(); err == nil {
+			t.Errorf("expected error for columns %v", columns)
+		}
+	}
+
+	s := &Settings{Columns: []string{"user", "version"}}
+	if err := s.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := (&Settings{}).ListColumnNames(); len(got) != 2 || got[0] != "modified" {
+		t.Errorf("expected the default columns, got %v", got)
+	}
+	t.Setenv("PS9S_COLUMNS", "size, type")
+	if err := s.applyEnv(); err != nil || len(s.Columns) != 2 || s.Columns[1] != "type" {
+		t.Errorf("expected PS9S_COLUMNS to set the columns, got %v, %v", s.Columns, err)
+	}
+}
+
+func TestValidate_NamingConvention(t *testing.T) {
+	s := &Settings{NamingConvention: "^/(team"}
+	if err := s.Validate(); err == nil {
+		t.Fatal("expected error for an invalid regular expression")
+	}
+
+	s.NamingConvention = "^/(team)/(env)/"
+	if err := s.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if re, _ := s.NamingPattern(); re == nil || !re.MatchString("/team/env/x") {
+		t.Errorf("unexpected naming pattern %v", re)
+	}
+}
+
+func TestValidate_SessionDurations(t *testing.T) {
+	for _, value := range []string{"4", "5m", "13h"} {
+		s := &Settings{SessionDurations: map[string]string{"prod": value}}
+		if err := s.Validate(); err == nil {
+			t.Errorf("expected error for session duration %q", value)
+		}
+	}
+
+	s := &Settings{SessionDurations: map[string]string{"prod": "4h"}}
+	if err := s.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := s.SessionDuration("prod"); got != 4*time.Hour {
+		t.Errorf("SessionDuration(prod) = %s, want 4h", got)
+	}
+	if got := s.SessionDuration("dev"); got != 0 {
+		t.Errorf("SessionDuration(dev) = %s, want 0", got)
+	}
+}
+
+func TestManagedProfiles(t *testing.T) {
+	available := []string{"default", "dev", "prod"}
+
+	s := &Settings{}
+	if got := s.ManagedProfiles(available); !slices.Equal(got, available) {
+		t.Errorf("expected every profile without a selection, got %v", got)
+	}
+
+	s.Profiles = []string{"prod", "gone", "dev"}
+	if got := s.ManagedProfiles(available); !slices.Equal(got, []string{"dev", "prod"}) {
+		t.Errorf("expected the selected profiles in AWS config order, got %v", got)
+	}
+
+	s.Profiles = []string{"gone"}
+	if got := s.ManagedProfiles(available); !slices.Equal(got, available) {
+		t.Errorf("expected every profile when none of the selection exists, got %v", got)
+	}
+}
+
+func TestValidate_EnvCase(t *testing.T) {
+	if err := (&Settings{EnvCase: "camel"}).Validate(); err == nil {
+		t.Error("expected error for unknown env_case")
+	}
+	if err := (&Settings{EnvCase: EnvCasePreserve}).Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
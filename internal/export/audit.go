@@ -0,0 +1,96 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+// AuditRecord is a parameter's metadata and tags as listed in an audit
+// export; values are never included
+type AuditRecord struct {
+	Parameter *aws.Parameter
+	Tags      []aws.Tag
+}
+
+// auditJSON is the JSON shape of an AuditRecord
+type auditJSON struct {
+	Name             string            `json:"name"`
+	Type             string            `json:"type"`
+	KeyID            string            `json:"kms_key_id"`
+	Tier             string            `json:"tier"`
+	Version          int64             `json:"version"`
+	LastModifiedDate string            `json:"last_modified"`
+	LastModifiedUser string            `json:"last_modified_user"`
+	Tags             map[string]string `json:"tags"`
+}
+
+// WriteAudit writes records to w as CSV or JSON
+func WriteAudit(w io.Writer, f Format, records []AuditRecord) error {
+	switch f {
+	case FormatCSV:
+		return writeAuditCSV(w, records)
+	case FormatJSON:
+		return writeAuditJSON(w, records)
+	}
+	return fmt.Errorf("audit exports are CSV or JSON, not %q", f)
+}
+
+func writeAuditCSV(w io.Writer, records []AuditRecord) error {
+	cw := csv.NewWriter(w)
+	header := []string{"name", "type", "kms_key_id", "tier", "version", "last_modified", "last_modified_user", "tags"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, r := range records {
+		p := r.Parameter
+		tags := make([]string, len(r.Tags))
+		for i, t := range r.Tags {
+			tags[i] = t.Key + "=" + t.Value
+		}
+		record := []string{p.Name, p.Type, p.KeyID, p.Tier, fmt.Sprintf("%d", p.Version),
+			auditTime(p.LastModifiedDate), p.LastModifiedUser, strings.Join(tags, ";")}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeAuditJSON(w io.Writer, records []AuditRecord) error {
+	out := make([]auditJSON, len(records))
+	for i, r := range records {
+		p := r.Parameter
+		tags := make(map[string]string, len(r.Tags))
+		for _, t := range r.Tags {
+			tags[t.Key] = t.Value
+		}
+		out[i] = auditJSON{
+			Name:             p.Name,
+			Type:             p.Type,
+			KeyID:            p.KeyID,
+			Tier:             p.Tier,
+			Version:          p.Version,
+			LastModifiedDate: auditTime(p.LastModifiedDate),
+			LastModifiedUser: p.LastModifiedUser,
+			Tags:             tags,
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// auditTime formats t in UTC as RFC 3339, or empty when unknown
+func auditTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,369 @@
+// Package export writes parameters to files in formats other tools consume
+package export
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+// Format is an export file format
+type Format string
+
+// Supported export formats
+const (
+	FormatDotenv    Format = "dotenv"
+	FormatJSON      Format = "json"
+	FormatTerraform Format = "terraform"
+	FormatYAML      Format = "yaml"
+	FormatCSV       Format = "csv"
+	FormatMarkdown  Format = "markdown"
+	FormatSOPS      Format = "sops"
+	FormatBackup    Format = "backup"
+)
+
+// Formats lists the export formats in the order the UI cycles through them
+var Formats = []Format{FormatDotenv, FormatJSON, FormatTerraform, FormatYAML, FormatCSV, FormatMarkdown, FormatSOPS, FormatBackup}
+
+// MaskedValue replaces SecureString values when they are not exported
+const MaskedValue = "********"
+
+// Options controls how parameters are written
+type Options struct {
+	// MaskSecure writes MaskedValue instead of SecureString values
+	MaskSecure bool
+	// OmitValues leaves the value column out of formats where it is optional (CSV)
+	OmitValues bool
+	// Digests adds a column with the Digest of each value to formats that have
+	// one (CSV); unlike the value it is written for masked SecureStrings too
+	Digests bool
+	// SOPS are the recipients of FormatSOPS files
+	SOPS SOPSRecipients
+}
+
+// HasOptionalValues reports whether values can be left out of f
+func (f Format) HasOptionalValues() bool {
+	return f == FormatCSV
+}
+
+// HasDigests reports whether value digests can be added to f
+func (f Format) HasDigests() bool {
+	return f == FormatCSV
+}
+
+// Encrypted reports whether f is written encrypted, so SecureString values
+// are safe to include
+func (f Format) Encrypted() bool {
+	return f == FormatSOPS
+}
+
+// Extension returns the conventional file name suffix for f
+func (f Format) Extension() string {
+	switch f {
+	case FormatDotenv:
+		return ".env"
+	case FormatJSON:
+		return ".json"
+	case FormatTerraform:
+		return ".tf"
+	case FormatYAML:
+		return ".yaml"
+	case FormatCSV:
+		return ".csv"
+	case FormatMarkdown:
+		return ".md"
+	case FormatSOPS:
+		return ".sops.yaml"
+	case FormatBackup:
+		return ".backup.json"
+	}
+	return ""
+}
+
+// Write writes params to w in format f
+func Write(w io.Writer, f Format, params []*aws.Parameter, opts Options) error {
+	switch f {
+	case FormatDotenv:
+		return writeDotenv(w, params, opts)
+	case FormatJSON:
+		return writeJSON(w, params, opts)
+	case FormatTerraform:
+		return writeTerraform(w, params, opts)
+	case FormatYAML:
+		_, err := w.Write(yamlMapping(params, opts))
+		return err
+	case FormatCSV:
+		return writeCSV(w, params, opts)
+	case FormatMarkdown:
+		return writeMarkdown(w, params, opts)
+	case FormatSOPS:
+		return writeSOPS(w, params, opts)
+	case FormatBackup:
+		return writeBackup(w, params, opts)
+	}
+	return fmt.Errorf("unknown export format %q", f)
+}
+
+// value returns the value to export for p
+func value(p *aws.Parameter, opts Options) string {
+	if opts.MaskSecure && p.Type == "SecureString" {
+		return MaskedValue
+	}
+	return p.Value
+}
+
+// Digest returns a short SHA-256 digest of value, so two people can confirm
+// they hold the same value without sharing it
+func Digest(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// EnvName converts a parameter path to an environment variable name,
+// e.g. "/app/prod/db-host" becomes "APP_PROD_DB_HOST"
+func EnvName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.TrimPrefix(name, "/") {
+		switch {
+		case r >= 'a' && r <= 'z':
+			b.WriteRune(r - 'a' + 'A')
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// Environ returns params as NAME=value environment entries, named relative to
+// the deepest directory they share, e.g. "/app/prod/db-host" becomes "DB_HOST"
+func Environ(params []*aws.Parameter) []string {
+	prefix := commonPath(params)
+	env := make([]string, len(params))
+	for i, p := range params {
+		env[i] = EnvName(strings.TrimPrefix(p.Name, prefix)) + "=" + p.Value
+	}
+	return env
+}
+
+func writeDotenv(w io.Writer, params []*aws.Parameter, opts Options) error {
+	for _, p := range params {
+		if _, err := fmt.Fprintf(w, "%s=%s\n", EnvName(p.Name), dotenvQuote(value(p, opts))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dotenvQuote double-quotes values that a shell or dotenv loader would otherwise split or expand
+func dotenvQuote(v string) string {
+	if v != "" && !strings.ContainsAny(v, " \t\n\"'\\$#`") {
+		return v
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, `$`, `\$`, "`", "\\`")
+	return `"` + r.Replace(v) + `"`
+}
+
+func writeJSON(w io.Writer, params []*aws.Parameter, opts Options) error {
+	// Build the object by hand to keep parameter order
+	var b strings.Builder
+	b.WriteString("{\n")
+	for i, p := range params {
+		key, _ := json.Marshal(p.Name)
+		val, _ := json.Marshal(value(p, opts))
+		fmt.Fprintf(&b, "  %s: %s", key, val)
+		if i < len(params)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// yamlMapping renders params as a YAML mapping of names to values. Strings
+// are JSON-quoted, which YAML reads as double-quoted scalars.
+func yamlMapping(params []*aws.Parameter, opts Options) []byte {
+	var b bytes.Buffer
+	for _, p := range params {
+		key, _ := json.Marshal(p.Name)
+		val, _ := json.Marshal(value(p, opts))
+		fmt.Fprintf(&b, "%s: %s\n", key, val)
+	}
+	return b.Bytes()
+}
+
+// backupJSON is the JSON shape of a parameter in a backup: everything needed
+// to recreate it
+type backupJSON struct {
+	Name             string `json:"name"`
+	Type             string `json:"type"`
+	Value            string `json:"value"`
+	Description      string `json:"description,omitempty"`
+	Tier             string `json:"tier,omitempty"`
+	DataType         string `json:"data_type,omitempty"`
+	KeyID            string `json:"kms_key_id,omitempty"`
+	Version          int64  `json:"version"`
+	LastModifiedDate string `json:"last_modified,omitempty"`
+	LastModifiedUser string `json:"last_modified_user,omitempty"`
+}
+
+func writeBackup(w io.Writer, params []*aws.Parameter, opts Options) error {
+	out := make([]backupJSON, len(params))
+	for i, p := range params {
+		out[i] = backupJSON{
+			Name:             p.Name,
+			Type:             p.Type,
+			Value:            value(p, opts),
+			Description:      p.Description,
+			Tier:             p.Tier,
+			DataType:         p.DataType,
+			KeyID:            p.KeyID,
+			Version:          p.Version,
+			LastModifiedDate: auditTime(p.LastModifiedDate),
+			LastModifiedUser: p.LastModifiedUser,
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func writeTerraform(w io.Writer, params []*aws.Parameter, opts Options) error {
+	for i, p := range params {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		_, err := fmt.Fprintf(w, "resource \"aws_ssm_parameter\" %s {\n  name  = %s\n  type  = %s\n  value = %s\n}\n",
+			hclString(resourceName(p.Name)), hclString(p.Name), hclString(p.Type), hclString(value(p, opts)))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCSV(w io.Writer, params []*aws.Parameter, opts Options) error {
+	cw := csv.NewWriter(w)
+	header := []string{"name", "type", "version", "last_modified", "last_modified_user"}
+	if opts.Digests {
+		header = append(header, "sha256")
+	}
+	if !opts.OmitValues {
+		header = append(header, "value")
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, p := range params {
+		modified := ""
+		if !p.LastModifiedDate.IsZero() {
+			modified = p.LastModifiedDate.UTC().Format(time.RFC3339)
+		}
+		record := []string{p.Name, p.Type, fmt.Sprintf("%d", p.Version), modified, p.LastModifiedUser}
+		if opts.Digests {
+			record = append(record, Digest(p.Value))
+		}
+		if !opts.OmitValues {
+			record = append(record, value(p, opts))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// markdownExampleWidth caps the example values in Markdown tables
+const markdownExampleWidth = 60
+
+func writeMarkdown(w io.Writer, params []*aws.Parameter, opts Options) error {
+	var b strings.Builder
+	b.WriteString("# Parameters")
+	if prefix := commonPath(params); prefix != "/" && prefix != "" {
+		fmt.Fprintf(&b, " under `%s`", prefix)
+	}
+	b.WriteString("\n\n")
+	b.WriteString("| Name | Type | Description | Example |\n")
+	b.WriteString("| --- | --- | --- | --- |\n")
+	for _, p := range params {
+		example := strings.Join(strings.Fields(value(p, opts)), " ")
+		if r := []rune(example); len(r) > markdownExampleWidth {
+			example = string(r[:markdownExampleWidth-1]) + "…"
+		}
+		if example != "" {
+			example = "`" + strings.ReplaceAll(example, "`", "'") + "`"
+		}
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n",
+			p.Name, p.Type, markdownCell(p.Description), markdownCell(example))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownCell keeps text on one table row and escapes column separators
+func markdownCell(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// commonPath returns the deepest directory shared by all parameter names
+func commonPath(params []*aws.Parameter) string {
+	if len(params) == 0 {
+		return ""
+	}
+	prefix := params[0].Name[:strings.LastIndex(params[0].Name, "/")+1]
+	for _, p := range params[1:] {
+		for !strings.HasPrefix(p.Name, prefix) {
+			prefix = prefix[:strings.LastIndex(strings.TrimSuffix(prefix, "/"), "/")+1]
+		}
+	}
+	return prefix
+}
+
+// resourceName derives a Terraform resource name from a parameter path
+func resourceName(name string) string {
+	n := strings.ToLower(EnvName(name))
+	if n == "" || (n[0] >= '0' && n[0] <= '9') {
+		n = "p_" + n
+	}
+	return n
+}
+
+// hclString quotes s as an HCL string literal, escaping template sequences
+func hclString(s string) string {
+	q, _ := json.Marshal(s)
+	r := strings.NewReplacer("${", "$${", "%{", "%%{")
+	return r.Replace(string(q))
+}
+
+// WriteFile writes params to path in format f. The file is created readable
+// only by the owner because it may hold decrypted secrets.
+func WriteFile(path string, f Format, params []*aws.Parameter, opts Options) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	if err := Write(file, f, params, opts); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+package export
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+var testParams = []*aws.Parameter{
+	{Name: "/app/prod/db-host", Type: "String", Value: "db.internal"},
+	{Name: "/app/prod/db-password", Type: "SecureString", Value: "s3cret $HOME"},
+	{Name: "/app/prod/banner", Type: "String", Value: "hello\nworld"},
+}
+
+func TestEnvName(t *testing.T) {
+	tests := map[string]string{
+		"/app/prod/db-host": "APP_PROD_DB_HOST",
+		"plain":             "PLAIN",
+		"/a.b/c_d":          "A_B_C_D",
+	}
+	for in, want := range tests {
+		if got := EnvName(in); got != want {
+			t.Errorf("EnvName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestWriteDotenv(t *testing.T) {
+	var b strings.Builder
+	if err := Write(&b, FormatDotenv, testParams, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	want := "APP_PROD_DB_HOST=db.internal\n" +
+		"APP_PROD_DB_PASSWORD=\"s3cret \\$HOME\"\n" +
+		"APP_PROD_BANNER=\"hello\\nworld\"\n"
+	if b.String() != want {
+		t.Fatalf("unexpected dotenv output:\n%s", b.String())
+	}
+}
+
+func TestEnviron(t *testing.T) {
+	got := Environ(testParams)
+	want := []string{"DB_HOST=db.internal", "DB_PASSWORD=s3cret $HOME", "BANNER=hello\nworld"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("Environ() = %q, want %q", got, want)
+	}
+}
+
+func TestWriteJSON_MaskSecure(t *testing.T) {
+	var b strings.Builder
+	if err := Write(&b, FormatJSON, testParams, Options{MaskSecure: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]string
+	if err := json.Unmarshal([]byte(b.String()), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, b.String())
+	}
+	if got["/app/prod/db-password"] != MaskedValue {
+		t.Fatalf("expected masked SecureString, got %q", got["/app/prod/db-password"])
+	}
+	if got["/app/prod/db-host"] != "db.internal" {
+		t.Fatalf("expected plain value, got %q", got["/app/prod/db-host"])
+	}
+	if strings.Index(b.String(), "db-host") > strings.Index(b.String(), "banner") {
+		t.Fatalf("expected parameter order to be kept")
+	}
+}
+
+func TestWriteBackup(t *testing.T) {
+	var b strings.Builder
+	params := []*aws.Parameter{
+		{Name: "/app/host", Type: "String", Value: "db.internal", Description: "Database host", Tier: "Standard", DataType: "text", Version: 3},
+		{Name: "/app/password", Type: "SecureString", Value: "s3cret", KeyID: "alias/app", Version: 1},
+	}
+	if err := Write(&b, FormatBackup, params, Options{MaskSecure: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []backupJSON
+	if err := json.Unmarshal([]byte(b.String()), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, b.String())
+	}
+	if len(got) != 2 || got[0].Description != "Database host" || got[0].Tier != "Standard" || got[0].Version != 3 {
+		t.Fatalf("expected the metadata to be kept, got %+v", got)
+	}
+	if got[1].Value != MaskedValue || got[1].KeyID != "alias/app" {
+		t.Fatalf("expected a masked SecureString with its key, got %+v", got[1])
+	}
+}
+
+func TestWriteTerraform(t *testing.T) {
+	var b strings.Builder
+	params := []*aws.Parameter{{Name: "/app/tpl", Type: "String", Value: "${var} %{if}"}}
+	if err := Write(&b, FormatTerraform, params, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		`resource "aws_ssm_parameter" "app_tpl" {`,
+		`name  = "/app/tpl"`,
+		`value = "$${var} %%{if}"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	params := []*aws.Parameter{
+		{Name: "/app/a", Type: "String", Value: "x,y", Version: 3,
+			LastModifiedDate: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), LastModifiedUser: "arn:aws:iam::1:user/bob"},
+		{Name: "/app/s", Type: "SecureString", Value: "s3cret", Version: 1},
+	}
+
+	var b strings.Builder
+	if err := Write(&b, FormatCSV, params, Options{MaskSecure: true}); err != nil {
+		t.Fatal(err)
+	}
+	want := "name,type,version,last_modified,last_modified_user,value\n" +
+		"/app/a,String,3,2024-05-01T12:00:00Z,arn:aws:iam::1:user/bob,\"x,y\"\n" +
+		"/app/s,SecureString,1,,,********\n"
+	if b.String() != want {
+		t.Fatalf("unexpected CSV output:\n%s", b.String())
+	}
+
+	b.Reset()
+	if err := Write(&b, FormatCSV, params, Options{OmitValues: true}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(b.String(), "value") || strings.Contains(b.String(), "s3cret") {
+		t.Fatalf("expected values to be omitted:\n%s", b.String())
+	}
+
+	b.Reset()
+	if err := Write(&b, FormatCSV, params, Options{MaskSecure: true, OmitValues: true, Digests: true}); err != nil {
+		t.Fatal(err)
+	}
+	want = "name,type,version,last_modified,last_modified_user,sha256\n" +
+		"/app/a,String,3,2024-05-01T12:00:00Z,arn:aws:iam::1:user/bob," + Digest("x,y") + "\n" +
+		"/app/s,SecureString,1,,," + Digest("s3cret") + "\n"
+	if b.String() != want {
+		t.Fatalf("unexpected CSV output with digests:\n%s", b.String())
+	}
+}
+
+func TestDigest(t *testing.T) {
+	// First 12 hex characters of the SHA-256 of "hello"
+	if got := Digest("hello"); got != "2cf24dba5fb0" {
+		t.Errorf("unexpected digest %q", got)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	params := []*aws.Parameter{
+		{Name: "/app/prod/db-host", Type: "String", Value: "db.internal", Description: "Primary | replica host"},
+		{Name: "/app/prod/db/password", Type: "SecureString", Value: "s3cret"},
+	}
+	var b strings.Builder
+	if err := Write(&b, FormatMarkdown, params, Options{MaskSecure: true}); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"# Parameters under `/app/prod/`",
+		"| `/app/prod/db-host` | String | Primary \\| replica host | `db.internal` |",
+		"| `/app/prod/db/password` | SecureString |  | `********` |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestWrite_UnknownFormat(t *testing.T) {
+	if err := Write(&strings.Builder{}, Format("xml"), testParams, Options{}); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
+
+func TestWriteAudit(t *testing.T) {
+	records := []AuditRecord{{
+		Parameter: &aws.Parameter{
+			Name:             "/app/prod/db-password",
+			Type:             "SecureString",
+			Value:            "s3cret",
+			KeyID:            "alias/app",
+			Tier:             "Standard",
+			Version:          3,
+			LastModifiedDate: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			LastModifiedUser: "arn:aws:iam::123456789012:user/alice",
+		},
+		Tags: []aws.Tag{{Key: "owner", Value: "platform"}, {Key: "pci", Value: "true"}},
+	}}
+
+	var b strings.Builder
+	if err := WriteAudit(&b, FormatCSV, records); err != nil {
+		t.Fatal(err)
+	}
+	want := "name,type,kms_key_id,tier,version,last_modified,last_modified_user,tags\n" +
+		"/app/prod/db-password,SecureString,alias/app,Standard,3,2026-01-02T03:04:05Z,arn:aws:iam::123456789012:user/alice,owner=platform;pci=true\n"
+	if b.String() != want {
+		t.Fatalf("unexpected CSV output:\n%s", b.String())
+	}
+
+	b.Reset()
+	if err := WriteAudit(&b, FormatJSON, records); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(b.String(), "s3cret") {
+		t.Fatal("audit export must not include values")
+	}
+	var out []map[string]any
+	if err := json.Unmarshal([]byte(b.String()), &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 1 || out[0]["kms_key_id"] != "alias/app" || out[0]["tags"].(map[string]any)["pci"] != "true" {
+		t.Fatalf("unexpected JSON output: %v", out)
+	}
+
+	if err := WriteAudit(&b, FormatDotenv, records); err == nil {
+		t.Error("expected dotenv to be rejected")
+	}
+}
+
+func TestYAMLAndSOPSArgs(t *testing.T) {
+	params := []*aws.Parameter{
+		{Name: "/app/host", Type: "String", Value: "db: internal"},
+		{Name: "/app/password", Type: "SecureString", Value: "s3\"cret\n"},
+	}
+	want := "\"/app/host\": \"db: internal\"\n\"/app/password\": \"s3\\\"cret\\n\"\n"
+	if got := string(yamlMapping(params, Options{})); got != want {
+		t.Errorf("unexpected plaintext:\n%s", got)
+	}
+
+	var b strings.Builder
+	if err := Write(&b, FormatYAML, params, Options{MaskSecure: true}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "\"/app/host\": \"db: internal\"\n\"/app/password\": \"********\"\n"; b.String() != want {
+		t.Errorf("unexpected masked YAML:\n%s", b.String())
+	}
+
+	args := strings.Join(sopsArgs(SOPSRecipients{Age: []string{"age1a", "age1b"}}), " ")
+	if args != "encrypt --input-type yaml --output-type yaml --filename-override parameters.sops.yaml --age age1a,age1b" {
+		t.Errorf("unexpected sops arguments %q", args)
+	}
+}
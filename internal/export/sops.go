@@ -0,0 +1,51 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+// SOPSRecipients are the keys sops encrypts SOPS exports for. When both are
+// empty sops falls back to its own configuration: the SOPS_AGE_RECIPIENTS and
+// SOPS_KMS_ARN environment variables or a .sops.yaml creation rule.
+type SOPSRecipients struct {
+	Age []string // age public keys
+	KMS []string // KMS key ARNs
+}
+
+// sopsStdinName names the document sops reads from stdin, for its
+// .sops.yaml creation rules
+const sopsStdinName = "parameters.sops.yaml"
+
+// sopsArgs returns the sops arguments encrypting YAML from stdin. With no file
+// argument sops (3.10 or newer) reads stdin itself, which unlike /dev/stdin
+// also works on Windows.
+func sopsArgs(r SOPSRecipients) []string {
+	args := []string{"encrypt", "--input-type", "yaml", "--output-type", "yaml", "--filename-override", sopsStdinName}
+	if len(r.Age) > 0 {
+		args = append(args, "--age", strings.Join(r.Age, ","))
+	}
+	if len(r.KMS) > 0 {
+		args = append(args, "--kms", strings.Join(r.KMS, ","))
+	}
+	return args
+}
+
+// writeSOPS pipes the YAML of params through sops, so only the encrypted
+// document reaches w
+func writeSOPS(w io.Writer, params []*aws.Parameter, opts Options) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command("sops", sopsArgs(opts.SOPS)...)
+	cmd.Stdin = bytes.NewReader(yamlMapping(params, opts))
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to encrypt with sops: %w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return nil
+}
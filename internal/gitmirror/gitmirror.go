@@ -0,0 +1,228 @@
+// Package gitmirror mirrors parameter subtrees into a local git repository,
+// one file per parameter, and commits the changes made through ps9s so teams
+// get a reviewable history outside AWS
+package gitmirror
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/export"
+)
+
+// markerFile marks the directory of a mirrored subtree, so later changes to
+// parameters under it are committed too
+const markerFile = ".ps9s-mirror"
+
+// valueSuffix ends the file of every parameter, so a parameter can share its
+// name with the directory of deeper ones: /a/b is a/b.value and /a/b/c is
+// a/b/c.value
+const valueSuffix = ".value"
+
+// Mirror is a git repository holding parameters as
+// DIR/PROFILE/REGION/PATH/TO/NAME.value files
+type Mirror struct {
+	Dir string
+}
+
+// New returns the mirror in dir, expanding a leading "~/"
+func New(dir string) Mirror {
+	if rest, ok := strings.CutPrefix(dir, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, rest)
+		}
+	}
+	return Mirror{Dir: dir}
+}
+
+// root returns the directory holding the parameters of a profile and region
+func (m Mirror) root(profile, region string) string {
+	return filepath.Join(m.Dir, profile, region)
+}
+
+// path returns the directory of name, refusing names that would leave the
+// mirror
+func (m Mirror) path(profile, region, name string) (string, error) {
+	root := m.root(profile, region)
+	p := filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(name, "/")))
+	if p != root && !strings.HasPrefix(p, root+string(filepath.Separator)) {
+		return "", fmt.Errorf("cannot mirror %s outside %s", name, root)
+	}
+	return p, nil
+}
+
+// file returns the file of parameter name
+func (m Mirror) file(profile, region, name string) (string, error) {
+	p, err := m.path(profile, region, name)
+	if err != nil {
+		return "", err
+	}
+	return p + valueSuffix, nil
+}
+
+// Content returns what the file of p holds: its value, or for SecureStrings
+// a masked placeholder with the version, so changes still show up as commits
+func Content(p *aws.Parameter) []byte {
+	if p.Type == "SecureString" {
+		return []byte(fmt.Sprintf("%s (SecureString version %d)\n", export.MaskedValue, p.Version))
+	}
+	return []byte(p.Value)
+}
+
+// Tracks reports whether name lies in a mirrored subtree
+func (m Mirror) Tracks(profile, region, name string) bool {
+	file, err := m.path(profile, region, name)
+	if err != nil {
+		return false
+	}
+	root := m.root(profile, region)
+	for dir := filepath.Dir(file); strings.HasPrefix(dir, root); dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, markerFile)); err == nil {
+			return true
+		}
+		if dir == root {
+			break
+		}
+	}
+	return false
+}
+
+// Sync writes params, the parameters under prefix, to the mirror, removes the
+// files of parameters no longer under prefix, marks prefix as mirrored and
+// commits the result. It reports whether anything was committed.
+func (m Mirror) Sync(ctx context.Context, profile, region, prefix string, params []*aws.Parameter) (bool, error) {
+	dir, err := m.path(profile, region, prefix)
+	if err != nil {
+		return false, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return false, fmt.Errorf("failed to create mirror directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, markerFile), nil, 0o644); err != nil {
+		return false, fmt.Errorf("failed to mark mirrored path: %w", err)
+	}
+
+	keep := make(map[string]bool, len(params))
+	for _, p := range params {
+		if aws.IsShared(p.Name) {
+			continue
+		}
+		file, err := m.write(profile, region, p)
+		if err != nil {
+			return false, err
+		}
+		keep[file] = true
+	}
+
+	// Parameters deleted since the last sync
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() == markerFile || keep[path] {
+			return nil
+		}
+		return os.Remove(path)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to remove deleted parameters from mirror: %w", err)
+	}
+
+	return m.commit(ctx, fmt.Sprintf("Mirror %s (%s, %s)", prefix, profile, region))
+}
+
+// Record writes p to the mirror and commits it when p lies in a mirrored
+// subtree. It reports whether anything was committed.
+func (m Mirror) Record(ctx context.Context, profile, region string, p *aws.Parameter) (bool, error) {
+	if !m.Tracks(profile, region, p.Name) {
+		return false, nil
+	}
+	if _, err := m.write(profile, region, p); err != nil {
+		return false, err
+	}
+	return m.commit(ctx, fmt.Sprintf("Update %s to version %d (%s, %s)", p.Name, p.Version, profile, region))
+}
+
+// Remove deletes the files of names in mirrored subtrees and commits the
+// result. It reports whether anything was committed.
+func (m Mirror) Remove(ctx context.Context, profile, region string, names []string) (bool, error) {
+	var removed []string
+	for _, name := range names {
+		if !m.Tracks(profile, region, name) {
+			continue
+		}
+		file, err := m.file(profile, region, name)
+		if err != nil {
+			return false, err
+		}
+		if err := os.Remove(file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return false, fmt.Errorf("failed to remove %s from mirror: %w", name, err)
+		}
+		removed = append(removed, name)
+	}
+	if len(removed) == 0 {
+		return false, nil
+	}
+	message := fmt.Sprintf("Delete %s (%s, %s)", removed[0], profile, region)
+	if len(removed) > 1 {
+		message = fmt.Sprintf("Delete %d parameters (%s, %s)\n\n%s", len(removed), profile, region, strings.Join(removed, "\n"))
+	}
+	return m.commit(ctx, message)
+}
+
+// write stores p in its file and returns the file's path
+func (m Mirror) write(profile, region string, p *aws.Parameter) (string, error) {
+	file, err := m.file(profile, region, p.Name)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create mirror directory: %w", err)
+	}
+	if err := os.WriteFile(file, Content(p), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write %s to mirror: %w", p.Name, err)
+	}
+	return file, nil
+}
+
+// commit commits every change in the mirror, creating the repository first
+// if needed. It reports whether there was anything to commit.
+func (m Mirror) commit(ctx context.Context, message string) (bool, error) {
+	if _, err := os.Stat(filepath.Join(m.Dir, ".git")); errors.Is(err, fs.ErrNotExist) {
+		if _, err := m.git(ctx, "init", "--quiet"); err != nil {
+			return false, err
+		}
+	}
+	if _, err := m.git(ctx, "add", "--all"); err != nil {
+		return false, err
+	}
+	status, err := m.git(ctx, "status", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+	if len(bytes.TrimSpace(status)) == 0 {
+		return false, nil
+	}
+	if _, err := m.git(ctx, "commit", "--quiet", "--message", message); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// git runs a git command in the mirror
+func (m Mirror) git(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", m.Dir}, args...)...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run git %s: %w: %s", args[0], err, bytes.TrimSpace(out))
+	}
+	return out, nil
+}
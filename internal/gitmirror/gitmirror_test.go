@@ -0,0 +1,162 @@
+package gitmirror
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+// testMirror returns a mirror in a temporary directory, with a git identity
+func testMirror(t *testing.T) Mirror {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	for _, v := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(v, "ps9s test")
+	}
+	for _, v := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(v, "test@example.com")
+	}
+	return New(t.TempDir())
+}
+
+func commitCount(t *testing.T, m Mirror) int {
+	t.Helper()
+	out, err := m.git(context.Background(), "rev-list", "--count", "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(string(out)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestMirror_SyncRecordRemove(t *testing.T) {
+	ctx := context.Background()
+	m := testMirror(t)
+	params := []*aws.Parameter{
+		{Name: "/app/prod/db/host", Type: "String", Value: "db.internal", Version: 1},
+		{Name: "/app/prod/db/password", Type: "SecureString", Value: "s3cret", Version: 4},
+	}
+
+	committed, err := m.Sync(ctx, "prod", "eu-west-1", "/app/prod/", params)
+	if err != nil || !committed {
+		t.Fatalf("expected the first sync to commit, got %v, %v", committed, err)
+	}
+	host, err := os.ReadFile(filepath.Join(m.Dir, "prod", "eu-west-1", "app", "prod", "db", "host.value"))
+	if err != nil || string(host) != "db.internal" {
+		t.Fatalf("unexpected mirrored value %q, %v", host, err)
+	}
+	password, _ := os.ReadFile(filepath.Join(m.Dir, "prod", "eu-west-1", "app", "prod", "db", "password.value"))
+	if strings.Contains(string(password), "s3cret") || !strings.Contains(string(password), "version 4") {
+		t.Fatalf("expected a masked SecureString, got %q", password)
+	}
+
+	if committed, err := m.Sync(ctx, "prod", "eu-west-1", "/app/prod/", params); err != nil || committed {
+		t.Fatalf("expected an unchanged sync not to commit, got %v, %v", committed, err)
+	}
+
+	if !m.Tracks("prod", "eu-west-1", "/app/prod/new") || m.Tracks("prod", "eu-west-1", "/app/staging/new") || m.Tracks("prod", "us-east-1", "/app/prod/new") {
+		t.Fatal("expected only parameters under the synced path to be tracked")
+	}
+
+	updated := &aws.Parameter{Name: "/app/prod/db/host", Type: "String", Value: "db2.internal", Version: 2}
+	if committed, err := m.Record(ctx, "prod", "eu-west-1", updated); err != nil || !committed {
+		t.Fatalf("expected the update to be committed, got %v, %v", committed, err)
+	}
+	if committed, err := m.Record(ctx, "prod", "eu-west-1", &aws.Parameter{Name: "/other/x", Type: "String"}); err != nil || committed {
+		t.Fatalf("expected untracked parameters to be ignored, got %v, %v", committed, err)
+	}
+
+	if committed, err := m.Remove(ctx, "prod", "eu-west-1", []string{"/app/prod/db/password"}); err != nil || !committed {
+		t.Fatalf("expected the deletion to be committed, got %v, %v", committed, err)
+	}
+	if n := commitCount(t, m); n != 3 {
+		t.Errorf("expected 3 commits, got %d", n)
+	}
+
+	// Parameters gone from AWS are removed by the next sync
+	if _, err := m.Sync(ctx, "prod", "eu-west-1", "/app/prod/", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(m.Dir, "prod", "eu-west-1", "app", "prod", "db", "host.value")); !os.IsNotExist(err) {
+		t.Errorf("expected the removed parameter's file to be deleted, got %v", err)
+	}
+}
+
+func TestMirror_ParameterAndDirectoryShareName(t *testing.T) {
+	ctx := context.Background()
+	m := testMirror(t)
+	params := []*aws.Parameter{
+		{Name: "/app/db", Type: "String", Value: "primary"},
+		{Name: "/app/db/host", Type: "String", Value: "db.internal"},
+	}
+	if _, err := m.Sync(ctx, "prod", "eu-west-1", "/app/", params); err != nil {
+		t.Fatalf("expected /app/db and /app/db/host to mirror side by side, got %v", err)
+	}
+
+	s, err := ReadSnapshot(m.Root("prod", "eu-west-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Files["/app/db"] != "primary" || s.Files["/app/db/host"] != "db.internal" {
+		t.Fatalf("unexpected snapshot %+v", s.Files)
+	}
+}
+
+func TestMirror_RefusesEscapingNames(t *testing.T) {
+	m := New(t.TempDir())
+	if _, err := m.path("prod", "eu-west-1", "/../../etc/passwd"); err == nil {
+		t.Fatal("expected names leaving the mirror to be refused")
+	}
+}
+
+func TestDrift(t *testing.T) {
+	ctx := context.Background()
+	m := testMirror(t)
+	snapshot := []*aws.Parameter{
+		{Name: "/app/host", Type: "String", Value: "db.internal"},
+		{Name: "/app/port", Type: "String", Value: "5432"},
+		{Name: "/app/password", Type: "SecureString", Version: 2},
+	}
+	if _, err := m.Sync(ctx, "prod", "eu-west-1", "/app/", snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := ReadSnapshot(m.Root("prod", "eu-west-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Files) != 3 || len(s.Prefixes) != 1 || s.Prefixes[0] != "/app/" {
+		t.Fatalf("unexpected snapshot %+v", s)
+	}
+
+	live := []*aws.Parameter{
+		{Name: "/app/host", Type: "String", Value: "db.internal"},
+		{Name: "/app/password", Type: "SecureString", Version: 3},
+		{Name: "/app/new", Type: "String", Value: "x"},
+		{Name: "/other/ignored", Type: "String", Value: "y"},
+	}
+	changes := Drift(s, live)
+	want := []struct {
+		name string
+		kind ChangeKind
+	}{{"/app/new", Added}, {"/app/password", Changed}, {"/app/port", Removed}}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %+v", len(want), changes)
+	}
+	for i, w := range want {
+		if changes[i].Name != w.name || changes[i].Kind != w.kind {
+			t.Errorf("change %d: got %s %d, want %s %d", i, changes[i].Name, changes[i].Kind, w.name, w.kind)
+		}
+	}
+}
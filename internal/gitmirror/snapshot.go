@@ -0,0 +1,124 @@
+package gitmirror
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+// Snapshot is a mirror directory of one profile and region read back, e.g. a
+// checkout of an earlier commit
+type Snapshot struct {
+	Files    map[string]string // File contents by parameter name
+	Prefixes []string          // Mirrored subtrees; empty when the snapshot covers every parameter
+}
+
+// Root returns the directory of the mirror holding a profile and region,
+// which ReadSnapshot reads back
+func (m Mirror) Root(profile, region string) string {
+	return m.root(profile, region)
+}
+
+// ReadSnapshot reads the parameter files below dir, named by their path
+// relative to dir without the value suffix
+func ReadSnapshot(dir string) (*Snapshot, error) {
+	s := &Snapshot{Files: make(map[string]string)}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name := "/" + filepath.ToSlash(rel)
+		if d.Name() == markerFile {
+			s.Prefixes = append(s.Prefixes, strings.TrimSuffix(name, markerFile))
+			return nil
+		}
+		name, ok := strings.CutSuffix(name, valueSuffix)
+		if !ok {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		s.Files[name] = string(data)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	return s, nil
+}
+
+// Covers reports whether name lies in a subtree the snapshot holds
+func (s *Snapshot) Covers(name string) bool {
+	if len(s.Prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range s.Prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangeKind is how a parameter differs from a snapshot
+type ChangeKind int
+
+// Kinds of drift
+const (
+	Added ChangeKind = iota + 1
+	Changed
+	Removed
+)
+
+// Change is a parameter that differs from a snapshot
+type Change struct {
+	Name     string
+	Kind     ChangeKind
+	Snapshot string // File content in the snapshot, empty when added
+	Live     string // Content of the live parameter, empty when removed
+}
+
+// Drift compares live parameters, with values of all but SecureStrings, to
+// the snapshot and returns the differences by name
+func Drift(s *Snapshot, live []*aws.Parameter) []Change {
+	var changes []Change
+	seen := make(map[string]bool, len(live))
+	for _, p := range live {
+		if aws.IsShared(p.Name) || !s.Covers(p.Name) {
+			continue
+		}
+		seen[p.Name] = true
+		content := string(Content(p))
+		snapshot, ok := s.Files[p.Name]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Name: p.Name, Kind: Added, Live: content})
+		case snapshot != content:
+			changes = append(changes, Change{Name: p.Name, Kind: Changed, Snapshot: snapshot, Live: content})
+		}
+	}
+	for name, snapshot := range s.Files {
+		if !seen[name] {
+			changes = append(changes, Change{Name: name, Kind: Removed, Snapshot: snapshot})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
@@ -0,0 +1,282 @@
+// Package manifest reads declarative lists of parameters for `ps9s apply`
+// and plans the writes that bring an account in line with them
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ilia/ps9s/internal/aws"
+	"gopkg.in/yaml.v3"
+)
+
+// Parameter is a parameter as the manifest declares it
+type Parameter struct {
+	Name      string
+	Type      string // String, StringList or SecureString
+	Value     string
+	ValueFile string // Path the value was read from, relative to the manifest
+	KeyID     string // KMS key for SecureString; empty uses alias/aws/ssm
+	Tags      []aws.Tag
+	HasTags   bool // Tags are managed; without a tags key they are left alone
+}
+
+// Manifest is a list of parameters to create or update
+type Manifest struct {
+	Parameters []Parameter
+}
+
+// Load reads the manifest at path; value_file paths are relative to it
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	m, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+	for i := range m.Parameters {
+		p := &m.Parameters[i]
+		if p.ValueFile == "" {
+			continue
+		}
+		file := p.ValueFile
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(filepath.Dir(path), file)
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read value of %s: %w", p.Name, err)
+		}
+		p.Value = strings.TrimSuffix(string(data), "\n")
+		if p.Value == "" {
+			return nil, fmt.Errorf("%s: value file %s is empty", p.Name, p.ValueFile)
+		}
+	}
+	return m, nil
+}
+
+// Parse reads a YAML (or JSON) manifest:
+//
+//	parameters:
+//	  - name: /app/prod/db/host
+//	    value: db.internal
+//	  - name: /app/prod/db/password
+//	    type: SecureString
+//	    value_file: secrets/db-password
+//	    tags:
+//	      team: payments
+func Parse(data []byte) (*Manifest, error) {
+	var doc document
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&doc); errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("the manifest is empty")
+	} else if err != nil {
+		return nil, err
+	}
+	if doc.Parameters == nil {
+		return nil, fmt.Errorf("parameters must be a list")
+	}
+
+	m := &Manifest{}
+	seen := make(map[string]bool, len(doc.Parameters))
+	for i, e := range doc.Parameters {
+		p, err := parseParameter(e)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %d: %w", i+1, err)
+		}
+		if seen[p.Name] {
+			return nil, fmt.Errorf("parameter %s is listed twice", p.Name)
+		}
+		seen[p.Name] = true
+		m.Parameters = append(m.Parameters, p)
+	}
+	return m, nil
+}
+
+// document is the manifest as written
+type document struct {
+	Parameters []entry `yaml:"parameters"`
+}
+
+// entry is one parameter as written; tags stay nil unless given as a mapping
+type entry struct {
+	Name      string            `yaml:"name"`
+	Type      string            `yaml:"type"`
+	Value     string            `yaml:"value"`
+	ValueFile string            `yaml:"value_file"`
+	KeyID     string            `yaml:"key_id"`
+	Tags      map[string]string `yaml:"tags"`
+}
+
+// parseParameter validates one entry of the parameters list
+func parseParameter(e entry) (Parameter, error) {
+	p := Parameter{
+		Name:      e.Name,
+		Type:      e.Type,
+		Value:     e.Value,
+		ValueFile: e.ValueFile,
+		KeyID:     e.KeyID,
+	}
+	if p.Name == "" {
+		return p, fmt.Errorf("name is required")
+	}
+	if p.Type == "" {
+		p.Type = "String"
+	}
+	switch p.Type {
+	case "String", "StringList", "SecureString":
+	default:
+		return p, fmt.Errorf("%s: unsupported type %q", p.Name, p.Type)
+	}
+	if p.KeyID != "" && p.Type != "SecureString" {
+		return p, fmt.Errorf("%s: key_id only applies to SecureString parameters", p.Name)
+	}
+	if (p.Value == "") == (p.ValueFile == "") {
+		return p, fmt.Errorf("%s: set exactly one of value and value_file", p.Name)
+	}
+
+	// A tags key without a value leaves tags unmanaged; "tags: {}" removes them
+	if e.Tags != nil {
+		p.HasTags = true
+		for key, value := range e.Tags {
+			p.Tags = append(p.Tags, aws.Tag{Key: key, Value: value})
+		}
+		sort.Slice(p.Tags, func(i, j int) bool { return p.Tags[i].Key < p.Tags[j].Key })
+	}
+	return p, nil
+}
+
+// Action is what applying does to a parameter
+type Action string
+
+const (
+	ActionCreate    Action = "create"
+	ActionUpdate    Action = "update"
+	ActionTag       Action = "tag" // Only the tags change
+	ActionUnchanged Action = "unchanged"
+)
+
+// Change is a planned write for one manifest parameter
+type Change struct {
+	Parameter Parameter
+	Action    Action
+	Reasons   []string  // What differs, e.g. "value" or "type String → SecureString"
+	OldTags   []aws.Tag // Current tags of existing parameters with managed tags
+}
+
+// Plan compares m with the parameters in client's account
+func Plan(ctx context.Context, client *aws.Client, m *Manifest) ([]Change, error) {
+	names := make([]string, len(m.Parameters))
+	for i, p := range m.Parameters {
+		names[i] = p.Name
+	}
+	existing, err := client.GetParameters(ctx, names, true)
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[string]*aws.Parameter, len(existing))
+	var secure []string
+	for _, p := range existing {
+		current[p.Name] = p
+		if p.Type == "SecureString" {
+			secure = append(secure, p.Name)
+		}
+	}
+	keys := map[string]string{}
+	if len(secure) > 0 {
+		if keys, err = client.KeyIDs(ctx, secure); err != nil {
+			return nil, err
+		}
+	}
+
+	changes := make([]Change, len(m.Parameters))
+	for i, p := range m.Parameters {
+		c := Change{Parameter: p, Action: ActionUnchanged}
+		cur, ok := current[p.Name]
+		if !ok {
+			c.Action = ActionCreate
+			changes[i] = c
+			continue
+		}
+		if cur.Type != p.Type {
+			c.Reasons = append(c.Reasons, fmt.Sprintf("type %s → %s", cur.Type, p.Type))
+		}
+		if cur.Value != p.Value {
+			c.Reasons = append(c.Reasons, "value")
+		}
+		if cur.Type == "SecureString" && p.Type == "SecureString" {
+			if old, want := keys[p.Name], keyID(p); old != want {
+				c.Reasons = append(c.Reasons, fmt.Sprintf("key_id %s → %s", old, want))
+			}
+		}
+		if len(c.Reasons) > 0 {
+			c.Action = ActionUpdate
+		}
+		if p.HasTags {
+			tags, err := client.ListTags(ctx, p.Name)
+			if err != nil {
+				return nil, err
+			}
+			if add, remove := aws.DiffTags(tags, p.Tags); len(add) > 0 || len(remove) > 0 {
+				c.OldTags = tags
+				c.Reasons = append(c.Reasons, "tags")
+				if c.Action == ActionUnchanged {
+					c.Action = ActionTag
+				}
+			}
+		}
+		changes[i] = c
+	}
+	return changes, nil
+}
+
+// Apply makes the planned changes, stopping at the first failure. It returns
+// how many were applied.
+func Apply(ctx context.Context, client *aws.Client, changes []Change) (int, error) {
+	applied := 0
+	for _, c := range changes {
+		p := c.Parameter
+		var err error
+		switch c.Action {
+		case ActionCreate:
+			err = client.CreateParameter(ctx, p.Name, p.Value, p.Type, p.KeyID, "", p.Tags)
+		case ActionUpdate:
+			if p.Type == "SecureString" {
+				err = client.PutSecureParameter(ctx, p.Name, p.Value, keyID(p))
+			} else {
+				err = client.PutParameter(ctx, p.Name, p.Value, p.Type)
+			}
+			if err == nil && c.OldTags != nil {
+				err = client.UpdateTags(ctx, p.Name, c.OldTags, p.Tags)
+			}
+		case ActionTag:
+			err = client.UpdateTags(ctx, p.Name, c.OldTags, p.Tags)
+		default:
+			continue
+		}
+		if err != nil {
+			return applied, err
+		}
+		applied++
+	}
+	return applied, nil
+}
+
+// keyID returns the KMS key p is encrypted with, naming the default key so
+// that leaving key_id out moves parameters back to it
+func keyID(p Parameter) string {
+	if p.KeyID == "" {
+		return aws.DefaultKeyID
+	}
+	return p.KeyID
+}
@@ -0,0 +1,148 @@
+package manifest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+const testManifest = `# Production settings
+parameters:
+  - name: /api/prod/log-level   # changed
+    value: error
+  - name: /api/prod/db/port
+    value: 5432
+    tags:
+      team: platform
+      owner: 'db''s team'
+  - name: /api/prod/tls/cert
+    type: SecureString
+    key_id: alias/app
+    value: |
+      line 1
+      line 2
+    tags: {"team": "edge"}
+`
+
+func TestParse(t *testing.T) {
+	m, err := Parse([]byte(testManifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Parameters) != 3 {
+		t.Fatalf("expected 3 parameters, got %+v", m.Parameters)
+	}
+	if p := m.Parameters[0]; p.Name != "/api/prod/log-level" || p.Value != "error" || p.Type != "String" || p.HasTags {
+		t.Fatalf("unexpected first parameter: %+v", p)
+	}
+	if p := m.Parameters[1]; p.Value != "5432" || len(p.Tags) != 2 || p.Tags[0] != (aws.Tag{Key: "owner", Value: "db's team"}) {
+		t.Fatalf("unexpected tags: %+v", p.Tags)
+	}
+	if p := m.Parameters[2]; p.Value != "line 1\nline 2\n" || p.KeyID != "alias/app" || p.Tags[0].Value != "edge" {
+		t.Fatalf("unexpected block scalar parameter: %+v", p)
+	}
+}
+
+func TestParse_Tags(t *testing.T) {
+	for doc, managed := range map[string]bool{
+		"parameters:\n  - name: /a\n    value: x\n":                 false,
+		"parameters:\n  - name: /a\n    value: x\n    tags:\n":      false,
+		"parameters:\n  - name: /a\n    value: x\n    tags: null\n": false,
+		"parameters:\n  - name: /a\n    value: x\n    tags: {}\n":   true,
+	} {
+		m, err := Parse([]byte(doc))
+		if err != nil {
+			t.Fatalf("%q: %v", doc, err)
+		}
+		if p := m.Parameters[0]; p.HasTags != managed || len(p.Tags) != 0 {
+			t.Errorf("%q: expected managed tags %v, got %+v", doc, managed, p)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for name, doc := range map[string]string{
+		"unknown key":   "parameters:\n  - name: /a\n    valeu: x\n",
+		"no value":      "parameters:\n  - name: /a\n",
+		"bad type":      "parameters:\n  - name: /a\n    type: Secret\n    value: x\n",
+		"duplicate":     "parameters:\n  - name: /a\n    value: x\n  - name: /a\n    value: y\n",
+		"key_id":        "parameters:\n  - name: /a\n    key_id: k\n    value: x\n",
+		"bad indent":    "parameters:\n  - name: /a\n      value: x\n",
+		"not a list":    "parameters: x\n",
+		"unterminated":  "parameters:\n  - name: \"/a\n    value: x\n",
+		"tab indented":  "parameters:\n\t- name: /a\n",
+		"empty":         "# nothing\n",
+		"top-level key": "params:\n  - name: /a\n    value: x\n",
+	} {
+		if _, err := Parse([]byte(doc)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestLoad_ValueFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "secret"), []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "manifest.json")
+	doc := `{"parameters": [{"name": "/a", "type": "SecureString", "value_file": "secret"}]}`
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	m, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Parameters[0].Value != "s3cret" {
+		t.Fatalf("expected the value from the file, got %q", m.Parameters[0].Value)
+	}
+}
+
+func TestPlanAndApply(t *testing.T) {
+	t.Cleanup(aws.ResetDemo)
+	ctx := context.Background()
+	client := aws.NewDemoClient("demo-manifest", "eu-west-1")
+	m, err := Parse([]byte(testManifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := Plan(ctx, client, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Action{ActionUpdate, ActionTag, ActionCreate}
+	for i, c := range changes {
+		if c.Action != want[i] {
+			t.Fatalf("%s: expected %s, got %s (%v)", c.Parameter.Name, want[i], c.Action, c.Reasons)
+		}
+	}
+
+	applied, err := Apply(ctx, client, changes)
+	if err != nil || applied != 3 {
+		t.Fatalf("applied %d: %v", applied, err)
+	}
+	changes, err = Plan(ctx, client, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range changes {
+		if c.Action != ActionUnchanged {
+			t.Fatalf("%s should be unchanged after apply, got %s (%v)", c.Parameter.Name, c.Action, c.Reasons)
+		}
+	}
+
+	// Moving to another KMS key is drift too
+	m.Parameters[2].KeyID = "alias/other"
+	changes, err = Plan(ctx, client, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := changes[2]; c.Action != ActionUpdate || len(c.Reasons) != 1 || c.Reasons[0] != "key_id alias/app → alias/other" {
+		t.Fatalf("expected a key_id update, got %s (%v)", c.Action, c.Reasons)
+	}
+}
@@ -0,0 +1,170 @@
+// Package snapshot remembers what each parameter looked like when it was last
+// viewed, so later changes can be pointed out and diffed without CloudTrail
+package snapshot
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+// Entry is a parameter as it was last viewed
+type Entry struct {
+	Version      int64     `json:"version"`
+	SHA256       string    `json:"sha256"`
+	Viewed       time.Time `json:"viewed"`
+	LastModified time.Time `json:"last_modified,omitempty"`
+	Value        string    `json:"value,omitempty"` // AES-GCM sealed, base64; only in value mode
+}
+
+// Store holds the snapshots of every context in snapshots.json
+type Store struct {
+	mu      sync.Mutex
+	dir     string
+	values  bool // Keep encrypted values, not only digests
+	entries map[string]Entry
+}
+
+// Open loads the snapshots kept in dir. With values, new snapshots also keep
+// the value, encrypted with a key kept next to them.
+func Open(dir string, values bool) (*Store, error) {
+	s := &Store{dir: dir, values: values, entries: make(map[string]Entry)}
+	data, err := os.ReadFile(filepath.Join(dir, "snapshots.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshots: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.entries); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshots: %w", err)
+	}
+	return s, nil
+}
+
+// key identifies a parameter across profiles and regions
+func key(profile, region, name string) string {
+	return profile + "/" + region + "/" + name
+}
+
+// Digest returns the hex SHA-256 of value
+func Digest(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// Get returns the snapshot of a parameter, if it was viewed before
+func (s *Store) Get(profile, region, name string) (Entry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key(profile, region, name)]
+	return e, ok
+}
+
+// Record replaces the snapshot of p with its current state and saves the store
+func (s *Store) Record(profile, region string, p *aws.Parameter) error {
+	e := Entry{
+		Version:      p.Version,
+		SHA256:       Digest(p.Value),
+		Viewed:       time.Now(),
+		LastModified: p.LastModifiedDate,
+	}
+	if s.values {
+		sealed, err := s.seal(p.Value)
+		if err != nil {
+			return err
+		}
+		e.Value = sealed
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key(profile, region, p.Name)] = e
+	data, err := json.MarshalIndent(s.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshots: %w", err)
+	}
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(s.dir, "snapshots.json"), data, 0600); err != nil {
+		return fmt.Errorf("failed to write snapshots: %w", err)
+	}
+	return nil
+}
+
+// Value decrypts the value kept in e, reporting false when e holds none
+func (s *Store) Value(e Entry) (string, bool, error) {
+	if e.Value == "" {
+		return "", false, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(e.Value)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	gcm, err := s.cipher()
+	if err != nil {
+		return "", false, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", false, fmt.Errorf("snapshot is too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to decrypt snapshot: %w", err)
+	}
+	return string(plain), true, nil
+}
+
+// seal encrypts value with the local key
+func (s *Store) seal(value string) (string, error) {
+	gcm, err := s.cipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(value), nil)), nil
+}
+
+// cipher returns AES-GCM with the key in snapshot.key, creating the key on
+// first use. The key is only readable by the user, and keeps snapshot values
+// unreadable when snapshots.json alone is copied or shared.
+func (s *Store) cipher() (cipher.AEAD, error) {
+	path := filepath.Join(s.dir, "snapshot.key")
+	k, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		k = make([]byte, 32)
+		if _, err := rand.Read(k); err != nil {
+			return nil, fmt.Errorf("failed to generate snapshot key: %w", err)
+		}
+		if err := os.MkdirAll(s.dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create config directory: %w", err)
+		}
+		if err := os.WriteFile(path, k, 0600); err != nil {
+			return nil, fmt.Errorf("failed to write snapshot key: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot key: %w", err)
+	}
+	block, err := aes.NewCipher(k)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
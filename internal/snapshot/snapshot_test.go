@@ -0,0 +1,65 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+func TestRecordAndReopen(t *testing.T) {
+	dir := t.TempDir()
+	store, err := Open(dir, false)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if err := store.Record("prod", "eu-west-1", &aws.Parameter{Name: "/app/db", Version: 3, Value: "secret"}); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	reopened, err := Open(dir, false)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	e, ok := reopened.Get("prod", "eu-west-1", "/app/db")
+	if !ok || e.Version != 3 || e.SHA256 != Digest("secret") {
+		t.Fatalf("unexpected snapshot %+v (found %v)", e, ok)
+	}
+	if _, ok := reopened.Get("staging", "eu-west-1", "/app/db"); ok {
+		t.Fatal("snapshots must be kept per context")
+	}
+	if _, ok, _ := reopened.Value(e); ok {
+		t.Fatal("hash snapshots must not keep the value")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "snapshot.key")); !os.IsNotExist(err) {
+		t.Fatal("hash snapshots must not create a key")
+	}
+}
+
+func TestValueSnapshotsAreEncrypted(t *testing.T) {
+	dir := t.TempDir()
+	store, err := Open(dir, true)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if err := store.Record("prod", "eu-west-1", &aws.Parameter{Name: "/app/db", Version: 1, Value: "hunter2"}); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "snapshots.json"))
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if strings.Contains(string(data), "hunter2") {
+		t.Fatalf("value stored in plaintext:\n%s", data)
+	}
+
+	reopened, _ := Open(dir, true)
+	e, _ := reopened.Get("prod", "eu-west-1", "/app/db")
+	value, ok, err := reopened.Value(e)
+	if err != nil || !ok || value != "hunter2" {
+		t.Fatalf("Value() = %q, %v, %v", value, ok, err)
+	}
+}
@@ -1,43 +1,166 @@
 package styles
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Palette colors adapt to the terminal background. Screens use these instead
+// of hardcoded colors so themes and NO_COLOR apply everywhere.
 var (
-	// Colors
-	primaryColor   = lipgloss.Color("86")
-	secondaryColor = lipgloss.Color("205")
-	successColor   = lipgloss.Color("42")
-	errorColor     = lipgloss.Color("196")
-	subtleColor    = lipgloss.Color("240")
+	Primary   lipgloss.TerminalColor
+	Secondary lipgloss.TerminalColor
+	Success   lipgloss.TerminalColor
+	Error     lipgloss.TerminalColor
+	Warning   lipgloss.TerminalColor
+	Subtle    lipgloss.TerminalColor
+	Text      lipgloss.TerminalColor
+)
+
+// defaultPalette sets the standard colors: the dark variants are the original
+// ANSI 256 colors, the light ones stay legible on white
+func defaultPalette() {
+	Primary = lipgloss.AdaptiveColor{Light: "30", Dark: "86"}
+	Secondary = lipgloss.AdaptiveColor{Light: "162", Dark: "205"}
+	Success = lipgloss.AdaptiveColor{Light: "28", Dark: "42"}
+	Error = lipgloss.AdaptiveColor{Light: "160", Dark: "196"}
+	Warning = lipgloss.AdaptiveColor{Light: "130", Dark: "214"}
+	Subtle = lipgloss.AdaptiveColor{Light: "243", Dark: "240"}
+	Text = lipgloss.AdaptiveColor{Light: "235", Dark: "252"}
+}
+
+// highContrastPalette uses the basic ANSI colors at full intensity, and plain
+// foreground text instead of dim gray for help lines and borders
+func highContrastPalette() {
+	Primary = lipgloss.AdaptiveColor{Light: "4", Dark: "14"}
+	Secondary = lipgloss.AdaptiveColor{Light: "5", Dark: "13"}
+	Success = lipgloss.AdaptiveColor{Light: "2", Dark: "10"}
+	Error = lipgloss.AdaptiveColor{Light: "1", Dark: "9"}
+	Warning = lipgloss.AdaptiveColor{Light: "3", Dark: "11"}
+	Subtle = lipgloss.AdaptiveColor{Light: "0", Dark: "15"}
+	Text = lipgloss.AdaptiveColor{Light: "0", Dark: "15"}
+}
+
+// Glyphs are the decorative characters screens draw. SetASCII swaps them for
+// plain ASCII on terminals and fonts that render Unicode poorly.
+var (
+	Cursor   string          // Selected row marker
+	Expanded string          // Open tree directory marker
+	Mask     string          // Stands in for each character of concealed text
+	Border   lipgloss.Border // Panel border
+	Spinner  spinner.Spinner // Loading indicator frames
+)
+
+var (
+	ascii        bool
+	reduceMotion bool
+)
 
+// SetASCII switches the glyphs to plain ASCII (or back to Unicode) and
+// rebuilds the shared styles. Call it before creating the screens, which copy
+// the spinner when they are constructed.
+func SetASCII(on bool) {
+	ascii = on
+	buildGlyphs()
+	buildStyles()
+}
+
+// SetReduceMotion replaces the animated spinner with a static marker. Like
+// SetASCII, it must be called before the screens are created.
+func SetReduceMotion(on bool) {
+	reduceMotion = on
+	buildGlyphs()
+}
+
+// buildGlyphs derives the glyphs from the ASCII and reduce motion options
+func buildGlyphs() {
+	if ascii {
+		Cursor, Expanded, Mask = ">", "v", "*"
+		Border = lipgloss.ASCIIBorder()
+		Spinner = spinner.Line
+	} else {
+		Cursor, Expanded, Mask = "▸", "▾", "•"
+		Border = lipgloss.RoundedBorder()
+		Spinner = spinner.Dot
+	}
+	if reduceMotion {
+		// A single frame never changes; the slow tick keeps the spinner
+		// from redrawing the screen
+		Spinner = spinner.Spinner{Frames: []string{"*"}, FPS: time.Minute}
+	}
+}
+
+var (
 	// Styles
+	TitleStyle   lipgloss.Style
+	LabelStyle   lipgloss.Style
+	ValueStyle   lipgloss.Style
+	ErrorStyle   lipgloss.Style
+	WarningStyle lipgloss.Style
+	SuccessStyle lipgloss.Style
+	HelpStyle    lipgloss.Style
+	InfoStyle    lipgloss.Style
+)
+
+func init() {
+	defaultPalette()
+	buildGlyphs()
+	buildStyles()
+}
+
+// buildStyles derives the shared styles from the palette
+func buildStyles() {
 	TitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(primaryColor).
-			MarginBottom(1)
+		Bold(true).
+		Foreground(Primary).
+		MarginBottom(1)
 
 	LabelStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(secondaryColor)
+		Bold(true).
+		Foreground(Secondary)
 
 	ValueStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("252")).
-			Padding(1, 2).
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(subtleColor)
+		Foreground(Text).
+		Padding(1, 2).
+		Border(Border).
+		BorderForeground(Subtle)
 
 	ErrorStyle = lipgloss.NewStyle().
-			Foreground(errorColor).
-			Bold(true)
+		Foreground(Error).
+		Bold(true)
+
+	WarningStyle = lipgloss.NewStyle().
+		Foreground(Warning)
 
 	SuccessStyle = lipgloss.NewStyle().
-			Foreground(successColor).
-			Bold(true)
+		Foreground(Success).
+		Bold(true)
 
 	HelpStyle = lipgloss.NewStyle().
-			Foreground(subtleColor).
-			MarginTop(1)
+		Foreground(Subtle).
+		MarginTop(1)
 
 	InfoStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("252"))
-)
+		Foreground(Text)
+}
+
+// Themes lists the built-in theme names accepted by ApplyTheme
+var Themes = []string{"default", "high-contrast"}
+
+// ApplyTheme switches the shared styles to the named theme ("" is the default)
+func ApplyTheme(name string) error {
+	switch name {
+	case "", "default":
+		defaultPalette()
+	case "high-contrast":
+		highContrastPalette()
+	default:
+		return fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(Themes, ", "))
+	}
+	buildStyles()
+	return nil
+}
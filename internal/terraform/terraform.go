@@ -0,0 +1,154 @@
+// Package terraform finds the parameters Terraform manages in state and plan
+// JSON files, so manual edits that the next apply would revert can be flagged
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// parameterType is the Terraform resource type of SSM parameters
+const parameterType = "aws_ssm_parameter"
+
+// Resources maps the names of Terraform-managed parameters to their resource
+// addresses, e.g. "/app/db-host" to "module.app.aws_ssm_parameter.db_host"
+type Resources map[string]string
+
+// Load reads the state and plan files at paths and merges their parameters
+func Load(paths []string) (Resources, error) {
+	resources := make(Resources)
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Terraform file: %w", err)
+		}
+		found, err := Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Terraform file %s: %w", path, err)
+		}
+		for name, address := range found {
+			resources[name] = address
+		}
+	}
+	return resources, nil
+}
+
+// module is a module of `terraform show -json` output
+type module struct {
+	Resources []struct {
+		Address string         `json:"address"`
+		Mode    string         `json:"mode"`
+		Type    string         `json:"type"`
+		Values  map[string]any `json:"values"`
+	} `json:"resources"`
+	ChildModules []module `json:"child_modules"`
+}
+
+// document holds the parts of raw state files (.tfstate), and of state and
+// plan JSON from `terraform show -json`, that list resources
+type document struct {
+	// Raw state files
+	Resources []struct {
+		Module    string `json:"module"`
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Instances []struct {
+			IndexKey   any            `json:"index_key"`
+			Attributes map[string]any `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+	// `terraform show -json` of a state
+	Values *struct {
+		RootModule module `json:"root_module"`
+	} `json:"values"`
+	// `terraform show -json` of a plan
+	PlannedValues *struct {
+		RootModule module `json:"root_module"`
+	} `json:"planned_values"`
+	PriorState *struct {
+		Values *struct {
+			RootModule module `json:"root_module"`
+		} `json:"values"`
+	} `json:"prior_state"`
+	ResourceChanges []struct {
+		Address string `json:"address"`
+		Mode    string `json:"mode"`
+		Type    string `json:"type"`
+		Change  struct {
+			Before map[string]any `json:"before"`
+			After  map[string]any `json:"after"`
+		} `json:"change"`
+	} `json:"resource_changes"`
+}
+
+// Parse returns the parameters of a raw state file, or of state or plan JSON
+// from `terraform show -json`
+func Parse(data []byte) (Resources, error) {
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	resources := make(Resources)
+	add := func(address string, attrs map[string]any) {
+		if name, ok := attrs["name"].(string); ok && name != "" {
+			resources[name] = address
+		}
+	}
+
+	for _, r := range doc.Resources {
+		if r.Mode != "managed" || r.Type != parameterType {
+			continue
+		}
+		base := r.Type + "." + r.Name
+		if r.Module != "" {
+			base = r.Module + "." + base
+		}
+		for _, inst := range r.Instances {
+			add(base+indexSuffix(inst.IndexKey), inst.Attributes)
+		}
+	}
+
+	var walk func(m module)
+	walk = func(m module) {
+		for _, r := range m.Resources {
+			if r.Mode == "managed" && r.Type == parameterType {
+				add(r.Address, r.Values)
+			}
+		}
+		for _, child := range m.ChildModules {
+			walk(child)
+		}
+	}
+	if doc.Values != nil {
+		walk(doc.Values.RootModule)
+	}
+	if doc.PriorState != nil && doc.PriorState.Values != nil {
+		walk(doc.PriorState.Values.RootModule)
+	}
+	if doc.PlannedValues != nil {
+		walk(doc.PlannedValues.RootModule)
+	}
+	for _, rc := range doc.ResourceChanges {
+		if rc.Mode != "managed" || rc.Type != parameterType {
+			continue
+		}
+		// Both names, so parameters renamed or destroyed by the plan count too
+		add(rc.Address, rc.Change.Before)
+		add(rc.Address, rc.Change.After)
+	}
+	return resources, nil
+}
+
+// indexSuffix renders a count or for_each key as in resource addresses
+func indexSuffix(key any) string {
+	switch k := key.(type) {
+	case float64:
+		return fmt.Sprintf("[%d]", int(k))
+	case string:
+		return fmt.Sprintf("[%q]", k)
+	}
+	return ""
+}
@@ -0,0 +1,71 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParse_RawState(t *testing.T) {
+	state := `{
+  "version": 4,
+  "resources": [
+    {"mode": "managed", "type": "aws_ssm_parameter", "name": "db_host",
+     "instances": [{"attributes": {"name": "/app/db-host", "value": "x"}}]},
+    {"module": "module.svc", "mode": "managed", "type": "aws_ssm_parameter", "name": "flags",
+     "instances": [{"index_key": "prod", "attributes": {"name": "/svc/prod/flags"}}]},
+    {"mode": "data", "type": "aws_ssm_parameter", "name": "ami",
+     "instances": [{"attributes": {"name": "/aws/service/ami"}}]}
+  ]
+}`
+	got, err := Parse([]byte(state))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Resources{
+		"/app/db-host":    "aws_ssm_parameter.db_host",
+		"/svc/prod/flags": `module.svc.aws_ssm_parameter.flags["prod"]`,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for name, address := range want {
+		if got[name] != address {
+			t.Errorf("%s: got %q, want %q", name, got[name], address)
+		}
+	}
+}
+
+func TestParse_ShowJSON(t *testing.T) {
+	plan := `{
+  "format_version": "1.2",
+  "planned_values": {"root_module": {
+    "resources": [{"address": "aws_ssm_parameter.a", "mode": "managed", "type": "aws_ssm_parameter", "values": {"name": "/a"}}],
+    "child_modules": [{"resources": [{"address": "module.m.aws_ssm_parameter.b", "mode": "managed", "type": "aws_ssm_parameter", "values": {"name": "/b"}}]}]
+  }},
+  "resource_changes": [
+    {"address": "aws_ssm_parameter.gone", "mode": "managed", "type": "aws_ssm_parameter",
+     "change": {"before": {"name": "/gone"}, "after": null}}
+  ]
+}`
+	got, err := Parse([]byte(plan))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["/a"] != "aws_ssm_parameter.a" || got["/b"] != "module.m.aws_ssm_parameter.b" || got["/gone"] != "aws_ssm_parameter.gone" {
+		t.Fatalf("unexpected resources %v", got)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "terraform.tfstate")
+	if err := os.WriteFile(path, []byte(`{"resources": []}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load([]string{path}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load([]string{path + ".missing"}); err == nil {
+		t.Error("expected a missing file to fail")
+	}
+}
@@ -1,10 +1,15 @@
 package types
 
-import "github.com/ilia/ps9s/internal/aws"
+import (
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/gitmirror"
+)
 
-// ProfileSelectedMsg is sent when a user selects an AWS profile
+// ProfileSelectedMsg is sent when a user selects an AWS profile. A non-empty
+// Region skips the region selector and opens that context directly.
 type ProfileSelectedMsg struct {
 	Profile string
+	Region  string
 }
 
 // RegionSelectedMsg is sent when a user selects an AWS region
@@ -21,6 +26,7 @@ type ViewParameterMsg struct {
 type EditParameterMsg struct {
 	Parameter *aws.Parameter
 	JSONKey   string // Optional: if set, edit only this JSON key
+	ListItem  int    // Optional: if set, edit only this StringList item (1-based)
 }
 
 // BackMsg is sent when a user wants to go back to the previous screen
@@ -39,6 +45,9 @@ type ErrorMsg struct {
 // ParametersLoadedMsg is sent when parameters are loaded from AWS
 type ParametersLoadedMsg struct {
 	Parameters []*aws.Parameter
+	// Context the parameters were loaded for, so results reach the right tab
+	Profile string
+	Region  string
 }
 
 // ParameterValueLoadedMsg is sent when a parameter value is loaded
@@ -55,7 +64,323 @@ type SwitchRecentMsg struct {
 // GoToProfileSelectionMsg is sent when user wants to jump to profile selection
 type GoToProfileSelectionMsg struct{}
 
+// SwitchRegionMsg is sent when user wants another region for the current profile
+type SwitchRegionMsg struct{}
+
 // AddJSONKeyMsg is sent when a user wants to add a new JSON key to a parameter
 type AddJSONKeyMsg struct {
 	Parameter *aws.Parameter
 }
+
+// CreateParameterMsg is sent when a user wants to create a new parameter
+type CreateParameterMsg struct {
+	Prefix   string         // Optional: path the new name starts with
+	Template *aws.Parameter // Optional: parameter whose type, tags, KMS key and value structure are copied
+}
+
+// ShowTreeMsg is sent when a user switches to the hierarchical parameter view
+type ShowTreeMsg struct{}
+
+// NotifyChangesMsg is sent when a user wants change notifications for a
+// parameter, or for every parameter under a path when Prefix is set
+type NotifyChangesMsg struct {
+	Name   string
+	Prefix bool
+}
+
+// ChangeRuleLoadedMsg is sent when the EventBridge rule for a notification was looked up
+type ChangeRuleLoadedMsg struct {
+	Rule *aws.ChangeRule
+}
+
+// ChangeRuleSavedMsg is sent when the EventBridge rule was created or updated
+type ChangeRuleSavedMsg struct {
+	Rule *aws.ChangeRule
+}
+
+// ShowAppConfigMsg is sent when a user opens the AppConfig browser
+type ShowAppConfigMsg struct{}
+
+// AppConfigLoadedMsg is sent when a level of the AppConfig browser has loaded;
+// only the fields of that level are set
+type AppConfigLoadedMsg struct {
+	Seq          int
+	Applications []aws.AppConfigApplication
+	Environments []aws.AppConfigEnvironment
+	Profiles     []aws.AppConfigProfile
+	Deployments  map[string]aws.AppConfigDeployment
+	Versions     []aws.AppConfigVersion
+	Version      *aws.AppConfigVersion
+}
+
+// AppConfigSavedMsg is sent when edited AppConfig content was stored as a new version
+type AppConfigSavedMsg struct {
+	Version *aws.AppConfigVersion
+}
+
+// ParameterCreatedMsg is sent when a new parameter has been created
+type ParameterCreatedMsg struct {
+	Parameter *aws.Parameter
+}
+
+// ToggleDryRunMsg is sent when the user toggles dry-run mode
+type ToggleDryRunMsg struct{}
+
+// ToggleTimestampsMsg is sent when the user switches between relative and absolute times
+type ToggleTimestampsMsg struct{}
+
+// ToggleListModeMsg is sent when the user switches between compact and detailed list display
+type ToggleListModeMsg struct{}
+
+// ViewHistoryMsg is sent when a user wants to see a parameter's version history
+type ViewHistoryMsg struct {
+	Parameter *aws.Parameter
+}
+
+// HistoryLoadedMsg is sent when a parameter's version history is loaded
+type HistoryLoadedMsg struct {
+	Versions []*aws.Parameter
+}
+
+// CompareVersionsMsg is sent when a user wants to compare two parameter versions side by side
+type CompareVersionsMsg struct {
+	Older *aws.Parameter
+	Newer *aws.Parameter
+}
+
+// RestoreVersionMsg is sent when a user confirms restoring an old version of
+// a parameter; its value is written back as the latest version
+type RestoreVersionMsg struct {
+	Version *aws.Parameter
+}
+
+// ValuesLoadedMsg carries parameter values fetched for the list's value column
+type ValuesLoadedMsg struct {
+	Batch   int      // Prefetch batch that requested the values
+	Names   []string // Names that were requested
+	Values  map[string]string
+	Profile string
+	Region  string
+}
+
+// ToggleValuePreviewMsg is sent when the user toggles the list's value column
+type ToggleValuePreviewMsg struct{}
+
+// PeekLoadedMsg carries the value fetched for the list's peek popup
+type PeekLoadedMsg struct {
+	Parameter *aws.Parameter
+	Err       error
+}
+
+// ExportParametersMsg is sent when the user wants to export parameters to a file
+type ExportParametersMsg struct {
+	Parameters []*aws.Parameter
+	Format     string // Optional: export format to preselect
+}
+
+// ExportDoneMsg is sent when an export file was written
+type ExportDoneMsg struct {
+	Path  string
+	Count int
+}
+
+// ViewTagsMsg is sent when a user wants to see and edit a parameter's tags
+type ViewTagsMsg struct {
+	Parameter *aws.Parameter
+}
+
+// TagsLoadedMsg is sent when a parameter's tags are loaded
+type TagsLoadedMsg struct {
+	Tags []aws.Tag
+}
+
+// ActionKind identifies a parameter action that can be repeated
+type ActionKind int
+
+// Repeatable actions
+const (
+	ActionCopyValue ActionKind = iota + 1
+	ActionCopyLink
+	ActionAddTags
+)
+
+// RepeatableAction is a parameter action '.' applies again to another parameter
+type RepeatableAction struct {
+	Kind    ActionKind
+	JSONKey string    // Key of a copied JSON value, "" for the whole value
+	Tags    []aws.Tag // Tags added
+	// Base64 copies the value base64-encoded, e.g. for Kubernetes Secret data
+	Base64 bool
+}
+
+// ActionPerformedMsg records the last repeatable action
+type ActionPerformedMsg struct {
+	Action RepeatableAction
+}
+
+// RepeatActionMsg asks to apply the last repeatable action to Parameter
+type RepeatActionMsg struct {
+	Parameter *aws.Parameter
+}
+
+// ActionRepeatedMsg reports the result of repeating an action
+type ActionRepeatedMsg struct {
+	Status string
+	Err    error
+}
+
+// TagsSavedMsg is sent when a parameter's tags were updated
+type TagsSavedMsg struct {
+	Tags []aws.Tag
+}
+
+// ViewReferencesMsg opens the reference graph focused on Parameter
+type ViewReferencesMsg struct {
+	Parameter *aws.Parameter
+}
+
+// ReferenceGraphLoadedMsg is sent when the references between parameters were found
+type ReferenceGraphLoadedMsg struct {
+	Graph      *aws.ReferenceGraph
+	Parameters []*aws.Parameter
+}
+
+// ShowStatsMsg opens the statistics of the listed parameters
+type ShowStatsMsg struct{}
+
+// ShowIAMPolicyMsg opens the screen showing the IAM policy ps9s needs
+type ShowIAMPolicyMsg struct{}
+
+// SubshellMsg asks to start a subshell with Parameters exported as environment variables
+type SubshellMsg struct {
+	Parameters []*aws.Parameter
+}
+
+// SubshellExitedMsg is sent when the subshell exits
+type SubshellExitedMsg struct {
+	Count int // Parameters exported
+	Err   error
+}
+
+// ReportKind identifies a report over parameters
+type ReportKind int
+
+// Reports
+const (
+	ReportPlaceholders ReportKind = iota + 1
+	ReportLargest
+	ReportStale
+)
+
+// ReportRow is a parameter flagged by a report, with the reason
+type ReportRow struct {
+	Parameter *aws.Parameter
+	Note      string
+	Warn      bool // Highlight the note
+}
+
+// ShowReportMsg runs a report over Parameters; Scope describes them, e.g. a path prefix
+type ShowReportMsg struct {
+	Kind       ReportKind
+	Parameters []*aws.Parameter
+	Scope      string
+}
+
+// ReportLoadedMsg is sent when a report's rows were computed
+type ReportLoadedMsg struct {
+	Seq  int
+	Rows []ReportRow
+}
+
+// ParametersTaggedMsg is sent when a tag was added to several parameters
+type ParametersTaggedMsg struct {
+	Tag   aws.Tag
+	Names []string // Parameters tagged before any error
+	Err   error
+}
+
+// ParametersDeletedMsg is sent when parameters were deleted
+type ParametersDeletedMsg struct {
+	Names []string // Parameters deleted before any error
+	Err   error
+}
+
+// MirrorSubtreeMsg mirrors Parameters, the parameters under Prefix, into the git mirror
+type MirrorSubtreeMsg struct {
+	Prefix     string
+	Parameters []*aws.Parameter
+}
+
+// MirroredMsg is sent when changes were written to the git mirror
+type MirroredMsg struct {
+	Status string
+	Err    error
+}
+
+// ShowDriftMsg compares the listed parameters with a snapshot directory
+type ShowDriftMsg struct{}
+
+// DriftLoadedMsg is sent when the live parameters were compared with a snapshot
+type DriftLoadedMsg struct {
+	Seq     int
+	Changes []gitmirror.Change
+}
+
+// MoveSubtreeMsg opens the move of Parameters, the parameters under Prefix,
+// to another prefix
+type MoveSubtreeMsg struct {
+	Prefix     string
+	Parameters []*aws.Parameter
+}
+
+// CopySubtreeMsg opens the copy of Parameters, the parameters under Prefix,
+// to another prefix, possibly in another context
+type CopySubtreeMsg struct {
+	Prefix     string
+	Parameters []*aws.Parameter
+}
+
+// CopyParameterMsg opens the copy of Parameter to another context or name
+type CopyParameterMsg struct {
+	Parameter *aws.Parameter
+}
+
+// BulkAction is an action applied to the marked parameters of the list
+type BulkAction int
+
+const (
+	BulkDelete BulkAction = iota
+	BulkTag
+	BulkCopy
+	BulkExport
+)
+
+// BulkActionMsg applies Action to Parameters, the marked parameters; Tag is
+// the tag BulkTag adds
+type BulkActionMsg struct {
+	Action     BulkAction
+	Parameters []*aws.Parameter
+	Tag        aws.Tag
+}
+
+// CompareContextsMsg opens the comparison of Parameter with a parameter in
+// another context
+type CompareContextsMsg struct {
+	Parameter *aws.Parameter
+}
+
+// DeleteSubtreeMsg opens the deletion of Parameters, the parameters under
+// Prefix
+type DeleteSubtreeMsg struct {
+	Prefix     string
+	Parameters []*aws.Parameter
+}
+
+// SubtreeMovedMsg is sent when a subtree move or copy finished. Originals are
+// only deleted by moves, once their copy was read back unchanged.
+type SubtreeMovedMsg struct {
+	Created  []string         // Copies created at the destination
+	Deleted  []string         // Originals deleted
+	Failures map[string]error // Why originals were kept, by name
+	Err      error
+}
@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/styles"
+)
+
+// activityBarHeight is the number of lines reserved below each screen for the API indicator
+const activityBarHeight = 1
+
+// activityRefresh is how often the indicator is redrawn while calls are
+// running; reduce_motion slows it to calmRefresh
+const (
+	activityRefresh = 250 * time.Millisecond
+	calmRefresh     = time.Second
+)
+
+// activityTickMsg triggers a redraw of the API activity indicator
+type activityTickMsg struct{}
+
+// activityTick schedules the next indicator refresh
+func activityTick(reduceMotion bool) tea.Cmd {
+	interval := activityRefresh
+	if reduceMotion {
+		interval = calmRefresh
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return activityTickMsg{}
+	})
+}
+
+// waitActivity redraws the indicator once the next API call starts, so an
+// idle screen is not redrawn at all
+func waitActivity() tea.Cmd {
+	return func() tea.Msg {
+		<-aws.CallStarted()
+		return activityTickMsg{}
+	}
+}
+
+// nextActivityRefresh keeps refreshing the indicator while calls are in
+// flight and waits for the next call otherwise
+func nextActivityRefresh(reduceMotion bool) tea.Cmd {
+	if aws.Activity().InFlight > 0 {
+		return activityTick(reduceMotion)
+	}
+	return waitActivity()
+}
+
+// formatRoleChain renders the current profile's role chain for the status line
+func formatRoleChain(chain string) string {
+	return styles.HelpStyle.UnsetMarginTop().Render("role " + chain)
+}
+
+// joinStatus appends part to the status line
+func joinStatus(line, part string) string {
+	if line == "" {
+		return part
+	}
+	return line + styles.HelpStyle.UnsetMarginTop().Render(" • ") + part
+}
+
+// formatActivity renders API counters compactly, e.g. "AWS ● 1 running • 12 done • 2 retries"
+func formatActivity(a aws.APIActivity) string {
+	if a.InFlight == 0 && a.Completed == 0 {
+		return ""
+	}
+
+	dot := styles.HelpStyle.UnsetMarginTop().Render("○")
+	if a.InFlight > 0 {
+		dot = styles.SuccessStyle.Render("●")
+	}
+	s := fmt.Sprintf("%d running • %d done", a.InFlight, a.Completed)
+	line := styles.HelpStyle.UnsetMarginTop().Render("AWS ") + dot + styles.HelpStyle.UnsetMarginTop().Render(" "+s)
+	if a.Retries > 0 {
+		line += styles.WarningStyle.Render(fmt.Sprintf(" • %d retries", a.Retries))
+	}
+	return line
+}
+
+// apiLogHeight is the number of lines of the API log pane, including its header
+const apiLogHeight = 10
+
+// renderAPILog renders recent AWS calls, newest first, with their duration and status
+func renderAPILog(calls []aws.APICall, width int) string {
+	dim := styles.HelpStyle.UnsetMarginTop()
+	lines := []string{styles.LabelStyle.Render("Recent AWS calls") + dim.Render(" (ctrl+l: hide)")}
+	if len(calls) == 0 {
+		lines = append(lines, dim.Render("No AWS calls yet"))
+	}
+	for _, c := range calls {
+		line := fmt.Sprintf("%s  %-8s %-9s %-32s %7s", c.Start.Format("15:04:05"), c.Service, c.Region, c.Operation, formatCallDuration(c.Duration))
+		if c.Attempts > 1 {
+			line += fmt.Sprintf(" (%d attempts)", c.Attempts)
+		}
+		status := styles.SuccessStyle.Render(c.Status)
+		if c.Err != nil {
+			status = styles.ErrorStyle.Render(c.Status)
+		}
+		if width > 0 && len(line) > width-20 {
+			line = line[:max(0, width-20)]
+		}
+		lines = append(lines, line+"  "+status)
+	}
+	for len(lines) < apiLogHeight {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatCallDuration renders a call's duration with millisecond precision
+func formatCallDuration(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"expvar"
+	"fmt"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// messageCounts counts the messages the root model handled, by type, once
+// EnableMessageCounts was called
+var messageCounts struct {
+	sync.Mutex
+	enabled bool
+	byType  map[string]uint64
+}
+
+// EnableMessageCounts starts counting handled messages by type and publishes
+// the counts as the "tea_messages" expvar, served at /debug/vars next to pprof
+func EnableMessageCounts() {
+	messageCounts.Lock()
+	defer messageCounts.Unlock()
+	if messageCounts.enabled {
+		return
+	}
+	messageCounts.enabled = true
+	messageCounts.byType = make(map[string]uint64)
+	expvar.Publish("tea_messages", expvar.Func(func() any { return MessageCounts() }))
+}
+
+// MessageCounts returns the number of messages handled so far, by type
+func MessageCounts() map[string]uint64 {
+	messageCounts.Lock()
+	defer messageCounts.Unlock()
+	counts := make(map[string]uint64, len(messageCounts.byType))
+	for t, n := range messageCounts.byType {
+		counts[t] = n
+	}
+	return counts
+}
+
+// countMessage records msg when counting is enabled
+func countMessage(msg tea.Msg) {
+	messageCounts.Lock()
+	defer messageCounts.Unlock()
+	if messageCounts.enabled {
+		messageCounts.byType[fmt.Sprintf("%T", msg)]++
+	}
+}
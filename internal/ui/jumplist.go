@@ -0,0 +1,108 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+// maxJumps caps the number of remembered locations
+const maxJumps = 100
+
+// jumpEntry is a visited location: a screen plus the parameter shown on it
+type jumpEntry struct {
+	screen    Screen
+	profile   string
+	region    string
+	parameter *aws.Parameter
+}
+
+// same reports whether two entries point at the same location
+func (e jumpEntry) same(o jumpEntry) bool {
+	if e.screen != o.screen || e.profile != o.profile || e.region != o.region {
+		return false
+	}
+	if e.parameter == nil || o.parameter == nil {
+		return e.parameter == o.parameter
+	}
+	return e.parameter.Name == o.parameter.Name
+}
+
+// jumpList is a vim-style jump list; pos is the index of the current location
+type jumpList struct {
+	entries []jumpEntry
+	pos     int
+}
+
+// push records a new location, dropping any forward history
+func (j *jumpList) push(e jumpEntry) {
+	if len(j.entries) > 0 {
+		j.entries = j.entries[:j.pos+1]
+		if j.entries[j.pos].same(e) {
+			return
+		}
+	}
+	j.entries = append(j.entries, e)
+	if len(j.entries) > maxJumps {
+		j.entries = j.entries[len(j.entries)-maxJumps:]
+	}
+	j.pos = len(j.entries) - 1
+}
+
+// move steps delta entries, skipping locations rejected by ok
+func (j *jumpList) move(delta int, ok func(jumpEntry) bool) (jumpEntry, bool) {
+	for i := j.pos + delta; i >= 0 && i < len(j.entries); i += delta {
+		if ok(j.entries[i]) {
+			j.pos = i
+			return j.entries[i], true
+		}
+	}
+	return jumpEntry{}, false
+}
+
+// recordJump pushes the current location onto the jump list
+func (m *Model) recordJump(param *aws.Parameter) {
+	m.jumps.push(jumpEntry{
+		screen:    m.currentScreen,
+		profile:   m.currentProfile,
+		region:    m.currentRegion,
+		parameter: param,
+	})
+}
+
+// jump moves through the jump list (delta -1 back, +1 forward) and restores the
+// location. Entries from other profile/region contexts are skipped.
+func (m Model) jump(delta int) (Model, tea.Cmd) {
+	entry, ok := m.jumps.move(delta, func(e jumpEntry) bool {
+		return e.profile == m.currentProfile && e.region == m.currentRegion
+	})
+	if !ok {
+		return m, nil
+	}
+	debugLog("[Model.jump] %s -> %s", screenName(m.currentScreen), screenName(entry.screen))
+
+	client := m.awsClients[m.currentProfile]
+	m.currentScreen = entry.screen
+	switch entry.screen {
+	case ParameterViewScreen:
+		m.parameterView.SetContext(m.currentProfile, m.currentRegion)
+		return m, m.parameterView.LoadParameter(entry.parameter, client)
+	case HistoryScreen:
+		m.history.SetContext(m.currentProfile, m.currentRegion)
+		return m, m.history.LoadHistory(entry.parameter, client)
+	}
+	return m, nil
+}
+
+// jumpsAllowed reports whether ctrl+o/ctrl+i may navigate away from the current screen.
+// Screens with text input keep the keys (ctrl+i is tab in terminals).
+func (m Model) jumpsAllowed() bool {
+	switch m.currentScreen {
+	case ParameterListScreen:
+		return !m.parameterList.Capturing()
+	case ParameterViewScreen:
+		return !m.parameterView.PromptActive
+	case HistoryScreen, VersionCompareScreen, TreeScreen:
+		return true
+	}
+	return false
+}
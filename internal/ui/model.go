@@ -2,6 +2,7 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,6 +11,10 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/ilia/ps9s/internal/aws"
 	"github.com/ilia/ps9s/internal/config"
+	"github.com/ilia/ps9s/internal/export"
+	"github.com/ilia/ps9s/internal/gitmirror"
+	"github.com/ilia/ps9s/internal/snapshot"
+	"github.com/ilia/ps9s/internal/terraform"
 	"github.com/ilia/ps9s/internal/types"
 	"github.com/ilia/ps9s/internal/ui/screens"
 )
@@ -54,6 +59,24 @@ const (
 	ParameterViewScreen
 	ParameterEditScreen
 	JSONAddScreen
+	DryRunScreen
+	HistoryScreen
+	VersionCompareScreen
+	ParameterCreateScreen
+	TreeScreen
+	ContextSwitcherScreen
+	ExportScreen
+	TagsScreen
+	AppConfigScreen
+	NotifyScreen
+	IAMPolicyScreen
+	ReferencesScreen
+	ReportScreen
+	StatsScreen
+	DriftScreen
+	MoveScreen
+	DeleteSubtreeScreen
+	ContextCompareScreen
 )
 
 // Model represents the root application model
@@ -67,6 +90,24 @@ type Model struct {
 	parameterView   screens.ParameterViewModel
 	parameterEdit   screens.ParameterEditModel
 	jsonAdd         screens.JSONAddModel
+	dryRunPreview   screens.DryRunModel
+	parameterCreate screens.ParameterCreateModel
+	tree            screens.TreeModel
+	contextSwitcher screens.ContextSwitcherModel
+	exporter        screens.ExportModel
+	tags            screens.TagsModel
+	appConfig       screens.AppConfigModel
+	notify          screens.NotifyModel
+	iamPolicy       screens.IAMPolicyModel
+	references      screens.ReferencesModel
+	report          screens.ReportModel
+	stats           screens.StatsModel
+	drift           screens.DriftModel
+	move            screens.MoveModel
+	deleteSubtree   screens.DeleteSubtreeModel
+	history         screens.HistoryModel
+	versionCompare  screens.VersionCompareModel
+	contextCompare  screens.ContextCompareModel
 
 	// Shared state
 	profiles       []string
@@ -76,8 +117,58 @@ type Model struct {
 	regionMapping  *config.RegionMapping
 	// Recent profile+region entries (most recent first)
 	recents []config.RecentEntry
+	// Formatted role chains of profiles that assume a role
+	roleChains map[string]string
+	// Set while picking another region from the parameter list, so esc returns there
+	switchingRegion bool
+	// Context opened at startup instead of showing the selectors
+	startContext *config.RecentEntry
 	// Flag to prevent reordering recents when switching via keyboard
 	switchingToRecent bool
+	// When set, clients use in-memory sample data instead of AWS
+	demo bool
+	// When set, writes are previewed on DryRunScreen instead of sent
+	dryRun bool
+	// Screen to return to when leaving the dry-run preview
+	dryRunReturn Screen
+	// Screens to return to from the view and create screens (flat list or tree)
+	viewReturn   Screen
+	createReturn Screen
+	// Screen the context switcher overlay was opened from
+	switcherReturn Screen
+	// Screen to return to when leaving the export screen
+	exportReturn Screen
+	// Screen to return to when leaving the change notification screen
+	notifyReturn Screen
+	// Screen to return to when leaving a report
+	reportReturn Screen
+	// Screen to return to when leaving the version comparison (history or view)
+	compareReturn Screen
+	// Screen to return to when leaving a move or copy (tree, view or list)
+	moveReturn Screen
+	// Screen to return to when leaving a deletion (tree or list)
+	deleteReturn Screen
+	// Last parameter action, applied again to another parameter with '.'
+	lastAction *types.RepeatableAction
+	// Show recent AWS calls below the screen (ctrl+l)
+	showAPILog bool
+	// Mouse capture is off, leaving text selection to the terminal (ctrl+x)
+	mouseOff bool
+	// Open profile/region contexts; the active one is mirrored in the fields above
+	tabs      []contextTab
+	activeTab int
+	// Set while selecting the context for a new tab
+	openingTab bool
+	// Visited locations for ctrl+o/ctrl+i navigation
+	jumps jumpList
+	// User preferences from config.json
+	settings *config.Settings
+	// Parameters managed by Terraform, from the terraform_state files
+	terraform terraform.Resources
+	// How modification times are rendered on the list and view screens
+	timestamps screens.TimestampFormat
+	// Terminal window title last set
+	title string
 
 	// UI dimensions
 	width, height int
@@ -87,6 +178,12 @@ type Model struct {
 func NewModel(profiles []string, clientPool map[string]*aws.Client, regionMapping *config.RegionMapping) Model {
 	pl := screens.NewParameterList()
 
+	// Role chains of profiles assuming a role, shown so it's clear which
+	// role writes are attributed to (non-fatal)
+	roleChains, _ := config.GetRoleChains(profiles)
+	ps := screens.NewProfileSelector(profiles)
+	ps.SetRoleChains(roleChains)
+
 	// Load recents, prune stale profiles, and persist if changed (non-fatal)
 	recents, err := config.LoadRecentEntries()
 	if err == nil {
@@ -100,38 +197,287 @@ func NewModel(profiles []string, clientPool map[string]*aws.Client, regionMappin
 
 	return Model{
 		currentScreen:   ProfileSelectorScreen,
-		profileSelector: screens.NewProfileSelector(profiles),
+		profileSelector: ps,
 		regionSelector:  screens.NewRegionSelector(),
 		parameterList:   pl,
 		parameterView:   screens.NewParameterView(),
 		parameterEdit:   screens.NewParameterEdit(),
 		jsonAdd:         screens.NewJSONAdd(),
+		dryRunPreview:   screens.NewDryRun(),
+		parameterCreate: screens.NewParameterCreate(),
+		tree:            screens.NewTree(),
+		contextSwitcher: screens.NewContextSwitcher(),
+		exporter:        screens.NewExport(),
+		tags:            screens.NewTags(),
+		appConfig:       screens.NewAppConfig(),
+		notify:          screens.NewNotify(),
+		iamPolicy:       screens.NewIAMPolicy(),
+		references:      screens.NewReferences(),
+		report:          screens.NewReport(),
+		stats:           screens.NewStats(),
+		drift:           screens.NewDrift(),
+		move:            screens.NewMove(),
+		deleteSubtree:   screens.NewDeleteSubtree(),
+		history:         screens.NewHistory(),
+		versionCompare:  screens.NewVersionCompare(),
+		contextCompare:  screens.NewContextCompare(),
 		profiles:        profiles,
 		awsClients:      clientPool,
 		regionMapping:   regionMapping,
 		recents:         recents,
+		roleChains:      roleChains,
+		settings:        &config.Settings{},
+		viewReturn:      ParameterListScreen,
+		createReturn:    ParameterListScreen,
+	}
+}
+
+// ApplySettings applies user preferences to current and future clients and lists
+func (m *Model) ApplySettings(settings *config.Settings) {
+	m.settings = settings
+	m.timestamps.Layout = settings.TimeFormat
+	if loc, err := settings.Location(); err == nil {
+		m.timestamps.Location = loc
+	}
+	m.applyTimestampFormat()
+	for _, c := range m.awsClients {
+		m.configureClient(c)
+	}
+	m.parameterList.SetPageSize(settings.ListPageSize)
+	m.parameterList.SetDetailed(settings.ListMode == config.ListModeDetailed)
+	m.parameterList.SetReadOnly(settings.ReadOnly)
+	m.parameterList.SetShowValues(settings.ShowValues)
+	m.parameterList.SetColumns(settings.ListColumnNames())
+	m.report.SetStaleDays(settings.StaleAfter())
+	naming, _ := settings.NamingPattern()
+	m.parameterList.SetNaming(naming)
+	m.parameterCreate.SetNaming(naming)
+	m.exporter.SetSOPS(export.SOPSRecipients{Age: settings.SOPSAge, KMS: settings.SOPSKMS})
+	m.profileSelector.SetFavorites(settings.Favorites)
+	m.parameterView.SetEnvStyle(settings.EnvSeparator, settings.EnvCase)
+	m.parameterEdit.SetConcealSecrets(settings.ConcealSecrets)
+	m.parameterView.SetSnapshots(m.openSnapshots())
+	for i := range m.tabs {
+		if m.tabs[i].client != nil {
+			m.configureClient(m.tabs[i].client)
+		}
+		m.tabs[i].list.SetPageSize(settings.ListPageSize)
+		m.tabs[i].list.SetDetailed(settings.ListMode == config.ListModeDetailed)
+		m.tabs[i].list.SetReadOnly(settings.ReadOnly)
+		m.tabs[i].list.SetShowValues(settings.ShowValues)
+		m.tabs[i].list.SetColumns(settings.ListColumnNames())
+		m.tabs[i].list.SetNaming(naming)
+	}
+}
+
+// openSnapshots returns the store of viewed parameters when the snapshots
+// setting is on; demo data is never remembered
+func (m Model) openSnapshots() *snapshot.Store {
+	if m.settings.Snapshots == "" || m.demo {
+		return nil
+	}
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return nil
+	}
+	store, err := snapshot.Open(dir, m.settings.Snapshots == config.SnapshotsValue)
+	if err != nil {
+		debugLog("[Model.ApplySettings] snapshots: %v", err)
+		return nil
+	}
+	return store
+}
+
+// mirror returns the git mirror configured with mirror_dir, if any
+func (m Model) mirror() (gitmirror.Mirror, bool) {
+	if m.settings.MirrorDir == "" {
+		return gitmirror.Mirror{}, false
+	}
+	return gitmirror.New(m.settings.MirrorDir), true
+}
+
+// mirrorChange commits parameter name to the git mirror when it is mirrored
+func (m Model) mirrorChange(name string) tea.Cmd {
+	mirror, ok := m.mirror()
+	if !ok {
+		return nil
+	}
+	return screens.MirrorChange(m.awsClients[m.currentProfile], mirror, m.currentProfile, m.currentRegion, name)
+}
+
+// SetTerraform marks the parameters Terraform manages on the list and view screens
+func (m *Model) SetTerraform(resources terraform.Resources) {
+	m.terraform = resources
+	m.parameterList.SetTerraform(resources)
+	m.parameterView.SetTerraform(resources)
+	for i := range m.tabs {
+		m.tabs[i].list.SetTerraform(resources)
 	}
 }
 
+// SetDemo makes future clients use in-memory sample data instead of AWS
+func (m *Model) SetDemo(on bool) {
+	m.demo = on
+}
+
+// SetMouse records whether the program starts with mouse capture, which
+// ctrl+x toggles
+func (m *Model) SetMouse(on bool) {
+	m.mouseOff = !on
+}
+
+// SetDryRun enables or disables dry-run mode for all current and future clients
+func (m *Model) SetDryRun(on bool) {
+	m.dryRun = on
+	for _, c := range m.awsClients {
+		c.SetDryRun(on)
+	}
+	m.parameterList.SetDryRun(on)
+	for i := range m.tabs {
+		if m.tabs[i].client != nil {
+			m.tabs[i].client.SetDryRun(on)
+		}
+		m.tabs[i].list.SetDryRun(on)
+	}
+}
+
+// applyTimestampFormat pushes the timestamp format to every screen showing times
+func (m *Model) applyTimestampFormat() {
+	m.parameterList.SetTimestampFormat(m.timestamps)
+	m.parameterView.SetTimestampFormat(m.timestamps)
+	for i := range m.tabs {
+		m.tabs[i].list.SetTimestampFormat(m.timestamps)
+	}
+}
+
+// OpenLastContext makes the program start in the most recent profile/region,
+// skipping both selectors. It reports false when there is no recent context.
+func (m *Model) OpenLastContext() bool {
+	if len(m.recents) == 0 {
+		return false
+	}
+	last := m.recents[0]
+	m.startContext = &last
+	return true
+}
+
 // Init initializes the root model
 func (m Model) Init() tea.Cmd {
-	return m.profileSelector.Init()
+	if m.startContext != nil {
+		ctx := *m.startContext
+		return tea.Batch(activityTick(m.settings.ReduceMotion), func() tea.Msg {
+			return types.ProfileSelectedMsg{Profile: ctx.Profile, Region: ctx.Region}
+		})
+	}
+	if len(m.profiles) == 1 && !m.settings.AlwaysShowProfiles {
+		// Nothing to choose between, go straight to region selection
+		profile := m.profiles[0]
+		return tea.Batch(activityTick(m.settings.ReduceMotion), func() tea.Msg { return types.ProfileSelectedMsg{Profile: profile} })
+	}
+	return tea.Batch(m.profileSelector.Init(), activityTick(m.settings.ReduceMotion))
 }
 
-// Update handles messages for the root model
+// Update handles messages for the root model, keeps the terminal title in
+// step with the context and counts messages for --pprof
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	countMessage(msg)
+	updated, cmd := m.update(msg)
+	next, ok := updated.(Model)
+	if !ok {
+		return updated, cmd
+	}
+	if title := next.windowTitle(); title != next.title {
+		next.title = title
+		cmd = tea.Batch(cmd, tea.SetWindowTitle(title))
+	}
+	return next, cmd
+}
+
+// update handles messages for the root model
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
 		screen := screenName(m.currentScreen)
 		debugLog("[Model.Update] Received KeyMsg(%s), currentScreen=%s", keyMsg.String(), screen)
 	}
 
 	if keyMsg, ok := msg.(tea.KeyMsg); ok && (keyMsg.String() == "esc" || keyMsg.String() == "alt+esc") {
-		// Let ParameterList handle ESC to cancel search
-		if m.currentScreen == ParameterListScreen && m.parameterList.SearchActive {
+		// Let ParameterList handle ESC to cancel search or close a value peek
+		if m.currentScreen == ParameterListScreen && m.parameterList.Capturing() {
 			var cmd tea.Cmd
 			m.parameterList, cmd = m.parameterList.Update(msg)
 			return m, cmd
 		}
+		// Let the selectors cancel a typed jump
+		if m.currentScreen == RegionSelectorScreen && m.regionSelector.Jumping() {
+			var cmd tea.Cmd
+			m.regionSelector, cmd = m.regionSelector.Update(msg)
+			return m, cmd
+		}
+		if m.currentScreen == ProfileSelectorScreen && m.profileSelector.Jumping() {
+			var cmd tea.Cmd
+			m.profileSelector, cmd = m.profileSelector.Update(msg)
+			return m, cmd
+		}
+		// Let ParameterView handle ESC to cancel an open prompt or return from a followed reference
+		if m.currentScreen == ParameterViewScreen && (m.parameterView.PromptActive || m.parameterView.Nested()) {
+			var cmd tea.Cmd
+			m.parameterView, cmd = m.parameterView.Update(msg)
+			return m, cmd
+		}
+		// Let the tag editor handle ESC to cancel the tag being typed
+		if m.currentScreen == TagsScreen && m.tags.Editing() {
+			var cmd tea.Cmd
+			m.tags, cmd = m.tags.Update(msg)
+			return m, cmd
+		}
+		if m.currentScreen == ParameterCreateScreen && m.parameterCreate.TagEditing() {
+			var cmd tea.Cmd
+			m.parameterCreate, cmd = m.parameterCreate.Update(msg)
+			return m, cmd
+		}
+		// Let the context comparison close its prompt
+		if m.currentScreen == ContextCompareScreen && m.contextCompare.Prompting() {
+			var cmd tea.Cmd
+			m.contextCompare, cmd = m.contextCompare.Update(msg)
+			return m, cmd
+		}
+		// Let the tree close its path prompt
+		if m.currentScreen == TreeScreen && m.tree.Prompting() {
+			var cmd tea.Cmd
+			m.tree, cmd = m.tree.Update(msg)
+			return m, cmd
+		}
+		// Let the reference graph step back along followed references
+		if m.currentScreen == ReferencesScreen && m.references.Nested() {
+			var cmd tea.Cmd
+			m.references, cmd = m.references.Update(msg)
+			return m, cmd
+		}
+		// Let the edit screen leave the merge of a save conflict
+		if m.currentScreen == ParameterEditScreen && m.parameterEdit.Merging() {
+			var cmd tea.Cmd
+			m.parameterEdit, cmd = m.parameterEdit.Update(msg)
+			return m, cmd
+		}
+		// Keep the move screen open while it writes
+		if m.currentScreen == MoveScreen && m.move.Busy() {
+			return m, nil
+		}
+		if m.currentScreen == DeleteSubtreeScreen && m.deleteSubtree.Busy() {
+			return m, nil
+		}
+		// Let the report cancel its delete confirmation
+		if m.currentScreen == ReportScreen && m.report.Prompting() {
+			var cmd tea.Cmd
+			m.report, cmd = m.report.Update(msg)
+			return m, cmd
+		}
+		// Let the AppConfig browser go up a level or cancel editing
+		if m.currentScreen == AppConfigScreen && m.appConfig.Nested() {
+			var cmd tea.Cmd
+			m.appConfig, cmd = m.appConfig.Update(msg)
+			return m, cmd
+		}
 
 		m = m.goBack()
 		return m, nil
@@ -141,27 +487,47 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.resize()
 
-		// Propagate size to all screens
-		m.profileSelector.SetSize(msg.Width, msg.Height)
-		m.regionSelector.SetSize(msg.Width, msg.Height)
-		m.parameterList.SetSize(msg.Width, msg.Height)
-		m.parameterView.SetSize(msg.Width, msg.Height)
-		m.parameterEdit.SetSize(msg.Width, msg.Height)
-		m.jsonAdd.SetSize(msg.Width, msg.Height)
+	case activityTickMsg:
+		return m, nextActivityRefresh(m.settings.ReduceMotion)
 
 	case types.ProfileSelectedMsg:
+		m.switchingRegion = false
 		m.currentProfile = msg.Profile
 		m.currentScreen = RegionSelectorScreen
-		// Set default region for this profile if it exists
-		if lastRegion, ok := m.regionMapping.ProfileRegions[msg.Profile]; ok {
+		// Preselect the last used region, then the profile's region from the
+		// shared AWS config, then the configured default
+		lastRegion, remembered := m.regionMapping.ProfileRegions[msg.Profile]
+		if remembered {
 			m.regionSelector.SetDefaultRegion(lastRegion)
+		} else if region, _ := config.GetProfileRegion(msg.Profile); region != "" {
+			m.regionSelector.SetDefaultRegion(region)
+		} else {
+			m.regionSelector.SetDefaultRegion(m.settings.DefaultRegion)
+		}
+
+		// Open the context directly when the region is already known
+		region := msg.Region
+		if region == "" && remembered && m.settings.SkipRegionSelector {
+			region = lastRegion
+		}
+		if region != "" {
+			return m.update(types.RegionSelectedMsg{Region: region})
 		}
 		return m, nil
 
 	case types.RegionSelectedMsg:
+		m.switchingRegion = false
+		if m.openingTab {
+			m.openingTab = false
+			m.openTab()
+		} else if len(m.tabs) == 0 {
+			m.tabs = []contextTab{{}}
+		}
 		m.currentRegion = msg.Region
 		m.currentScreen = ParameterListScreen
+		m.recordJump(nil)
 
 		// Save the region selection for this profile
 		m.regionMapping.ProfileRegions[m.currentProfile] = msg.Region
@@ -170,7 +536,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Create/update client with selected region
-		client, err := aws.NewClientWithRegion(context.Background(), m.currentProfile, msg.Region)
+		client, err := m.newClient(m.currentProfile, msg.Region)
 		if err != nil {
 			// TODO: Show error in UI
 			return m, nil
@@ -183,6 +549,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, m.parameterList.LoadParameters(client)
 
 	case types.ParametersLoadedMsg:
+		// Results for a context open in a background tab go to that tab
+		if msg.Profile != m.currentProfile || msg.Region != m.currentRegion {
+			if m.routeToTab(msg.Profile, msg.Region, msg) {
+				return m, nil
+			}
+		}
 		// Only add to recents if we found parameters (don't add empty results)
 		// and we're not switching to an existing recent entry (keep list stable)
 		if len(msg.Parameters) > 0 && !m.switchingToRecent {
@@ -193,11 +565,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// Reset the flag after use
 		m.switchingToRecent = false
-		// Let the parameter list screen handle the actual parameter loading
-		return m.updateCurrentScreen(msg)
+		// Let the parameter list screen handle the actual parameter loading,
+		// also when it reloads behind another screen after a change
+		var cmd tea.Cmd
+		m.parameterList, cmd = m.parameterList.Update(msg)
+		if m.currentScreen == TreeScreen {
+			m.tree.Load(m.parameterList.Parameters())
+		}
+		return m, cmd
 
 	case types.ViewParameterMsg:
+		if m.currentScreen == TreeScreen || m.currentScreen == ParameterListScreen || m.currentScreen == AppConfigScreen || m.currentScreen == ReportScreen || m.currentScreen == DriftScreen {
+			m.viewReturn = m.currentScreen
+		}
 		m.currentScreen = ParameterViewScreen
+		m.recordJump(msg.Parameter)
 		client := m.awsClients[m.currentProfile]
 		// Pass profile/region context to parameter view
 		m.parameterView.SetContext(m.currentProfile, m.currentRegion)
@@ -208,6 +590,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		client := m.awsClients[m.currentProfile]
 		// Pass profile/region context to parameter edit
 		m.parameterEdit.SetContext(m.currentProfile, m.currentRegion)
+		if msg.ListItem > 0 {
+			return m, m.parameterEdit.LoadListItem(msg.Parameter, client, msg.ListItem)
+		}
 		return m, m.parameterEdit.LoadParameter(msg.Parameter, client, msg.JSONKey)
 
 	case types.AddJSONKeyMsg:
@@ -217,17 +602,241 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.jsonAdd.SetContext(m.currentProfile, m.currentRegion)
 		return m, m.jsonAdd.LoadParameter(msg.Parameter, client)
 
+	case types.ViewHistoryMsg:
+		m.currentScreen = HistoryScreen
+		m.recordJump(msg.Parameter)
+		m.history.SetContext(m.currentProfile, m.currentRegion)
+		return m, m.history.LoadHistory(msg.Parameter, m.awsClients[m.currentProfile])
+
+	case types.ViewTagsMsg:
+		m.currentScreen = TagsScreen
+		m.tags.SetContext(m.currentProfile, m.currentRegion)
+		return m, m.tags.Load(m.awsClients[m.currentProfile], msg.Parameter)
+
+	case types.ActionPerformedMsg:
+		action := msg.Action
+		m.lastAction = &action
+		return m, nil
+
+	case types.RepeatActionMsg:
+		if m.lastAction == nil {
+			return m.updateCurrentScreen(types.ActionRepeatedMsg{Err: errors.New("no action to repeat yet")})
+		}
+		client := m.awsClients[m.currentProfile]
+		if client == nil || msg.Parameter == nil {
+			return m, nil
+		}
+		return m, screens.RepeatAction(client, m.currentRegion, *m.lastAction, msg.Parameter)
+
+	case types.ShowStatsMsg:
+		m.currentScreen = StatsScreen
+		m.stats.SetContext(m.currentProfile, m.currentRegion)
+		m.stats.Load(m.parameterList.Parameters())
+		return m, nil
+
+	case types.ShowDriftMsg:
+		m.currentScreen = DriftScreen
+		m.drift.SetContext(m.currentProfile, m.currentRegion)
+		dir := ""
+		if mirror, ok := m.mirror(); ok {
+			dir = mirror.Root(m.currentProfile, m.currentRegion)
+		}
+		return m, m.drift.Open(m.awsClients[m.currentProfile], m.parameterList.Parameters(), dir)
+
+	case types.ShowReportMsg:
+		m.reportReturn = m.currentScreen
+		m.currentScreen = ReportScreen
+		m.report.SetContext(m.currentProfile, m.currentRegion)
+		return m, m.report.Open(m.awsClients[m.currentProfile], msg.Kind, msg.Parameters, msg.Scope)
+
+	case types.ParametersDeletedMsg:
+		// A dry run deleted nothing; preview the request instead of
+		// reporting it as a partial deletion
+		var dryRunErr *aws.DryRunError
+		if errors.As(msg.Err, &dryRunErr) {
+			return m.Update(types.ErrorMsg{Err: msg.Err})
+		}
+		// Drop the deleted rows and reload the list behind the report or
+		// subtree delete
+		var cmd tea.Cmd
+		if m.currentScreen == DeleteSubtreeScreen {
+			m.deleteSubtree, cmd = m.deleteSubtree.Update(msg)
+		} else {
+			m.report, cmd = m.report.Update(msg)
+		}
+		if len(msg.Names) == 0 {
+			return m, cmd
+		}
+		cmds := []tea.Cmd{cmd, m.parameterList.LoadParameters(m.awsClients[m.currentProfile])}
+		if mirror, ok := m.mirror(); ok {
+			cmds = append(cmds, screens.MirrorDeletes(mirror, m.currentProfile, m.currentRegion, msg.Names))
+		}
+		return m, tea.Batch(cmds...)
+
+	case types.MoveSubtreeMsg:
+		m.moveReturn = TreeScreen
+		m.currentScreen = MoveScreen
+		m.move.SetContext(m.currentProfile, m.currentRegion)
+		return m, m.move.Open(m.awsClients[m.currentProfile], msg.Prefix, msg.Parameters)
+
+	case types.CopySubtreeMsg:
+		m.moveReturn = TreeScreen
+		m.currentScreen = MoveScreen
+		m.move.SetContext(m.currentProfile, m.currentRegion)
+		m.move.SetPool(m.knownContexts())
+		return m, m.move.OpenCopy(m.awsClients[m.currentProfile], msg.Prefix, msg.Parameters, m.newClient)
+
+	case types.CompareContextsMsg:
+		m.currentScreen = ContextCompareScreen
+		m.contextCompare.SetContext(m.currentProfile, m.currentRegion)
+		m.contextCompare.SetPool(m.knownContexts())
+		return m, m.contextCompare.Open(m.awsClients[m.currentProfile], msg.Parameter.Name, m.newClient)
+
+	case types.CopyParameterMsg:
+		m.moveReturn = ParameterViewScreen
+		m.currentScreen = MoveScreen
+		m.move.SetContext(m.currentProfile, m.currentRegion)
+		m.move.SetPool(m.knownContexts())
+		return m, m.move.OpenCopyParameter(m.awsClients[m.currentProfile], msg.Parameter, m.newClient)
+
+	case types.BulkActionMsg:
+		client := m.awsClients[m.currentProfile]
+		switch msg.Action {
+		case types.BulkDelete:
+			m.deleteReturn = ParameterListScreen
+			m.currentScreen = DeleteSubtreeScreen
+			m.deleteSubtree.SetContext(m.currentProfile, m.currentRegion)
+			return m, m.deleteSubtree.OpenMarked(client, msg.Parameters)
+		case types.BulkCopy:
+			m.moveReturn = ParameterListScreen
+			m.currentScreen = MoveScreen
+			m.move.SetContext(m.currentProfile, m.currentRegion)
+			m.move.SetPool(m.knownContexts())
+			return m, m.move.OpenCopyMarked(client, msg.Parameters, m.newClient)
+		case types.BulkTag:
+			return m, screens.TagParameters(client, msg.Parameters, msg.Tag)
+		case types.BulkExport:
+			params := msg.Parameters
+			return m, func() tea.Msg { return types.ExportParametersMsg{Parameters: params} }
+		}
+		return m, nil
+
+	case types.DeleteSubtreeMsg:
+		m.deleteReturn = TreeScreen
+		m.currentScreen = DeleteSubtreeScreen
+		m.deleteSubtree.SetContext(m.currentProfile, m.currentRegion)
+		return m, m.deleteSubtree.Open(m.awsClients[m.currentProfile], msg.Prefix, msg.Parameters)
+
+	case types.SubtreeMovedMsg:
+		// A dry run wrote nothing; preview its first request instead
+		var dryRunErr *aws.DryRunError
+		if errors.As(msg.Err, &dryRunErr) {
+			return m.Update(types.ErrorMsg{Err: msg.Err})
+		}
+		// Show the result and reload the list behind it
+		var cmd tea.Cmd
+		m.move, cmd = m.move.Update(msg)
+		if len(msg.Created) == 0 && len(msg.Deleted) == 0 {
+			return m, cmd
+		}
+		return m, tea.Batch(cmd, m.parameterList.LoadParameters(m.awsClients[m.currentProfile]))
+
+	case types.MirrorSubtreeMsg:
+		mirror, ok := m.mirror()
+		if !ok {
+			return m.updateCurrentScreen(types.MirroredMsg{Err: errors.New("set mirror_dir in config.json to mirror parameters into git")})
+		}
+		client := m.awsClients[m.currentProfile]
+		return m, screens.MirrorSubtree(client, mirror, m.currentProfile, m.currentRegion, msg.Prefix, msg.Parameters)
+
+	case types.MirroredMsg:
+		return m.updateCurrentScreen(msg)
+
+	case types.ViewReferencesMsg:
+		m.currentScreen = ReferencesScreen
+		m.references.SetContext(m.currentProfile, m.currentRegion)
+		return m, m.references.Load(m.awsClients[m.currentProfile], m.parameterList.Parameters(), msg.Parameter.Name)
+
+	case types.NotifyChangesMsg:
+		m.notifyReturn = m.currentScreen
+		m.currentScreen = NotifyScreen
+		m.notify.SetContext(m.currentProfile, m.currentRegion)
+		return m, m.notify.Load(m.awsClients[m.currentProfile], msg.Name, msg.Prefix)
+
+	case types.ShowIAMPolicyMsg:
+		// Default to the mode and scope the current client works in
+		write, prefix := true, ""
+		if client := m.awsClients[m.currentProfile]; client != nil {
+			write, prefix = !client.ReadOnly(), client.PathPrefix()
+		}
+		m.currentScreen = IAMPolicyScreen
+		m.iamPolicy.SetContext(m.currentProfile, m.currentRegion)
+		m.iamPolicy.Open(write, prefix)
+		return m, nil
+
+	case types.ShowAppConfigMsg:
+		m.currentScreen = AppConfigScreen
+		m.appConfig.SetContext(m.currentProfile, m.currentRegion)
+		return m, m.appConfig.Open(m.awsClients[m.currentProfile])
+
+	case types.RestoreVersionMsg:
+		// Written as a save, so the view shows the restored value afterwards
+		return m, screens.RestoreVersion(m.awsClients[m.currentProfile], msg.Version)
+
+	case types.CompareVersionsMsg:
+		m.compareReturn = m.currentScreen
+		m.currentScreen = VersionCompareScreen
+		m.versionCompare.SetContext(m.currentProfile, m.currentRegion)
+		m.versionCompare.Load(msg.Older, msg.Newer)
+		return m, nil
+
+	case types.ShowTreeMsg:
+		m.currentScreen = TreeScreen
+		m.tree.SetContext(m.currentProfile, m.currentRegion)
+		m.tree.SetClient(m.awsClients[m.currentProfile])
+		m.tree.Load(m.parameterList.Parameters())
+		return m, nil
+
+	case types.CreateParameterMsg:
+		m.createReturn = m.currentScreen
+		m.currentScreen = ParameterCreateScreen
+		m.parameterCreate.SetContext(m.currentProfile, m.currentRegion)
+		cmd := m.parameterCreate.Reset(m.awsClients[m.currentProfile], m.parameterList.Parameters(), msg.Prefix)
+		if msg.Template != nil {
+			cmd = tea.Batch(cmd, m.parameterCreate.UseTemplate(msg.Template))
+		}
+		return m, cmd
+
+	case types.ParameterCreatedMsg:
+		// Show the new parameter and refresh the list behind it
+		client := m.awsClients[m.currentProfile]
+		m.viewReturn = m.createReturn
+		m.currentScreen = ParameterViewScreen
+		m.recordJump(msg.Parameter)
+		m.parameterView.SetContext(m.currentProfile, m.currentRegion)
+		return m, tea.Batch(
+			m.parameterView.LoadParameter(msg.Parameter, client),
+			m.parameterList.LoadParameters(client),
+			m.mirrorChange(msg.Parameter.Name),
+		)
+
 	case types.SaveSuccessMsg:
 		// Parameter saved successfully, update the view and go back
 		// Ensure view has current profile/region
 		m.parameterView.SetContext(m.currentProfile, m.currentRegion)
 		// Load the updated parameter and return the command so Bubble Tea executes it
 		cmd := m.parameterView.LoadParameter(msg.Parameter, m.awsClients[m.currentProfile])
+		m.parameterView.MarkSaved()
 		m.currentScreen = ParameterViewScreen
-		return m, cmd
+		return m, tea.Batch(cmd, m.mirrorChange(msg.Parameter.Name))
 
 	case types.SwitchRecentMsg:
-		// User selected a recent profile+region entry from the list
+		// User selected a recent profile+region entry from the list; it
+		// replaces the active tab's context rather than opening a new tab
+		m.openingTab = false
+		if len(m.tabs) == 0 {
+			m.tabs = []contextTab{{}}
+		}
 		m.currentProfile = msg.Profile
 		m.currentRegion = msg.Region
 
@@ -236,7 +845,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		_ = config.SaveRegionMapping(m.regionMapping)
 
 		// Create/update client
-		client, err := aws.NewClientWithRegion(context.Background(), m.currentProfile, m.currentRegion)
+		client, err := m.newClient(m.currentProfile, m.currentRegion)
 		if err != nil {
 			// TODO: show error
 			return m, nil
@@ -249,8 +858,94 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		m.parameterList.SetContext(m.currentProfile, m.currentRegion)
 		m.currentScreen = ParameterListScreen
+		m.recordJump(nil)
 		return m, m.parameterList.LoadParameters(client)
 
+	case types.ErrorMsg:
+		// Let the active screen reset its saving state first
+		result, cmd := m.updateCurrentScreen(msg)
+		m = result.(Model)
+
+		var dryRunErr *aws.DryRunError
+		if errors.As(msg.Err, &dryRunErr) {
+			var previous *aws.Parameter
+			if p := m.parameterView.Parameter(); p != nil && p.Name == dryRunErr.Request.Name {
+				previous = p
+			}
+			m.dryRunPreview.SetContext(m.currentProfile, m.currentRegion)
+			m.dryRunPreview.Load(dryRunErr.Request, previous)
+			m.dryRunReturn = m.currentScreen
+			m.currentScreen = DryRunScreen
+		}
+		return m, cmd
+
+	case types.ToggleDryRunMsg:
+		m.SetDryRun(!m.dryRun)
+		return m, nil
+
+	case types.ToggleListModeMsg:
+		if m.settings.ListMode == config.ListModeDetailed {
+			m.settings.ListMode = config.ListModeCompact
+		} else {
+			m.settings.ListMode = config.ListModeDetailed
+		}
+		m.ApplySettings(m.settings)
+		// Persist mode without environment overrides (non-fatal)
+		mode := m.settings.ListMode
+		_ = config.UpdateSettings(func(s *config.Settings) { s.ListMode = mode })
+		return m, nil
+
+	case types.SubshellMsg:
+		client := m.awsClients[m.currentProfile]
+		if client == nil {
+			return m, nil
+		}
+		return m, screens.OpenSubshell(client, m.currentProfile, m.currentRegion, msg.Parameters)
+
+	case types.SubshellExitedMsg:
+		// Reported on the list, also when the shell was opened from the tree
+		var cmd tea.Cmd
+		m.parameterList, cmd = m.parameterList.Update(msg)
+		return m, cmd
+
+	case types.ExportParametersMsg:
+		m.exportReturn = m.currentScreen
+		m.exporter.SetContext(m.currentProfile, m.currentRegion)
+		m.currentScreen = ExportScreen
+		if msg.Format != "" {
+			m.exporter.SetFormat(export.Format(msg.Format))
+		}
+		return m, m.exporter.Load(m.awsClients[m.currentProfile], msg.Parameters)
+
+	case types.ToggleValuePreviewMsg:
+		m.settings.ShowValues = !m.settings.ShowValues
+		m.ApplySettings(m.settings)
+		// Persist the column without environment overrides (non-fatal)
+		show := m.settings.ShowValues
+		_ = config.UpdateSettings(func(s *config.Settings) { s.ShowValues = show })
+		return m, m.parameterList.LoadVisibleValues()
+
+	case types.ValuesLoadedMsg:
+		// Values arrive for the list even while another screen is shown
+		if msg.Profile == m.currentProfile && msg.Region == m.currentRegion {
+			var cmd tea.Cmd
+			m.parameterList, cmd = m.parameterList.Update(msg)
+			return m, cmd
+		}
+		m.routeToTab(msg.Profile, msg.Region, msg)
+		return m, nil
+
+	case types.ToggleTimestampsMsg:
+		m.timestamps.Absolute = !m.timestamps.Absolute
+		m.applyTimestampFormat()
+		return m, nil
+
+	case types.SwitchRegionMsg:
+		m.switchingRegion = true
+		m.regionSelector.SetDefaultRegion(m.currentRegion)
+		m.currentScreen = RegionSelectorScreen
+		return m, nil
+
 	case types.GoToProfileSelectionMsg:
 		// Jump directly to profile selection screen
 		m.currentScreen = ProfileSelectorScreen
@@ -265,6 +960,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.String() == "ctrl+c" {
 			return m, tea.Quit
 		}
+		if m.currentScreen == ParameterListScreen && !m.parameterList.Capturing() && m.handleTabKey(msg) {
+			return m, nil
+		}
+		if msg.String() == "ctrl+l" {
+			// Toggle the log of recent AWS calls
+			m.showAPILog = !m.showAPILog
+			m.resize()
+			return m, nil
+		}
+		if msg.String() == "ctrl+x" {
+			// Release the mouse so the terminal can select text, or take it back
+			m.mouseOff = !m.mouseOff
+			if m.mouseOff {
+				return m, tea.DisableMouse
+			}
+			return m, tea.EnableMouseCellMotion
+		}
+		if msg.String() == "ctrl+p" && m.switcherAllowed() {
+			m.switcherReturn = m.currentScreen
+			m.currentScreen = ContextSwitcherScreen
+			return m, m.contextSwitcher.Open(m.profiles, m.recents)
+		}
+		// Jump list: ctrl+i arrives as tab in most terminals
+		if m.jumpsAllowed() {
+			switch msg.String() {
+			case "ctrl+o":
+				return m.jump(-1)
+			case "ctrl+i", "tab":
+				return m.jump(1)
+			}
+		}
 	}
 
 	// Route to active screen
@@ -274,27 +1000,115 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return result, cmd
 }
 
+// switcherAllowed reports whether ctrl+p may open the context switcher; like the
+// jump keys it is left to screens with text input
+func (m Model) switcherAllowed() bool {
+	switch m.currentScreen {
+	case ProfileSelectorScreen, RegionSelectorScreen:
+		return true
+	}
+	return m.jumpsAllowed()
+}
+
 func (m Model) goBack() Model {
 	oldScreen := screenName(m.currentScreen)
 	debugLog("[Model.Update] Back navigation from %s", oldScreen)
 
 	switch m.currentScreen {
 	case RegionSelectorScreen:
+		if m.switchingRegion {
+			m.switchingRegion = false
+			m.currentScreen = ParameterListScreen
+			debugLog("[Model.Update] RegionSelector -> ParameterList (region switch cancelled)")
+			break
+		}
 		m.currentScreen = ProfileSelectorScreen
 		debugLog("[Model.Update] RegionSelector -> ProfileSelector")
 	case ParameterListScreen:
 		m.currentScreen = RegionSelectorScreen
 		debugLog("[Model.Update] ParameterList -> RegionSelector")
 	case ParameterViewScreen:
-		m.currentScreen = ParameterListScreen
-		debugLog("[Model.Update] ParameterView -> ParameterList")
+		m.currentScreen = m.viewReturn
+		debugLog("[Model.Update] ParameterView -> %s", screenName(m.viewReturn))
 	case ParameterEditScreen:
 		m.currentScreen = ParameterViewScreen
 		debugLog("[Model.Update] ParameterEdit -> ParameterView")
 	case JSONAddScreen:
 		m.currentScreen = ParameterViewScreen
 		debugLog("[Model.Update] JSONAdd -> ParameterView")
+	case ContextCompareScreen:
+		m.currentScreen = ParameterViewScreen
+		debugLog("[Model.Update] ContextCompare -> ParameterView")
+	case DryRunScreen:
+		m.currentScreen = m.dryRunReturn
+		debugLog("[Model.Update] DryRun -> %s", screenName(m.dryRunReturn))
+	case HistoryScreen:
+		m.currentScreen = ParameterViewScreen
+		debugLog("[Model.Update] History -> ParameterView")
+	case VersionCompareScreen:
+		m.currentScreen = m.compareReturn
+		debugLog("[Model.Update] VersionCompare -> %s", screenName(m.compareReturn))
+	case ParameterCreateScreen:
+		m.currentScreen = m.createReturn
+		debugLog("[Model.Update] ParameterCreate -> %s", screenName(m.createReturn))
+	case TreeScreen:
+		m.currentScreen = ParameterListScreen
+		debugLog("[Model.Update] Tree -> ParameterList")
+	case ContextSwitcherScreen:
+		m.currentScreen = m.switcherReturn
+		debugLog("[Model.Update] ContextSwitcher -> %s", screenName(m.switcherReturn))
+	case ExportScreen:
+		m.currentScreen = m.exportReturn
+		debugLog("[Model.Update] Export -> %s", screenName(m.exportReturn))
+	case TagsScreen:
+		m.currentScreen = ParameterViewScreen
+		debugLog("[Model.Update] Tags -> ParameterView")
+	case AppConfigScreen:
+		m.currentScreen = ParameterListScreen
+		debugLog("[Model.Update] AppConfig -> ParameterList")
+	case NotifyScreen:
+		m.currentScreen = m.notifyReturn
+		debugLog("[Model.Update] Notify -> %s", screenName(m.notifyReturn))
+	case IAMPolicyScreen:
+		m.currentScreen = ParameterListScreen
+		debugLog("[Model.Update] IAMPolicy -> ParameterList")
+	case ReferencesScreen:
+		m.currentScreen = ParameterViewScreen
+		debugLog("[Model.Update] References -> ParameterView")
+	case ReportScreen:
+		m.currentScreen = m.reportReturn
+		debugLog("[Model.Update] Report -> %s", screenName(m.reportReturn))
+	case StatsScreen:
+		m.currentScreen = ParameterListScreen
+		debugLog("[Model.Update] Stats -> ParameterList")
+	case DriftScreen:
+		m.currentScreen = ParameterListScreen
+		debugLog("[Model.Update] Drift -> ParameterList")
+	case MoveScreen:
+		if m.moveReturn != TreeScreen {
+			m.currentScreen = m.moveReturn
+			debugLog("[Model.Update] Move -> %s", screenName(m.moveReturn))
+			break
+		}
+		// The move may have changed the subtree
+		m.currentScreen = TreeScreen
+		m.tree.Load(m.parameterList.Parameters())
+		debugLog("[Model.Update] Move -> Tree")
+	case DeleteSubtreeScreen:
+		if m.deleteReturn == ParameterListScreen {
+			m.currentScreen = ParameterListScreen
+			debugLog("[Model.Update] DeleteSubtree -> ParameterList")
+			break
+		}
+		m.currentScreen = TreeScreen
+		m.tree.Load(m.parameterList.Parameters())
+		debugLog("[Model.Update] DeleteSubtree -> Tree")
 	case ProfileSelectorScreen:
+		if m.openingTab {
+			m.cancelOpeningTab()
+			debugLog("[Model.Update] ProfileSelector -> ParameterList (new tab canceled)")
+			break
+		}
 		debugLog("[Model.Update] Already at ProfileSelector, no transition")
 	}
 
@@ -307,7 +1121,7 @@ func (m Model) goBack() Model {
 func (m Model) updateCurrentScreen(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	screen := screenName(m.currentScreen)
-	
+
 	// Log all messages
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
 		debugLog("[updateCurrentScreen] Routing KeyMsg(%s) to %s", keyMsg.String(), screen)
@@ -335,19 +1149,127 @@ func (m Model) updateCurrentScreen(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case JSONAddScreen:
 		m.jsonAdd, cmd = m.jsonAdd.Update(msg)
 		debugLog("[updateCurrentScreen] JSONAdd processed, cmd=%v", cmd != nil)
+	case DryRunScreen:
+		m.dryRunPreview, cmd = m.dryRunPreview.Update(msg)
+		debugLog("[updateCurrentScreen] DryRun processed, cmd=%v", cmd != nil)
+	case HistoryScreen:
+		m.history, cmd = m.history.Update(msg)
+		debugLog("[updateCurrentScreen] History processed, cmd=%v", cmd != nil)
+	case VersionCompareScreen:
+		m.versionCompare, cmd = m.versionCompare.Update(msg)
+	case ContextCompareScreen:
+		m.contextCompare, cmd = m.contextCompare.Update(msg)
+		debugLog("[updateCurrentScreen] VersionCompare processed, cmd=%v", cmd != nil)
+	case ParameterCreateScreen:
+		m.parameterCreate, cmd = m.parameterCreate.Update(msg)
+		debugLog("[updateCurrentScreen] ParameterCreate processed, cmd=%v", cmd != nil)
+	case TreeScreen:
+		m.tree, cmd = m.tree.Update(msg)
+		debugLog("[updateCurrentScreen] Tree processed, cmd=%v", cmd != nil)
+	case ContextSwitcherScreen:
+		m.contextSwitcher, cmd = m.contextSwitcher.Update(msg)
+		debugLog("[updateCurrentScreen] ContextSwitcher processed, cmd=%v", cmd != nil)
+	case ExportScreen:
+		m.exporter, cmd = m.exporter.Update(msg)
+		debugLog("[updateCurrentScreen] Export processed, cmd=%v", cmd != nil)
+	case TagsScreen:
+		m.tags, cmd = m.tags.Update(msg)
+		debugLog("[updateCurrentScreen] Tags processed, cmd=%v", cmd != nil)
+	case AppConfigScreen:
+		m.appConfig, cmd = m.appConfig.Update(msg)
+		debugLog("[updateCurrentScreen] AppConfig processed, cmd=%v", cmd != nil)
+	case NotifyScreen:
+		m.notify, cmd = m.notify.Update(msg)
+		debugLog("[updateCurrentScreen] Notify processed, cmd=%v", cmd != nil)
+	case IAMPolicyScreen:
+		m.iamPolicy, cmd = m.iamPolicy.Update(msg)
+		debugLog("[updateCurrentScreen] IAMPolicy processed, cmd=%v", cmd != nil)
+	case ReferencesScreen:
+		m.references, cmd = m.references.Update(msg)
+		debugLog("[updateCurrentScreen] References processed, cmd=%v", cmd != nil)
+	case ReportScreen:
+		m.report, cmd = m.report.Update(msg)
+		debugLog("[updateCurrentScreen] Report processed, cmd=%v", cmd != nil)
+	case DriftScreen:
+		m.drift, cmd = m.drift.Update(msg)
+	case MoveScreen:
+		m.move, cmd = m.move.Update(msg)
+	case DeleteSubtreeScreen:
+		m.deleteSubtree, cmd = m.deleteSubtree.Update(msg)
+	case StatsScreen:
+		m.stats, cmd = m.stats.Update(msg)
+		debugLog("[updateCurrentScreen] Stats processed, cmd=%v", cmd != nil)
 	}
 
 	return m, cmd
 }
 
+// resize propagates the window size to all screens, keeping room for the API
+// indicator and log
+func (m *Model) resize() {
+	w, h := m.width, m.screenHeight()
+	m.profileSelector.SetSize(w, h)
+	m.regionSelector.SetSize(w, h)
+	m.resizeTabs()
+	m.parameterView.SetSize(w, h)
+	m.parameterEdit.SetSize(w, h)
+	m.jsonAdd.SetSize(w, h)
+	m.dryRunPreview.SetSize(w, h)
+	m.history.SetSize(w, h)
+	m.versionCompare.SetSize(w, h)
+	m.contextCompare.SetSize(w, h)
+	m.parameterCreate.SetSize(w, h)
+	m.tree.SetSize(w, h)
+	m.contextSwitcher.SetSize(w, h)
+	m.exporter.SetSize(w, h)
+	m.tags.SetSize(w, h)
+	m.appConfig.SetSize(w, h)
+	m.notify.SetSize(w, h)
+	m.iamPolicy.SetSize(w, h)
+	m.references.SetSize(w, h)
+	m.report.SetSize(w, h)
+	m.stats.SetSize(w, h)
+	m.drift.SetSize(w, h)
+	m.move.SetSize(w, h)
+	m.deleteSubtree.SetSize(w, h)
+}
+
+// screenHeight is the height available to screens above the API indicator and log
+func (m Model) screenHeight() int {
+	h := m.height - activityBarHeight
+	if m.showAPILog {
+		h -= apiLogHeight
+	}
+	return h
+}
+
 // View renders the current screen
 func (m Model) View() string {
+	view := m.screenView()
+	indicator := formatActivity(aws.Activity())
+	if chain := m.roleChains[m.currentProfile]; chain != "" && m.currentScreen != ProfileSelectorScreen {
+		indicator = joinStatus(indicator, formatRoleChain(chain))
+	}
+	if m.showAPILog {
+		view += "\n" + renderAPILog(aws.RecentCalls(apiLogHeight-1), m.width)
+	}
+	if indicator != "" {
+		view += "\n" + indicator
+	}
+	return view
+}
+
+// screenView renders the current screen
+func (m Model) screenView() string {
 	switch m.currentScreen {
 	case ProfileSelectorScreen:
 		return m.profileSelector.View()
 	case RegionSelectorScreen:
 		return m.regionSelector.View()
 	case ParameterListScreen:
+		if len(m.tabs) > 1 {
+			return m.renderTabBar() + "\n" + m.parameterList.View()
+		}
 		return m.parameterList.View()
 	case ParameterViewScreen:
 		return m.parameterView.View()
@@ -355,6 +1277,42 @@ func (m Model) View() string {
 		return m.parameterEdit.View()
 	case JSONAddScreen:
 		return m.jsonAdd.View()
+	case DryRunScreen:
+		return m.dryRunPreview.View()
+	case HistoryScreen:
+		return m.history.View()
+	case VersionCompareScreen:
+		return m.versionCompare.View()
+	case ContextCompareScreen:
+		return m.contextCompare.View()
+	case ParameterCreateScreen:
+		return m.parameterCreate.View()
+	case TreeScreen:
+		return m.tree.View()
+	case ContextSwitcherScreen:
+		return m.contextSwitcher.View()
+	case ExportScreen:
+		return m.exporter.View()
+	case TagsScreen:
+		return m.tags.View()
+	case AppConfigScreen:
+		return m.appConfig.View()
+	case NotifyScreen:
+		return m.notify.View()
+	case IAMPolicyScreen:
+		return m.iamPolicy.View()
+	case ReferencesScreen:
+		return m.references.View()
+	case ReportScreen:
+		return m.report.View()
+	case StatsScreen:
+		return m.stats.View()
+	case DriftScreen:
+		return m.drift.View()
+	case MoveScreen:
+		return m.move.View()
+	case DeleteSubtreeScreen:
+		return m.deleteSubtree.View()
 	default:
 		return "Unknown screen"
 	}
@@ -375,11 +1333,105 @@ func screenName(s Screen) string {
 		return "ParameterEdit"
 	case JSONAddScreen:
 		return "JSONAdd"
+	case DryRunScreen:
+		return "DryRun"
+	case HistoryScreen:
+		return "History"
+	case VersionCompareScreen:
+		return "VersionCompare"
+	case ParameterCreateScreen:
+		return "ParameterCreate"
+	case TreeScreen:
+		return "Tree"
+	case ContextSwitcherScreen:
+		return "ContextSwitcher"
+	case ExportScreen:
+		return "Export"
+	case TagsScreen:
+		return "Tags"
+	case AppConfigScreen:
+		return "AppConfig"
+	case NotifyScreen:
+		return "Notify"
+	case IAMPolicyScreen:
+		return "IAMPolicy"
+	case ReferencesScreen:
+		return "References"
+	case ReportScreen:
+		return "Report"
+	case StatsScreen:
+		return "Stats"
+	case DriftScreen:
+		return "Drift"
+	case MoveScreen:
+		return "Move"
+	case DeleteSubtreeScreen:
+		return "DeleteSubtree"
+	case ContextCompareScreen:
+		return "ContextCompare"
 	default:
 		return "Unknown"
 	}
 }
 
+// newClient creates an AWS client for profile/region honouring the current dry-run mode
+func (m Model) newClient(profile, region string) (*aws.Client, error) {
+	var client *aws.Client
+	if m.demo {
+		client = aws.NewDemoClient(profile, region)
+	} else {
+		var err error
+		client, err = aws.NewClientWithSessionDuration(context.Background(), profile, region, m.settings.SessionDuration(profile))
+		if err != nil {
+			return nil, err
+		}
+	}
+	client.SetDryRun(m.dryRun)
+	m.configureClient(client)
+	return client, nil
+}
+
+// knownContexts returns the contexts offered as copy destinations, as
+// "profile region": recent ones first, then each profile in its remembered or
+// configured region
+func (m Model) knownContexts() []string {
+	seen := make(map[string]bool)
+	var contexts []string
+	add := func(profile, region string) {
+		c := profile + " " + region
+		if region != "" && !seen[c] {
+			seen[c] = true
+			contexts = append(contexts, c)
+		}
+	}
+	known := make(map[string]bool, len(m.profiles))
+	for _, p := range m.profiles {
+		known[p] = true
+	}
+	for _, e := range m.recents {
+		if known[e.Profile] {
+			add(e.Profile, e.Region)
+		}
+	}
+	for _, p := range m.profiles {
+		region := m.regionMapping.ProfileRegions[p]
+		if region == "" {
+			region, _ = config.GetProfileRegion(p)
+		}
+		add(p, region)
+	}
+	return contexts
+}
+
+// configureClient applies the client-level settings
+func (m Model) configureClient(c *aws.Client) {
+	c.SetMaxResults(m.settings.MaxResults)
+	c.SetHighThroughput(m.settings.HighThroughput)
+	c.SetReadOnly(m.settings.ReadOnly)
+	c.SetPathPrefix(m.settings.PathPrefix)
+	c.SetSharedARNs(m.settings.SharedParameters)
+}
+
 // copyClientMap returns a shallow copy of the client map with one entry added/replaced.
 func copyClientMap(src map[string]*aws.Client, key string, val *aws.Client) map[string]*aws.Client {
 	dst := make(map[string]*aws.Client, len(src)+1)
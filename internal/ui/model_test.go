@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -23,6 +24,161 @@ func TestEscapeInSearchMode_OnlyCancelsSearch(t *testing.T) {
 	assertEqual(t, RegionSelectorScreen, m.currentScreen, "second esc goes back to region selector")
 }
 
+func TestEscapeInConvertPrompt_OnlyClosesPrompt(t *testing.T) {
+	m := newTestModel([]string{"prod"})
+	m.currentScreen = ParameterViewScreen
+	m.parameterView.PromptActive = true
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assertEqual(t, ParameterViewScreen, m.currentScreen, "esc in prompt stays on view")
+	assertEqual(t, false, m.parameterView.PromptActive, "esc in prompt closes it")
+}
+
+func TestEscapeWhileEditingTag_OnlyCancelsTag(t *testing.T) {
+	m := newTestModel([]string{"prod"})
+	m = updateModel(m, types.ViewTagsMsg{Parameter: &aws.Parameter{Name: "/app/key"}})
+	assertEqual(t, TagsScreen, m.currentScreen, "tags screen")
+	m = updateModel(m, types.TagsLoadedMsg{})
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	assertEqual(t, true, m.tags.Editing(), "a starts a new tag")
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assertEqual(t, TagsScreen, m.currentScreen, "esc while editing stays on tags")
+	assertEqual(t, false, m.tags.Editing(), "esc cancels the tag")
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assertEqual(t, ParameterViewScreen, m.currentScreen, "second esc goes back to view")
+}
+
+func TestDryRunErrorShowsPreviewAndReturns(t *testing.T) {
+	m := newTestModel([]string{"prod"})
+	m.currentScreen = ParameterEditScreen
+
+	err := &aws.DryRunError{Request: aws.WriteRequest{Operation: "PutParameter", Name: "/app/key"}}
+	m = updateModel(m, types.ErrorMsg{Err: err})
+	assertEqual(t, DryRunScreen, m.currentScreen, "dry-run error opens preview")
+
+	m = updateModel(m, types.BackMsg{})
+	assertEqual(t, ParameterEditScreen, m.currentScreen, "back returns to originating screen")
+}
+
+func TestDryRunDeleteShowsPreview(t *testing.T) {
+	m := newTestModel([]string{"prod"})
+	m.currentScreen = DeleteSubtreeScreen
+
+	err := &aws.DryRunError{Request: aws.WriteRequest{Operation: "DeleteParameters", Names: []string{"/app/a", "/app/b"}}}
+	m = updateModel(m, types.ParametersDeletedMsg{Err: err})
+	assertEqual(t, DryRunScreen, m.currentScreen, "dry-run delete opens preview")
+
+	m = updateModel(m, types.BackMsg{})
+	assertEqual(t, DeleteSubtreeScreen, m.currentScreen, "back returns to the delete screen")
+	assertEqual(t, false, m.deleteSubtree.Busy(), "delete screen is no longer deleting")
+}
+
+func TestDryRunMoveShowsPreview(t *testing.T) {
+	m := newTestModel([]string{"prod"})
+	m.currentScreen = MoveScreen
+
+	err := &aws.DryRunError{Request: aws.WriteRequest{Operation: "PutParameter", Name: "/new/a"}}
+	m = updateModel(m, types.SubtreeMovedMsg{Err: err})
+	assertEqual(t, DryRunScreen, m.currentScreen, "dry-run move opens preview")
+
+	m = updateModel(m, types.BackMsg{})
+	assertEqual(t, MoveScreen, m.currentScreen, "back returns to the move screen")
+}
+
+func TestBackNavigationFromVersionCompare(t *testing.T) {
+	m := newTestModel([]string{"prod"})
+	m.currentScreen = HistoryScreen
+
+	older := &aws.Parameter{Name: "/app/key", Version: 1, Value: "a"}
+	newer := &aws.Parameter{Name: "/app/key", Version: 2, Value: "b"}
+	m = updateModel(m, types.CompareVersionsMsg{Older: older, Newer: newer})
+	assertEqual(t, VersionCompareScreen, m.currentScreen, "compare screen")
+
+	m = updateModel(m, types.BackMsg{})
+	assertEqual(t, HistoryScreen, m.currentScreen, "back to history")
+
+	m = updateModel(m, types.BackMsg{})
+	assertEqual(t, ParameterViewScreen, m.currentScreen, "back to view")
+
+	// A diff against the last look is opened from the view itself
+	m = updateModel(m, types.CompareVersionsMsg{Older: older, Newer: newer})
+	m = updateModel(m, types.BackMsg{})
+	assertEqual(t, ParameterViewScreen, m.currentScreen, "back to view from snapshot diff")
+}
+
+func TestNewTabCanBeCanceled(t *testing.T) {
+	m := newTestModel([]string{"prod", "staging"})
+	m = updateModel(m, types.ProfileSelectedMsg{Profile: "prod"})
+	m = updateModel(m, types.RegionSelectedMsg{Region: "us-east-1"})
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'T'}})
+	assertEqual(t, ProfileSelectorScreen, m.currentScreen, "new tab starts at profile selector")
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assertEqual(t, ParameterListScreen, m.currentScreen, "esc returns to the list")
+	assertEqual(t, 1, len(m.tabs), "no tab opened")
+	assertEqual(t, "prod", m.currentProfile, "original context kept")
+
+	// A later region switch must not open the abandoned tab
+	m = updateModel(m, types.RegionSelectedMsg{Region: "eu-west-1"})
+	assertEqual(t, 1, len(m.tabs), "region switch stays in the tab")
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'T'}})
+	m = updateModel(m, types.SwitchRecentMsg{Profile: "staging", Region: "eu-west-1"})
+	m = updateModel(m, types.RegionSelectedMsg{Region: "us-east-1"})
+	assertEqual(t, 1, len(m.tabs), "recent switch clears the pending tab")
+}
+
+func TestTabsKeepSeparateContexts(t *testing.T) {
+	m := newTestModel([]string{"prod", "staging"})
+	m = updateModel(m, types.ProfileSelectedMsg{Profile: "prod"})
+	m = updateModel(m, types.RegionSelectedMsg{Region: "us-east-1"})
+
+	// Open a second tab for staging
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'T'}})
+	assertEqual(t, ProfileSelectorScreen, m.currentScreen, "new tab starts at profile selector")
+	m = updateModel(m, types.ProfileSelectedMsg{Profile: "staging"})
+	m = updateModel(m, types.RegionSelectedMsg{Region: "eu-west-1"})
+	assertEqual(t, 2, len(m.tabs), "two tabs open")
+	assertEqual(t, 1, m.activeTab, "new tab is active")
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyCtrlLeft})
+	assertEqual(t, "prod", m.currentProfile, "first tab profile restored")
+	assertEqual(t, "us-east-1", m.currentRegion, "first tab region restored")
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyCtrlRight})
+	assertEqual(t, "staging", m.currentProfile, "second tab profile restored")
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyCtrlW})
+	assertEqual(t, 1, len(m.tabs), "tab closed")
+	assertEqual(t, "prod", m.currentProfile, "remaining tab is active")
+}
+
+func TestJumpListBackAndForward(t *testing.T) {
+	m := newTestModel([]string{"prod"})
+	m = updateModel(m, types.ProfileSelectedMsg{Profile: "prod"})
+	m = updateModel(m, types.RegionSelectedMsg{Region: "us-east-1"})
+
+	first := &aws.Parameter{Name: "/app/first"}
+	second := &aws.Parameter{Name: "/app/second"}
+	m = updateModel(m, types.ViewParameterMsg{Parameter: first})
+	m = updateModel(m, types.BackMsg{})
+	m = updateModel(m, types.ViewParameterMsg{Parameter: second})
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyCtrlO})
+	assertEqual(t, ParameterViewScreen, m.currentScreen, "ctrl+o returns to previous parameter")
+	assertEqual(t, "/app/first", m.parameterView.Parameter().Name, "previous parameter loaded")
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyCtrlO})
+	assertEqual(t, ParameterListScreen, m.currentScreen, "ctrl+o again returns to the list")
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyTab})
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyTab})
+	assertEqual(t, "/app/second", m.parameterView.Parameter().Name, "ctrl+i moves forward")
+}
+
 // TestHelpers
 
 // newTestModel creates a Model for testing with minimal dependencies
@@ -516,6 +672,66 @@ func TestContextPersistenceAcrossNavigations(t *testing.T) {
 	assertEqual(t, region, m.currentRegion, "region preserved")
 }
 
+func TestToggleListMode_PersistsSetting(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	m := newTestModel([]string{"prod"})
+	m.currentScreen = ParameterListScreen
+
+	m = updateModel(m, types.ToggleListModeMsg{})
+	assertEqual(t, config.ListModeDetailed, m.settings.ListMode, "mode after toggle")
+
+	saved, err := config.LoadSettings()
+	if err != nil {
+		t.Fatalf("load settings: %v", err)
+	}
+	assertEqual(t, config.ListModeDetailed, saved.ListMode, "persisted mode")
+
+	m = updateModel(m, types.ToggleListModeMsg{})
+	assertEqual(t, config.ListModeCompact, m.settings.ListMode, "mode after second toggle")
+}
+
+func TestCreateParameter_OpensAndEscReturnsToList(t *testing.T) {
+	m := newTestModel([]string{"prod"})
+	m.currentScreen = ParameterListScreen
+
+	m = updateModel(m, types.CreateParameterMsg{})
+	assertEqual(t, ParameterCreateScreen, m.currentScreen, "create screen")
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assertEqual(t, ParameterListScreen, m.currentScreen, "back to list")
+}
+
+func TestTree_CreateHereReturnsToTree(t *testing.T) {
+	m := newTestModel([]string{"prod"})
+	m.currentScreen = ParameterListScreen
+
+	m = updateModel(m, types.ShowTreeMsg{})
+	assertEqual(t, TreeScreen, m.currentScreen, "tree screen")
+
+	m = updateModel(m, types.CreateParameterMsg{Prefix: "/app/prod/"})
+	assertEqual(t, ParameterCreateScreen, m.currentScreen, "create screen")
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assertEqual(t, TreeScreen, m.currentScreen, "back to tree")
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assertEqual(t, ParameterListScreen, m.currentScreen, "back to list")
+}
+
+func TestFormatActivity(t *testing.T) {
+	assertEqual(t, "", formatActivity(aws.APIActivity{}), "hidden before any call")
+
+	line := formatActivity(aws.APIActivity{InFlight: 1, Completed: 12, Retries: 2})
+	for _, want := range []string{"1 running", "12 done", "2 retries"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("expected %q in indicator %q", want, line)
+		}
+	}
+	if strings.Contains(formatActivity(aws.APIActivity{Completed: 3}), "retries") {
+		t.Error("retries should be omitted when there were none")
+	}
+}
+
 // Benchmark tests
 
 // BenchmarkNavigationSequence benchmarks a typical navigation path
@@ -542,3 +758,164 @@ func BenchmarkBackNavigation(b *testing.B) {
 		m.currentScreen = ParameterViewScreen
 	}
 }
+
+func TestOpenLastContext(t *testing.T) {
+	m := newTestModel([]string{"prod"})
+	m.recents = nil
+	if m.OpenLastContext() {
+		t.Fatalf("expected no context to open without recents")
+	}
+
+	m.recents = []config.RecentEntry{{Profile: "prod", Region: "eu-west-1"}, {Profile: "prod", Region: "us-east-1"}}
+	if !m.OpenLastContext() {
+		t.Fatalf("expected the most recent context to open")
+	}
+	assertEqual(t, "eu-west-1", m.startContext.Region, "start region")
+	if m.Init() == nil {
+		t.Fatalf("expected startup command")
+	}
+}
+
+func TestInitAutoSelectsSingleProfile(t *testing.T) {
+	m := newTestModel([]string{"prod"})
+	m.recents = nil
+	cmd := m.Init()
+	if cmd == nil {
+		t.Fatalf("expected startup command")
+	}
+
+	var selected bool
+	for _, c := range cmd().(tea.BatchMsg) {
+		if c == nil {
+			continue
+		}
+		if msg, ok := c().(types.ProfileSelectedMsg); ok {
+			selected = msg.Profile == "prod"
+		}
+	}
+	if !selected {
+		t.Fatalf("expected the only profile to be selected")
+	}
+}
+
+func TestSkipRegionSelectorWithRememberedRegion(t *testing.T) {
+	m := newTestModel([]string{"prod", "dev"})
+	m.regionMapping.ProfileRegions["prod"] = "eu-west-1"
+
+	m = updateModel(m, types.ProfileSelectedMsg{Profile: "prod"})
+	assertEqual(t, RegionSelectorScreen, m.currentScreen, "selector shown by default")
+
+	m.settings = &config.Settings{SkipRegionSelector: true}
+	m = updateModel(m, types.ProfileSelectedMsg{Profile: "dev"})
+	assertEqual(t, RegionSelectorScreen, m.currentScreen, "selector shown without a remembered region")
+
+	m = updateModel(m, types.ProfileSelectedMsg{Profile: "prod"})
+	assertEqual(t, ParameterListScreen, m.currentScreen, "remembered region opened directly")
+	assertEqual(t, "eu-west-1", m.currentRegion, "remembered region")
+}
+
+func TestQuickRegionSwitch(t *testing.T) {
+	m := newTestModel([]string{"prod"})
+	m = updateModel(m, types.ProfileSelectedMsg{Profile: "prod"})
+	m = updateModel(m, types.RegionSelectedMsg{Region: "us-east-1"})
+
+	m = updateModel(m, types.SwitchRegionMsg{})
+	assertEqual(t, RegionSelectorScreen, m.currentScreen, "region selector opened")
+
+	// esc returns to the list instead of the profile selector
+	m = updateModel(m, types.BackMsg{})
+	assertEqual(t, ParameterListScreen, m.currentScreen, "cancelled region switch")
+	assertEqual(t, "us-east-1", m.currentRegion, "region unchanged")
+
+	m = updateModel(m, types.SwitchRegionMsg{})
+	m = updateModel(m, types.RegionSelectedMsg{Region: "eu-west-1"})
+	assertEqual(t, ParameterListScreen, m.currentScreen, "back on the list")
+	assertEqual(t, "eu-west-1", m.currentRegion, "switched region")
+	assertEqual(t, "prod", m.currentProfile, "profile kept")
+
+	m = updateModel(m, types.BackMsg{})
+	m = updateModel(m, types.BackMsg{})
+	assertEqual(t, ProfileSelectorScreen, m.currentScreen, "normal back navigation restored")
+}
+
+func TestContextSwitcherOverlay(t *testing.T) {
+	m := newTestModel([]string{"prod", "dev"})
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyCtrlP})
+	assertEqual(t, ContextSwitcherScreen, m.currentScreen, "switcher opened")
+
+	m = updateModel(m, types.BackMsg{})
+	assertEqual(t, ProfileSelectorScreen, m.currentScreen, "closed back to the selector")
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyCtrlP})
+	m = updateModel(m, types.ProfileSelectedMsg{Profile: "dev", Region: "us-west-2"})
+	assertEqual(t, ParameterListScreen, m.currentScreen, "context opened")
+	assertEqual(t, "dev", m.currentProfile, "profile")
+	assertEqual(t, "us-west-2", m.currentRegion, "region")
+}
+
+func TestEscClosesValuePeek(t *testing.T) {
+	m := newTestModel([]string{"prod"})
+	m.currentScreen = ParameterListScreen
+	m.parameterList.PeekActive = true
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assertEqual(t, ParameterListScreen, m.currentScreen, "esc stays on the list")
+	assertEqual(t, false, m.parameterList.PeekActive, "esc closes the peek")
+}
+
+func TestExportScreenReturnsToList(t *testing.T) {
+	m := newTestModel([]string{"prod"})
+	m.currentScreen = ParameterListScreen
+
+	m = updateModel(m, types.ExportParametersMsg{Parameters: []*aws.Parameter{{Name: "/app/a"}}})
+	assertEqual(t, ExportScreen, m.currentScreen, "export screen opened")
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assertEqual(t, ParameterListScreen, m.currentScreen, "esc returns to the list")
+}
+
+func TestWindowTitleFollowsContext(t *testing.T) {
+	m := newTestModel([]string{"prod"})
+	assertEqual(t, "ps9s", m.windowTitle(), "title before a context is open")
+
+	m = updateModel(m, types.ProfileSelectedMsg{Profile: "prod"})
+	m = updateModel(m, types.RegionSelectedMsg{Region: "us-east-1"})
+	assertEqual(t, "ps9s: prod : us-east-1", m.title, "title on the list")
+
+	updated, cmd := m.Update(types.ViewParameterMsg{Parameter: &aws.Parameter{Name: "/app/key"}})
+	m = updated.(Model)
+	assertEqual(t, "ps9s: prod : us-east-1 : /app/key", m.title, "title on the view")
+	if cmd == nil {
+		t.Fatal("expected the title change to be sent")
+	}
+
+	m = updateModel(m, types.BackMsg{})
+	assertEqual(t, "ps9s: prod : us-east-1", m.title, "title back on the list")
+}
+
+func TestCtrlXTogglesMouseCapture(t *testing.T) {
+	m := newTestModel([]string{"prod"})
+	m.SetMouse(true)
+	m.title = m.windowTitle()
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlX})
+	m = updated.(Model)
+	assertEqual(t, true, m.mouseOff, "ctrl+x releases the mouse")
+	if cmd == nil {
+		t.Fatal("expected a command disabling the mouse")
+	}
+
+	m = updateModel(m, tea.KeyMsg{Type: tea.KeyCtrlX})
+	assertEqual(t, false, m.mouseOff, "ctrl+x again captures it")
+}
+
+func TestMessageCounts(t *testing.T) {
+	EnableMessageCounts()
+	before := MessageCounts()["types.BackMsg"]
+
+	m := newTestModel([]string{"prod"})
+	m = updateModel(m, types.BackMsg{})
+	updateModel(m, types.BackMsg{})
+
+	assertEqual(t, before+2, MessageCounts()["types.BackMsg"], "counted back messages")
+}
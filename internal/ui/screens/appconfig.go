@@ -0,0 +1,528 @@
+package screens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+// appConfigLevel is the depth of the AppConfig browser
+type appConfigLevel int
+
+const (
+	appConfigApplications appConfigLevel = iota
+	appConfigEnvironments
+	appConfigProfiles
+	appConfigVersions
+	appConfigContent
+)
+
+// appConfigItem is a row of the AppConfig browser
+type appConfigItem struct {
+	index  int // Position in the model's slice for the level
+	name   string
+	detail string
+}
+
+func (i appConfigItem) FilterValue() string { return i.name }
+
+type appConfigDelegate struct{}
+
+func (d appConfigDelegate) Height() int                             { return 1 }
+func (d appConfigDelegate) Spacing() int                            { return 0 }
+func (d appConfigDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+func (d appConfigDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(appConfigItem)
+	if !ok {
+		return
+	}
+
+	str := i.name
+	if index == m.Index() {
+		str = lipgloss.NewStyle().
+			Foreground(styles.Primary).
+			Bold(true).
+			Render(styles.Cursor + " " + str)
+	} else {
+		str = lipgloss.NewStyle().
+			PaddingLeft(2).
+			Render(str)
+	}
+	if i.detail != "" {
+		str += "  " + styles.HelpStyle.UnsetMarginTop().Render(i.detail)
+	}
+
+	fmt.Fprint(w, str)
+}
+
+// AppConfigModel browses AppConfig applications, environments, configuration
+// profiles and hosted versions, and saves edited content as a new version
+type AppConfigModel struct {
+	client         *aws.Client
+	level          appConfigLevel
+	applications   []aws.AppConfigApplication
+	environments   []aws.AppConfigEnvironment
+	profiles       []aws.AppConfigProfile
+	deployments    map[string]aws.AppConfigDeployment // Latest deployment to the environment, by profile name
+	versions       []aws.AppConfigVersion
+	application    *aws.AppConfigApplication
+	environment    *aws.AppConfigEnvironment
+	profile        *aws.AppConfigProfile
+	version        *aws.AppConfigVersion // Opened version with its content
+	list           list.Model
+	content        viewport.Model
+	editor         textarea.Model
+	editing        bool
+	spinner        spinner.Model
+	loading        bool
+	saving         bool
+	err            error
+	status         string
+	currentProfile string
+	currentRegion  string
+	cancelLoad     context.CancelFunc
+	loadSeq        int // Identifies the latest load; results of earlier ones are dropped
+}
+
+// NewAppConfig creates the AppConfig browser
+func NewAppConfig() AppConfigModel {
+	s := spinner.New()
+	s.Spinner = styles.Spinner
+	s.Style = lipgloss.NewStyle().Foreground(styles.Secondary)
+
+	const defaultWidth = 80
+	const defaultHeight = 20
+
+	l := list.New([]list.Item{}, appConfigDelegate{}, defaultWidth, defaultHeight)
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+	l.Styles.Title = styles.TitleStyle
+	l.Styles.PaginationStyle = list.DefaultStyles().PaginationStyle.PaddingLeft(4)
+
+	ta := textarea.New()
+	ta.CharLimit = 0
+	ta.ShowLineNumbers = false
+
+	return AppConfigModel{
+		list:    l,
+		content: viewport.New(defaultWidth, defaultHeight),
+		editor:  ta,
+		spinner: s,
+	}
+}
+
+// Init initializes the AppConfig browser
+func (m AppConfigModel) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+// Open starts browsing the applications of client's region
+func (m *AppConfigModel) Open(client *aws.Client) tea.Cmd {
+	m.client = client
+	m.level = appConfigApplications
+	m.editing = false
+	m.status = ""
+	return m.load(func(ctx context.Context) (types.AppConfigLoadedMsg, error) {
+		apps, err := client.ListApplications(ctx)
+		return types.AppConfigLoadedMsg{Applications: apps}, err
+	})
+}
+
+// Nested reports whether esc should go up a level or cancel editing instead
+// of leaving the browser
+func (m AppConfigModel) Nested() bool {
+	return m.level > appConfigApplications || m.editing
+}
+
+// load runs fetch in the background with a cancellable context
+func (m *AppConfigModel) load(fetch func(context.Context) (types.AppConfigLoadedMsg, error)) tea.Cmd {
+	if m.cancelLoad != nil {
+		m.cancelLoad()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelLoad = cancel
+	m.loading = true
+	m.err = nil
+	m.loadSeq++
+	seq := m.loadSeq
+
+	return tea.Batch(
+		m.spinner.Tick,
+		func() tea.Msg {
+			msg, err := fetch(ctx)
+			if err != nil {
+				return types.ErrorMsg{Err: err}
+			}
+			msg.Seq = seq
+			return msg
+		},
+	)
+}
+
+// Update handles messages for the AppConfig browser
+func (m AppConfigModel) Update(msg tea.Msg) (AppConfigModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case types.AppConfigLoadedMsg:
+		if msg.Seq != m.loadSeq {
+			return m, nil
+		}
+		m.loading = false
+		m.applyLoaded(msg)
+		return m, nil
+
+	case types.AppConfigSavedMsg:
+		m.saving = false
+		m.editing = false
+		m.status = fmt.Sprintf("Created version %d; deploy it with AppConfig to roll it out", msg.Version.Number)
+		m.level = appConfigVersions
+		app, profile := m.application.ID, m.profile.ID
+		client := m.client
+		return m, m.load(func(ctx context.Context) (types.AppConfigLoadedMsg, error) {
+			versions, err := client.ListHostedVersions(ctx, app, profile)
+			return types.AppConfigLoadedMsg{Versions: versions}, err
+		})
+
+	case types.ErrorMsg:
+		m.loading = false
+		m.saving = false
+		if !errors.Is(msg.Err, context.Canceled) {
+			m.err = msg.Err
+		}
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.saving {
+			return m, nil
+		}
+		if msg.String() == "esc" {
+			return m.back()
+		}
+		if m.loading {
+			return m, nil
+		}
+		if m.editing {
+			if msg.String() == "ctrl+s" {
+				return m, m.save()
+			}
+			var cmd tea.Cmd
+			m.editor, cmd = m.editor.Update(msg)
+			return m, cmd
+		}
+
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "enter":
+			m.status = ""
+			return m.open()
+		case "e":
+			if m.level == appConfigContent && m.version != nil {
+				m.editing = true
+				m.status = ""
+				m.editor.SetValue(m.version.Content)
+				return m, m.editor.Focus()
+			}
+		}
+
+		if m.level == appConfigContent {
+			var cmd tea.Cmd
+			m.content, cmd = m.content.Update(msg)
+			return m, cmd
+		}
+	}
+
+	if m.loading || m.saving {
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+// applyLoaded stores the result of a load for the current level
+func (m *AppConfigModel) applyLoaded(msg types.AppConfigLoadedMsg) {
+	var items []list.Item
+	switch m.level {
+	case appConfigApplications:
+		m.applications = msg.Applications
+		for i, a := range msg.Applications {
+			items = append(items, appConfigItem{index: i, name: a.Name, detail: a.Description})
+		}
+	case appConfigEnvironments:
+		m.environments = msg.Environments
+		for i, e := range msg.Environments {
+			items = append(items, appConfigItem{index: i, name: e.Name, detail: strings.ToLower(e.State)})
+		}
+	case appConfigProfiles:
+		m.profiles = msg.Profiles
+		m.deployments = msg.Deployments
+		for i, p := range msg.Profiles {
+			detail := p.LocationURI
+			if d, ok := m.deployments[p.Name]; ok {
+				detail += fmt.Sprintf(" • deployed: %s", d.ConfigurationVersion)
+				if d.State != "COMPLETE" {
+					detail += " (" + strings.ToLower(d.State) + ")"
+				}
+			}
+			items = append(items, appConfigItem{index: i, name: p.Name, detail: detail})
+		}
+	case appConfigVersions:
+		m.versions = msg.Versions
+		for i, v := range msg.Versions {
+			detail := v.ContentType
+			if v.Label != "" {
+				detail += " • " + v.Label
+			}
+			if v.Description != "" {
+				detail += " • " + v.Description
+			}
+			items = append(items, appConfigItem{index: i, name: fmt.Sprintf("v%d", v.Number), detail: detail})
+		}
+	case appConfigContent:
+		if msg.Version != nil {
+			m.version = msg.Version
+			m.content.SetContent(msg.Version.Content)
+			m.content.GotoTop()
+		}
+		return
+	}
+	m.list.SetItems(items)
+	m.list.Select(0)
+	m.updateTitle()
+}
+
+// open descends into the selected row
+func (m AppConfigModel) open() (AppConfigModel, tea.Cmd) {
+	item, ok := m.list.SelectedItem().(appConfigItem)
+	if !ok || m.level == appConfigContent {
+		return m, nil
+	}
+
+	client := m.client
+	switch m.level {
+	case appConfigApplications:
+		app := m.applications[item.index]
+		m.application = &app
+		m.level = appConfigEnvironments
+		return m, m.load(func(ctx context.Context) (types.AppConfigLoadedMsg, error) {
+			envs, err := client.ListEnvironments(ctx, app.ID)
+			return types.AppConfigLoadedMsg{Environments: envs}, err
+		})
+
+	case appConfigEnvironments:
+		env := m.environments[item.index]
+		m.environment = &env
+		m.level = appConfigProfiles
+		app := m.application.ID
+		return m, m.load(func(ctx context.Context) (types.AppConfigLoadedMsg, error) {
+			profiles, err := client.ListConfigurationProfiles(ctx, app)
+			if err != nil {
+				return types.AppConfigLoadedMsg{}, err
+			}
+			// Deployments only annotate the profiles; browsing works without them
+			deployments, _ := client.LatestDeployments(ctx, app, env.ID)
+			return types.AppConfigLoadedMsg{Profiles: profiles, Deployments: deployments}, nil
+		})
+
+	case appConfigProfiles:
+		profile := m.profiles[item.index]
+		if name, ok := strings.CutPrefix(profile.LocationURI, "ssm-parameter://"); ok {
+			// Stored in Parameter Store: open it with the parameter screens
+			return m, func() tea.Msg {
+				return types.ViewParameterMsg{Parameter: &aws.Parameter{Name: name}}
+			}
+		}
+		if !profile.Hosted() {
+			m.status = fmt.Sprintf("%s is stored in %s; only hosted configurations can be browsed", profile.Name, profile.LocationURI)
+			return m, nil
+		}
+		m.profile = &profile
+		m.level = appConfigVersions
+		app := m.application.ID
+		return m, m.load(func(ctx context.Context) (types.AppConfigLoadedMsg, error) {
+			versions, err := client.ListHostedVersions(ctx, app, profile.ID)
+			return types.AppConfigLoadedMsg{Versions: versions}, err
+		})
+
+	case appConfigVersions:
+		number := m.versions[item.index].Number
+		m.level = appConfigContent
+		app, profile := m.application.ID, m.profile.ID
+		return m, m.load(func(ctx context.Context) (types.AppConfigLoadedMsg, error) {
+			version, err := client.GetHostedVersion(ctx, app, profile, number)
+			return types.AppConfigLoadedMsg{Version: version}, err
+		})
+	}
+	return m, nil
+}
+
+// back cancels editing or goes up a level, leaving the browser from the top
+func (m AppConfigModel) back() (AppConfigModel, tea.Cmd) {
+	m.status = ""
+	m.err = nil
+	if m.editing {
+		m.editing = false
+		m.editor.Blur()
+		return m, nil
+	}
+	if m.cancelLoad != nil {
+		m.cancelLoad()
+	}
+	m.loading = false
+	m.loadSeq++
+
+	switch m.level {
+	case appConfigApplications:
+		return m, func() tea.Msg { return types.BackMsg{} }
+	case appConfigContent:
+		m.level = appConfigVersions
+		m.applyLoaded(types.AppConfigLoadedMsg{Versions: m.versions})
+	case appConfigVersions:
+		m.level = appConfigProfiles
+		m.applyLoaded(types.AppConfigLoadedMsg{Profiles: m.profiles, Deployments: m.deployments})
+	case appConfigProfiles:
+		m.level = appConfigEnvironments
+		m.applyLoaded(types.AppConfigLoadedMsg{Environments: m.environments})
+	case appConfigEnvironments:
+		m.level = appConfigApplications
+		m.applyLoaded(types.AppConfigLoadedMsg{Applications: m.applications})
+	}
+	return m, nil
+}
+
+// save stores the edited content as a new hosted version
+func (m *AppConfigModel) save() tea.Cmd {
+	m.saving = true
+	m.err = nil
+
+	client := m.client
+	app, profile := m.application.ID, m.profile.ID
+	name := m.application.Name + "/" + m.profile.Name
+	var latest int32
+	if len(m.versions) > 0 {
+		latest = m.versions[0].Number
+	}
+	content := m.editor.Value()
+	contentType := m.version.ContentType
+
+	return tea.Batch(
+		m.spinner.Tick,
+		func() tea.Msg {
+			version, err := client.CreateHostedVersion(context.Background(), app, profile, name, latest, content, contentType)
+			if err != nil {
+				return types.ErrorMsg{Err: err}
+			}
+			return types.AppConfigSavedMsg{Version: version}
+		},
+	)
+}
+
+// path renders the names opened above the current level
+func (m AppConfigModel) path() string {
+	parts := []string{"AppConfig"}
+	if m.level > appConfigApplications && m.application != nil {
+		parts = append(parts, m.application.Name)
+	}
+	if m.level > appConfigEnvironments && m.environment != nil {
+		parts = append(parts, m.environment.Name)
+	}
+	if m.level > appConfigProfiles && m.profile != nil {
+		parts = append(parts, m.profile.Name)
+	}
+	if m.level > appConfigVersions && m.version != nil {
+		parts = append(parts, fmt.Sprintf("v%d", m.version.Number))
+	}
+	return strings.Join(parts, " / ")
+}
+
+// updateTitle updates the list title with the context and opened path
+func (m *AppConfigModel) updateTitle() {
+	profile := m.currentProfile
+	region := m.currentRegion
+	if profile == "" {
+		profile = "-"
+	}
+	if region == "" {
+		region = "-"
+	}
+	m.list.Title = fmt.Sprintf("%s : %s : %s", profile, region, m.path())
+}
+
+// View renders the AppConfig browser
+func (m AppConfigModel) View() string {
+	if m.loading {
+		return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), progressText("Loading AppConfig..."))
+	}
+	if m.saving {
+		return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), progressText("Creating version..."))
+	}
+
+	var b strings.Builder
+	help := "↑/↓: navigate • enter: open • esc: back • q: quit"
+
+	switch {
+	case m.level == appConfigContent:
+		b.WriteString("  " + styles.TitleStyle.Render(fmt.Sprintf("%s : %s : %s", m.currentProfile, m.currentRegion, m.path())))
+		b.WriteString("\n\n")
+		if m.version != nil {
+			b.WriteString("  " + styles.LabelStyle.Render("Content type: ") + m.version.ContentType + "\n\n")
+		}
+		if m.editing {
+			b.WriteString(m.editor.View())
+			help = "ctrl+s: save as new version • esc: cancel"
+		} else {
+			b.WriteString(m.content.View())
+			help = "↑/↓: scroll • e: edit • esc: back • q: quit"
+		}
+	default:
+		b.WriteString(m.list.View())
+	}
+	b.WriteString("\n")
+
+	if m.err != nil {
+		b.WriteString("  " + styles.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)) + "\n")
+	}
+	if m.status != "" {
+		b.WriteString("  " + styles.SuccessStyle.Render(m.status) + "\n")
+	}
+	b.WriteString(styles.HelpStyle.Render(help))
+
+	return b.String()
+}
+
+// SetContext sets the profile and region context for the AppConfig browser
+func (m *AppConfigModel) SetContext(profile, region string) {
+	m.currentProfile = profile
+	m.currentRegion = region
+	m.updateTitle()
+}
+
+// SetSize updates the dimensions of the AppConfig browser
+func (m *AppConfigModel) SetSize(width, height int) {
+	m.list.SetWidth(width)
+	m.list.SetHeight(height - 4)
+	m.content.Width = width - 4
+	m.content.Height = height - 8
+	m.editor.SetWidth(width - 4)
+	m.editor.SetHeight(height - 8)
+}
@@ -0,0 +1,92 @@
+package screens
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/styles"
+)
+
+// changeHighlightDuration is how long refresh changes stay marked in the list
+const changeHighlightDuration = 15 * time.Second
+
+// changeKind classifies how a parameter differs from the previous refresh
+type changeKind int
+
+const (
+	changeNew changeKind = iota + 1
+	changeUpdated
+)
+
+// listChanges records what the last refresh changed. It is shared by pointer
+// between the list model and its delegate, so markers expire without
+// rebuilding the delegate.
+type listChanges struct {
+	kinds   map[string]changeKind // New and updated names
+	removed []string              // Names gone since the previous refresh
+	until   time.Time             // Markers are hidden after this
+}
+
+// active reports whether there are changes still worth highlighting
+func (c *listChanges) active() bool {
+	return c != nil && time.Now().Before(c.until) && (len(c.kinds) > 0 || len(c.removed) > 0)
+}
+
+// marker renders the change indicator column for name
+func (c *listChanges) marker(name string) string {
+	switch c.kinds[name] {
+	case changeNew:
+		return styles.SuccessStyle.Render("+") + " "
+	case changeUpdated:
+		return styles.WarningStyle.Render("~") + " "
+	}
+	return "  "
+}
+
+// diffSnapshot compares params with the versions seen at the previous refresh
+func diffSnapshot(previous map[string]int64, params []*aws.Parameter) (map[string]changeKind, []string) {
+	kinds := make(map[string]changeKind)
+	seen := make(map[string]bool, len(params))
+	for _, p := range params {
+		seen[p.Name] = true
+		v, ok := previous[p.Name]
+		switch {
+		case !ok:
+			kinds[p.Name] = changeNew
+		case v != p.Version:
+			kinds[p.Name] = changeUpdated
+		}
+	}
+
+	var removed []string
+	for name := range previous {
+		if !seen[name] {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+	return kinds, removed
+}
+
+// snapshotVersions records the version of each parameter for the next refresh
+func snapshotVersions(params []*aws.Parameter) map[string]int64 {
+	versions := make(map[string]int64, len(params))
+	for _, p := range params {
+		versions[p.Name] = p.Version
+	}
+	return versions
+}
+
+// maxRemovedShown limits the removed names listed under the parameter list
+const maxRemovedShown = 3
+
+// removedSummary lists the first removed names and how many more there are
+func removedSummary(removed []string) string {
+	if len(removed) <= maxRemovedShown {
+		return strings.Join(removed, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(removed[:maxRemovedShown], ", "), len(removed)-maxRemovedShown)
+}
@@ -0,0 +1,396 @@
+package screens
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+// contextComparedMsg carries a parameter as read from both contexts; a side
+// is nil when the parameter does not exist there
+type contextComparedMsg struct {
+	left, right *aws.Parameter
+	target      string // "profile : region" of the right side
+	err         error
+}
+
+// jsonKeyChange is a JSON key whose value differs between two values
+type jsonKeyChange struct {
+	op    byte // '~' changed, '-' only on the left, '+' only on the right
+	key   string
+	left  string
+	right string
+}
+
+// ContextCompareModel compares a parameter with the same path, or another
+// one, in a second profile and region, to hunt down configuration drift
+// between environments
+type ContextCompareModel struct {
+	client         *aws.Client
+	clientFor      func(profile, region string) (*aws.Client, error)
+	name           string
+	nameInput      textinput.Model // Name in the other context
+	contextInput   textinput.Model // "profile region" of the other context
+	editContext    bool            // The context input has the focus
+	pool           []string
+	prompting      bool
+	loading        bool
+	left           *aws.Parameter
+	right          *aws.Parameter
+	target         string
+	err            error
+	viewport       viewport.Model
+	width          int
+	height         int
+	currentProfile string
+	currentRegion  string
+}
+
+// NewContextCompare creates the context compare screen
+func NewContextCompare() ContextCompareModel {
+	ni := textinput.New()
+	ni.Placeholder = "/app/prod/name"
+	ni.CharLimit = 2048
+	ni.Width = 60
+
+	ci := textinput.New()
+	ci.Placeholder = "profile region"
+	ci.CharLimit = 100
+	ci.Width = 40
+
+	return ContextCompareModel{nameInput: ni, contextInput: ci, viewport: viewport.New(80, 20)}
+}
+
+// Init initializes the context compare screen
+func (m ContextCompareModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Open starts a comparison of the parameter name with the same name in the
+// first other context of the pool. clientFor creates the other context's client.
+func (m *ContextCompareModel) Open(client *aws.Client, name string, clientFor func(profile, region string) (*aws.Client, error)) tea.Cmd {
+	m.client = client
+	m.clientFor = clientFor
+	m.name = name
+	m.left, m.right = nil, nil
+	m.target = ""
+	m.err = nil
+	m.loading = false
+	m.prompting = true
+	m.nameInput.SetValue(name)
+	m.nameInput.CursorEnd()
+	m.contextInput.SetValue(otherContext(m.pool, m.currentProfile+" "+m.currentRegion))
+	m.contextInput.CursorEnd()
+	m.editContext = true
+	m.nameInput.Blur()
+	return m.contextInput.Focus()
+}
+
+// SetPool sets the contexts offered for comparison, as "profile region"
+func (m *ContextCompareModel) SetPool(pool []string) {
+	m.pool = pool
+}
+
+// Prompting reports whether the other context is being typed, so esc
+// cancels instead of leaving
+func (m ContextCompareModel) Prompting() bool {
+	return m.prompting && (m.left != nil || m.right != nil)
+}
+
+// load reads the parameter from both contexts
+func (m *ContextCompareModel) load() tea.Cmd {
+	fields := strings.Fields(m.contextInput.Value())
+	other := strings.TrimSpace(m.nameInput.Value())
+	if len(fields) != 2 {
+		m.err = fmt.Errorf("the context must be a profile and a region")
+		return nil
+	}
+	if err := aws.ValidateParameterName(other); err != nil {
+		m.err = err
+		return nil
+	}
+	profile, region := fields[0], fields[1]
+	if profile == m.currentProfile && region == m.currentRegion && other == m.name {
+		m.err = fmt.Errorf("pick another context or name to compare with")
+		return nil
+	}
+
+	m.err = nil
+	m.prompting = false
+	m.loading = true
+	m.nameInput.Blur()
+	m.contextInput.Blur()
+	client, clientFor, name := m.client, m.clientFor, m.name
+	same := profile == m.currentProfile && region == m.currentRegion
+	return func() tea.Msg {
+		msg := contextComparedMsg{target: profile + " : " + region}
+		target := client
+		if !same {
+			var err error
+			if target, err = clientFor(profile, region); err != nil {
+				msg.err = fmt.Errorf("failed to open %s %s: %w", profile, region, err)
+				return msg
+			}
+		}
+		ctx := context.Background()
+		read := func(c *aws.Client, name string) (*aws.Parameter, error) {
+			p, err := c.GetParameter(ctx, name)
+			if aws.IsNotFound(err) {
+				return nil, nil
+			}
+			return p, err
+		}
+		if msg.left, msg.err = read(client, name); msg.err != nil {
+			return msg
+		}
+		msg.right, msg.err = read(target, other)
+		return msg
+	}
+}
+
+// Update handles messages for the context compare screen
+func (m ContextCompareModel) Update(msg tea.Msg) (ContextCompareModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case contextComparedMsg:
+		if !m.loading {
+			return m, nil
+		}
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			m.prompting = true
+			return m, m.contextInput.Focus()
+		}
+		m.left, m.right, m.target = msg.left, msg.right, msg.target
+		m.viewport.SetContent(m.renderComparison())
+		m.viewport.GotoTop()
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if m.loading {
+			return m, nil
+		}
+		if m.prompting {
+			switch msg.String() {
+			case "esc":
+				if m.left == nil && m.right == nil {
+					return m, func() tea.Msg { return types.BackMsg{} }
+				}
+				m.prompting = false
+				m.err = nil
+				m.nameInput.Blur()
+				m.contextInput.Blur()
+				return m, nil
+			case "tab", "shift+tab":
+				m.editContext = !m.editContext
+				if m.editContext {
+					m.nameInput.Blur()
+					return m, m.contextInput.Focus()
+				}
+				m.contextInput.Blur()
+				return m, m.nameInput.Focus()
+			case "up", "down":
+				if m.editContext {
+					step := 1
+					if msg.String() == "up" {
+						step = -1
+					}
+					m.contextInput.SetValue(cycleContext(m.pool, m.contextInput.Value(), step))
+					m.contextInput.CursorEnd()
+				}
+				return m, nil
+			case "enter":
+				return m, m.load()
+			}
+			var cmd tea.Cmd
+			if m.editContext {
+				m.contextInput, cmd = m.contextInput.Update(msg)
+			} else {
+				m.nameInput, cmd = m.nameInput.Update(msg)
+			}
+			return m, cmd
+		}
+
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg { return types.BackMsg{} }
+		case "q":
+			return m, tea.Quit
+		case "e":
+			// Compare with another context or name
+			m.prompting = true
+			m.editContext = true
+			return m, m.contextInput.Focus()
+		case "r":
+			// Read both sides again
+			return m, m.load()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// diffJSONKeys compares the leaves of two JSON values key by key, in the
+// order the parameter view lists them. It reports false unless both values
+// are JSON objects or arrays.
+func diffJSONKeys(left, right string) ([]jsonKeyChange, bool) {
+	var l, r interface{}
+	if json.Unmarshal([]byte(left), &l) != nil || json.Unmarshal([]byte(right), &r) != nil {
+		return nil, false
+	}
+	for _, v := range []interface{}{l, r} {
+		switch v.(type) {
+		case map[string]interface{}, []interface{}:
+		default:
+			return nil, false
+		}
+	}
+
+	rightValues := make(map[string]string)
+	for _, item := range flattenJSONForView(r, "") {
+		rightValues[item.key] = item.value
+	}
+	var changes []jsonKeyChange
+	seen := make(map[string]bool)
+	for _, item := range flattenJSONForView(l, "") {
+		seen[item.key] = true
+		rv, ok := rightValues[item.key]
+		switch {
+		case !ok:
+			changes = append(changes, jsonKeyChange{op: '-', key: item.key, left: item.value})
+		case rv != item.value:
+			changes = append(changes, jsonKeyChange{op: '~', key: item.key, left: item.value, right: rv})
+		}
+	}
+	for _, item := range flattenJSONForView(r, "") {
+		if !seen[item.key] {
+			changes = append(changes, jsonKeyChange{op: '+', key: item.key, right: item.value})
+		}
+	}
+	return changes, true
+}
+
+// renderComparison renders both sides' metadata, the changed JSON keys and
+// the value diff from the current context (-) to the other one (+)
+func (m ContextCompareModel) renderComparison() string {
+	removed := lipgloss.NewStyle().Foreground(styles.Error)
+	added := lipgloss.NewStyle().Foreground(styles.Success)
+	var b strings.Builder
+
+	side := func(style lipgloss.Style, op, label, name string, p *aws.Parameter) {
+		line := fmt.Sprintf("%s %s : %s", op, label, name)
+		if p == nil {
+			b.WriteString(style.Render(line) + " " + styles.WarningStyle.Render("(does not exist)") + "\n")
+			return
+		}
+		b.WriteString(style.Render(line) + styles.HelpStyle.UnsetMarginTop().Render(fmt.Sprintf("  v%d • %s • %s",
+			p.Version, p.Type, p.LastModifiedDate.Local().Format("2006-01-02 15:04"))) + "\n")
+	}
+	side(removed, "-", m.currentProfile+" : "+m.currentRegion, m.name, m.left)
+	name := strings.TrimSpace(m.nameInput.Value())
+	side(added, "+", m.target, name, m.right)
+	b.WriteString("\n")
+
+	if m.left == nil || m.right == nil {
+		if m.left == nil && m.right == nil {
+			b.WriteString(styles.WarningStyle.Render("Neither context has the parameter"))
+		}
+		return b.String()
+	}
+	if m.left.Type != m.right.Type {
+		b.WriteString(styles.WarningStyle.Render(fmt.Sprintf("⚠ Types differ: %s vs %s", m.left.Type, m.right.Type)) + "\n\n")
+	}
+	if m.left.Value == m.right.Value {
+		b.WriteString(styles.SuccessStyle.Render("✓ The values are identical"))
+		return b.String()
+	}
+
+	leftValue, rightValue := m.left.Value, m.right.Value
+	if changes, ok := diffJSONKeys(leftValue, rightValue); ok {
+		b.WriteString(styles.LabelStyle.Render(fmt.Sprintf("JSON keys (%d differ):", len(changes))) + "\n")
+		for _, c := range changes {
+			switch c.op {
+			case '~':
+				b.WriteString(fmt.Sprintf("~ %s: %s → %s\n", c.key, removed.Render(c.left), added.Render(c.right)))
+			case '-':
+				b.WriteString(removed.Render(fmt.Sprintf("- %s: %s", c.key, c.left)) + "\n")
+			case '+':
+				b.WriteString(added.Render(fmt.Sprintf("+ %s: %s", c.key, c.right)) + "\n")
+			}
+		}
+		b.WriteString("\n")
+		leftValue, rightValue = indentJSON(leftValue), indentJSON(rightValue)
+	}
+	b.WriteString(styles.LabelStyle.Render("Value diff:") + "\n")
+	b.WriteString(renderDiff(diffLines(leftValue, rightValue)))
+	return b.String()
+}
+
+// View renders the context compare screen
+func (m ContextCompareModel) View() string {
+	var b strings.Builder
+
+	profile := m.currentProfile
+	region := m.currentRegion
+	if profile == "" {
+		profile = "-"
+	}
+	if region == "" {
+		region = "-"
+	}
+	title := fmt.Sprintf("%s : %s : Compare %s", profile, region, m.name)
+	b.WriteString("  " + styles.TitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	if m.err != nil {
+		b.WriteString("  " + styles.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		b.WriteString("\n\n")
+	}
+
+	switch {
+	case m.loading:
+		b.WriteString("  " + progressText("Reading both contexts..."))
+	case m.prompting:
+		b.WriteString("  " + styles.LabelStyle.Render("Compare with context: ") + m.contextInput.View() + "\n")
+		b.WriteString("  " + styles.LabelStyle.Render("Name there: ") + m.nameInput.View() + "\n\n")
+		b.WriteString("  " + styles.HelpStyle.Render("enter: compare • ↑/↓ in context: known contexts • tab: context/name • esc: cancel"))
+	default:
+		b.WriteString(lipgloss.NewStyle().PaddingLeft(2).Render(m.viewport.View()))
+		b.WriteString("\n")
+		b.WriteString("  " + styles.HelpStyle.Render("↑/↓/pgup/pgdn: scroll • e: compare with another context • r: reload • esc: back • q: quit"))
+	}
+	return b.String()
+}
+
+// SetContext sets the profile and region context for the compare screen
+func (m *ContextCompareModel) SetContext(profile, region string) {
+	m.currentProfile = profile
+	m.currentRegion = region
+}
+
+// SetSize updates the dimensions of the compare screen
+func (m *ContextCompareModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.viewport.Width = width - 4
+	m.viewport.Height = max(1, height-6)
+	m.nameInput.Width = min(60, width-30)
+	m.contextInput.Width = min(40, width-30)
+	if m.left != nil || m.right != nil {
+		m.viewport.SetContent(m.renderComparison())
+	}
+}
@@ -0,0 +1,82 @@
+package screens
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+func TestDiffJSONKeys(t *testing.T) {
+	changes, ok := diffJSONKeys(`{"db":{"host":"a","port":5432},"debug":true}`, `{"db":{"host":"b","port":5432},"cache":"on"}`)
+	if !ok {
+		t.Fatal("expected both values to be compared as JSON")
+	}
+	want := []jsonKeyChange{
+		{op: '~', key: "db.host", left: "a", right: "b"},
+		{op: '-', key: "debug", left: "true"},
+		{op: '+', key: "cache", right: "on"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %+v", len(want), changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("change %d = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+
+	if _, ok := diffJSONKeys(`{"a":1}`, "plain"); ok {
+		t.Error("expected a plain value not to be compared as JSON")
+	}
+}
+
+func TestContextCompare_TwoContexts(t *testing.T) {
+	t.Cleanup(aws.ResetDemo)
+	ctx := context.Background()
+	staging := aws.NewDemoClient("compare-staging", "eu-west-1")
+	prod := aws.NewDemoClient("compare-prod", "eu-west-1")
+	if err := staging.CreateParameter(ctx, "/app/config", `{"timeout":30,"host":"staging.internal"}`, "String", "", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := prod.CreateParameter(ctx, "/app/config", `{"timeout":30,"host":"prod.internal"}`, "String", "", "", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewContextCompare()
+	m.SetContext("compare-staging", "eu-west-1")
+	m.SetSize(120, 40)
+	m.SetPool([]string{"compare-staging eu-west-1", "compare-prod eu-west-1"})
+	m.Open(staging, "/app/config", func(profile, region string) (*aws.Client, error) {
+		return prod, nil
+	})
+	if got := m.contextInput.Value(); got != "compare-prod eu-west-1" {
+		t.Fatalf("expected the other context of the pool, got %q", got)
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatalf("expected both contexts to be read, got %v", m.err)
+	}
+	m, _ = m.Update(cmd())
+	if m.left == nil || m.right == nil {
+		t.Fatalf("expected the parameter from both contexts, got %+v / %+v", m.left, m.right)
+	}
+	out := m.renderComparison()
+	for _, want := range []string{"JSON keys (1 differ)", "host", "staging.internal", "prod.internal"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the comparison:\n%s", want, out)
+		}
+	}
+
+	// A name missing on the other side is reported, not an error
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m.nameInput.SetValue("/app/missing")
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.Update(cmd())
+	if m.err != nil || m.right != nil || !strings.Contains(m.renderComparison(), "does not exist") {
+		t.Fatalf("expected the other side to be missing, got %v", m.err)
+	}
+}
@@ -0,0 +1,199 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	cfg "github.com/ilia/ps9s/internal/config"
+	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/types"
+	"github.com/sahilm/fuzzy"
+)
+
+// maxSwitcherRows limits the matches shown in the context switcher
+const maxSwitcherRows = 10
+
+// ContextSwitcherModel is an overlay listing every profile×region combination
+// with fuzzy filtering
+type ContextSwitcherModel struct {
+	input    textinput.Model
+	contexts []cfg.RecentEntry
+	labels   []string      // "profile region" per context, matched against the input
+	matches  []fuzzy.Match // Filtered contexts, best match first
+	cursor   int
+	width    int
+	height   int
+}
+
+// NewContextSwitcher creates a new context switcher overlay
+func NewContextSwitcher() ContextSwitcherModel {
+	input := textinput.New()
+	input.Placeholder = "profile region"
+	input.Prompt = "> "
+	input.CharLimit = 100
+	input.Width = 40
+
+	return ContextSwitcherModel{input: input}
+}
+
+// Open fills the switcher with all profile×region combinations, recent ones
+// first, and clears the filter
+func (m *ContextSwitcherModel) Open(profiles []string, recents []cfg.RecentEntry) tea.Cmd {
+	seen := make(map[cfg.RecentEntry]bool)
+	m.contexts = nil
+	add := func(e cfg.RecentEntry) {
+		if !seen[e] {
+			seen[e] = true
+			m.contexts = append(m.contexts, e)
+		}
+	}
+
+	known := make(map[string]bool, len(profiles))
+	for _, p := range profiles {
+		known[p] = true
+	}
+	for _, e := range recents {
+		if known[e.Profile] {
+			add(e)
+		}
+	}
+	for _, p := range profiles {
+		for _, r := range defaultRegions {
+			add(cfg.RecentEntry{Profile: p, Region: r})
+		}
+	}
+
+	m.labels = make([]string, len(m.contexts))
+	for i, e := range m.contexts {
+		m.labels[i] = e.Profile + " " + e.Region
+	}
+
+	m.input.SetValue("")
+	m.input.Focus()
+	m.filter()
+	return textinput.Blink
+}
+
+// filter refreshes the matches for the current input
+func (m *ContextSwitcherModel) filter() {
+	m.cursor = 0
+	query := strings.TrimSpace(m.input.Value())
+	if query == "" {
+		m.matches = make([]fuzzy.Match, len(m.labels))
+		for i, l := range m.labels {
+			m.matches[i] = fuzzy.Match{Str: l, Index: i}
+		}
+		return
+	}
+	m.matches = fuzzy.Find(query, m.labels)
+}
+
+// Selected returns the context under the cursor
+func (m ContextSwitcherModel) Selected() (cfg.RecentEntry, bool) {
+	if m.cursor >= len(m.matches) {
+		return cfg.RecentEntry{}, false
+	}
+	return m.contexts[m.matches[m.cursor].Index], true
+}
+
+// Init initializes the context switcher
+func (m ContextSwitcherModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles messages for the context switcher
+func (m ContextSwitcherModel) Update(msg tea.Msg) (ContextSwitcherModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "ctrl+p":
+			return m, func() tea.Msg { return types.BackMsg{} }
+		case "enter":
+			if e, ok := m.Selected(); ok {
+				return m, func() tea.Msg {
+					return types.ProfileSelectedMsg{Profile: e.Profile, Region: e.Region}
+				}
+			}
+			return m, nil
+		case "up", "ctrl+k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "ctrl+j":
+			if m.cursor < len(m.matches)-1 {
+				m.cursor++
+			}
+			return m, nil
+		}
+
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		m.filter()
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// View renders the switcher as a box centered in the window
+func (m ContextSwitcherModel) View() string {
+	var b strings.Builder
+	b.WriteString(styles.TitleStyle.Render("Switch context"))
+	b.WriteString("\n")
+	b.WriteString(m.input.View())
+	b.WriteString("\n\n")
+
+	if len(m.matches) == 0 {
+		b.WriteString(styles.HelpStyle.UnsetMarginTop().Render("no matching profile/region"))
+		b.WriteString("\n")
+	}
+
+	start := 0
+	if m.cursor >= maxSwitcherRows {
+		start = m.cursor - maxSwitcherRows + 1
+	}
+	for i := start; i < len(m.matches) && i < start+maxSwitcherRows; i++ {
+		e := m.contexts[m.matches[i].Index]
+		line := fmt.Sprintf("%s : %s", e.Profile, e.Region)
+		if i == m.cursor {
+			b.WriteString(lipgloss.NewStyle().Foreground(styles.Primary).Bold(true).Render(styles.Cursor + " " + line))
+		} else {
+			b.WriteString("  " + line)
+		}
+		b.WriteString("\n")
+	}
+	if len(m.matches) > maxSwitcherRows {
+		b.WriteString(styles.HelpStyle.UnsetMarginTop().Render(fmt.Sprintf("%d of %d", m.cursor+1, len(m.matches))))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(styles.HelpStyle.Render("type to filter • ↑/↓: select • enter: open • esc: close"))
+
+	box := lipgloss.NewStyle().
+		Border(styles.Border).
+		BorderForeground(styles.Subtle).
+		Padding(1, 2).
+		Width(50).
+		Render(b.String())
+
+	if m.width == 0 || m.height == 0 {
+		return box
+	}
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// SetSize updates the dimensions of the context switcher
+func (m *ContextSwitcherModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
@@ -0,0 +1,60 @@
+package screens
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	cfg "github.com/ilia/ps9s/internal/config"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+func TestContextSwitcher_RecentsFirst(t *testing.T) {
+	m := NewContextSwitcher()
+	m.Open([]string{"dev", "prod"}, []cfg.RecentEntry{
+		{Profile: "prod", Region: "eu-west-1"},
+		{Profile: "gone", Region: "us-east-1"},
+	})
+
+	if want := 2 * len(defaultRegions); len(m.contexts) != want {
+		t.Fatalf("expected %d contexts, got %d", want, len(m.contexts))
+	}
+	if e, _ := m.Selected(); e.Profile != "prod" || e.Region != "eu-west-1" {
+		t.Fatalf("expected the recent context first, got %#v", e)
+	}
+}
+
+func TestContextSwitcher_FuzzyFilterAndSelect(t *testing.T) {
+	m := NewContextSwitcher()
+	m.Open([]string{"dev", "production"}, nil)
+
+	for _, r := range "prodeuc1" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	e, ok := m.Selected()
+	if !ok || e.Profile != "production" || e.Region != "eu-central-1" {
+		t.Fatalf("expected production/eu-central-1, got %#v (ok=%v)", e, ok)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatalf("expected cmd for enter")
+	}
+	msg, ok := cmd().(types.ProfileSelectedMsg)
+	if !ok || msg.Profile != "production" || msg.Region != "eu-central-1" {
+		t.Fatalf("expected ProfileSelectedMsg for the match, got %#v", cmd())
+	}
+}
+
+func TestContextSwitcher_NoMatches(t *testing.T) {
+	m := NewContextSwitcher()
+	m.Open([]string{"dev"}, nil)
+	for _, r := range "zzz" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if _, ok := m.Selected(); ok {
+		t.Fatalf("expected no selection")
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Fatalf("expected no cmd without a match")
+	}
+}
@@ -0,0 +1,288 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+// DeleteSubtreeModel deletes every parameter under a prefix once the prefix
+// has been typed to confirm
+type DeleteSubtreeModel struct {
+	client         *aws.Client
+	prefix         string
+	names          []string // Parameters to delete
+	shared         int      // Shared parameters under the prefix, which are left alone
+	confirmInput   textinput.Model
+	deleting       bool
+	done           bool
+	result         types.ParametersDeletedMsg
+	offset         int
+	spinner        spinner.Model
+	err            error
+	width          int
+	height         int
+	currentProfile string
+	currentRegion  string
+	// Deleting the marked parameters of the list rather than a subtree
+	marked bool
+}
+
+// NewDeleteSubtree creates the subtree delete screen
+func NewDeleteSubtree() DeleteSubtreeModel {
+	s := spinner.New()
+	s.Spinner = styles.Spinner
+	s.Style = lipgloss.NewStyle().Foreground(styles.Secondary)
+
+	ti := textinput.New()
+	ti.CharLimit = 1011
+	ti.Width = 60
+
+	return DeleteSubtreeModel{confirmInput: ti, spinner: s}
+}
+
+// Init initializes the subtree delete screen
+func (m DeleteSubtreeModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Open lists params, the parameters under prefix, for deletion
+func (m *DeleteSubtreeModel) Open(client *aws.Client, prefix string, params []*aws.Parameter) tea.Cmd {
+	m.client = client
+	m.prefix = prefix
+	m.marked = false
+	m.names = nil
+	m.shared = 0
+	for _, p := range params {
+		if aws.IsShared(p.Name) {
+			m.shared++
+			continue
+		}
+		m.names = append(m.names, p.Name)
+	}
+	m.deleting = false
+	m.done = false
+	m.result = types.ParametersDeletedMsg{}
+	m.offset = 0
+	m.err = nil
+	m.confirmInput.SetValue("")
+	m.confirmInput.Placeholder = prefix
+	return m.confirmInput.Focus()
+}
+
+// OpenMarked lists params, the marked parameters of the list, for deletion.
+// Their number is typed to confirm, as they may share no prefix.
+func (m *DeleteSubtreeModel) OpenMarked(client *aws.Client, params []*aws.Parameter) tea.Cmd {
+	cmd := m.Open(client, "", params)
+	m.marked = true
+	m.confirmInput.Placeholder = m.confirmation()
+	return cmd
+}
+
+// confirmation returns the text to type before deleting
+func (m DeleteSubtreeModel) confirmation() string {
+	if m.marked {
+		return strconv.Itoa(len(m.names))
+	}
+	return m.prefix
+}
+
+// scope describes what is deleted, for the title and messages
+func (m DeleteSubtreeModel) scope() string {
+	if m.marked {
+		return fmt.Sprintf("%d marked parameters", len(m.names))
+	}
+	return m.prefix
+}
+
+// Busy reports whether the parameters are being deleted, so the screen must
+// not be left
+func (m DeleteSubtreeModel) Busy() bool {
+	return m.deleting
+}
+
+// failures returns the parameters the deletion left, with why
+func (m DeleteSubtreeModel) failures() []string {
+	deleted := make(map[string]bool, len(m.result.Names))
+	for _, name := range m.result.Names {
+		deleted[name] = true
+	}
+	reason := "not found"
+	if m.result.Err != nil {
+		reason = "not deleted"
+	}
+	var failed []string
+	for _, name := range m.names {
+		if !deleted[name] {
+			failed = append(failed, name+": "+reason)
+		}
+	}
+	return failed
+}
+
+// Update handles messages for the subtree delete screen
+func (m DeleteSubtreeModel) Update(msg tea.Msg) (DeleteSubtreeModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case types.ParametersDeletedMsg:
+		m.result = msg
+		m.deleting = false
+		m.done = true
+		m.offset = 0
+		return m, nil
+
+	case types.ErrorMsg:
+		// Nothing was deleted (e.g. a dry run); confirm again to retry
+		m.deleting = false
+		m.err = msg.Err
+		return m, m.confirmInput.Focus()
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if m.deleting {
+			return m, nil
+		}
+		if m.done {
+			switch msg.String() {
+			case "enter", "q":
+				return m, func() tea.Msg { return types.BackMsg{} }
+			case "up":
+				m.offset = max(0, m.offset-1)
+			case "down":
+				m.offset = min(max(0, len(m.failures())-1), m.offset+1)
+			}
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "up":
+			m.offset = max(0, m.offset-1)
+			return m, nil
+		case "down":
+			m.offset = min(max(0, len(m.names)-1), m.offset+1)
+			return m, nil
+		case "enter":
+			if len(m.names) == 0 {
+				return m, nil
+			}
+			if strings.TrimSpace(m.confirmInput.Value()) != m.confirmation() {
+				m.err = fmt.Errorf("type %s to confirm", m.confirmation())
+				return m, nil
+			}
+			m.err = nil
+			m.deleting = true
+			m.confirmInput.Blur()
+			client, names := m.client, m.names
+			return m, tea.Batch(m.spinner.Tick, func() tea.Msg {
+				deleted, err := client.DeleteParameters(context.Background(), names)
+				return types.ParametersDeletedMsg{Names: deleted, Err: err}
+			})
+		}
+		var cmd tea.Cmd
+		m.confirmInput, cmd = m.confirmInput.Update(msg)
+		return m, cmd
+	}
+
+	if m.deleting {
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// View renders the subtree delete screen
+func (m DeleteSubtreeModel) View() string {
+	if m.deleting {
+		return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), progressText(fmt.Sprintf("Deleting %d parameters...", len(m.names))))
+	}
+
+	var b strings.Builder
+
+	profile := m.currentProfile
+	region := m.currentRegion
+	if profile == "" {
+		profile = "-"
+	}
+	if region == "" {
+		region = "-"
+	}
+	title := fmt.Sprintf("%s : %s : Delete %s", profile, region, m.scope())
+	b.WriteString("  " + styles.TitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	visible := max(1, m.height-14)
+	if m.done {
+		r := m.result
+		done := fmt.Sprintf("✓ Deleted %d of %d parameters under %s", len(r.Names), len(m.names), m.prefix)
+		if m.marked {
+			done = fmt.Sprintf("✓ Deleted %d of %d marked parameters", len(r.Names), len(m.names))
+		}
+		b.WriteString("  " + styles.SuccessStyle.Render(done) + "\n")
+		if r.Err != nil {
+			b.WriteString("  " + styles.ErrorStyle.Render(fmt.Sprintf("Error: %v", r.Err)) + "\n")
+		}
+		failed := m.failures()
+		for i := m.offset; i < min(len(failed), m.offset+visible); i++ {
+			b.WriteString("    " + styles.ErrorStyle.Render("✗ "+failed[i]) + "\n")
+		}
+		b.WriteString("\n  " + styles.HelpStyle.Render("↑/↓: scroll • enter/esc: back"))
+		return b.String()
+	}
+
+	if m.err != nil {
+		b.WriteString("  " + styles.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		b.WriteString("\n\n")
+	}
+
+	if len(m.names) == 0 {
+		nothing := "There is nothing under " + m.prefix + " that can be deleted"
+		if m.marked {
+			nothing = "None of the marked parameters can be deleted"
+		}
+		b.WriteString("  " + styles.InfoStyle.Render(nothing) + "\n\n")
+		b.WriteString("  " + styles.HelpStyle.Render("esc: back"))
+		return b.String()
+	}
+
+	b.WriteString("  " + styles.WarningStyle.Render(fmt.Sprintf("⚠ These %d parameters will be deleted with all their versions:", len(m.names))) + "\n\n")
+	for i := m.offset; i < min(len(m.names), m.offset+visible); i++ {
+		b.WriteString("    " + m.names[i] + "\n")
+	}
+	if more := len(m.names) - m.offset - visible; more > 0 {
+		b.WriteString("    " + styles.HelpStyle.UnsetMarginTop().Render(fmt.Sprintf("... and %d more", more)) + "\n")
+	}
+	if m.shared > 0 {
+		b.WriteString("\n  " + styles.InfoStyle.Render(fmt.Sprintf("%d shared parameters are left alone", m.shared)) + "\n")
+	}
+	label := "Type the prefix to confirm: "
+	if m.marked {
+		label = "Type the number of parameters to confirm: "
+	}
+	b.WriteString("\n  " + styles.LabelStyle.Render(label) + m.confirmInput.View() + "\n\n")
+	b.WriteString("  " + styles.HelpStyle.Render("enter: delete • ↑/↓: scroll • esc: cancel"))
+	return b.String()
+}
+
+// SetContext sets the profile and region context for the subtree delete screen
+func (m *DeleteSubtreeModel) SetContext(profile, region string) {
+	m.currentProfile = profile
+	m.currentRegion = region
+}
+
+// SetSize updates the dimensions of the subtree delete screen
+func (m *DeleteSubtreeModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.confirmInput.Width = min(60, width-40)
+}
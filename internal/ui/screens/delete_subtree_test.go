@@ -0,0 +1,81 @@
+package screens
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+func TestDeleteSubtree_RequiresTypedPrefix(t *testing.T) {
+	t.Cleanup(aws.ResetDemo)
+	ctx := context.Background()
+	client := aws.NewDemoClient("delete-test", "eu-west-1")
+	for _, name := range []string{"/old/a", "/old/db/b", "/keep/c"} {
+		if err := client.CreateParameter(ctx, name, "v", "String", "", "", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	params := []*aws.Parameter{{Name: "/old/a"}, {Name: "/old/db/b"}, {Name: "/old/gone"}}
+
+	m := NewDeleteSubtree()
+	m.Open(client, "/old/", params)
+	m.confirmInput.SetValue("/old")
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || m.err == nil || m.Busy() {
+		t.Fatal("expected a mistyped prefix to be refused")
+	}
+
+	m.confirmInput.SetValue("/old/")
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || !m.Busy() {
+		t.Fatal("expected the typed prefix to start the deletion")
+	}
+	var deleted types.ParametersDeletedMsg
+	for _, msg := range cmd().(tea.BatchMsg) {
+		if d, ok := msg().(types.ParametersDeletedMsg); ok {
+			deleted = d
+		}
+	}
+	if deleted.Err != nil || len(deleted.Names) != 2 {
+		t.Fatalf("unexpected deletion %+v", deleted)
+	}
+
+	m, _ = m.Update(deleted)
+	if failed := m.failures(); len(failed) != 1 || failed[0] != "/old/gone: not found" {
+		t.Fatalf("expected the missing parameter to be reported, got %v", failed)
+	}
+	if _, err := client.GetParameter(ctx, "/keep/c"); err != nil {
+		t.Errorf("expected parameters outside the prefix to be kept: %v", err)
+	}
+}
+
+func TestDeleteSubtree_MarkedRequiresTypedCount(t *testing.T) {
+	ctx := context.Background()
+	client := aws.NewDemoClient("delete-marked-test", "eu-west-1")
+	for _, name := range []string{"/a/x", "/b/y"} {
+		if err := client.CreateParameter(ctx, name, "v", "String", "", "", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := NewDeleteSubtree()
+	m.OpenMarked(client, []*aws.Parameter{{Name: "/a/x"}, {Name: "/b/y"}})
+	m.confirmInput.SetValue("/")
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || m.err == nil {
+		t.Fatal("expected anything but the count to be refused")
+	}
+
+	m.confirmInput.SetValue("2")
+	if _, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil {
+		t.Fatal("expected the typed count to start the deletion")
+	}
+	for _, msg := range cmd().(tea.BatchMsg) {
+		if d, ok := msg().(types.ParametersDeletedMsg); ok && len(d.Names) != 2 {
+			t.Fatalf("expected both marked parameters deleted, got %+v", d)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package screens
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/styles"
+)
+
+// diffLine is a single line of a line-based diff
+type diffLine struct {
+	op   byte // ' ' unchanged, '-' removed, '+' added
+	text string
+}
+
+// diffLines computes a minimal line diff between old and new using LCS
+func diffLines(old, new string) []diffLine {
+	a := strings.Split(old, "\n")
+	b := strings.Split(new, "\n")
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out []diffLine
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, diffLine{op: ' ', text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, diffLine{op: '-', text: a[i]})
+			i++
+		default:
+			out = append(out, diffLine{op: '+', text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, diffLine{op: '-', text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		out = append(out, diffLine{op: '+', text: b[j]})
+	}
+
+	return out
+}
+
+// renderDiff renders diff lines with red removals and green additions
+func renderDiff(lines []diffLine) string {
+	removed := lipgloss.NewStyle().Foreground(styles.Error)
+	added := lipgloss.NewStyle().Foreground(styles.Success)
+
+	rendered := make([]string, len(lines))
+	for i, l := range lines {
+		text := string(l.op) + " " + l.text
+		switch l.op {
+		case '-':
+			text = removed.Render(text)
+		case '+':
+			text = added.Render(text)
+		}
+		rendered[i] = text
+	}
+	return strings.Join(rendered, "\n")
+}
@@ -0,0 +1,35 @@
+package screens
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	got := diffLines("a\nb\nc", "a\nx\nc\nd")
+	want := []diffLine{
+		{op: ' ', text: "a"},
+		{op: '-', text: "b"},
+		{op: '+', text: "x"},
+		{op: ' ', text: "c"},
+		{op: '+', text: "d"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("diffLines() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSideBySideLines_AlignsChangedRuns(t *testing.T) {
+	left, right := sideBySideLines(diffLines("a\nb\nc", "a\nx\ny\nc"))
+	if len(left) != len(right) {
+		t.Fatalf("panes must have equal row counts, got %d and %d", len(left), len(right))
+	}
+
+	wantLeft := []string{"a", "b", "", "c"}
+	wantRight := []string{"a", "x", "y", "c"}
+	for i := range wantLeft {
+		if left[i].text != wantLeft[i] || right[i].text != wantRight[i] {
+			t.Fatalf("row %d: got %q | %q, want %q | %q", i, left[i].text, right[i].text, wantLeft[i], wantRight[i])
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/gitmirror"
+	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+// DriftModel compares live parameters with a snapshot directory in the git
+// mirror layout and lists the added, changed and removed ones
+type DriftModel struct {
+	client         *aws.Client
+	params         []*aws.Parameter
+	dirInput       textinput.Model
+	editing        bool // Typing the snapshot directory
+	dir            string
+	changes        []gitmirror.Change
+	cursor         int
+	spinner        spinner.Model
+	loading        bool
+	loadSeq        int
+	err            error
+	width          int
+	height         int
+	currentProfile string
+	currentRegion  string
+	cancelLoad     context.CancelFunc
+}
+
+// NewDrift creates the drift screen
+func NewDrift() DriftModel {
+	s := spinner.New()
+	s.Spinner = styles.Spinner
+	s.Style = lipgloss.NewStyle().Foreground(styles.Secondary)
+
+	ti := textinput.New()
+	ti.Placeholder = "snapshot directory, e.g. ~/ps9s-mirror/PROFILE/REGION"
+	ti.Width = 60
+
+	return DriftModel{dirInput: ti, spinner: s}
+}
+
+// Init initializes the drift screen
+func (m DriftModel) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+// Open compares params with the snapshot in dir, or asks for the directory
+// when dir is empty
+func (m *DriftModel) Open(client *aws.Client, params []*aws.Parameter, dir string) tea.Cmd {
+	m.client = client
+	m.params = params
+	m.changes = nil
+	m.err = nil
+	if dir == "" {
+		dir = m.dir
+	}
+	m.dirInput.SetValue(dir)
+	if dir == "" {
+		m.editing = true
+		return m.dirInput.Focus()
+	}
+	m.dir = dir
+	return m.run()
+}
+
+// run (re)compares the live parameters with the snapshot
+func (m *DriftModel) run() tea.Cmd {
+	if m.cancelLoad != nil {
+		m.cancelLoad()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelLoad = cancel
+	m.loadSeq++
+	m.loading = true
+	m.editing = false
+	m.dirInput.Blur()
+	m.err = nil
+	m.cursor = 0
+
+	seq, client, dir, params := m.loadSeq, m.client, m.dir, m.params
+	return tea.Batch(m.spinner.Tick, func() tea.Msg {
+		changes, err := driftChanges(ctx, client, dir, params)
+		if err != nil {
+			return types.ErrorMsg{Err: err}
+		}
+		return types.DriftLoadedMsg{Seq: seq, Changes: changes}
+	})
+}
+
+// driftChanges reads the snapshot in dir and compares params with it. Values
+// of SecureStrings are not fetched, since snapshots only hold their version.
+func driftChanges(ctx context.Context, client *aws.Client, dir string, params []*aws.Parameter) ([]gitmirror.Change, error) {
+	snapshot, err := gitmirror.ReadSnapshot(gitmirror.New(dir).Dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, p := range params {
+		if p.Type != "SecureString" && !aws.IsShared(p.Name) && snapshot.Covers(p.Name) {
+			names = append(names, p.Name)
+		}
+	}
+	values, err := client.GetParameterValues(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	live := make([]*aws.Parameter, len(params))
+	for i, p := range params {
+		cp := *p
+		cp.Value = values[p.Name]
+		live[i] = &cp
+	}
+	return gitmirror.Drift(snapshot, live), nil
+}
+
+// Update handles messages for the drift screen
+func (m DriftModel) Update(msg tea.Msg) (DriftModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case types.DriftLoadedMsg:
+		if msg.Seq != m.loadSeq {
+			return m, nil
+		}
+		m.loading = false
+		m.changes = msg.Changes
+		return m, nil
+
+	case types.ErrorMsg:
+		m.loading = false
+		m.err = msg.Err
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			if m.cancelLoad != nil {
+				m.cancelLoad()
+			}
+			return m, func() tea.Msg { return types.BackMsg{} }
+		case "ctrl+c":
+			return m, tea.Quit
+		}
+		if m.editing {
+			if msg.String() == "enter" {
+				if dir := strings.TrimSpace(m.dirInput.Value()); dir != "" {
+					m.dir = dir
+					return m, m.run()
+				}
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.dirInput, cmd = m.dirInput.Update(msg)
+			return m, cmd
+		}
+		if m.loading {
+			return m, nil
+		}
+		switch msg.String() {
+		case "q":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.changes)-1 {
+				m.cursor++
+			}
+		case "enter":
+			if m.cursor < len(m.changes) && m.changes[m.cursor].Kind != gitmirror.Removed {
+				name := m.changes[m.cursor].Name
+				for _, p := range m.params {
+					if p.Name == name {
+						return m, func() tea.Msg { return types.ViewParameterMsg{Parameter: p} }
+					}
+				}
+			}
+		case "e":
+			m.editing = true
+			return m, m.dirInput.Focus()
+		case "R":
+			return m, m.run()
+		}
+		return m, nil
+	}
+
+	if m.loading {
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// driftLine is the first line of a value, shortened to width
+func driftLine(value string, width int) string {
+	line, _, more := strings.Cut(value, "\n")
+	if r := []rune(line); len(r) > width {
+		return string(r[:max(0, width-1)]) + "…"
+	}
+	if more {
+		return line + " …"
+	}
+	return line
+}
+
+// View renders the drift screen
+func (m DriftModel) View() string {
+	if m.loading {
+		return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), progressText("Comparing with snapshot..."))
+	}
+
+	var b strings.Builder
+
+	profile := m.currentProfile
+	region := m.currentRegion
+	if profile == "" {
+		profile = "-"
+	}
+	if region == "" {
+		region = "-"
+	}
+	title := fmt.Sprintf("%s : %s : Drift", profile, region)
+	b.WriteString("  " + styles.TitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	if m.editing {
+		b.WriteString("  " + styles.LabelStyle.Render("Snapshot: ") + m.dirInput.View() + "\n")
+		b.WriteString("  " + styles.HelpStyle.Render("enter: compare • esc: back"))
+		return b.String()
+	}
+
+	b.WriteString("  " + styles.LabelStyle.Render("Snapshot: ") + m.dir + "\n\n")
+
+	if m.err != nil {
+		b.WriteString("  " + styles.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)) + "\n")
+		b.WriteString("  " + styles.HelpStyle.Render("e: change directory • R: retry • esc: back"))
+		return b.String()
+	}
+
+	if len(m.changes) == 0 {
+		b.WriteString("  " + styles.SuccessStyle.Render("✓ No drift from the snapshot") + "\n")
+		b.WriteString("  " + styles.HelpStyle.Render("e: change directory • R: compare again • esc: back • q: quit"))
+		return b.String()
+	}
+
+	counts := map[gitmirror.ChangeKind]int{}
+	for _, c := range m.changes {
+		counts[c.Kind]++
+	}
+	summary := fmt.Sprintf("%d added, %d changed, %d removed since the snapshot",
+		counts[gitmirror.Added], counts[gitmirror.Changed], counts[gitmirror.Removed])
+	b.WriteString("  " + styles.LabelStyle.Render(summary) + "\n\n")
+
+	visible := max(1, m.height-12)
+	start := max(0, m.cursor-visible+1)
+	for i := start; i < min(len(m.changes), start+visible); i++ {
+		c := m.changes[i]
+		var line string
+		switch c.Kind {
+		case gitmirror.Added:
+			line = styles.SuccessStyle.Render("+ " + c.Name)
+		case gitmirror.Changed:
+			line = styles.WarningStyle.Render("~ " + c.Name)
+		case gitmirror.Removed:
+			line = styles.ErrorStyle.Render("- " + c.Name)
+		}
+		if i == m.cursor {
+			b.WriteString("  " + lipgloss.NewStyle().Foreground(styles.Primary).Bold(true).Render(styles.Cursor+" ") + line + "\n")
+		} else {
+			b.WriteString("    " + line + "\n")
+		}
+	}
+
+	if m.cursor < len(m.changes) {
+		c := m.changes[m.cursor]
+		width := max(20, m.width-16)
+		b.WriteString("\n")
+		if c.Kind != gitmirror.Added {
+			b.WriteString("  " + styles.LabelStyle.Render("Snapshot: ") + driftLine(c.Snapshot, width) + "\n")
+		}
+		if c.Kind != gitmirror.Removed {
+			b.WriteString("  " + styles.LabelStyle.Render("Live:     ") + driftLine(c.Live, width) + "\n")
+		}
+	}
+
+	b.WriteString("  " + styles.HelpStyle.Render("↑/↓: select • enter: view • e: change directory • R: compare again • esc: back • q: quit"))
+	return b.String()
+}
+
+// SetContext sets the profile and region context for the drift screen
+func (m *DriftModel) SetContext(profile, region string) {
+	m.currentProfile = profile
+	m.currentRegion = region
+}
+
+// SetSize updates the dimensions of the drift screen
+func (m *DriftModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.dirInput.Width = min(60, width-20)
+}
@@ -0,0 +1,186 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+// DryRunModel shows a write request that was skipped because dry-run mode is on
+type DryRunModel struct {
+	request        aws.WriteRequest
+	previous       *aws.Parameter
+	viewport       viewport.Model
+	currentProfile string
+	currentRegion  string
+}
+
+// NewDryRun creates a new dry-run preview screen
+func NewDryRun() DryRunModel {
+	vp := viewport.New(80, 20)
+	vp.Style = lipgloss.NewStyle().Padding(1, 2)
+
+	return DryRunModel{
+		viewport: vp,
+	}
+}
+
+// Init initializes the dry-run preview
+func (m DryRunModel) Init() tea.Cmd {
+	return nil
+}
+
+// Load sets the skipped request to preview. previous is the parameter as it
+// currently exists in AWS, or nil if unknown.
+func (m *DryRunModel) Load(req aws.WriteRequest, previous *aws.Parameter) {
+	m.request = req
+	m.previous = previous
+	m.viewport.SetContent(m.formatRequest())
+	m.viewport.GotoTop()
+}
+
+// Update handles messages for the dry-run preview
+func (m DryRunModel) Update(msg tea.Msg) (DryRunModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "enter":
+			return m, func() tea.Msg { return types.BackMsg{} }
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// View renders the dry-run preview
+func (m DryRunModel) View() string {
+	var b strings.Builder
+
+	profile := m.currentProfile
+	region := m.currentRegion
+	if profile == "" {
+		profile = "-"
+	}
+	if region == "" {
+		region = "-"
+	}
+	title := fmt.Sprintf("%s : %s : Dry run — %s not sent", profile, region, m.request.Operation)
+	b.WriteString("  " + styles.TitleStyle.Render(title))
+	b.WriteString("\n\n")
+	b.WriteString(m.viewport.View())
+	b.WriteString("\n\n")
+	b.WriteString("  " + styles.HelpStyle.Render("↑/↓: scroll • esc: back"))
+
+	return b.String()
+}
+
+// formatRequest renders the request fields and a diff against the previous value
+func (m DryRunModel) formatRequest() string {
+	var b strings.Builder
+	req := m.request
+
+	field := func(label, value string) {
+		b.WriteString(styles.LabelStyle.Render(label + ": "))
+		b.WriteString(value)
+		b.WriteString("\n")
+	}
+
+	// Batch requests name their parameters and carry nothing else
+	if len(req.Names) > 0 {
+		field("Parameters", fmt.Sprintf("%d", len(req.Names)))
+		b.WriteString("\n")
+		for _, name := range req.Names {
+			b.WriteString(styles.ErrorStyle.Render("- "+name) + "\n")
+		}
+		return b.String()
+	}
+
+	field("Name", req.Name)
+
+	// Tag requests carry no value, only the tags being set or removed
+	if req.Operation == "AddTagsToResource" || req.Operation == "RemoveTagsFromResource" {
+		b.WriteString("\n")
+		b.WriteString(styles.LabelStyle.Render("Tags:"))
+		b.WriteString("\n\n")
+		for _, t := range req.Tags {
+			if req.Operation == "RemoveTagsFromResource" {
+				b.WriteString(styles.ErrorStyle.Render("- "+t.Key) + "\n")
+			} else {
+				b.WriteString(styles.SuccessStyle.Render(fmt.Sprintf("+ %s = %s", t.Key, t.Value)) + "\n")
+			}
+		}
+		return b.String()
+	}
+
+	typ := req.Type
+	if m.previous != nil && m.previous.Type != "" && m.previous.Type != req.Type {
+		typ = fmt.Sprintf("%s → %s", m.previous.Type, req.Type)
+	}
+	field("Type", typ)
+
+	tier := req.Tier
+	if tier == "" {
+		// SSM applies the account's default tier to requests without one
+		tier = "(not sent, the account default tier applies)"
+		if m.previous != nil && m.previous.Tier != "" {
+			tier = fmt.Sprintf("(not sent, the account default tier applies; currently %s)", m.previous.Tier)
+		}
+	}
+	field("Tier", tier)
+
+	if req.KeyID != "" || req.Type == "SecureString" {
+		keyID := req.KeyID
+		if keyID == "" {
+			keyID = "(default alias/aws/ssm)"
+		}
+		field("KMS key", keyID)
+	}
+	field("Overwrite", fmt.Sprintf("%t", req.Overwrite))
+	if req.Description != "" {
+		field("Description", req.Description)
+	}
+	if len(req.Tags) > 0 {
+		tags := make([]string, len(req.Tags))
+		for i, t := range req.Tags {
+			tags[i] = t.Key + "=" + t.Value
+		}
+		field("Tags", strings.Join(tags, ", "))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.LabelStyle.Render("Value:"))
+	b.WriteString("\n\n")
+	if m.previous != nil {
+		b.WriteString(renderDiff(diffLines(m.previous.Value, req.Value)))
+	} else {
+		b.WriteString(req.Value)
+	}
+
+	return b.String()
+}
+
+// SetContext sets the profile and region context for the preview
+func (m *DryRunModel) SetContext(profile, region string) {
+	m.currentProfile = profile
+	m.currentRegion = region
+}
+
+// SetSize updates the dimensions of the dry-run preview
+func (m *DryRunModel) SetSize(width, height int) {
+	m.viewport.Width = width - 4
+	m.viewport.Height = height - 6
+}
@@ -0,0 +1,23 @@
+package screens
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+func TestDryRun_TierNotSent(t *testing.T) {
+	m := NewDryRun()
+	req := aws.WriteRequest{Operation: "PutParameter", Name: "/app/key", Value: "v", Type: "String", Overwrite: true}
+	m.Load(req, &aws.Parameter{Name: "/app/key", Value: "old", Type: "String", Tier: "Advanced"})
+	if got := m.formatRequest(); !strings.Contains(got, "not sent, the account default tier applies; currently Advanced") {
+		t.Fatalf("expected the preview to say no tier is sent, got:\n%s", got)
+	}
+
+	req.Tier = "Standard"
+	m.Load(req, nil)
+	if got := m.formatRequest(); !strings.Contains(got, "Standard") || strings.Contains(got, "not sent") {
+		t.Fatalf("expected the sent tier, got:\n%s", got)
+	}
+}
@@ -0,0 +1,56 @@
+package screens
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ilia/ps9s/internal/config"
+)
+
+// envStyle is how JSON keys become environment variable names
+type envStyle struct {
+	separator string // Between nesting levels, e.g. "__"
+	casing    string // config.EnvCaseUpper, EnvCaseLower or EnvCasePreserve
+}
+
+// name joins the path of a JSON leaf into a variable name
+func (s envStyle) name(path []string) string {
+	sep := s.separator
+	if sep == "" {
+		sep = config.DefaultEnvSeparator
+	}
+	name := strings.Join(path, sep)
+	switch s.casing {
+	case config.EnvCaseLower:
+		return strings.ToLower(name)
+	case config.EnvCasePreserve:
+		return name
+	}
+	return strings.ToUpper(name)
+}
+
+// flattenJSONForEnv returns the variable names of the leaves of data, in the
+// order flattenJSONForView lists them. Array elements are nested under their
+// index, as configuration loaders bind them (SERVERS__0__HOST).
+func flattenJSONForEnv(data interface{}, path []string, style envStyle) []string {
+	var names []string
+	switch v := data.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			names = append(names, flattenJSONForEnv(v[key], append(path[:len(path):len(path)], key), style)...)
+		}
+	case []interface{}:
+		for i, value := range v {
+			names = append(names, flattenJSONForEnv(value, append(path[:len(path):len(path)], strconv.Itoa(i)), style)...)
+		}
+	default:
+		names = append(names, style.name(path))
+	}
+	return names
+}
@@ -0,0 +1,374 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/export"
+	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+// defaultExportName is the file name exports start with, before the format extension
+const defaultExportName = "parameters"
+
+// ExportModel represents the screen that writes parameters to a file
+type ExportModel struct {
+	client         *aws.Client
+	params         []*aws.Parameter
+	format         int             // Index into export.Formats
+	pathInput      textinput.Model // Destination file
+	includeSecrets bool            // Export decrypted SecureString values instead of masking them
+	omitValues     bool            // Leave values out of formats where they are optional
+	digests        bool            // Add value digests to formats that support them
+	sops           export.SOPSRecipients
+	spinner        spinner.Model
+	exporting      bool
+	done           string // Result shown after a successful export
+	err            error
+	currentProfile string
+	currentRegion  string
+}
+
+// NewExport creates a new export screen
+func NewExport() ExportModel {
+	pathInput := textinput.New()
+	pathInput.CharLimit = 1024
+	pathInput.Width = 60
+
+	s := spinner.New()
+	s.Spinner = styles.Spinner
+	s.Style = lipgloss.NewStyle().Foreground(styles.Secondary)
+
+	return ExportModel{
+		pathInput: pathInput,
+		spinner:   s,
+	}
+}
+
+// Init initializes the export screen
+func (m ExportModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Load prepares an export of params read with client
+func (m *ExportModel) Load(client *aws.Client, params []*aws.Parameter) tea.Cmd {
+	m.client = client
+	m.params = params
+	m.includeSecrets = false
+	m.omitValues = false
+	m.digests = false
+	m.exporting = false
+	m.done = ""
+	m.err = nil
+	m.pathInput.SetValue(defaultExportName + m.Format().Extension())
+	m.pathInput.CursorEnd()
+	m.pathInput.Focus()
+	return textinput.Blink
+}
+
+// SetFormat preselects format f; call before Load so the file name matches
+func (m *ExportModel) SetFormat(f export.Format) {
+	for i, format := range export.Formats {
+		if format == f {
+			m.format = i
+		}
+	}
+}
+
+// SetSOPS sets the recipients SOPS exports are encrypted for
+func (m *ExportModel) SetSOPS(recipients export.SOPSRecipients) {
+	m.sops = recipients
+}
+
+// Format returns the selected export format
+func (m ExportModel) Format() export.Format {
+	return export.Formats[m.format]
+}
+
+// valuesOmitted reports whether the export leaves values out
+func (m ExportModel) valuesOmitted() bool {
+	return m.omitValues && m.Format().HasOptionalValues()
+}
+
+// secretsIncluded reports whether SecureStrings are exported decrypted, which
+// encrypted formats always do
+func (m ExportModel) secretsIncluded() bool {
+	return m.includeSecrets || m.Format().Encrypted()
+}
+
+// secureCount returns how many of the exported parameters are SecureStrings
+func (m ExportModel) secureCount() int {
+	n := 0
+	for _, p := range m.params {
+		if p.Type == "SecureString" {
+			n++
+		}
+	}
+	return n
+}
+
+// Update handles messages for the export screen
+func (m ExportModel) Update(msg tea.Msg) (ExportModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case types.ErrorMsg:
+		m.exporting = false
+		m.err = msg.Err
+		return m, nil
+
+	case types.ExportDoneMsg:
+		m.exporting = false
+		m.done = fmt.Sprintf("Exported %d parameters to %s", msg.Count, msg.Path)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.exporting {
+			return m, nil
+		}
+		if m.done != "" {
+			if msg.String() == "enter" {
+				return m, func() tea.Msg { return types.BackMsg{} }
+			}
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg { return types.BackMsg{} }
+		case "ctrl+c":
+			return m, tea.Quit
+		case "tab":
+			// Cycle formats, following the extension unless the path was edited
+			wasDefault := m.pathInput.Value() == defaultExportName+m.Format().Extension()
+			m.format = (m.format + 1) % len(export.Formats)
+			if wasDefault {
+				m.pathInput.SetValue(defaultExportName + m.Format().Extension())
+				m.pathInput.CursorEnd()
+			}
+			return m, nil
+		case "ctrl+r":
+			if m.secureCount() > 0 && !m.valuesOmitted() && !m.Format().Encrypted() {
+				m.includeSecrets = !m.includeSecrets
+			}
+			return m, nil
+		case "ctrl+e":
+			if m.Format().HasOptionalValues() {
+				m.omitValues = !m.omitValues
+			}
+			return m, nil
+		case "ctrl+d":
+			if m.Format().HasDigests() {
+				m.digests = !m.digests
+			}
+			return m, nil
+		case "enter", "ctrl+s":
+			if strings.TrimSpace(m.pathInput.Value()) == "" {
+				m.err = fmt.Errorf("file name is required")
+				return m, nil
+			}
+			return m, m.export()
+		}
+
+		var cmd tea.Cmd
+		m.pathInput, cmd = m.pathInput.Update(msg)
+		return m, cmd
+	}
+
+	if m.exporting {
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+// export fetches current values and writes the file
+func (m *ExportModel) export() tea.Cmd {
+	m.exporting = true
+	m.err = nil
+
+	names := make([]string, len(m.params))
+	for i, p := range m.params {
+		names[i] = p.Name
+	}
+	client := m.client
+	path := strings.TrimSpace(m.pathInput.Value())
+	format := m.Format()
+	opts := export.Options{
+		MaskSecure: !m.secretsIncluded(),
+		OmitValues: m.valuesOmitted(),
+		Digests:    m.digests && m.Format().HasDigests(),
+		SOPS:       m.sops,
+	}
+	listed := m.params
+
+	return tea.Batch(
+		m.spinner.Tick,
+		func() tea.Msg {
+			params := listed
+			if !opts.OmitValues || opts.Digests {
+				// Masked SecureStrings are only decrypted to digest them
+				fetched, err := client.GetParameters(context.Background(), names, !opts.MaskSecure || opts.Digests)
+				if err != nil {
+					return types.ErrorMsg{Err: err}
+				}
+				params = withListedMetadata(fetched, listed)
+			}
+			if err := export.WriteFile(path, format, params, opts); err != nil {
+				return types.ErrorMsg{Err: err}
+			}
+			return types.ExportDoneMsg{Path: path, Count: len(params)}
+		},
+	)
+}
+
+// withListedMetadata copies describe-only metadata, which GetParameters does
+// not return, from the listed parameters onto the fetched ones
+func withListedMetadata(fetched, listed []*aws.Parameter) []*aws.Parameter {
+	byName := make(map[string]*aws.Parameter, len(listed))
+	for _, p := range listed {
+		byName[p.Name] = p
+	}
+	for _, p := range fetched {
+		if l, ok := byName[p.Name]; ok {
+			p.LastModifiedUser = l.LastModifiedUser
+			p.KeyID = l.KeyID
+			p.Tier = l.Tier
+			p.Description = l.Description
+		}
+	}
+	return fetched
+}
+
+// View renders the export screen
+func (m ExportModel) View() string {
+	if m.exporting {
+		return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), progressText(fmt.Sprintf("Exporting %d parameters...", len(m.params))))
+	}
+
+	var b strings.Builder
+
+	profile := m.currentProfile
+	region := m.currentRegion
+	if profile == "" {
+		profile = "-"
+	}
+	if region == "" {
+		region = "-"
+	}
+	title := fmt.Sprintf("%s : %s : Export %d parameters", profile, region, len(m.params))
+	b.WriteString("  " + styles.TitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	if m.done != "" {
+		b.WriteString("  " + styles.SuccessStyle.Render("✓ "+m.done))
+		b.WriteString("\n\n")
+		b.WriteString("  " + styles.HelpStyle.Render("enter/esc: back"))
+		return b.String()
+	}
+
+	if m.err != nil {
+		b.WriteString("  " + styles.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		b.WriteString("\n\n")
+	}
+
+	// Format choice, highlighting the selected one
+	b.WriteString("  " + styles.LabelStyle.Render("Format: "))
+	for i, f := range export.Formats {
+		if i == m.format {
+			b.WriteString(lipgloss.NewStyle().Foreground(styles.Primary).Bold(true).Render("[" + string(f) + "]"))
+		} else {
+			b.WriteString(" " + string(f) + " ")
+		}
+		b.WriteString(" ")
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString("  " + styles.LabelStyle.Render("File:"))
+	b.WriteString("\n\n")
+	b.WriteString("  " + m.pathInput.View())
+	b.WriteString("\n\n")
+
+	if m.Format().HasOptionalValues() {
+		b.WriteString("  " + styles.LabelStyle.Render("Values: "))
+		if m.omitValues {
+			b.WriteString("omitted (metadata only)")
+		} else {
+			b.WriteString("included")
+		}
+		b.WriteString("\n\n")
+	}
+
+	if m.Format().HasDigests() {
+		b.WriteString("  " + styles.LabelStyle.Render("Digests: "))
+		if m.digests {
+			b.WriteString("included (short SHA-256 of each value")
+			if m.secureCount() > 0 && !m.secretsIncluded() {
+				b.WriteString(", SecureStrings are decrypted to compute them")
+			}
+			b.WriteString(")")
+		} else {
+			b.WriteString("omitted")
+		}
+		b.WriteString("\n\n")
+	}
+
+	if m.Format().Encrypted() {
+		b.WriteString("  " + styles.InfoStyle.Render("Values are encrypted with sops before they are written"))
+		b.WriteString("\n\n")
+	}
+
+	if n := m.secureCount(); n > 0 && !m.valuesOmitted() {
+		if m.Format().Encrypted() {
+			b.WriteString("  " + styles.InfoStyle.Render(fmt.Sprintf("%d SecureString values will be included decrypted inside the encrypted file", n)))
+		} else if m.includeSecrets {
+			b.WriteString("  " + styles.WarningStyle.Render(fmt.Sprintf("⚠ %d SecureString values will be written decrypted", n)))
+		} else {
+			b.WriteString("  " + styles.InfoStyle.Render(fmt.Sprintf("%d SecureString values will be masked as %s", n, export.MaskedValue)))
+		}
+		b.WriteString("\n\n")
+	}
+
+	// Names being exported, capped to keep the form on screen
+	const maxShown = 10
+	for i, p := range m.params {
+		if i == maxShown {
+			b.WriteString("    " + styles.HelpStyle.UnsetMarginTop().Render(fmt.Sprintf("… and %d more", len(m.params)-maxShown)) + "\n")
+			break
+		}
+		b.WriteString("    " + typeBadge(p.Type) + " " + p.Name + "\n")
+	}
+
+	help := "tab: format"
+	if m.Format().HasOptionalValues() {
+		help += " • ctrl+e: include/omit values"
+	}
+	if m.Format().HasDigests() {
+		help += " • ctrl+d: include/omit digests"
+	}
+	if m.secureCount() > 0 && !m.valuesOmitted() && !m.Format().Encrypted() {
+		help += " • ctrl+r: include/mask secrets"
+	}
+	help += " • enter: export • esc: cancel"
+	b.WriteString("  " + styles.HelpStyle.Render(help))
+
+	return b.String()
+}
+
+// SetContext sets the profile and region context for the export screen
+func (m *ExportModel) SetContext(profile, region string) {
+	m.currentProfile = profile
+	m.currentRegion = region
+}
+
+// SetSize updates the dimensions of the export screen
+func (m *ExportModel) SetSize(width, height int) {
+	m.pathInput.Width = width - 10
+}
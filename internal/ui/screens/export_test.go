@@ -0,0 +1,76 @@
+package screens
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/export"
+)
+
+func TestExport_FormatCycleFollowsExtension(t *testing.T) {
+	m := NewExport()
+	m.Load(nil, []*aws.Parameter{{Name: "/app/a", Type: "String"}})
+
+	if got := m.pathInput.Value(); got != "parameters.env" {
+		t.Fatalf("expected default dotenv file, got %q", got)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.Format() != export.FormatJSON || m.pathInput.Value() != "parameters.json" {
+		t.Fatalf("expected json with matching extension, got %s %q", m.Format(), m.pathInput.Value())
+	}
+
+	// An edited path is kept when the format changes
+	m.pathInput.SetValue("out.txt")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.Format() != export.FormatTerraform || m.pathInput.Value() != "out.txt" {
+		t.Fatalf("expected edited path to be kept, got %s %q", m.Format(), m.pathInput.Value())
+	}
+}
+
+func TestExport_SecretsMaskedByDefault(t *testing.T) {
+	m := NewExport()
+	m.Load(nil, []*aws.Parameter{{Name: "/app/a", Type: "String"}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if m.includeSecrets {
+		t.Fatalf("expected ctrl+r to do nothing without SecureStrings")
+	}
+
+	m.Load(nil, []*aws.Parameter{{Name: "/app/secret", Type: "SecureString"}})
+	if m.includeSecrets {
+		t.Fatalf("expected SecureStrings to be masked by default")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if !m.includeSecrets {
+		t.Fatalf("expected ctrl+r to include SecureString values")
+	}
+}
+
+func TestExport_CSVValuesOptional(t *testing.T) {
+	m := NewExport()
+	m.Load(nil, []*aws.Parameter{{Name: "/app/a", Type: "String"}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlE})
+	if m.omitValues {
+		t.Fatalf("expected ctrl+e to do nothing for dotenv")
+	}
+
+	for m.Format() != export.FormatCSV {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	}
+	if m.pathInput.Value() != "parameters.csv" {
+		t.Fatalf("expected csv extension, got %q", m.pathInput.Value())
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlE})
+	if !m.valuesOmitted() {
+		t.Fatalf("expected ctrl+e to omit values for csv")
+	}
+}
+
+func TestWithListedMetadata(t *testing.T) {
+	fetched := []*aws.Parameter{{Name: "/app/a", Value: "v"}}
+	listed := []*aws.Parameter{{Name: "/app/a", LastModifiedUser: "bob", Tier: "Standard"}}
+	got := withListedMetadata(fetched, listed)
+	if got[0].LastModifiedUser != "bob" || got[0].Tier != "Standard" || got[0].Value != "v" {
+		t.Fatalf("expected listed metadata on fetched parameter, got %+v", got[0])
+	}
+}
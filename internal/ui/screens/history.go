@@ -0,0 +1,387 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+// versionItem represents a parameter version in the history list
+type versionItem struct {
+	param *aws.Parameter
+}
+
+func (i versionItem) FilterValue() string { return fmt.Sprintf("%d", i.param.Version) }
+
+type versionDelegate struct {
+	marked map[int64]bool
+}
+
+func (d versionDelegate) Height() int                             { return 1 }
+func (d versionDelegate) Spacing() int                            { return 0 }
+func (d versionDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+func (d versionDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(versionItem)
+	if !ok {
+		return
+	}
+
+	mark := "[ ]"
+	if d.marked[i.param.Version] {
+		mark = "[x]"
+	}
+
+	user := i.param.LastModifiedUser
+	if user == "" {
+		user = "-"
+	}
+	str := fmt.Sprintf("%s v%-4d %s  %s", mark, i.param.Version,
+		i.param.LastModifiedDate.Local().Format("2006-01-02 15:04:05"), user)
+
+	if index == m.Index() {
+		str = lipgloss.NewStyle().
+			Foreground(styles.Primary).
+			Bold(true).
+			Render(styles.Cursor + " " + str)
+	} else {
+		str = lipgloss.NewStyle().
+			PaddingLeft(2).
+			Render(str)
+	}
+
+	fmt.Fprint(w, str)
+}
+
+// HistoryModel represents the parameter version history screen
+type HistoryModel struct {
+	parameter      *aws.Parameter
+	versions       []*aws.Parameter
+	marked         map[int64]bool
+	list           list.Model
+	spinner        spinner.Model
+	loading        bool
+	err            error
+	status         string
+	currentProfile string
+	currentRegion  string
+	cancelLoad     context.CancelFunc
+	// Version awaiting confirmation to be restored, and whether it is being written
+	restoring *aws.Parameter
+	saving    bool
+}
+
+// NewHistory creates a new version history screen
+func NewHistory() HistoryModel {
+	s := spinner.New()
+	s.Spinner = styles.Spinner
+	s.Style = lipgloss.NewStyle().Foreground(styles.Secondary)
+
+	marked := make(map[int64]bool)
+
+	const defaultWidth = 80
+	const defaultHeight = 20
+
+	l := list.New([]list.Item{}, versionDelegate{marked: marked}, defaultWidth, defaultHeight)
+	l.Title = "History"
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+	l.Styles.Title = styles.TitleStyle
+	l.Styles.PaginationStyle = list.DefaultStyles().PaginationStyle.PaddingLeft(4)
+
+	return HistoryModel{
+		marked:  marked,
+		list:    l,
+		spinner: s,
+	}
+}
+
+// Init initializes the history screen
+func (m HistoryModel) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+// LoadHistory starts loading the version history of param
+func (m *HistoryModel) LoadHistory(param *aws.Parameter, client *aws.Client) tea.Cmd {
+	if m.cancelLoad != nil {
+		m.cancelLoad()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelLoad = cancel
+	m.parameter = param
+	m.versions = nil
+	m.loading = true
+	m.err = nil
+	m.status = ""
+	m.restoring = nil
+	m.saving = false
+	for v := range m.marked {
+		delete(m.marked, v)
+	}
+	m.updateTitle()
+
+	return tea.Batch(
+		m.spinner.Tick,
+		func() tea.Msg {
+			versions, err := client.GetParameterHistory(ctx, param.Name)
+			if err != nil {
+				return types.ErrorMsg{Err: err}
+			}
+			return types.HistoryLoadedMsg{Versions: versions}
+		},
+	)
+}
+
+// Update handles messages for the history screen
+func (m HistoryModel) Update(msg tea.Msg) (HistoryModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case types.HistoryLoadedMsg:
+		m.loading = false
+		m.versions = msg.Versions
+		items := make([]list.Item, len(msg.Versions))
+		for i, v := range msg.Versions {
+			items[i] = versionItem{param: v}
+		}
+		m.list.SetItems(items)
+		m.list.Select(0)
+		m.updateTitle()
+		return m, nil
+
+	case types.ErrorMsg:
+		if m.saving {
+			// Keep the history on screen; the restore can be retried
+			m.saving = false
+			m.status = fmt.Sprintf("Restore failed: %v", msg.Err)
+			return m, nil
+		}
+		m.loading = false
+		m.err = msg.Err
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading || m.saving {
+			return m, nil
+		}
+
+		if m.restoring != nil {
+			switch msg.String() {
+			case "y":
+				version := m.restoring
+				m.restoring = nil
+				m.saving = true
+				m.status = ""
+				return m, tea.Batch(m.spinner.Tick, func() tea.Msg {
+					return types.RestoreVersionMsg{Version: version}
+				})
+			case "n", "esc":
+				m.restoring = nil
+			case "ctrl+c":
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "esc":
+			if m.cancelLoad != nil {
+				m.cancelLoad()
+			}
+			return m, func() tea.Msg { return types.BackMsg{} }
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case " ":
+			// Mark/unmark the selected version, keeping at most two marks
+			item, ok := m.list.SelectedItem().(versionItem)
+			if !ok {
+				return m, nil
+			}
+			v := item.param.Version
+			if m.marked[v] {
+				delete(m.marked, v)
+			} else if len(m.marked) < 2 {
+				m.marked[v] = true
+			} else {
+				m.status = "Two versions already marked, unmark one first"
+				return m, nil
+			}
+			m.status = ""
+			return m, nil
+		case "enter", "c":
+			older, newer, ok := m.comparePair()
+			if !ok {
+				m.status = "Mark two versions with space (or one to compare with the selected)"
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return types.CompareVersionsMsg{Older: older, Newer: newer}
+			}
+		case "r":
+			// Preview restoring the selected version as the latest value
+			item, ok := m.list.SelectedItem().(versionItem)
+			if !ok {
+				return m, nil
+			}
+			if latest := m.latest(); latest != nil && item.param.Version == latest.Version {
+				m.status = "This is already the latest version"
+				return m, nil
+			}
+			m.restoring = item.param
+			m.status = ""
+			return m, nil
+		}
+	}
+
+	if m.loading || m.saving {
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+// comparePair returns the two versions to compare, ordered older first.
+// With a single mark, the selected version is used as the other side.
+func (m HistoryModel) comparePair() (*aws.Parameter, *aws.Parameter, bool) {
+	var picked []*aws.Parameter
+	for _, v := range m.versions {
+		if m.marked[v.Version] {
+			picked = append(picked, v)
+		}
+	}
+	if len(picked) == 1 {
+		if item, ok := m.list.SelectedItem().(versionItem); ok && item.param.Version != picked[0].Version {
+			picked = append(picked, item.param)
+		}
+	}
+	if len(picked) != 2 {
+		return nil, nil, false
+	}
+	if picked[0].Version > picked[1].Version {
+		return picked[1], picked[0], true
+	}
+	return picked[0], picked[1], true
+}
+
+// latest returns the newest version
+func (m HistoryModel) latest() *aws.Parameter {
+	if len(m.versions) == 0 {
+		return nil
+	}
+	return m.versions[0]
+}
+
+// RestoreVersion writes the value of an old version back as the latest,
+// with the type and KMS key it had, reporting SaveSuccessMsg or ErrorMsg
+func RestoreVersion(client *aws.Client, version *aws.Parameter) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		var err error
+		if version.Type == "SecureString" {
+			err = client.PutSecureParameter(ctx, version.Name, version.Value, version.KeyID)
+		} else {
+			err = client.PutParameter(ctx, version.Name, version.Value, version.Type)
+		}
+		if err != nil {
+			return types.ErrorMsg{Err: fmt.Errorf("failed to restore version %d: %w", version.Version, err)}
+		}
+		return types.SaveSuccessMsg{Parameter: version}
+	}
+}
+
+// View renders the history screen
+func (m HistoryModel) View() string {
+	if m.loading {
+		return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), progressText("Loading history..."))
+	}
+	if m.saving {
+		return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), progressText("Restoring version..."))
+	}
+
+	if m.err != nil {
+		return styles.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)) + "\n\n" +
+			styles.HelpStyle.Render("Press 'esc' to go back")
+	}
+
+	if m.restoring != nil {
+		return m.restoreView()
+	}
+
+	var b strings.Builder
+	b.WriteString(m.list.View())
+	b.WriteString("\n")
+	b.WriteString(styles.HelpStyle.Render("↑/↓: navigate • space: mark • enter: compare side by side • r: restore version • esc: back • q: quit"))
+	b.WriteString("\n")
+	if m.status != "" {
+		b.WriteString(styles.LabelStyle.Render(m.status))
+	}
+
+	return b.String()
+}
+
+// restoreView asks to confirm restoring a version, showing how the latest
+// value would change
+func (m HistoryModel) restoreView() string {
+	v := m.restoring
+	latest := m.latest()
+
+	var b strings.Builder
+	b.WriteString(styles.TitleStyle.Render(fmt.Sprintf("Restore %s to version %d", v.Name, v.Version)))
+	b.WriteString("\n\n")
+	b.WriteString(styles.LabelStyle.Render(fmt.Sprintf("Changes from the latest version %d:", latest.Version)))
+	b.WriteString("\n\n")
+	b.WriteString(renderDiff(diffLines(latest.Value, v.Value)))
+	b.WriteString("\n")
+	if v.Type != latest.Type {
+		b.WriteString("\n" + styles.WarningStyle.Render(fmt.Sprintf("The type changes back from %s to %s", latest.Type, v.Type)) + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(styles.HelpStyle.Render(fmt.Sprintf("y: write it as version %d • esc: cancel", latest.Version+1)))
+	return b.String()
+}
+
+// updateTitle updates the list title with profile, region and parameter name
+func (m *HistoryModel) updateTitle() {
+	profile := m.currentProfile
+	region := m.currentRegion
+	if profile == "" {
+		profile = "-"
+	}
+	if region == "" {
+		region = "-"
+	}
+	name := "-"
+	if m.parameter != nil {
+		name = m.parameter.Name
+	}
+	m.list.Title = fmt.Sprintf("%s : %s : %s : History (%d)", profile, region, name, len(m.versions))
+}
+
+// SetContext sets the profile and region context for the history screen
+func (m *HistoryModel) SetContext(profile, region string) {
+	m.currentProfile = profile
+	m.currentRegion = region
+	m.updateTitle()
+}
+
+// SetSize updates the dimensions of the history screen
+func (m *HistoryModel) SetSize(width, height int) {
+	m.list.SetWidth(width)
+	m.list.SetHeight(height - 4)
+}
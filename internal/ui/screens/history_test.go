@@ -0,0 +1,61 @@
+package screens
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+func TestHistory_RestoreVersion(t *testing.T) {
+	ctx := context.Background()
+	client := aws.NewDemoClient("restore-test", "eu-west-1")
+	name := "/restore/mode"
+	for _, v := range []string{"blue", "green"} {
+		if err := client.PutParameter(ctx, name, v, "String"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	versions, err := client.GetParameterHistory(ctx, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewHistory()
+	m.SetSize(100, 40)
+	m, _ = m.Update(types.HistoryLoadedMsg{Versions: versions})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if m.restoring != nil || !strings.Contains(m.status, "already the latest") {
+		t.Fatal("expected the latest version not to be restorable")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	view := m.View()
+	if !strings.Contains(view, "- green") || !strings.Contains(view, "+ blue") {
+		t.Fatalf("expected a diff preview:\n%s", view)
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	var restore types.RestoreVersionMsg
+	for _, c := range cmd().(tea.BatchMsg) {
+		if msg, ok := c().(types.RestoreVersionMsg); ok {
+			restore = msg
+		}
+	}
+	if restore.Version == nil || restore.Version.Value != "blue" {
+		t.Fatalf("unexpected restore %+v", restore)
+	}
+
+	if _, ok := RestoreVersion(client, restore.Version)().(types.SaveSuccessMsg); !ok {
+		t.Fatal("expected the restore to succeed")
+	}
+	p, err := client.GetParameter(ctx, name)
+	if err != nil || p.Value != "blue" || p.Version != versions[0].Version+1 {
+		t.Fatalf("expected blue as a new version, got %+v (%v)", p, err)
+	}
+}
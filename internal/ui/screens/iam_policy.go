@@ -0,0 +1,131 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+// IAMPolicyModel shows the IAM policy ps9s needs, for read-only or read-write use
+type IAMPolicyModel struct {
+	write          bool
+	prefix         string // Parameter name prefix the policy is scoped to
+	viewport       viewport.Model
+	status         string
+	currentProfile string
+	currentRegion  string
+}
+
+// NewIAMPolicy creates the IAM policy screen
+func NewIAMPolicy() IAMPolicyModel {
+	return IAMPolicyModel{viewport: viewport.New(80, 20)}
+}
+
+// Init initializes the IAM policy screen
+func (m IAMPolicyModel) Init() tea.Cmd {
+	return nil
+}
+
+// Open shows the policy for the given mode, scoped to prefix when non-empty
+func (m *IAMPolicyModel) Open(write bool, prefix string) {
+	m.write = write
+	m.prefix = prefix
+	m.status = ""
+	m.refresh()
+	m.viewport.GotoTop()
+}
+
+// Update handles messages for the IAM policy screen
+func (m IAMPolicyModel) Update(msg tea.Msg) (IAMPolicyModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case copyResultMsg:
+		m.status = "Copied policy to clipboard"
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Copy failed: %v", msg.Err)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg { return types.BackMsg{} }
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "w":
+			// Switch between the read-only and read-write policy
+			m.write = !m.write
+			m.status = ""
+			m.refresh()
+			return m, nil
+		case "c":
+			policy := aws.IAMPolicy(m.write, m.prefix).JSON()
+			return m, func() tea.Msg {
+				return copyResultMsg{Err: clipboard.WriteAll(policy), Text: policy}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// refresh renders the policy for the current mode
+func (m *IAMPolicyModel) refresh() {
+	lines := strings.Split(aws.IAMPolicy(m.write, m.prefix).JSON(), "\n")
+	m.viewport.SetContent("  " + strings.Join(lines, "\n  "))
+}
+
+// View renders the IAM policy screen
+func (m IAMPolicyModel) View() string {
+	var b strings.Builder
+
+	profile := m.currentProfile
+	region := m.currentRegion
+	if profile == "" {
+		profile = "-"
+	}
+	if region == "" {
+		region = "-"
+	}
+	title := fmt.Sprintf("%s : %s : IAM policy", profile, region)
+	b.WriteString("  " + styles.TitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	mode := "read-only"
+	if m.write {
+		mode = "read-write"
+	}
+	scope := "all parameters"
+	if m.prefix != "" {
+		scope = "parameters under " + m.prefix
+	}
+	b.WriteString("  " + styles.LabelStyle.Render("Mode: ") + mode + " • " + styles.LabelStyle.Render("Scope: ") + scope + "\n\n")
+	b.WriteString(m.viewport.View())
+	b.WriteString("\n")
+
+	if m.status != "" {
+		b.WriteString("\n  " + styles.SuccessStyle.Render(m.status) + "\n")
+	}
+	b.WriteString("  " + styles.HelpStyle.Render("w: toggle read-only/read-write • c: copy • ↑/↓: scroll • esc: back • q: quit"))
+
+	return b.String()
+}
+
+// SetContext sets the profile and region context for the IAM policy screen
+func (m *IAMPolicyModel) SetContext(profile, region string) {
+	m.currentProfile = profile
+	m.currentRegion = region
+}
+
+// SetSize updates the dimensions of the IAM policy screen
+func (m *IAMPolicyModel) SetSize(width, height int) {
+	m.viewport.Width = width - 4
+	m.viewport.Height = max(1, height-8)
+}
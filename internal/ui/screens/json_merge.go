@@ -0,0 +1,245 @@
+package screens
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/styles"
+)
+
+// mergeKey is a flattened JSON key whose value differs between the edited
+// value and the version someone else saved meanwhile
+type mergeKey struct {
+	key              string
+	mine, theirs     string // Values as shown in the JSON key list
+	inMine, inTheirs bool
+	useMine          bool
+	conflict         bool // Changed on both sides since the edit started
+}
+
+// jsonMerge is a key-by-key merge of an edited JSON value into the version
+// saved by someone else since the edit started
+type jsonMerge struct {
+	current *aws.Parameter // The version saved meanwhile
+	mine    interface{}
+	keys    []mergeKey
+	cursor  int
+}
+
+// jsonLeaves flattens a JSON document like the parameter view's key list
+func jsonLeaves(data interface{}) (map[string]string, []string) {
+	items := flattenJSONForView(data, "")
+	values := make(map[string]string, len(items))
+	order := make([]string, len(items))
+	for i, item := range items {
+		values[item.key] = item.value
+		order[i] = item.key
+	}
+	return values, order
+}
+
+// jsonContainer parses s as a JSON object or array
+func jsonContainer(s string) (interface{}, bool) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(s), &data); err != nil {
+		return nil, false
+	}
+	switch data.(type) {
+	case map[string]interface{}, []interface{}:
+		return data, true
+	}
+	return nil, false
+}
+
+// newJSONMerge lists the keys in which mine, edited from base, differs from
+// current. Keys only changed on one side start with that side's value; keys
+// changed on both start with mine. It fails unless all three are JSON
+// objects or arrays.
+func newJSONMerge(base, mine string, current *aws.Parameter) (*jsonMerge, bool) {
+	baseData, ok := jsonContainer(base)
+	if !ok {
+		return nil, false
+	}
+	mineData, ok := jsonContainer(mine)
+	if !ok {
+		return nil, false
+	}
+	theirsData, ok := jsonContainer(current.Value)
+	if !ok {
+		return nil, false
+	}
+
+	baseLeaves, _ := jsonLeaves(baseData)
+	mineLeaves, mineOrder := jsonLeaves(mineData)
+	theirsLeaves, theirsOrder := jsonLeaves(theirsData)
+
+	merge := &jsonMerge{current: current, mine: mineData}
+	seen := make(map[string]bool)
+	for _, key := range append(theirsOrder, mineOrder...) {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		mv, inMine := mineLeaves[key]
+		tv, inTheirs := theirsLeaves[key]
+		if inMine == inTheirs && mv == tv {
+			continue
+		}
+		bv, inBase := baseLeaves[key]
+		mineChanged := inMine != inBase || mv != bv
+		theirsChanged := inTheirs != inBase || tv != bv
+		merge.keys = append(merge.keys, mergeKey{
+			key:      key,
+			mine:     mv,
+			theirs:   tv,
+			inMine:   inMine,
+			inTheirs: inTheirs,
+			useMine:  mineChanged,
+			conflict: mineChanged && theirsChanged,
+		})
+	}
+	return merge, true
+}
+
+// value applies the keys taken from mine to the current version and returns
+// the merged JSON
+func (j *jsonMerge) value(parse func(string) []pathPart) (string, error) {
+	result, _ := jsonContainer(j.current.Value)
+	for _, k := range j.keys {
+		if !k.useMine || !k.inMine {
+			continue
+		}
+		v, _ := jsonPathValue(j.mine, parse(k.key))
+		result = setJSONPath(result, parse(k.key), v)
+	}
+	// Removed keys last and backwards, so array elements go from the end and
+	// the indexes of the remaining ones still match
+	for i := len(j.keys) - 1; i >= 0; i-- {
+		if k := j.keys[i]; k.useMine && !k.inMine {
+			result = deleteJSONPath(result, parse(k.key))
+		}
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return string(data), nil
+}
+
+// jsonPathValue returns the value at parts in data
+func jsonPathValue(data interface{}, parts []pathPart) (interface{}, bool) {
+	for _, part := range parts {
+		if part.isArray {
+			arr, ok := data.([]interface{})
+			if !ok || part.index >= len(arr) {
+				return nil, false
+			}
+			data = arr[part.index]
+		} else {
+			obj, ok := data.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if data, ok = obj[part.key]; !ok {
+				return nil, false
+			}
+		}
+	}
+	return data, true
+}
+
+// setJSONPath sets the value at parts in data, creating the objects and
+// array elements on the way, and returns the updated data
+func setJSONPath(data interface{}, parts []pathPart, v interface{}) interface{} {
+	if len(parts) == 0 {
+		return v
+	}
+	part := parts[0]
+	if part.isArray {
+		arr, _ := data.([]interface{})
+		for len(arr) <= part.index {
+			arr = append(arr, nil)
+		}
+		arr[part.index] = setJSONPath(arr[part.index], parts[1:], v)
+		return arr
+	}
+	obj, ok := data.(map[string]interface{})
+	if !ok {
+		obj = make(map[string]interface{})
+	}
+	obj[part.key] = setJSONPath(obj[part.key], parts[1:], v)
+	return obj
+}
+
+// deleteJSONPath removes the value at parts from data and returns the
+// updated data
+func deleteJSONPath(data interface{}, parts []pathPart) interface{} {
+	if len(parts) == 0 {
+		return data
+	}
+	part, last := parts[0], len(parts) == 1
+	if part.isArray {
+		arr, ok := data.([]interface{})
+		if !ok || part.index >= len(arr) {
+			return data
+		}
+		if last {
+			return append(arr[:part.index], arr[part.index+1:]...)
+		}
+		arr[part.index] = deleteJSONPath(arr[part.index], parts[1:])
+		return arr
+	}
+	obj, ok := data.(map[string]interface{})
+	if !ok {
+		return data
+	}
+	if last {
+		delete(obj, part.key)
+	} else if child, ok := obj[part.key]; ok {
+		obj[part.key] = deleteJSONPath(child, parts[1:])
+	}
+	return obj
+}
+
+// mergeValue shows one side of a merge key
+func mergeValue(value string, present bool, width int) string {
+	if !present {
+		return styles.HelpStyle.UnsetMarginTop().Render("(removed)")
+	}
+	return driftLine(value, width)
+}
+
+// View renders the merge: each differing key with the side it takes
+func (j *jsonMerge) View(height, width int) string {
+	var b strings.Builder
+	if len(j.keys) == 0 {
+		b.WriteString("  " + styles.InfoStyle.Render("Both versions hold the same keys and values") + "\n")
+		return b.String()
+	}
+
+	valueWidth := max(10, (width-20)/2)
+	visible := max(1, (height-14)/2)
+	start := max(0, j.cursor-visible+1)
+	for i := start; i < min(len(j.keys), start+visible); i++ {
+		k := j.keys[i]
+		side := "theirs"
+		if k.useMine {
+			side = "mine  "
+		}
+		line := fmt.Sprintf("[%s] %s", side, k.key)
+		if k.conflict {
+			line += " " + styles.WarningStyle.Render("(changed on both sides)")
+		}
+		if i == j.cursor {
+			b.WriteString("  " + lipgloss.NewStyle().Foreground(styles.Primary).Bold(true).Render(styles.Cursor+" ") + line + "\n")
+		} else {
+			b.WriteString("    " + line + "\n")
+		}
+		b.WriteString("        mine: " + mergeValue(k.mine, k.inMine, valueWidth) +
+			"   theirs: " + mergeValue(k.theirs, k.inTheirs, valueWidth) + "\n")
+	}
+	return b.String()
+}
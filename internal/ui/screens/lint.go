@@ -0,0 +1,44 @@
+package screens
+
+import (
+	"strings"
+
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/styles"
+)
+
+// valueLint holds the warnings shown about a value before it is saved
+type valueLint struct {
+	warnings []string
+	value    string // Value the warnings were shown for
+}
+
+// check reports whether value may be saved: it has no warnings, or they were
+// already shown for this exact value and saving again confirms it
+func (l *valueLint) check(value string) bool {
+	if l.warnings != nil && value == l.value {
+		return true
+	}
+	l.warnings = aws.LintValue(value)
+	l.value = value
+	return l.warnings == nil
+}
+
+// reset forgets the warnings, e.g. when another value is loaded
+func (l *valueLint) reset() {
+	l.warnings = nil
+	l.value = ""
+}
+
+// View renders the warnings, or nothing when there are none
+func (l valueLint) View() string {
+	if len(l.warnings) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, w := range l.warnings {
+		b.WriteString("  " + styles.WarningStyle.Render("⚠ Value "+w) + "\n")
+	}
+	b.WriteString("  " + styles.HelpStyle.UnsetMarginTop().Render("Press ctrl+s again to save anyway") + "\n\n")
+	return b.String()
+}
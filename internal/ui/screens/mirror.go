@@ -0,0 +1,76 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/gitmirror"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+// MirrorSubtree writes params, the parameters under prefix, to the git
+// mirror and commits them; SecureString values are not fetched since the
+// mirror masks them
+func MirrorSubtree(client *aws.Client, mirror gitmirror.Mirror, profile, region, prefix string, params []*aws.Parameter) tea.Cmd {
+	var names []string
+	for _, p := range params {
+		if p.Type != "SecureString" {
+			names = append(names, p.Name)
+		}
+	}
+	return func() tea.Msg {
+		ctx := context.Background()
+		values, err := client.GetParameterValues(ctx, names)
+		if err != nil {
+			return types.MirroredMsg{Err: err}
+		}
+		mirrored := make([]*aws.Parameter, len(params))
+		for i, p := range params {
+			cp := *p
+			cp.Value = values[p.Name]
+			mirrored[i] = &cp
+		}
+		committed, err := mirror.Sync(ctx, profile, region, prefix, mirrored)
+		if err != nil {
+			return types.MirroredMsg{Err: err}
+		}
+		status := fmt.Sprintf("Mirrored %d parameters under %s, nothing changed", len(params), prefix)
+		if committed {
+			status = fmt.Sprintf("Mirrored %d parameters under %s and committed", len(params), prefix)
+		}
+		return types.MirroredMsg{Status: status}
+	}
+}
+
+// MirrorChange commits the current state of parameter name to the git mirror
+// when it lies in a mirrored subtree
+func MirrorChange(client *aws.Client, mirror gitmirror.Mirror, profile, region, name string) tea.Cmd {
+	if !mirror.Tracks(profile, region, name) {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx := context.Background()
+		p, err := client.GetParameter(ctx, name)
+		if err != nil {
+			return types.MirroredMsg{Err: err}
+		}
+		committed, err := mirror.Record(ctx, profile, region, p)
+		if err != nil || !committed {
+			return types.MirroredMsg{Err: err}
+		}
+		return types.MirroredMsg{Status: fmt.Sprintf("Committed %s to the git mirror", name)}
+	}
+}
+
+// MirrorDeletes removes deleted parameters from the git mirror and commits
+func MirrorDeletes(mirror gitmirror.Mirror, profile, region string, names []string) tea.Cmd {
+	return func() tea.Msg {
+		committed, err := mirror.Remove(context.Background(), profile, region, names)
+		if err != nil || !committed {
+			return types.MirroredMsg{Err: err}
+		}
+		return types.MirroredMsg{Status: "Committed the deletion to the git mirror"}
+	}
+}
@@ -0,0 +1,726 @@
+package screens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+// moveStep is the stage a subtree move or copy is in
+type moveStep int
+
+const (
+	moveDestination moveStep = iota // Typing the destination prefix
+	movePlanning                    // Running the dry run
+	moveReview                      // Showing the dry run for confirmation
+	moveRunning
+	moveDone
+)
+
+// moveItem is one parameter of a subtree move or copy
+type moveItem struct {
+	from    *aws.Parameter // With its value, KMS key, tier and description
+	to      string
+	tags    []aws.Tag
+	exists  bool   // A parameter already has the new name
+	problem string // Why the dry run found it cannot be moved
+}
+
+// movePlannedMsg carries the dry run of a move or copy
+type movePlannedMsg struct {
+	target *aws.Client
+	items  []moveItem
+	err    error
+}
+
+// MoveModel moves every parameter under a prefix to another prefix: it copies
+// them, reads the copies back and only then deletes the originals. In copy
+// mode it only copies them, possibly into another context.
+type MoveModel struct {
+	client         *aws.Client
+	prefix         string
+	params         []*aws.Parameter
+	destInput      textinput.Model
+	dest           string
+	step           moveStep
+	items          []moveItem
+	result         types.SubtreeMovedMsg
+	offset         int
+	spinner        spinner.Model
+	err            error
+	width          int
+	height         int
+	currentProfile string
+	currentRegion  string
+
+	// Copy mode
+	copying      bool
+	contextInput textinput.Model // "profile region" of the destination
+	editContext  bool            // The context input has the focus
+	clientFor    func(profile, region string) (*aws.Client, error)
+	target       *aws.Client
+	targetName   string // Destination context as shown, empty when it is the current one
+	overwrite    bool   // Overwrite existing parameters instead of skipping them
+	// Copying a single parameter to a full name rather than a subtree
+	single bool
+	pool   []string // "profile region" of the known contexts, cycled with ↑/↓
+}
+
+// NewMove creates the subtree move screen
+func NewMove() MoveModel {
+	s := spinner.New()
+	s.Spinner = styles.Spinner
+	s.Style = lipgloss.NewStyle().Foreground(styles.Secondary)
+
+	ti := textinput.New()
+	ti.Placeholder = "/new-service/"
+	ti.CharLimit = 1011
+	ti.Width = 60
+
+	ci := textinput.New()
+	ci.Placeholder = "profile region"
+	ci.CharLimit = 100
+	ci.Width = 40
+
+	return MoveModel{destInput: ti, contextInput: ci, spinner: s}
+}
+
+// Init initializes the move screen
+func (m MoveModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Open starts a move of params, the parameters under prefix
+func (m *MoveModel) Open(client *aws.Client, prefix string, params []*aws.Parameter) tea.Cmd {
+	m.client = client
+	m.prefix = prefix
+	m.params = params
+	m.items = nil
+	m.err = nil
+	m.offset = 0
+	m.step = moveDestination
+	m.copying = false
+	m.single = false
+	m.target = client
+	m.targetName = ""
+	m.editContext = false
+	m.contextInput.Blur()
+	m.destInput.SetValue(prefix)
+	m.destInput.CursorEnd()
+	return m.destInput.Focus()
+}
+
+// OpenCopy starts a copy of params, the parameters under prefix. The
+// destination may be in another context, whose client clientFor creates.
+func (m *MoveModel) OpenCopy(client *aws.Client, prefix string, params []*aws.Parameter, clientFor func(profile, region string) (*aws.Client, error)) tea.Cmd {
+	cmd := m.Open(client, prefix, params)
+	m.copying = true
+	m.clientFor = clientFor
+	m.overwrite = false
+	m.contextInput.SetValue(m.currentProfile + " " + m.currentRegion)
+	return cmd
+}
+
+// OpenCopyParameter starts a copy of p, by default to the same name in the
+// first other context of the pool
+func (m *MoveModel) OpenCopyParameter(client *aws.Client, p *aws.Parameter, clientFor func(profile, region string) (*aws.Client, error)) tea.Cmd {
+	m.OpenCopy(client, p.Name, []*aws.Parameter{p}, clientFor)
+	m.single = true
+	m.contextInput.SetValue(otherContext(m.pool, m.currentProfile+" "+m.currentRegion))
+	m.contextInput.CursorEnd()
+	return m.destInput.Focus()
+}
+
+// OpenCopyMarked starts a copy of params, the marked parameters of the list,
+// from the deepest directory they share
+func (m *MoveModel) OpenCopyMarked(client *aws.Client, params []*aws.Parameter, clientFor func(profile, region string) (*aws.Client, error)) tea.Cmd {
+	return m.OpenCopy(client, commonDirectory(params), params, clientFor)
+}
+
+// commonDirectory returns the deepest path ending in / that every name of
+// params starts with, or "" when they share none
+func commonDirectory(params []*aws.Parameter) string {
+	if len(params) == 0 {
+		return ""
+	}
+	dir := params[0].Name[:strings.LastIndex(params[0].Name, "/")+1]
+	for _, p := range params[1:] {
+		for !strings.HasPrefix(p.Name, dir) {
+			dir = dir[:strings.LastIndex(strings.TrimSuffix(dir, "/"), "/")+1]
+		}
+	}
+	return dir
+}
+
+// SetPool sets the contexts offered as copy destinations, as "profile region"
+func (m *MoveModel) SetPool(pool []string) {
+	m.pool = pool
+}
+
+// cycleContext returns the context of pool after (step 1) or before (step -1)
+// the typed "profile region"; a context not in pool starts from the first
+func cycleContext(pool []string, typed string, step int) string {
+	if len(pool) == 0 {
+		return typed
+	}
+	i := -1
+	for j, c := range pool {
+		if c == strings.Join(strings.Fields(typed), " ") {
+			i = j
+			break
+		}
+	}
+	if i < 0 && step < 0 {
+		i = 0
+	}
+	return pool[(i+step+len(pool))%len(pool)]
+}
+
+// otherContext returns the first context of pool other than current
+func otherContext(pool []string, current string) string {
+	for _, c := range pool {
+		if c != current {
+			return c
+		}
+	}
+	return current
+}
+
+// Busy reports whether the move is being written, so it must not be left
+func (m MoveModel) Busy() bool {
+	return m.step == moveRunning
+}
+
+// checkDestination validates the destination prefix of a move or copy from
+// prefix. Another context may use the same prefix.
+func checkDestination(prefix, dest string, otherContext bool) error {
+	if !strings.HasPrefix(dest, "/") || !strings.HasSuffix(dest, "/") {
+		return fmt.Errorf("the destination must start and end with /")
+	}
+	if otherContext {
+		return nil
+	}
+	if dest == prefix {
+		return fmt.Errorf("the destination is the current prefix")
+	}
+	if strings.HasPrefix(dest, prefix) || strings.HasPrefix(prefix, dest) {
+		return fmt.Errorf("the destination must not contain %s or lie inside it", prefix)
+	}
+	return nil
+}
+
+// checkParameterDestination validates the destination name of a single
+// parameter copy from name
+func checkParameterDestination(name, dest string, otherContext bool) error {
+	if err := aws.ValidateParameterName(dest); err != nil {
+		return err
+	}
+	if !otherContext && dest == name {
+		return fmt.Errorf("the destination is the parameter itself")
+	}
+	return nil
+}
+
+// planMove is the dry run of moving or copying params from prefix to dest in
+// target: it reads every value and its tags and checks each new name,
+// including whether a parameter already has it. KMS keys are dropped when
+// target is another context, where they do not exist.
+func planMove(ctx context.Context, client, target *aws.Client, prefix, dest string, params []*aws.Parameter) ([]moveItem, error) {
+	names := make([]string, len(params))
+	for i, p := range params {
+		names[i] = p.Name
+	}
+	fetched, err := client.GetParameters(ctx, names, true)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*aws.Parameter, len(fetched))
+	for _, p := range withListedMetadata(fetched, params) {
+		byName[p.Name] = p
+	}
+
+	items := make([]moveItem, len(params))
+	var targets []string
+	for i, p := range params {
+		item := moveItem{from: p, to: dest + strings.TrimPrefix(p.Name, prefix)}
+		if f, ok := byName[p.Name]; ok {
+			item.from = f
+			if target != client {
+				c := *f
+				c.KeyID = ""
+				item.from = &c
+			}
+		} else {
+			item.problem = "could not be read"
+		}
+		if aws.IsShared(p.Name) {
+			item.problem = "shared from another account"
+		} else if err := aws.ValidateParameterName(item.to); err != nil {
+			item.problem = err.Error()
+		} else {
+			targets = append(targets, item.to)
+		}
+		if item.problem == "" {
+			if item.tags, err = client.ListTags(ctx, p.Name); err != nil {
+				return nil, err
+			}
+		}
+		items[i] = item
+	}
+
+	existing, err := target.GetParameters(ctx, targets, false)
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[string]bool, len(existing))
+	for _, p := range existing {
+		taken[p.Name] = true
+	}
+	for i := range items {
+		items[i].exists = items[i].problem == "" && taken[items[i].to]
+	}
+	return items, nil
+}
+
+// runCopy copies every item to target, skipping or overwriting the ones whose
+// name is taken
+func runCopy(ctx context.Context, target *aws.Client, items []moveItem, overwrite bool) types.SubtreeMovedMsg {
+	result := types.SubtreeMovedMsg{Failures: make(map[string]error)}
+	for _, item := range items {
+		if item.exists && !overwrite {
+			continue
+		}
+		if err := target.CopyParameter(ctx, item.from, item.to, item.tags, item.exists); err != nil {
+			if isDryRun(err) {
+				return types.SubtreeMovedMsg{Err: err}
+			}
+			result.Failures[item.from.Name] = err
+			continue
+		}
+		result.Created = append(result.Created, item.to)
+	}
+	return result
+}
+
+// isDryRun reports whether err is a request skipped by dry-run mode. Nothing
+// is written then, so a copy or move stops at its first request and previews
+// it rather than failing every item.
+func isDryRun(err error) bool {
+	var dryRunErr *aws.DryRunError
+	return errors.As(err, &dryRunErr)
+}
+
+// runMove copies every item, reads the copies back and deletes the originals
+// whose copy matches
+func runMove(ctx context.Context, client *aws.Client, items []moveItem) types.SubtreeMovedMsg {
+	result := types.SubtreeMovedMsg{Failures: make(map[string]error)}
+	for _, item := range items {
+		if err := client.CopyParameter(ctx, item.from, item.to, item.tags, false); err != nil {
+			if isDryRun(err) {
+				return types.SubtreeMovedMsg{Err: err}
+			}
+			result.Failures[item.from.Name] = err
+			continue
+		}
+		result.Created = append(result.Created, item.to)
+	}
+
+	copies, err := client.GetParameters(ctx, result.Created, true)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to verify the copies, no originals were deleted: %w", err)
+		return result
+	}
+	byName := make(map[string]*aws.Parameter, len(copies))
+	for _, p := range copies {
+		byName[p.Name] = p
+	}
+	var verified []string
+	for _, item := range items {
+		if _, failed := result.Failures[item.from.Name]; failed {
+			continue
+		}
+		c, ok := byName[item.to]
+		if !ok || c.Value != item.from.Value || c.Type != item.from.Type {
+			result.Failures[item.from.Name] = fmt.Errorf("the copy at %s does not match, the original was kept", item.to)
+			continue
+		}
+		verified = append(verified, item.from.Name)
+	}
+
+	result.Deleted, result.Err = client.DeleteParameters(ctx, verified)
+	deleted := make(map[string]bool, len(result.Deleted))
+	for _, name := range result.Deleted {
+		deleted[name] = true
+	}
+	for _, name := range verified {
+		if !deleted[name] {
+			result.Failures[name] = fmt.Errorf("copied, but the original was not deleted")
+		}
+	}
+	return result
+}
+
+// problem returns why item cannot be moved or copied; a taken name only
+// stops moves
+func (m MoveModel) problem(item moveItem) string {
+	if item.problem == "" && item.exists && !m.copying {
+		return "a parameter with the new name already exists"
+	}
+	return item.problem
+}
+
+// problems returns how many items the dry run found cannot be moved or copied
+func (m MoveModel) problems() int {
+	n := 0
+	for _, item := range m.items {
+		if m.problem(item) != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// existing returns how many items have a name that is already taken
+func (m MoveModel) existing() int {
+	n := 0
+	for _, item := range m.items {
+		if item.exists {
+			n++
+		}
+	}
+	return n
+}
+
+// destinationContext returns the typed destination context of a copy and
+// whether it differs from the current one
+func (m MoveModel) destinationContext() (profile, region string, other bool, err error) {
+	if !m.copying {
+		return "", "", false, nil
+	}
+	fields := strings.Fields(m.contextInput.Value())
+	if len(fields) != 2 {
+		return "", "", false, fmt.Errorf("the context must be a profile and a region")
+	}
+	profile, region = fields[0], fields[1]
+	return profile, region, profile != m.currentProfile || region != m.currentRegion, nil
+}
+
+// Update handles messages for the move screen
+func (m MoveModel) Update(msg tea.Msg) (MoveModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case movePlannedMsg:
+		if m.step != movePlanning {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			m.step = moveDestination
+			return m, m.destInput.Focus()
+		}
+		m.items = msg.items
+		m.target = msg.target
+		m.step = moveReview
+		return m, nil
+
+	case types.SubtreeMovedMsg:
+		m.result = msg
+		m.step = moveDone
+		return m, nil
+
+	case types.ErrorMsg:
+		// Nothing was written (e.g. a dry run); go back to the review
+		if m.step == moveRunning {
+			m.step = moveReview
+		}
+		m.err = msg.Err
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		switch m.step {
+		case moveDestination:
+			switch msg.String() {
+			case "esc":
+				return m, func() tea.Msg { return types.BackMsg{} }
+			case "tab", "shift+tab":
+				if !m.copying {
+					return m, nil
+				}
+				m.editContext = !m.editContext
+				if m.editContext {
+					m.destInput.Blur()
+					return m, m.contextInput.Focus()
+				}
+				m.contextInput.Blur()
+				return m, m.destInput.Focus()
+			case "up", "down":
+				if m.editContext {
+					step := 1
+					if msg.String() == "up" {
+						step = -1
+					}
+					m.contextInput.SetValue(cycleContext(m.pool, m.contextInput.Value(), step))
+					m.contextInput.CursorEnd()
+				}
+				return m, nil
+			case "enter":
+				dest := strings.TrimSpace(m.destInput.Value())
+				profile, region, other, err := m.destinationContext()
+				if err == nil && m.single {
+					err = checkParameterDestination(m.prefix, dest, other)
+				} else if err == nil {
+					err = checkDestination(m.prefix, dest, other)
+				}
+				if err != nil {
+					m.err = err
+					return m, nil
+				}
+				m.dest = dest
+				m.targetName = ""
+				if other {
+					m.targetName = profile + " : " + region
+				}
+				m.err = nil
+				m.offset = 0
+				m.step = movePlanning
+				m.destInput.Blur()
+				m.contextInput.Blur()
+				client, prefix, params, clientFor := m.client, m.prefix, m.params, m.clientFor
+				return m, tea.Batch(m.spinner.Tick, func() tea.Msg {
+					target := client
+					if other {
+						var err error
+						if target, err = clientFor(profile, region); err != nil {
+							return movePlannedMsg{err: fmt.Errorf("failed to open %s %s: %w", profile, region, err)}
+						}
+					}
+					items, err := planMove(context.Background(), client, target, prefix, dest, params)
+					return movePlannedMsg{target: target, items: items, err: err}
+				})
+			}
+			var cmd tea.Cmd
+			if m.editContext {
+				m.contextInput, cmd = m.contextInput.Update(msg)
+			} else {
+				m.destInput, cmd = m.destInput.Update(msg)
+			}
+			return m, cmd
+
+		case moveReview:
+			switch msg.String() {
+			case "esc", "q":
+				return m, func() tea.Msg { return types.BackMsg{} }
+			case "e":
+				m.step = moveDestination
+				m.editContext = false
+				return m, m.destInput.Focus()
+			case "o":
+				if m.copying {
+					m.overwrite = !m.overwrite
+				}
+			case "up", "k":
+				m.offset = max(0, m.offset-1)
+			case "down", "j":
+				m.offset = min(max(0, len(m.items)-1), m.offset+1)
+			case "y":
+				if m.problems() > 0 {
+					return m, nil
+				}
+				if m.single && m.existing() > 0 && !m.overwrite {
+					// Overwriting a single parameter needs 'o' first
+					return m, nil
+				}
+				m.step = moveRunning
+				client, target, items, overwrite := m.client, m.target, m.items, m.overwrite
+				if m.copying {
+					return m, tea.Batch(m.spinner.Tick, func() tea.Msg {
+						return runCopy(context.Background(), target, items, overwrite)
+					})
+				}
+				return m, tea.Batch(m.spinner.Tick, func() tea.Msg {
+					return runMove(context.Background(), client, items)
+				})
+			}
+			return m, nil
+
+		case moveDone:
+			switch msg.String() {
+			case "enter", "esc", "q":
+				return m, func() tea.Msg { return types.BackMsg{} }
+			}
+		}
+		return m, nil
+	}
+
+	if m.step == movePlanning || m.step == moveRunning {
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// View renders the move screen
+func (m MoveModel) View() string {
+	action, verb, moving, moved := "Move", "move", "Moving", "moved"
+	if m.copying {
+		action, verb, moving, moved = "Copy", "copy", "Copying", "copied"
+	}
+	switch m.step {
+	case movePlanning:
+		return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), progressText(fmt.Sprintf("Checking the %s of %d parameters...", verb, len(m.params))))
+	case moveRunning:
+		return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), progressText(fmt.Sprintf("%s %d parameters...", moving, len(m.items))))
+	}
+
+	var b strings.Builder
+
+	profile := m.currentProfile
+	region := m.currentRegion
+	if profile == "" {
+		profile = "-"
+	}
+	if region == "" {
+		region = "-"
+	}
+	title := fmt.Sprintf("%s : %s : %s %s", profile, region, action, m.prefix)
+	b.WriteString("  " + styles.TitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	if m.err != nil {
+		b.WriteString("  " + styles.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		b.WriteString("\n\n")
+	}
+
+	switch m.step {
+	case moveDestination:
+		if m.single {
+			b.WriteString("  " + styles.LabelStyle.Render("Copy to name: ") + m.destInput.View() + "\n")
+		} else {
+			b.WriteString("  " + styles.LabelStyle.Render(fmt.Sprintf("%s %d parameters to: ", action, len(m.params))) + m.destInput.View() + "\n")
+		}
+		if m.copying {
+			b.WriteString("  " + styles.LabelStyle.Render("In context: ") + m.contextInput.View() + "\n\n")
+			help := "enter: dry run • tab: prefix/context • esc: cancel"
+			if m.single {
+				help = "enter: dry run • tab: name/context • ↑/↓ in context: known contexts • esc: cancel"
+			} else if len(m.pool) > 0 {
+				help = "enter: dry run • tab: prefix/context • ↑/↓ in context: known contexts • esc: cancel"
+			}
+			b.WriteString("  " + styles.HelpStyle.Render(help))
+		} else {
+			b.WriteString("\n  " + styles.HelpStyle.Render("enter: dry run • esc: cancel"))
+		}
+
+	case moveReview:
+		dest := m.dest
+		if m.targetName != "" {
+			dest = m.targetName + " : " + m.dest
+		}
+		summary := fmt.Sprintf("Dry run: %d parameters will be copied to %s, read back, and deleted from %s once their copy matches",
+			len(m.items), dest, m.prefix)
+		if m.copying {
+			summary = fmt.Sprintf("Dry run: %d parameters will be copied to %s", len(m.items), dest)
+			if m.single {
+				summary = fmt.Sprintf("Dry run: %s will be copied to %s", m.prefix, dest)
+			}
+			if m.targetName != "" {
+				summary += " (SecureStrings use that account's default KMS key)"
+			}
+		}
+		b.WriteString("  " + styles.InfoStyle.Render(summary) + "\n\n")
+		visible := max(1, m.height-12)
+		for i := m.offset; i < min(len(m.items), m.offset+visible); i++ {
+			item := m.items[i]
+			line := "    " + item.from.Name + " → " + item.to
+			if problem := m.problem(item); problem != "" {
+				line = "    " + styles.ErrorStyle.Render(item.from.Name+": "+problem)
+			} else if item.exists && m.overwrite {
+				line += " " + styles.WarningStyle.Render("(overwrite)")
+			} else if item.exists {
+				line += " " + styles.HelpStyle.UnsetMarginTop().Render("(exists, skipped)")
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n")
+		if n := m.problems(); n > 0 {
+			b.WriteString("  " + styles.WarningStyle.Render(fmt.Sprintf("⚠ %d parameters cannot be %s; choose another destination or fix them first", n, moved)) + "\n")
+			b.WriteString("  " + styles.HelpStyle.Render("e: change destination • ↑/↓: scroll • esc: cancel"))
+		} else if m.single && m.existing() > 0 {
+			item := m.items[0]
+			if m.overwrite {
+				b.WriteString("  " + styles.WarningStyle.Render(fmt.Sprintf("⚠ %s already exists and will be overwritten, keeping its tags", item.to)) + "\n")
+				b.WriteString("  " + styles.HelpStyle.Render("y: overwrite • o: don't overwrite • e: change destination • esc: cancel"))
+			} else {
+				b.WriteString("  " + styles.WarningStyle.Render(fmt.Sprintf("⚠ %s already exists; press 'o' to overwrite it", item.to)) + "\n")
+				b.WriteString("  " + styles.HelpStyle.Render("o: overwrite • e: change destination • esc: cancel"))
+			}
+		} else if m.copying {
+			if n := m.existing(); n > 0 {
+				policy := "skipped"
+				if m.overwrite {
+					policy = "overwritten, keeping their tags"
+				}
+				b.WriteString("  " + styles.WarningStyle.Render(fmt.Sprintf("⚠ %d parameters already exist and will be %s", n, policy)) + "\n")
+			}
+			b.WriteString("  " + styles.HelpStyle.Render("y: copy • o: overwrite/skip existing • e: change destination • ↑/↓: scroll • esc: cancel"))
+		} else {
+			b.WriteString("  " + styles.HelpStyle.Render("y: move • e: change destination • ↑/↓: scroll • esc: cancel"))
+		}
+
+	case moveDone:
+		r := m.result
+		dest := m.dest
+		if m.targetName != "" {
+			dest = m.targetName + " : " + m.dest
+		}
+		if m.single && len(r.Created) == 1 {
+			b.WriteString("  " + styles.SuccessStyle.Render(fmt.Sprintf("✓ Copied %s to %s", m.prefix, dest)) + "\n")
+		} else if m.single {
+			b.WriteString("  " + styles.ErrorStyle.Render(fmt.Sprintf("✗ %s was not copied to %s", m.prefix, dest)) + "\n")
+		} else if m.copying {
+			b.WriteString("  " + styles.SuccessStyle.Render(fmt.Sprintf("✓ Copied %d of %d parameters to %s", len(r.Created), len(m.items), m.dest)) + "\n")
+		} else {
+			b.WriteString("  " + styles.SuccessStyle.Render(fmt.Sprintf("✓ Moved %d of %d parameters to %s", len(r.Deleted), len(m.items), m.dest)) + "\n")
+		}
+		if r.Err != nil {
+			b.WriteString("  " + styles.ErrorStyle.Render(fmt.Sprintf("Error: %v", r.Err)) + "\n")
+		}
+		names := make([]string, 0, len(r.Failures))
+		for name := range r.Failures {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			b.WriteString("    " + styles.ErrorStyle.Render(fmt.Sprintf("✗ %s: %v", name, r.Failures[name])) + "\n")
+		}
+		b.WriteString("\n  " + styles.HelpStyle.Render("enter/esc: back"))
+	}
+	return b.String()
+}
+
+// SetContext sets the profile and region context for the move screen
+func (m *MoveModel) SetContext(profile, region string) {
+	m.currentProfile = profile
+	m.currentRegion = region
+}
+
+// SetSize updates the dimensions of the move screen
+func (m *MoveModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.destInput.Width = min(60, width-30)
+	m.contextInput.Width = min(40, width-30)
+}
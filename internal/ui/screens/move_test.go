@@ -0,0 +1,233 @@
+package screens
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+func TestCheckDestination(t *testing.T) {
+	for _, dest := range []string{"/old/", "/old/sub/", "/", "new/", "/new"} {
+		if checkDestination("/old/", dest, false) == nil {
+			t.Errorf("expected %q to be refused", dest)
+		}
+	}
+	if err := checkDestination("/old/", "/new/", false); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := checkDestination("/old/", "/old/", true); err != nil {
+		t.Errorf("expected another context to take the same prefix, got %v", err)
+	}
+}
+
+func TestMoveSubtree(t *testing.T) {
+	t.Cleanup(aws.ResetDemo)
+	ctx := context.Background()
+	client := aws.NewDemoClient("move-test", "eu-west-1")
+	for name, value := range map[string]string{"/old/a": "1", "/old/db/b": "2", "/taken/a": "x"} {
+		if err := client.CreateParameter(ctx, name, value, "String", "", "", []aws.Tag{{Key: "team", Value: "core"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	params := []*aws.Parameter{{Name: "/old/a", Description: "first"}, {Name: "/old/db/b"}}
+
+	items, err := planMove(ctx, client, client, "/old/", "/taken/", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !items[0].exists || items[1].exists {
+		t.Fatalf("expected only /taken/a to collide, got %+v", items)
+	}
+
+	items, err = planMove(ctx, client, client, "/old/", "/new/", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := runMove(ctx, client, items)
+	if result.Err != nil || len(result.Failures) != 0 || len(result.Deleted) != 2 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	moved, err := client.GetParameter(ctx, "/new/db/b")
+	if err != nil || moved.Value != "2" {
+		t.Fatalf("expected the copy with its value, got %+v, %v", moved, err)
+	}
+	if tags, _ := client.ListTags(ctx, "/new/a"); len(tags) != 1 || tags[0].Key != "team" {
+		t.Errorf("expected the tags to be copied, got %+v", tags)
+	}
+	if _, err := client.GetParameter(ctx, "/old/a"); err == nil {
+		t.Error("expected the original to be deleted")
+	}
+}
+
+func TestMoveSubtree_DryRunPreviewsInsteadOfFailing(t *testing.T) {
+	t.Cleanup(aws.ResetDemo)
+	ctx := context.Background()
+	client := aws.NewDemoClient("move-dry-run-test", "eu-west-1")
+	for _, name := range []string{"/old/a", "/old/b"} {
+		if err := client.CreateParameter(ctx, name, "v", "String", "", "", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	items, err := planMove(ctx, client, client, "/old/", "/new/", []*aws.Parameter{{Name: "/old/a"}, {Name: "/old/b"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client.SetDryRun(true)
+	var dryRunErr *aws.DryRunError
+	for _, result := range []types.SubtreeMovedMsg{runMove(ctx, client, items), runCopy(ctx, client, items, false)} {
+		if !errors.As(result.Err, &dryRunErr) || len(result.Failures) != 0 || len(result.Created) != 0 {
+			t.Fatalf("expected the dry run to stop at its first request, got %+v", result)
+		}
+	}
+	if dryRunErr.Request.Name != "/new/a" {
+		t.Errorf("expected the first copy to be previewed, got %+v", dryRunErr.Request)
+	}
+	if _, err := client.GetParameter(ctx, "/old/a"); err != nil {
+		t.Errorf("expected the original to be kept, got %v", err)
+	}
+}
+
+func TestCopySubtree_OtherContext(t *testing.T) {
+	t.Cleanup(aws.ResetDemo)
+	ctx := context.Background()
+	source := aws.NewDemoClient("copy-test", "eu-west-1")
+	target := aws.NewDemoClient("copy-test", "us-east-1")
+	if err := source.CreateParameter(ctx, "/app/staging/a", "1", "String", "", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := source.CreateParameter(ctx, "/app/staging/key", "s3cret", "SecureString", "alias/staging", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := target.CreateParameter(ctx, "/app/qa/a", "old", "String", "", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	params := []*aws.Parameter{{Name: "/app/staging/a"}, {Name: "/app/staging/key"}}
+
+	items, err := planMove(ctx, source, target, "/app/staging/", "/app/qa/", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !items[0].exists || items[1].exists || items[1].from.KeyID != "" {
+		t.Fatalf("expected /app/qa/a to exist and the KMS key to be dropped, got %+v", items)
+	}
+
+	result := runCopy(ctx, target, items, false)
+	if len(result.Created) != 1 || len(result.Failures) != 0 {
+		t.Fatalf("expected the existing parameter to be skipped, got %+v", result)
+	}
+	if p, _ := target.GetParameter(ctx, "/app/qa/a"); p == nil || p.Value != "old" {
+		t.Fatalf("expected the existing value to be kept, got %+v", p)
+	}
+
+	if items, err = planMove(ctx, source, target, "/app/staging/", "/app/qa/", params); err != nil {
+		t.Fatal(err)
+	}
+	result = runCopy(ctx, target, items, true)
+	if len(result.Created) != 2 || len(result.Failures) != 0 {
+		t.Fatalf("expected both parameters to be copied, got %+v", result)
+	}
+	if p, _ := target.GetParameter(ctx, "/app/qa/a"); p == nil || p.Value != "1" {
+		t.Fatalf("expected the existing value to be overwritten, got %+v", p)
+	}
+	if p, _ := source.GetParameter(ctx, "/app/staging/a"); p == nil {
+		t.Error("expected the original to be kept")
+	}
+}
+
+func TestCopyParameter_ConfirmsOverwrite(t *testing.T) {
+	t.Cleanup(aws.ResetDemo)
+	ctx := context.Background()
+	source := aws.NewDemoClient("copy-one-test", "eu-west-1")
+	target := aws.NewDemoClient("copy-one-prod", "eu-west-1")
+	if err := source.CreateParameter(ctx, "/app/timeout", "30", "String", "", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := target.CreateParameter(ctx, "/app/timeout", "10", "String", "", "", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewMove()
+	m.SetContext("copy-one-test", "eu-west-1")
+	m.SetPool([]string{"copy-one-test eu-west-1", "copy-one-prod eu-west-1"})
+	m.OpenCopyParameter(source, &aws.Parameter{Name: "/app/timeout"}, func(profile, region string) (*aws.Client, error) {
+		return target, nil
+	})
+	if got := m.contextInput.Value(); got != "copy-one-prod eu-west-1" {
+		t.Fatalf("expected the other context of the pool, got %q", got)
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.err != nil || cmd == nil {
+		t.Fatalf("expected a dry run, got %v", m.err)
+	}
+	m, _ = m.Update(batchResult(cmd))
+	if m.step != moveReview || m.existing() != 1 {
+		t.Fatalf("expected the existing parameter in the review, got step %d", m.step)
+	}
+
+	// 'y' alone must not overwrite
+	if m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}}); cmd != nil || m.step != moveReview {
+		t.Fatal("expected 'y' to wait for the overwrite confirmation")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Fatal("expected the copy to run")
+	}
+	m, _ = m.Update(batchResult(cmd))
+	if len(m.result.Created) != 1 {
+		t.Fatalf("expected the parameter to be copied, got %+v", m.result)
+	}
+	if p, _ := target.GetParameter(ctx, "/app/timeout"); p == nil || p.Value != "30" {
+		t.Fatalf("expected the value overwritten in the other context, got %+v", p)
+	}
+}
+
+// batchResult runs the batched command of the move screen and returns its
+// non-spinner message
+func batchResult(cmd tea.Cmd) tea.Msg {
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		for _, c := range batch {
+			if c == nil {
+				continue
+			}
+			if m := c(); m != nil {
+				if _, tick := m.(spinner.TickMsg); !tick {
+					return m
+				}
+			}
+		}
+	}
+	return msg
+}
+
+func TestCommonDirectory(t *testing.T) {
+	params := func(names ...string) []*aws.Parameter {
+		var ps []*aws.Parameter
+		for _, n := range names {
+			ps = append(ps, &aws.Parameter{Name: n})
+		}
+		return ps
+	}
+	for _, tc := range []struct {
+		names []string
+		want  string
+	}{
+		{[]string{"/app/prod/a", "/app/prod/db/b"}, "/app/prod/"},
+		{[]string{"/app/prod/a", "/app/staging/a"}, "/app/"},
+		{[]string{"/app/a", "/other/b"}, "/"},
+		{[]string{"/app/a", "plain"}, ""},
+	} {
+		if got := commonDirectory(params(tc.names...)); got != tc.want {
+			t.Errorf("commonDirectory(%v) = %q, want %q", tc.names, got, tc.want)
+		}
+	}
+}
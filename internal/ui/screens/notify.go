@@ -0,0 +1,244 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+// NotifyModel shows or creates the EventBridge rule that forwards changes of a
+// parameter, or of a path prefix, to an SNS topic
+type NotifyModel struct {
+	client         *aws.Client
+	name           string
+	prefix         bool
+	rule           *aws.ChangeRule
+	topicInput     textinput.Model
+	spinner        spinner.Model
+	loading        bool
+	saving         bool
+	err            error
+	status         string
+	currentProfile string
+	currentRegion  string
+	cancelLoad     context.CancelFunc
+}
+
+// NewNotify creates the change notification screen
+func NewNotify() NotifyModel {
+	s := spinner.New()
+	s.Spinner = styles.Spinner
+	s.Style = lipgloss.NewStyle().Foreground(styles.Secondary)
+
+	ti := textinput.New()
+	ti.Placeholder = "arn:aws:sns:REGION:ACCOUNT:TOPIC"
+	ti.Width = 60
+
+	return NotifyModel{
+		topicInput: ti,
+		spinner:    s,
+	}
+}
+
+// Init initializes the change notification screen
+func (m NotifyModel) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+// Load looks up the rule for name, or for every parameter under name when prefix is set
+func (m *NotifyModel) Load(client *aws.Client, name string, prefix bool) tea.Cmd {
+	if m.cancelLoad != nil {
+		m.cancelLoad()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelLoad = cancel
+	m.client = client
+	m.name = name
+	m.prefix = prefix
+	m.rule = nil
+	m.loading = true
+	m.saving = false
+	m.err = nil
+	m.status = ""
+	m.topicInput.SetValue("")
+
+	rule := aws.NewChangeRule(name, prefix)
+	return tea.Batch(
+		m.spinner.Tick,
+		m.topicInput.Focus(),
+		func() tea.Msg {
+			found, err := client.GetChangeRule(ctx, rule)
+			if err != nil {
+				return types.ErrorMsg{Err: err}
+			}
+			return types.ChangeRuleLoadedMsg{Rule: found}
+		},
+	)
+}
+
+// Update handles messages for the change notification screen
+func (m NotifyModel) Update(msg tea.Msg) (NotifyModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case types.ChangeRuleLoadedMsg:
+		m.loading = false
+		m.rule = msg.Rule
+		return m, nil
+
+	case types.ChangeRuleSavedMsg:
+		m.saving = false
+		m.rule = msg.Rule
+		m.topicInput.SetValue("")
+		m.status = "Rule enabled; changes are sent to its targets"
+		return m, nil
+
+	case types.ErrorMsg:
+		m.loading = false
+		m.saving = false
+		m.err = msg.Err
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading || m.saving {
+			return m, nil
+		}
+		switch msg.String() {
+		case "esc":
+			if m.cancelLoad != nil {
+				m.cancelLoad()
+			}
+			return m, func() tea.Msg { return types.BackMsg{} }
+		case "ctrl+c":
+			return m, tea.Quit
+		case "enter":
+			topic := strings.TrimSpace(m.topicInput.Value())
+			if topic == "" || m.rule == nil {
+				return m, nil
+			}
+			return m, m.save(topic)
+		}
+
+		m.status = ""
+		m.err = nil
+		var cmd tea.Cmd
+		m.topicInput, cmd = m.topicInput.Update(msg)
+		return m, cmd
+	}
+
+	if m.loading || m.saving {
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+// save creates or updates the rule with topic as a target
+func (m *NotifyModel) save(topic string) tea.Cmd {
+	m.saving = true
+	m.err = nil
+
+	client := m.client
+	rule := *m.rule
+
+	return tea.Batch(
+		m.spinner.Tick,
+		func() tea.Msg {
+			if err := client.PutChangeRule(context.Background(), rule, topic); err != nil {
+				return types.ErrorMsg{Err: err}
+			}
+			rule.State = "ENABLED"
+			if !slices.Contains(rule.Targets, topic) {
+				rule.Targets = append(rule.Targets, topic)
+			}
+			return types.ChangeRuleSavedMsg{Rule: &rule}
+		},
+	)
+}
+
+// View renders the change notification screen
+func (m NotifyModel) View() string {
+	if m.loading {
+		return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), progressText("Looking up EventBridge rule..."))
+	}
+	if m.saving {
+		return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), progressText("Creating EventBridge rule..."))
+	}
+
+	var b strings.Builder
+
+	profile := m.currentProfile
+	region := m.currentRegion
+	if profile == "" {
+		profile = "-"
+	}
+	if region == "" {
+		region = "-"
+	}
+	title := fmt.Sprintf("%s : %s : Change notifications", profile, region)
+	b.WriteString("  " + styles.TitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	watched := m.name
+	if m.prefix {
+		watched += "* (every parameter under this path)"
+	}
+	b.WriteString("  " + styles.LabelStyle.Render("Parameters: ") + watched + "\n")
+
+	if m.rule != nil {
+		state := "not created yet"
+		if m.rule.Exists() {
+			state = strings.ToLower(m.rule.State)
+		}
+		b.WriteString("  " + styles.LabelStyle.Render("Rule: ") + m.rule.Name + " (" + state + ")\n")
+		if len(m.rule.Targets) > 0 {
+			b.WriteString("  " + styles.LabelStyle.Render("Targets:") + "\n")
+			for _, t := range m.rule.Targets {
+				b.WriteString("    " + t + "\n")
+			}
+		}
+		b.WriteString("\n  " + styles.LabelStyle.Render("Event pattern:") + "\n")
+		for _, line := range strings.Split(m.rule.Pattern, "\n") {
+			b.WriteString("    " + line + "\n")
+		}
+	}
+
+	b.WriteString("\n  " + styles.LabelStyle.Render("SNS topic: ") + m.topicInput.View() + "\n")
+	b.WriteString("  " + styles.HelpStyle.UnsetMarginTop().Render("The topic's access policy must allow events.amazonaws.com to publish") + "\n")
+
+	if m.err != nil {
+		b.WriteString("\n  " + styles.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)) + "\n")
+	}
+	if m.status != "" {
+		b.WriteString("\n  " + styles.SuccessStyle.Render(m.status) + "\n")
+	}
+
+	action := "create rule"
+	if m.rule != nil && m.rule.Exists() {
+		action = "add topic"
+	}
+	b.WriteString("  " + styles.HelpStyle.Render("enter: "+action+" • esc: back"))
+
+	return b.String()
+}
+
+// SetContext sets the profile and region context for the change notification screen
+func (m *NotifyModel) SetContext(profile, region string) {
+	m.currentProfile = profile
+	m.currentRegion = region
+}
+
+// SetSize updates the dimensions of the change notification screen
+func (m *NotifyModel) SetSize(width, height int) {
+	m.topicInput.Width = min(60, width-20)
+}
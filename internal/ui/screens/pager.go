@@ -0,0 +1,48 @@
+package screens
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultPager is used when $PAGER is unset
+const defaultPager = "less"
+
+// pagerClosedMsg is sent when the external pager exits
+type pagerClosedMsg struct {
+	Err error
+}
+
+// pagerCommand builds the $PAGER command with value on stdin. JSON values
+// are indented so large documents stay readable.
+func pagerCommand(value string) *exec.Cmd {
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if len(pager) == 0 {
+		pager = []string{defaultPager}
+	}
+
+	c := exec.Command(pager[0], pager[1:]...)
+	c.Stdin = strings.NewReader(indentJSON(value))
+	return c
+}
+
+// indentJSON returns value indented when it is JSON, otherwise unchanged
+func indentJSON(value string) string {
+	var pretty bytes.Buffer
+	if json.Indent(&pretty, []byte(value), "", "  ") == nil {
+		return pretty.String()
+	}
+	return value
+}
+
+// openInPager suspends the TUI and pipes value into the pager
+func openInPager(value string) tea.Cmd {
+	return tea.ExecProcess(pagerCommand(value), func(err error) tea.Msg {
+		return pagerClosedMsg{Err: err}
+	})
+}
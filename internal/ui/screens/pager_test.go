@@ -0,0 +1,26 @@
+package screens
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestPagerCommand(t *testing.T) {
+	t.Setenv("PAGER", "less -R")
+
+	c := pagerCommand(`{"a":1}`)
+	if strings.Join(c.Args, " ") != "less -R" {
+		t.Fatalf("expected pager args from $PAGER, got %v", c.Args)
+	}
+
+	input, _ := io.ReadAll(c.Stdin)
+	if string(input) != "{\n  \"a\": 1\n}" {
+		t.Fatalf("expected indented JSON on stdin, got %q", input)
+	}
+
+	t.Setenv("PAGER", "")
+	if c := pagerCommand("plain"); c.Args[0] != defaultPager {
+		t.Fatalf("expected %s when $PAGER is unset, got %v", defaultPager, c.Args)
+	}
+}
@@ -0,0 +1,483 @@
+package screens
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+// ParameterCreateModel represents the screen for creating a new parameter
+type ParameterCreateModel struct {
+	client         *aws.Client
+	nameInput      textinput.Model
+	valueInput     textarea.Model
+	focusedInput   int    // 0 = name, 1 = value, 2 = tags, 3 = description
+	paramType      string // Type the parameter is created with
+	nameErr        error  // Live validation result for the name
+	keyID          string // KMS key for SecureString parameters, empty for the default
+	tagEditor      TagEditor
+	spinner        spinner.Model
+	saving         bool
+	err            error
+	width          int
+	height         int
+	currentProfile string
+	currentRegion  string
+	lint           valueLint
+	// naming is the convention new names must match, if any
+	naming *regexp.Regexp
+	// Optional description the parameter is created with
+	descInput textinput.Model
+}
+
+// NewParameterCreate creates a new parameter creation screen
+func NewParameterCreate() ParameterCreateModel {
+	nameInput := textinput.New()
+	nameInput.Placeholder = "/app/env/name"
+	nameInput.CharLimit = 2048
+	nameInput.Width = 60
+	nameInput.ShowSuggestions = true
+
+	valueInput := textarea.New()
+	valueInput.Placeholder = "Enter value..."
+	valueInput.CharLimit = 0
+	valueInput.ShowLineNumbers = false
+
+	descInput := textinput.New()
+	descInput.Placeholder = "Optional"
+	descInput.CharLimit = 1024 // SSM's limit for descriptions
+	descInput.Width = 60
+
+	s := spinner.New()
+	s.Spinner = styles.Spinner
+	s.Style = lipgloss.NewStyle().Foreground(styles.Secondary)
+
+	return ParameterCreateModel{
+		nameInput:  nameInput,
+		valueInput: valueInput,
+		paramType:  "String",
+		tagEditor:  NewTagEditor(),
+		spinner:    s,
+		descInput:  descInput,
+	}
+}
+
+// Init initializes the creation screen
+func (m ParameterCreateModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Reset clears the form for a new parameter created with client, starting the
+// name with prefix and offering the paths of existing parameters as completions
+func (m *ParameterCreateModel) Reset(client *aws.Client, existing []*aws.Parameter, prefix string) tea.Cmd {
+	m.client = client
+	m.err = nil
+	m.saving = false
+	m.lint.reset()
+	m.focusedInput = 0
+	m.paramType = "String"
+	m.keyID = ""
+
+	m.nameInput.SetSuggestions(pathPrefixes(existing))
+	m.nameInput.SetValue(prefix)
+	m.nameInput.CursorEnd()
+	m.valueInput.SetValue("")
+	m.descInput.SetValue("")
+	m.tagEditor.SetTags(nil)
+	m.nameErr = nil
+	m.nameInput.Focus()
+	m.valueInput.Blur()
+	m.descInput.Blur()
+
+	return textinput.Blink
+}
+
+// templateTagsMsg carries the tags of the parameter used as a template
+type templateTagsMsg struct {
+	Tags []aws.Tag
+	Err  error
+}
+
+// UseTemplate fills the form from param: its name, type, KMS key, description
+// and tags, and its value with SecureString contents blanked. Call after Reset.
+func (m *ParameterCreateModel) UseTemplate(param *aws.Parameter) tea.Cmd {
+	m.paramType = param.Type
+	m.keyID = param.KeyID
+	m.descInput.SetValue(param.Description)
+	m.nameInput.SetValue(param.Name)
+	m.nameInput.CursorEnd()
+	m.validateName()
+	m.valueInput.SetValue(templateValue(param))
+
+	client := m.client
+	name := param.Name
+	return func() tea.Msg {
+		tags, err := client.ListTags(context.Background(), name)
+		return templateTagsMsg{Tags: tags, Err: err}
+	}
+}
+
+// templateValue returns the value a new parameter starts with when copying p.
+// SecureStrings keep only their JSON structure, with every value blanked.
+func templateValue(p *aws.Parameter) string {
+	if p.Type != "SecureString" {
+		return p.Value
+	}
+	var v interface{}
+	if err := json.Unmarshal([]byte(p.Value), &v); err != nil {
+		return ""
+	}
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+	default:
+		return ""
+	}
+	out, err := json.MarshalIndent(blankJSON(v), "", "  ")
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
+
+// blankJSON replaces every leaf of a decoded JSON value with an empty string
+func blankJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			v[k] = blankJSON(child)
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = blankJSON(child)
+		}
+		return v
+	}
+	return ""
+}
+
+// Update handles messages for the creation screen
+func (m ParameterCreateModel) Update(msg tea.Msg) (ParameterCreateModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+		return m, nil
+
+	case templateTagsMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		m.tagEditor.SetTags(msg.Tags)
+		return m, nil
+
+	case types.ErrorMsg:
+		m.saving = false
+		m.err = msg.Err
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.saving {
+			return m, nil
+		}
+
+		// The tag editor takes every key while a tag is being typed
+		if m.focusedInput == 2 && m.tagEditor.Editing() {
+			var cmd tea.Cmd
+			m.tagEditor, cmd = m.tagEditor.Update(msg)
+			return m, cmd
+		}
+
+		switch msg.String() {
+		case "ctrl+s":
+			if m.nameErr != nil || m.nameInput.Value() == "" {
+				m.err = fmt.Errorf("invalid name: %w", m.checkName(m.nameInput.Value()))
+				return m, nil
+			}
+			if !m.lint.check(m.valueInput.Value()) {
+				return m, nil
+			}
+			return m, m.create()
+		case "ctrl+y":
+			// Cycle the type the parameter will be created with
+			m.paramType = nextParameterType(m.paramType)
+			return m, nil
+		case "esc":
+			return m, func() tea.Msg { return types.BackMsg{} }
+		case "ctrl+c":
+			return m, tea.Quit
+		case "tab":
+			// Tab completes the suggested path first, then moves to the value
+			if m.focusedInput == 0 && len(m.nameInput.CurrentSuggestion()) > len(m.nameInput.Value()) {
+				var cmd tea.Cmd
+				m.nameInput, cmd = m.nameInput.Update(msg)
+				m.validateName()
+				return m, cmd
+			}
+			return m, m.switchFocus(1)
+		case "shift+tab":
+			return m, m.switchFocus(-1)
+		}
+
+		// Update the focused input, validating the name as it is typed
+		var cmd tea.Cmd
+		switch m.focusedInput {
+		case 0:
+			m.nameInput, cmd = m.nameInput.Update(msg)
+			m.validateName()
+		case 2:
+			m.tagEditor, cmd = m.tagEditor.Update(msg)
+		case 3:
+			m.descInput, cmd = m.descInput.Update(msg)
+		default:
+			m.valueInput, cmd = m.valueInput.Update(msg)
+		}
+		return m, cmd
+	}
+
+	// Update spinner if saving
+	if m.saving {
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+// switchFocus moves focus by step through the name, value, tags and
+// description fields
+func (m *ParameterCreateModel) switchFocus(step int) tea.Cmd {
+	m.focusedInput = (m.focusedInput + step + 4) % 4
+	m.nameInput.Blur()
+	m.valueInput.Blur()
+	m.descInput.Blur()
+	switch m.focusedInput {
+	case 0:
+		m.nameInput.Focus()
+		return textinput.Blink
+	case 1:
+		m.valueInput.Focus()
+		return textarea.Blink
+	case 3:
+		m.descInput.Focus()
+		return textinput.Blink
+	}
+	return nil
+}
+
+// TagEditing reports whether a tag is being typed, so esc cancels the tag
+// instead of leaving the screen
+func (m ParameterCreateModel) TagEditing() bool {
+	return m.focusedInput == 2 && m.tagEditor.Editing()
+}
+
+// maxShownSuggestions limits the completion list under the name input
+const maxShownSuggestions = 5
+
+// pathPrefixes returns the distinct parent paths of the given parameters, e.g.
+// "/app/", "/app/prod/" for "/app/prod/db", sorted for stable suggestions
+func pathPrefixes(params []*aws.Parameter) []string {
+	seen := make(map[string]bool)
+	var prefixes []string
+	for _, p := range params {
+		for i := 1; i < len(p.Name); i++ {
+			if p.Name[i] != '/' {
+				continue
+			}
+			prefix := p.Name[:i+1]
+			if !seen[prefix] {
+				seen[prefix] = true
+				prefixes = append(prefixes, prefix)
+			}
+		}
+	}
+	sort.Strings(prefixes)
+	return prefixes
+}
+
+// validateName refreshes the inline name error; an empty name is not flagged until saving
+func (m *ParameterCreateModel) validateName() {
+	if m.nameInput.Value() == "" {
+		m.nameErr = nil
+		return
+	}
+	m.nameErr = m.checkName(m.nameInput.Value())
+}
+
+// checkName checks name against the SSM naming rules and the naming convention
+func (m ParameterCreateModel) checkName(name string) error {
+	if err := aws.ValidateParameterName(name); err != nil {
+		return err
+	}
+	if m.naming != nil && !m.naming.MatchString(name) {
+		return fmt.Errorf("name does not follow the naming convention %s", m.naming)
+	}
+	return nil
+}
+
+// SetNaming sets the naming convention new names must match; nil allows any
+func (m *ParameterCreateModel) SetNaming(naming *regexp.Regexp) {
+	m.naming = naming
+}
+
+// create sends the new parameter to AWS
+func (m *ParameterCreateModel) create() tea.Cmd {
+	m.saving = true
+	m.err = nil
+
+	name := m.nameInput.Value()
+	value := m.valueInput.Value()
+	paramType := m.paramType
+	keyID := m.keyID
+	description := strings.TrimSpace(m.descInput.Value())
+	tags := m.tagEditor.Tags()
+	client := m.client
+
+	return tea.Batch(
+		m.spinner.Tick,
+		func() tea.Msg {
+			if err := client.CreateParameter(context.Background(), name, value, paramType, keyID, description, tags); err != nil {
+				return types.ErrorMsg{Err: err}
+			}
+			return types.ParameterCreatedMsg{Parameter: &aws.Parameter{
+				Name:        name,
+				Type:        paramType,
+				Value:       value,
+				Description: description,
+			}}
+		},
+	)
+}
+
+// View renders the creation screen
+func (m ParameterCreateModel) View() string {
+	if m.saving {
+		return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), progressText("Creating parameter..."))
+	}
+
+	var b strings.Builder
+
+	profile := m.currentProfile
+	region := m.currentRegion
+	if profile == "" {
+		profile = "-"
+	}
+	if region == "" {
+		region = "-"
+	}
+	title := fmt.Sprintf("%s : %s : New parameter", profile, region)
+	b.WriteString("  " + styles.TitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	if m.err != nil {
+		b.WriteString("  " + styles.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		b.WriteString("\n\n")
+	}
+
+	// Name input with inline validation
+	b.WriteString("  " + styles.LabelStyle.Render("Name:"))
+	b.WriteString("\n\n")
+	b.WriteString("  " + m.nameInput.View())
+	b.WriteString("\n")
+	if m.nameErr != nil {
+		b.WriteString("  " + styles.ErrorStyle.Render("✗ "+m.nameErr.Error()))
+	} else if m.nameInput.Value() != "" {
+		b.WriteString("  " + styles.SuccessStyle.Render("✓ valid name"))
+	}
+	b.WriteString("\n")
+	if m.focusedInput == 0 {
+		b.WriteString(m.renderSuggestions())
+	}
+	b.WriteString("\n")
+
+	// Value input (textarea)
+	b.WriteString("  " + styles.LabelStyle.Render("Value:"))
+	b.WriteString("\n\n")
+	b.WriteString(m.valueInput.View())
+	b.WriteString("\n\n")
+	b.WriteString(m.lint.View())
+
+	b.WriteString("  " + styles.LabelStyle.Render("Type: "))
+	b.WriteString(m.paramType)
+	b.WriteString("\n")
+	if m.paramType == "SecureString" {
+		keyID := m.keyID
+		if keyID == "" {
+			keyID = "(default alias/aws/ssm)"
+		}
+		b.WriteString("  " + styles.LabelStyle.Render("KMS key: "))
+		b.WriteString(keyID)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	b.WriteString("  " + styles.LabelStyle.Render(fmt.Sprintf("Tags (%d):", len(m.tagEditor.Tags()))))
+	b.WriteString("\n")
+	b.WriteString(m.tagEditor.View(m.focusedInput == 2))
+	b.WriteString("\n")
+
+	b.WriteString("  " + styles.LabelStyle.Render("Description: "))
+	b.WriteString(m.descInput.View())
+	b.WriteString("\n\n")
+
+	helpText := "tab: complete path / switch field • ↑/↓: pick path • ctrl+y: change type • ctrl+s: create • esc: cancel • ctrl+c: quit"
+	b.WriteString("  " + styles.HelpStyle.Render(helpText))
+
+	return b.String()
+}
+
+// renderSuggestions lists matching existing paths, marking the one tab accepts
+func (m ParameterCreateModel) renderSuggestions() string {
+	matches := m.nameInput.MatchedSuggestions()
+	if len(matches) == 0 || (len(matches) == 1 && matches[0] == m.nameInput.Value()) {
+		return ""
+	}
+
+	current := m.nameInput.CurrentSuggestionIndex()
+	start := 0
+	if current >= maxShownSuggestions {
+		start = current - maxShownSuggestions + 1
+	}
+
+	var b strings.Builder
+	for i := start; i < len(matches) && i < start+maxShownSuggestions; i++ {
+		if i == current {
+			b.WriteString("    " + styles.LabelStyle.Render(styles.Cursor+" "+matches[i]) + "\n")
+		} else {
+			b.WriteString("      " + styles.HelpStyle.UnsetMarginTop().Render(matches[i]) + "\n")
+		}
+	}
+	if len(matches) > maxShownSuggestions {
+		b.WriteString("      " + styles.HelpStyle.UnsetMarginTop().Render(fmt.Sprintf("%d matching paths", len(matches))) + "\n")
+	}
+	return b.String()
+}
+
+// SetContext sets the profile and region context for the creation screen
+func (m *ParameterCreateModel) SetContext(profile, region string) {
+	m.currentProfile = profile
+	m.currentRegion = region
+}
+
+// SetSize updates the dimensions of the creation screen
+func (m *ParameterCreateModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.nameInput.Width = width - 20
+	m.valueInput.SetWidth(width - 4)
+	m.descInput.Width = width - 20
+	m.valueInput.SetHeight(height - 23)
+}
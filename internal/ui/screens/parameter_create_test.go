@@ -0,0 +1,189 @@
+package screens
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+func typeText(m ParameterCreateModel, s string) ParameterCreateModel {
+	for _, r := range s {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m
+}
+
+func TestParameterCreate_ValidatesNameWhileTyping(t *testing.T) {
+	m := NewParameterCreate()
+	m.Reset(nil, nil, "")
+
+	m = typeText(m, "/aws/x")
+	if m.nameErr == nil {
+		t.Fatalf("expected reserved prefix to be flagged while typing")
+	}
+	if !strings.Contains(m.View(), "reserved") {
+		t.Fatalf("expected inline error in view")
+	}
+
+	m.nameInput.SetValue("")
+	m = typeText(m, "/app/db")
+	if m.nameErr != nil {
+		t.Fatalf("expected valid name, got %v", m.nameErr)
+	}
+}
+
+func TestParameterCreate_SaveBlockedForInvalidName(t *testing.T) {
+	m := NewParameterCreate()
+	m.Reset(nil, nil, "")
+	m = typeText(m, "app/db")
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd != nil || m.saving {
+		t.Fatalf("expected save to be blocked for invalid name")
+	}
+	if m.err == nil {
+		t.Fatalf("expected error to be shown")
+	}
+}
+
+func TestParameterCreate_EnforcesNamingConvention(t *testing.T) {
+	m := NewParameterCreate()
+	m.SetNaming(regexp.MustCompile(`^/(platform|payments)/(prod|staging)/`))
+	m.Reset(nil, nil, "")
+
+	m = typeText(m, "/misc/db")
+	if m.nameErr == nil || !strings.Contains(m.View(), "naming convention") {
+		t.Fatalf("expected the naming convention to be flagged, got %v", m.nameErr)
+	}
+	if m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS}); cmd != nil || m.err == nil {
+		t.Fatal("expected save to be blocked")
+	}
+
+	m.nameInput.SetValue("")
+	m = typeText(m, "/platform/prod/db")
+	if m.nameErr != nil {
+		t.Fatalf("expected a conforming name, got %v", m.nameErr)
+	}
+}
+
+func TestParameterCreate_TabAcceptsPathSuggestion(t *testing.T) {
+	m := NewParameterCreate()
+	m.Reset(nil, []*aws.Parameter{
+		{Name: "/apps/prod/db"},
+		{Name: "/apps/staging/db"},
+	}, "")
+
+	m = typeText(m, "/apps/pr")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := m.nameInput.Value(); got != "/apps/prod/" {
+		t.Fatalf("expected completion to /apps/prod/, got %q", got)
+	}
+	if m.focusedInput != 0 {
+		t.Fatalf("accepting a suggestion should keep focus on the name")
+	}
+
+	// Nothing left to complete: tab moves on to the value
+	m = typeText(m, "api")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.focusedInput != 1 {
+		t.Fatalf("expected focus to move to value")
+	}
+}
+
+func TestPathPrefixes(t *testing.T) {
+	got := pathPrefixes([]*aws.Parameter{{Name: "/app/prod/db"}, {Name: "/app/dev/db"}, {Name: "flat"}})
+	want := []string{"/app/", "/app/dev/", "/app/prod/"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("pathPrefixes = %v, want %v", got, want)
+	}
+}
+
+func TestParameterCreate_PrefixPrefillsName(t *testing.T) {
+	m := NewParameterCreate()
+	m.Reset(nil, nil, "/app/prod/")
+	m = typeText(m, "token")
+	if got := m.nameInput.Value(); got != "/app/prod/token" {
+		t.Fatalf("expected name to extend the prefix, got %q", got)
+	}
+}
+
+func TestParameterCreate_UseTemplate(t *testing.T) {
+	m := NewParameterCreate()
+	m.Reset(nil, nil, "")
+	m.UseTemplate(&aws.Parameter{
+		Name:  "/app/prod/db",
+		Type:  "SecureString",
+		KeyID: "alias/app",
+		Value: `{"user":"admin","pass":"s3cret","ports":[5432]}`,
+	})
+	m, _ = m.Update(templateTagsMsg{Tags: []aws.Tag{{Key: "team", Value: "core"}}})
+
+	if m.paramType != "SecureString" || m.keyID != "alias/app" {
+		t.Fatalf("expected type and KMS key to be copied, got %s %q", m.paramType, m.keyID)
+	}
+	if m.nameInput.Value() != "/app/prod/db" {
+		t.Fatalf("expected name to be copied, got %q", m.nameInput.Value())
+	}
+	want := "{\n  \"pass\": \"\",\n  \"ports\": [\n    \"\"\n  ],\n  \"user\": \"\"\n}"
+	if got := m.valueInput.Value(); got != want {
+		t.Fatalf("expected blanked JSON structure, got %q", got)
+	}
+	if got := m.tagEditor.Tags(); len(got) != 1 || got[0].Key != "team" {
+		t.Fatalf("expected template tags, got %v", got)
+	}
+}
+
+func TestTemplateValue(t *testing.T) {
+	if got := templateValue(&aws.Parameter{Type: "String", Value: "plain"}); got != "plain" {
+		t.Errorf("expected String value to be kept, got %q", got)
+	}
+	if got := templateValue(&aws.Parameter{Type: "SecureString", Value: "s3cret"}); got != "" {
+		t.Errorf("expected plain SecureString to be blanked, got %q", got)
+	}
+}
+
+func TestParameterCreate_Description(t *testing.T) {
+	t.Cleanup(aws.ResetDemo)
+	ctx := context.Background()
+	client := aws.NewDemoClient("create-test", "eu-west-1")
+
+	m := NewParameterCreate()
+	m.Reset(client, nil, "/app/new")
+	for range 3 {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	}
+	if m.focusedInput != 3 {
+		t.Fatalf("expected tab to reach the description, got field %d", m.focusedInput)
+	}
+	m = typeText(m, "Feature flag owned by payments")
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	var created types.ParameterCreatedMsg
+	for _, c := range cmd().(tea.BatchMsg) {
+		if msg, ok := c().(types.ParameterCreatedMsg); ok {
+			created = msg
+		}
+	}
+	if created.Parameter == nil || created.Parameter.Description != "Feature flag owned by payments" {
+		t.Fatalf("unexpected result %+v", created)
+	}
+
+	params, err := client.ListParameters(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range params {
+		if p.Name == "/app/new" {
+			if p.Description != "Feature flag owned by payments" {
+				t.Fatalf("expected the description to be saved, got %q", p.Description)
+			}
+			return
+		}
+	}
+	t.Fatal("expected the parameter to be created")
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textarea"
@@ -23,6 +24,8 @@ type ParameterEditModel struct {
 	jsonData       map[string]interface{} // Parsed JSON
 	textarea       textarea.Model         // Value editor
 	selectedKey    string                 // Currently selected key path
+	listItem       int                    // StringList item being edited (1-based), 0 for the whole value
+	paramType      string                 // Type to save with (may differ from parameter.Type)
 	spinner        spinner.Model
 	saving         bool
 	navigatingBack bool
@@ -32,6 +35,22 @@ type ParameterEditModel struct {
 	currentProfile string
 	currentRegion  string
 	cancelSave     context.CancelFunc
+	lint           valueLint
+	base           string     // Value the edit started from
+	baseVersion    int64      // Version the edit started from; 0 skips the conflict check
+	overwrite      bool       // Save even if someone else saved a newer version
+	merge          *jsonMerge // Key-by-key merge after a save conflict
+	// Concealed editing: SecureString values start masked when concealSecrets
+	// is set, and ctrl+r reveals or hides any value
+	concealSecrets bool
+	concealed      bool
+}
+
+// saveConflictMsg reports that someone else saved the parameter since the
+// edit started, so value was not saved
+type saveConflictMsg struct {
+	Current *aws.Parameter
+	Value   string
 }
 
 // NewParameterEdit creates a new parameter edit screen
@@ -42,8 +61,8 @@ func NewParameterEdit() ParameterEditModel {
 	ta.ShowLineNumbers = false
 
 	s := spinner.New()
-	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	s.Spinner = styles.Spinner
+	s.Style = lipgloss.NewStyle().Foreground(styles.Secondary)
 
 	return ParameterEditModel{
 		textarea: ta,
@@ -64,6 +83,14 @@ func (m *ParameterEditModel) LoadParameter(param *aws.Parameter, client *aws.Cli
 	m.saving = false
 	m.navigatingBack = false
 	m.selectedKey = jsonKey
+	m.listItem = 0
+	m.paramType = param.Type
+	m.lint.reset()
+	m.base = param.Value
+	m.baseVersion = param.Version
+	m.overwrite = false
+	m.merge = nil
+	m.concealed = m.concealSecrets && param.Type == "SecureString"
 
 	// Check if value is JSON
 	m.isJSON = isValidJSON(param.Value)
@@ -94,6 +121,17 @@ func (m *ParameterEditModel) LoadParameter(param *aws.Parameter, client *aws.Cli
 	return textarea.Blink
 }
 
+// LoadListItem loads one item of a StringList parameter for editing
+func (m *ParameterEditModel) LoadListItem(param *aws.Parameter, client *aws.Client, item int) tea.Cmd {
+	cmd := m.LoadParameter(param, client, "")
+	items := strings.Split(param.Value, ",")
+	if item >= 1 && item <= len(items) {
+		m.listItem = item
+		m.textarea.SetValue(items[item-1])
+	}
+	return cmd
+}
+
 // getJSONValue retrieves a value from JSON using dot notation path
 func (m *ParameterEditModel) getJSONValue(data interface{}, path string) string {
 	parts := m.parsePath(path)
@@ -133,6 +171,11 @@ func (m *ParameterEditModel) getJSONValue(data interface{}, path string) string
 	}
 }
 
+// Merging reports whether the key-by-key merge of a save conflict is shown
+func (m ParameterEditModel) Merging() bool {
+	return m.merge != nil
+}
+
 // Update handles messages for the parameter edit screen
 func (m ParameterEditModel) Update(msg tea.Msg) (ParameterEditModel, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -148,16 +191,41 @@ func (m ParameterEditModel) Update(msg tea.Msg) (ParameterEditModel, tea.Cmd) {
 		m.err = msg.Err
 		return m, nil
 
+	case saveConflictMsg:
+		m.saving = false
+		if merge, ok := newJSONMerge(m.base, msg.Value, msg.Current); ok {
+			m.merge = merge
+			return m, nil
+		}
+		m.overwrite = true
+		m.err = fmt.Errorf("%s was saved by someone else as version %d since you started editing; press ctrl+s again to overwrite it",
+			msg.Current.Name, msg.Current.Version)
+		return m, nil
+
 	case tea.KeyMsg:
 		if m.saving || m.navigatingBack {
 			return m, nil
 		}
+		if m.merge != nil {
+			return m.updateMerge(msg)
+		}
 
 		// Handle edit mode keys
 		switch msg.String() {
 		case "ctrl+s":
-			// Save the value
+			// Save the value, warning first about invisible mistakes in it
+			if !m.lint.check(m.textarea.Value()) {
+				return m, nil
+			}
 			return m, m.saveParameter()
+		case "ctrl+y":
+			// Cycle the type the parameter will be saved with
+			m.paramType = nextParameterType(m.paramType)
+			return m, nil
+		case "ctrl+r":
+			// Reveal or hide the value, e.g. while sharing the screen
+			m.concealed = !m.concealed
+			return m, nil
 		case "esc":
 			// Cancel edit and return to parameter details
 			if m.cancelSave != nil {
@@ -185,19 +253,62 @@ func (m ParameterEditModel) Update(msg tea.Msg) (ParameterEditModel, tea.Cmd) {
 	return m, nil
 }
 
-// saveParameter saves the edited parameter value
-func (m *ParameterEditModel) saveParameter() tea.Cmd {
-	if m.cancelSave != nil {
-		m.cancelSave()
+// updateMerge handles keys while the merge of a save conflict is shown
+func (m ParameterEditModel) updateMerge(msg tea.KeyMsg) (ParameterEditModel, tea.Cmd) {
+	j := m.merge
+	switch msg.String() {
+	case "up", "k":
+		if j.cursor > 0 {
+			j.cursor--
+		}
+	case "down", "j":
+		if j.cursor < len(j.keys)-1 {
+			j.cursor++
+		}
+	case "left", "right", "space", " ", "tab":
+		if j.cursor < len(j.keys) {
+			j.keys[j.cursor].useMine = !j.keys[j.cursor].useMine
+		}
+	case "M", "T":
+		for i := range j.keys {
+			j.keys[i].useMine = msg.String() == "M"
+		}
+	case "enter", "ctrl+s":
+		value, err := j.value(m.parsePath)
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		// Merged onto their version, so only a newer one conflicts again
+		m.base = j.current.Value
+		m.baseVersion = j.current.Version
+		m.merge = nil
+		return m, m.put(value)
+	case "esc":
+		// Back to editing; saving again shows the merge again
+		m.merge = nil
+	case "ctrl+c":
+		return m, tea.Quit
 	}
+	return m, nil
+}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	m.cancelSave = cancel
-	m.saving = true
-	m.err = nil
-
+// saveParameter saves the edited parameter value
+func (m *ParameterEditModel) saveParameter() tea.Cmd {
 	newValue := m.textarea.Value()
 
+	// If editing a StringList item, put it back in its place
+	if m.listItem > 0 {
+		if strings.Contains(newValue, ",") {
+			return func() tea.Msg {
+				return types.ErrorMsg{Err: fmt.Errorf("a list item cannot contain commas")}
+			}
+		}
+		items := strings.Split(m.parameter.Value, ",")
+		items[m.listItem-1] = newValue
+		newValue = strings.Join(items, ",")
+	}
+
 	// If editing JSON key, reconstruct the JSON
 	if m.isJSON && m.selectedKey != "" {
 		if err := m.updateJSONValue(m.jsonData, m.selectedKey, newValue); err != nil {
@@ -216,20 +327,49 @@ func (m *ParameterEditModel) saveParameter() tea.Cmd {
 		newValue = string(jsonBytes)
 	}
 
+	return m.put(newValue)
+}
+
+// put saves newValue unless someone else saved a newer version since the
+// edit started, which is reported with a saveConflictMsg instead
+func (m *ParameterEditModel) put(newValue string) tea.Cmd {
+	if m.cancelSave != nil {
+		m.cancelSave()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelSave = cancel
+	m.saving = true
+	m.err = nil
+
+	paramType := m.paramType
+	checkVersion := m.baseVersion != 0 && !m.overwrite
+	baseVersion := m.baseVersion
+
 	return tea.Batch(
 		m.spinner.Tick,
 		func() tea.Msg {
+			if checkVersion {
+				current, err := m.client.GetParameter(ctx, m.parameter.Name)
+				if err != nil {
+					return types.ErrorMsg{Err: err}
+				}
+				if current.Version != baseVersion {
+					return saveConflictMsg{Current: current, Value: newValue}
+				}
+			}
 			err := m.client.PutParameter(
 				ctx,
 				m.parameter.Name,
 				newValue,
-				m.parameter.Type,
+				paramType,
 			)
 			if err != nil {
 				return types.ErrorMsg{Err: err}
 			}
 			updatedParam := *m.parameter
 			updatedParam.Value = newValue
+			updatedParam.Type = paramType
 			return types.SaveSuccessMsg{Parameter: &updatedParam}
 		},
 	)
@@ -379,7 +519,7 @@ func parseNumber(s string) interface{} {
 // View renders the parameter edit screen
 func (m ParameterEditModel) View() string {
 	if m.saving {
-		return fmt.Sprintf("\n  %s Saving parameter...\n", m.spinner.View())
+		return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), progressText("Saving parameter..."))
 	}
 
 	var b strings.Builder
@@ -403,25 +543,125 @@ func (m ParameterEditModel) View() string {
 		b.WriteString("\n\n")
 	}
 
+	if m.merge != nil {
+		notice := fmt.Sprintf("Someone else saved version %d since you started editing version %d. Pick a side for each key that differs:",
+			m.merge.current.Version, m.baseVersion)
+		b.WriteString("  " + styles.WarningStyle.Render(notice))
+		b.WriteString("\n\n")
+		b.WriteString(m.merge.View(m.height, m.width))
+		b.WriteString("\n")
+		helpText := "↑/↓: select • space/←/→: mine/theirs • M: all mine • T: all theirs • enter: save merged • esc: back to editing"
+		b.WriteString("  " + styles.HelpStyle.Render(helpText))
+		return b.String()
+	}
+
 	// Show value editor
 	if m.isJSON && m.selectedKey != "" {
 		b.WriteString("  " + styles.LabelStyle.Render("Editing: "))
 		b.WriteString(m.selectedKey)
 		b.WriteString("\n\n")
+	} else if m.listItem > 0 {
+		b.WriteString("  " + styles.LabelStyle.Render("Editing: "))
+		b.WriteString(fmt.Sprintf("item %d", m.listItem))
+		b.WriteString("\n\n")
 	} else {
 		b.WriteString("  " + styles.LabelStyle.Render("Edit Value:"))
 		b.WriteString("\n\n")
 	}
 
-	b.WriteString(m.textarea.View())
+	if m.concealed {
+		b.WriteString(m.concealedView())
+	} else {
+		b.WriteString(m.textarea.View())
+	}
 	b.WriteString("\n\n")
+	b.WriteString(m.lint.View())
 
-	helpText := "Press 'ctrl+s' to save • 'esc' to cancel • 'ctrl+c' to quit"
+	b.WriteString("  " + styles.LabelStyle.Render("Type: "))
+	b.WriteString(m.paramType)
+	if m.parameter != nil && m.paramType != m.parameter.Type {
+		b.WriteString(fmt.Sprintf(" (was %s)", m.parameter.Type))
+		b.WriteString("\n  " + styles.WarningStyle.Render(typeChangeWarning(m.parameter.Type, m.paramType)))
+	}
+	b.WriteString("\n\n")
+
+	reveal := "'ctrl+r' to hide"
+	if m.concealed {
+		reveal = "'ctrl+r' to reveal"
+	}
+	helpText := "Press 'ctrl+s' to save • 'ctrl+y' to change type • " + reveal + " • 'esc' to cancel • 'ctrl+c' to quit"
 	b.WriteString("  " + styles.HelpStyle.Render(helpText))
 
 	return b.String()
 }
 
+// concealedView renders the editor with each character masked and the
+// cursor kept visible, in the space the textarea takes
+func (m ParameterEditModel) concealedView() string {
+	lines := strings.Split(m.textarea.Value(), "\n")
+	row := m.textarea.Line()
+	info := m.textarea.LineInfo()
+	col := info.StartColumn + info.ColumnOffset
+	width := max(1, m.textarea.Width())
+	height := max(1, m.textarea.Height())
+	cursor := lipgloss.NewStyle().Reverse(true)
+
+	start := max(0, min(row-height/2, len(lines)-height))
+	var out []string
+	for i := start; i < min(len(lines), start+height); i++ {
+		n := utf8.RuneCountInString(lines[i])
+		if i != row {
+			out = append(out, strings.Repeat(styles.Mask, min(n, width)))
+			continue
+		}
+		// Scroll long lines so the cursor stays in view
+		from := max(0, col-width+1)
+		before := strings.Repeat(styles.Mask, col-from)
+		under := " "
+		if col < n {
+			under = styles.Mask
+		}
+		after := strings.Repeat(styles.Mask, max(0, min(n-col-1, width-(col-from)-1)))
+		out = append(out, before+cursor.Render(under)+after)
+	}
+	for len(out) < height {
+		out = append(out, "")
+	}
+	return lipgloss.NewStyle().PaddingLeft(1).Render(strings.Join(out, "\n"))
+}
+
+// SetConcealSecrets masks SecureString values when editing starts
+func (m *ParameterEditModel) SetConcealSecrets(on bool) {
+	m.concealSecrets = on
+}
+
+// parameterTypes lists the SSM parameter types in the order ctrl+y cycles through them
+var parameterTypes = []string{"String", "StringList", "SecureString"}
+
+// nextParameterType returns the type following t in parameterTypes
+func nextParameterType(t string) string {
+	for i, pt := range parameterTypes {
+		if pt == t {
+			return parameterTypes[(i+1)%len(parameterTypes)]
+		}
+	}
+	return parameterTypes[0]
+}
+
+// typeChangeWarning describes the implications of saving a parameter with a different type
+func typeChangeWarning(from, to string) string {
+	switch {
+	case from == "SecureString" && to != "SecureString":
+		return "Warning: the value will be stored unencrypted and readable without KMS permissions"
+	case to == "SecureString":
+		return "Warning: the value will be encrypted with the default KMS key; readers will need kms:Decrypt"
+	case to == "StringList":
+		return "Warning: commas in the value will be treated as item separators"
+	default:
+		return "Warning: consumers expecting a list will now receive a single string"
+	}
+}
+
 // SetContext sets the profile and region context for the edit screen
 func (m *ParameterEditModel) SetContext(profile, region string) {
 	m.currentProfile = profile
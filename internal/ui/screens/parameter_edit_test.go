@@ -1,7 +1,10 @@
 package screens
 
 import (
+	"context"
+	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -117,3 +120,186 @@ func TestGetJSONValue_TopLevelArray(t *testing.T) {
 	}
 }
 
+func TestParameterEdit_CtrlYCyclesType(t *testing.T) {
+	m := NewParameterEdit()
+	param := &aws.Parameter{Name: "/test", Type: "String", Value: "plain"}
+	_ = m.LoadParameter(param, nil, "")
+
+	want := []string{"StringList", "SecureString", "String"}
+	for _, w := range want {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlY})
+		if m.paramType != w {
+			t.Fatalf("expected type %q after ctrl+y, got %q", w, m.paramType)
+		}
+	}
+
+	// ctrl+t is left to the textarea, which transposes characters with it
+	m.textarea.SetValue("ab")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	if m.paramType != "String" || m.textarea.Value() != "ba" {
+		t.Fatalf("expected ctrl+t to transpose the value, got type %q value %q", m.paramType, m.textarea.Value())
+	}
+	if param.Type != "String" {
+		t.Fatalf("original parameter type must not change, got %q", param.Type)
+	}
+}
+
+func TestParameterEdit_LintWarnsBeforeSaving(t *testing.T) {
+	m := NewParameterEdit()
+	_ = m.LoadParameter(&aws.Parameter{Name: "/test", Type: "String", Value: "token \n"}, nil, "")
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd != nil || m.saving {
+		t.Fatal("expected the first ctrl+s to show warnings instead of saving")
+	}
+	if len(m.lint.warnings) == 0 {
+		t.Fatal("expected warnings about the trailing whitespace and newline")
+	}
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd == nil || !m.saving {
+		t.Fatal("expected the second ctrl+s to save anyway")
+	}
+}
+
+// saveResult runs the save command of an edit screen and returns its result
+func saveResult(t *testing.T, cmd tea.Cmd) tea.Msg {
+	t.Helper()
+	for _, c := range cmd().(tea.BatchMsg) {
+		switch msg := c().(type) {
+		case saveConflictMsg, types.SaveSuccessMsg, types.ErrorMsg:
+			return msg
+		}
+	}
+	t.Fatal("expected the save to finish")
+	return nil
+}
+
+func TestParameterEdit_MergesJSONKeysOnConflict(t *testing.T) {
+	ctx := context.Background()
+	client := aws.NewDemoClient("merge-test", "eu-west-1")
+	name := "/merge/settings"
+	if err := client.PutParameter(ctx, name, `{"host":"a","port":1,"tags":["x","y"]}`, "String"); err != nil {
+		t.Fatal(err)
+	}
+	param, err := client.GetParameter(ctx, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewParameterEdit()
+	_ = m.LoadParameter(param, client, "port")
+	m.textarea.SetValue("2")
+
+	// Someone else changes the host and the port meanwhile
+	if err := client.PutParameter(ctx, name, `{"host":"b","port":3,"tags":["x","y"]}`, "String"); err != nil {
+		t.Fatal(err)
+	}
+
+	m, _ = m.Update(saveResult(t, m.saveParameter()))
+	if !m.Merging() {
+		t.Fatalf("expected a merge, got error %v", m.err)
+	}
+	keys := m.merge.keys
+	if len(keys) != 2 || keys[0].key != "host" || keys[0].useMine || keys[1].key != "port" || !keys[1].useMine || !keys[1].conflict {
+		t.Fatalf("unexpected merge keys %+v", keys)
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if _, ok := saveResult(t, cmd).(types.SaveSuccessMsg); !ok {
+		t.Fatal("expected the merged value to be saved")
+	}
+	saved, err := client.GetParameter(ctx, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(saved.Value), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["host"] != "b" || got["port"] != float64(2) || len(got["tags"].([]interface{})) != 2 {
+		t.Errorf("unexpected merged value %s", saved.Value)
+	}
+}
+
+func TestJSONMerge_RemovedKeys(t *testing.T) {
+	current := &aws.Parameter{Value: `{"a":1,"list":[1,2,3],"b":2}`}
+	merge, ok := newJSONMerge(`{"a":1,"list":[1,2,3]}`, `{"list":[1]}`, current)
+	if !ok {
+		t.Fatal("expected JSON values to merge")
+	}
+	edit := NewParameterEdit()
+	value, err := merge.value(edit.parsePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &got); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got["a"]; ok || got["b"] != float64(2) || len(got["list"].([]interface{})) != 1 {
+		t.Errorf("unexpected merged value %s", value)
+	}
+
+	if _, ok := newJSONMerge("plain", `{"a":1}`, current); ok {
+		t.Error("expected non-JSON values not to merge")
+	}
+}
+
+func TestParameterEdit_ListItem(t *testing.T) {
+	ctx := context.Background()
+	client := aws.NewDemoClient("list-item-test", "eu-west-1")
+	name := "/list/origins"
+	if err := client.PutParameter(ctx, name, "a,b,c", "StringList"); err != nil {
+		t.Fatal(err)
+	}
+	param, err := client.GetParameter(ctx, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewParameterEdit()
+	_ = m.LoadListItem(param, client, 2)
+	if got := m.textarea.Value(); got != "b" {
+		t.Fatalf("expected the second item to be edited, got %q", got)
+	}
+
+	m.textarea.SetValue("x,y")
+	if _, ok := m.saveParameter()().(types.ErrorMsg); !ok {
+		t.Fatal("expected items with commas to be refused")
+	}
+
+	m.textarea.SetValue("x")
+	if _, ok := saveResult(t, m.saveParameter()).(types.SaveSuccessMsg); !ok {
+		t.Fatal("expected the item to be saved")
+	}
+	saved, err := client.GetParameter(ctx, name)
+	if err != nil || saved.Value != "a,x,c" {
+		t.Fatalf("expected a,x,c, got %q, %v", saved.Value, err)
+	}
+}
+
+func TestParameterEdit_ConcealsSecureStrings(t *testing.T) {
+	m := NewParameterEdit()
+	m.SetSize(80, 30)
+	m.SetConcealSecrets(true)
+	_ = m.LoadParameter(&aws.Parameter{Name: "/db/password", Type: "SecureString", Value: "hunter2"}, nil, "")
+
+	if strings.Contains(m.View(), "hunter2") {
+		t.Fatal("expected the SecureString to be masked")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if m.textarea.Value() != "hunter2x" || strings.Contains(m.View(), "hunter2") {
+		t.Fatalf("expected typing to stay concealed, value %q", m.textarea.Value())
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if !strings.Contains(m.View(), "hunter2x") {
+		t.Fatal("expected ctrl+r to reveal the value")
+	}
+
+	_ = m.LoadParameter(&aws.Parameter{Name: "/db/host", Type: "String", Value: "db.internal"}, nil, "")
+	if !strings.Contains(m.View(), "db.internal") {
+		t.Fatal("expected plain Strings to stay visible")
+	}
+}
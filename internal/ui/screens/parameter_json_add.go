@@ -45,8 +45,8 @@ func NewJSONAdd() JSONAddModel {
 	valueInput.ShowLineNumbers = false
 
 	s := spinner.New()
-	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	s.Spinner = styles.Spinner
+	s.Style = lipgloss.NewStyle().Foreground(styles.Secondary)
 
 	return JSONAddModel{
 		keyInput:     keyInput,
@@ -231,7 +231,7 @@ func (m *JSONAddModel) saveNewKey() tea.Cmd {
 // View renders the JSON add screen
 func (m JSONAddModel) View() string {
 	if m.saving {
-		return fmt.Sprintf("\n  %s Saving parameter...\n", m.spinner.View())
+		return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), progressText("Saving parameter..."))
 	}
 
 	var b strings.Builder
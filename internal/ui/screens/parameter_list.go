@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
@@ -14,6 +17,7 @@ import (
 	"github.com/ilia/ps9s/internal/aws"
 	cfg "github.com/ilia/ps9s/internal/config"
 	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/terraform"
 	"github.com/ilia/ps9s/internal/types"
 )
 
@@ -24,9 +28,25 @@ type parameterItem struct {
 
 func (i parameterItem) FilterValue() string { return i.param.Name }
 
-type paramDelegate struct{}
+type paramDelegate struct {
+	times      TimestampFormat
+	detailed   bool              // Show a metadata line under each name
+	showValues bool              // Show a value preview after each name
+	values     map[string]string // Values fetched for the preview, by name
+	marked     map[string]bool   // Names marked for bulk actions
+	changes    *listChanges      // Changes found by the last refresh
+	terraform  terraform.Resources
+	naming     *regexp.Regexp // Naming convention; names that break it are flagged
+	columns    []string       // Metadata columns right of the name, in order
+}
+
+func (d paramDelegate) Height() int {
+	if d.detailed {
+		return 2
+	}
+	return 1
+}
 
-func (d paramDelegate) Height() int                             { return 1 }
 func (d paramDelegate) Spacing() int                            { return 0 }
 func (d paramDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
 func (d paramDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
@@ -35,19 +55,187 @@ func (d paramDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 		return
 	}
 
+	// Mark column, only shown while something is marked
+	mark := ""
+	if len(d.marked) > 0 {
+		mark = "  "
+		if d.marked[i.param.Name] {
+			mark = lipgloss.NewStyle().Foreground(styles.Secondary).Render("● ")
+		}
+	}
+	// Change column, shown for a while after a refresh found changes
+	if d.changes.active() {
+		mark += d.changes.marker(i.param.Name)
+	}
+
 	var nameStr string
 	if index == m.Index() {
 		nameStr = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("86")).
+			Foreground(styles.Primary).
+			Bold(true).
+			Render(styles.Cursor+" ") + mark + typeBadge(i.param.Type) + " " + lipgloss.NewStyle().
+			Foreground(styles.Primary).
 			Bold(true).
-			Render("▸ " + i.param.Name)
-	} else {
-		nameStr = lipgloss.NewStyle().
-			PaddingLeft(2).
 			Render(i.param.Name)
+	} else {
+		nameStr = "  " + mark + typeBadge(i.param.Type) + " " + i.param.Name
+	}
+	if aws.IsShared(i.param.Name) {
+		nameStr += " " + lipgloss.NewStyle().Foreground(styles.Warning).Render("[shared]")
+	}
+	if _, ok := d.terraform[i.param.Name]; ok {
+		nameStr += " " + lipgloss.NewStyle().Foreground(styles.Secondary).Render("[tf]")
+	}
+	if d.naming != nil && !aws.IsShared(i.param.Name) && !d.naming.MatchString(i.param.Name) {
+		nameStr += " " + lipgloss.NewStyle().Foreground(styles.Warning).Render("[naming]")
 	}
 
-	fmt.Fprint(w, nameStr)
+	// Right-aligned metadata columns, dropped when the terminal is too narrow
+	columnStyle := lipgloss.NewStyle().
+		Foreground(styles.Subtle).
+		Align(lipgloss.Right)
+	if d.showValues {
+		nameStr += "  " + columnStyle.UnsetAlign().Render(valuePreview(i.param, d.values))
+	}
+	var columns string
+	filled := false
+	for _, name := range d.columns {
+		text, width := d.column(name, i.param)
+		columns += columnStyle.Width(width).Render(truncateToWidth(text, width-1))
+		filled = filled || text != ""
+	}
+	gap := m.Width() - lipgloss.Width(nameStr) - lipgloss.Width(columns)
+	line := nameStr
+	if filled && gap >= 1 {
+		line = nameStr + strings.Repeat(" ", gap) + columns
+	}
+
+	if d.detailed {
+		details := truncateToWidth(parameterDetails(i.param, d.times), m.Width()-7)
+		line += "\n" + columnStyle.UnsetAlign().PaddingLeft(7).Render(details)
+	}
+
+	fmt.Fprint(w, line)
+}
+
+// column returns the text of the metadata column name for p and the width
+// of the column
+func (d paramDelegate) column(name string, p *aws.Parameter) (string, int) {
+	switch name {
+	case "type":
+		return p.Type, typeColumnWidth
+	case "version":
+		if p.Version == 0 {
+			return "", versionColumnWidth
+		}
+		return fmt.Sprintf("v%d", p.Version), versionColumnWidth
+	case "tier":
+		return p.Tier, tierColumnWidth
+	case "modified":
+		return d.times.Format(p.LastModifiedDate), modifiedColumnWidth
+	case "user":
+		// The name at the end of the IAM ARN is enough to recognize someone
+		user := p.LastModifiedUser
+		return user[strings.LastIndex(user, "/")+1:], userColumnWidth
+	case "size":
+		// Known once the value was fetched for the preview
+		if v, ok := d.values[p.Name]; ok && p.Type != "SecureString" {
+			return formatBytes(len(v)), sizeColumnWidth
+		}
+		return "", sizeColumnWidth
+	}
+	return "", 0
+}
+
+// parameterDetails summarises parameter metadata for the detailed list mode
+func parameterDetails(p *aws.Parameter, times TimestampFormat) string {
+	parts := []string{fmt.Sprintf("v%d", p.Version), p.Type}
+	if modified := times.Format(p.LastModifiedDate); modified != "" {
+		if p.LastModifiedUser != "" {
+			modified += " by " + p.LastModifiedUser
+		}
+		parts = append(parts, "modified "+modified)
+	}
+	if p.KeyID != "" {
+		parts = append(parts, "key "+p.KeyID)
+	}
+	if p.DataType != "" && p.DataType != "text" {
+		parts = append(parts, p.DataType)
+	}
+	return strings.Join(parts, " • ")
+}
+
+// valuePreviewWidth is the number of value characters shown in the value column
+const valuePreviewWidth = 40
+
+// valuePreview renders the start of a parameter's value on one line. SecureStrings
+// are masked and values not fetched yet show as "…".
+func valuePreview(p *aws.Parameter, values map[string]string) string {
+	if p.Type == "SecureString" {
+		return "••••••"
+	}
+	v, ok := values[p.Name]
+	if !ok {
+		return "…"
+	}
+	return truncateToWidth(strings.Join(strings.Fields(v), " "), valuePreviewWidth)
+}
+
+// typeBadge renders a fixed-width colored marker for the parameter type
+func typeBadge(paramType string) string {
+	var label string
+	var color lipgloss.Color
+	switch paramType {
+	case "String":
+		label, color = "[S] ", "245"
+	case "SecureString":
+		label, color = "[SS]", "205"
+	case "StringList":
+		label, color = "[SL]", "39"
+	default:
+		label, color = "[?] ", "240"
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(label)
+}
+
+// tierColumnWidth fits the longest tier name, "Intelligent-Tiering"
+const tierColumnWidth = 20
+
+// modifiedColumnWidth fits relative times and the default absolute layout
+const modifiedColumnWidth = 18
+
+// Widths of the other metadata columns
+const (
+	typeColumnWidth    = 14
+	versionColumnWidth = 7
+	userColumnWidth    = 20
+	sizeColumnWidth    = 10
+)
+
+// Lists at least this long filter after typing pauses instead of on every key
+const filterDebounceThreshold = 5000
+
+// filterDebounce is the typing pause that applies the filter on long lists
+const filterDebounce = 150 * time.Millisecond
+
+// filterTickMsg applies the filter if no key was typed since it was scheduled
+type filterTickMsg struct {
+	seq int
+}
+
+// Values for the preview column are prefetched for rows within
+// valuePrefetchRadius of the cursor, in GetParameters-sized batches with a
+// few requests in flight at once (more when the client allows it)
+const (
+	valuePrefetchRadius     = 15
+	maxValueBatch           = 10
+	maxValueBatchesInFlight = 2
+)
+
+// valueBatch is a value request in flight for the preview column
+type valueBatch struct {
+	names  []string
+	cancel context.CancelFunc
 }
 
 // ParameterListModel represents the parameter list screen
@@ -59,12 +247,45 @@ type ParameterListModel struct {
 	spinner        spinner.Model
 	loading        bool
 	SearchActive   bool // Exported so root model can check it
+	PeekActive     bool // A value peek is shown; exported so esc closes it
+	OpenActive     bool // The open-by-name prompt is shown; exported so esc closes it
+	openInput      textinput.Model
+	peek           *aws.Parameter
+	peekErr        error
+	advancedOnly   bool              // Only show Advanced tier parameters
+	dryRun         bool              // Writes are previewed instead of sent
+	readOnly       bool              // Writes are disabled
+	pageSize       int               // Items per page; 0 fills the available height
+	showValues     bool              // Show the value preview column
+	values         map[string]string // Values fetched for the preview column, by name
+	pendingValues  map[string]bool   // Names whose values are being fetched
+	marked         map[string]bool   // Names marked with space for bulk actions
+	snapshot       map[string]int64  // Versions seen by the previous load, nil before the first
+	changes        *listChanges      // Changes found by the last refresh
+	filterQuery    string            // Lowercased query behind filtered
+	filterAdvanced bool              // advancedOnly value behind filtered
+	filterValid    bool              // filtered matches the loaded parameters
+	filterSeq      int               // Latest scheduled debounced filter
+	delegate       paramDelegate
+	height         int // Last height given to the screen
 	client         *aws.Client
+	endpoint       string // Custom endpoint of the client, shown in the title
+	pathPrefix     string // Listing prefix of the client, shown in the title
 	err            error
 	currentProfile string
 	currentRegion  string
 	// Recent profile+region entries (most recent first)
 	recents []cfg.RecentEntry
+	// Value requests in flight for the preview column, by batch id
+	valueBatches   map[int]*valueBatch
+	nextValueBatch int
+	// Result of the last repeated action, cleared by the next key
+	status    string
+	statusErr bool
+	// Bulk action menu for the marked parameters; exported so esc closes it
+	BulkActive bool
+	tagInput   textinput.Model // Tag added to the marked parameters, shown while tagging
+	tagging    bool
 }
 
 // NewParameterList creates a new parameter list screen
@@ -74,15 +295,32 @@ func NewParameterList() ParameterListModel {
 	ti.Placeholder = "Search parameters..."
 	ti.CharLimit = 156
 
+	// Input for opening a parameter that is not listed, such as a shared ARN
+	oi := textinput.New()
+	oi.Placeholder = "/path/name or arn:aws:ssm:region:account:parameter/name"
+	oi.CharLimit = 2048
+
+	// Input for the key=value tag added to the marked parameters
+	tagIn := textinput.New()
+	tagIn.Placeholder = "key=value"
+	tagIn.CharLimit = 384
+
 	// Initialize spinner
 	s := spinner.New()
-	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	s.Spinner = styles.Spinner
+	s.Style = lipgloss.NewStyle().Foreground(styles.Secondary)
 
 	const defaultWidth = 80
 	const defaultHeight = 20
 
-	l := list.New([]list.Item{}, paramDelegate{}, defaultWidth, defaultHeight)
+	delegate := paramDelegate{
+		values:  make(map[string]string),
+		marked:  make(map[string]bool),
+		changes: &listChanges{},
+		columns: cfg.DefaultColumns,
+	}
+
+	l := list.New([]list.Item{}, delegate, defaultWidth, defaultHeight)
 	l.Title = "Parameters"
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(false)
@@ -92,9 +330,17 @@ func NewParameterList() ParameterListModel {
 	l.Styles.HelpStyle = list.DefaultStyles().HelpStyle.PaddingLeft(4).PaddingBottom(1)
 
 	return ParameterListModel{
-		searchInput: ti,
-		spinner:     s,
-		list:        l,
+		openInput:     oi,
+		searchInput:   ti,
+		spinner:       s,
+		list:          l,
+		delegate:      delegate,
+		values:        delegate.values,
+		pendingValues: make(map[string]bool),
+		valueBatches:  make(map[int]*valueBatch),
+		marked:        delegate.marked,
+		changes:       delegate.changes,
+		tagInput:      tagIn,
 	}
 }
 
@@ -106,8 +352,17 @@ func (m ParameterListModel) Init() tea.Cmd {
 // LoadParameters starts loading parameters from AWS
 func (m *ParameterListModel) LoadParameters(client *aws.Client) tea.Cmd {
 	m.client = client
+	m.endpoint = client.Endpoint()
+	m.pathPrefix = client.PathPrefix()
+	m.updateListTitle()
 	m.loading = true
 	m.err = nil
+	m.cancelValueBatches()
+	m.values = make(map[string]string)
+	m.pendingValues = make(map[string]bool)
+	m.delegate.values = m.values
+	m.list.SetDelegate(m.delegate)
+	profile, region := m.currentProfile, m.currentRegion
 	return tea.Batch(
 		m.spinner.Tick,
 		func() tea.Msg {
@@ -115,7 +370,7 @@ func (m *ParameterListModel) LoadParameters(client *aws.Client) tea.Cmd {
 			if err != nil {
 				return types.ErrorMsg{Err: err}
 			}
-			return types.ParametersLoadedMsg{Parameters: params}
+			return types.ParametersLoadedMsg{Parameters: params, Profile: profile, Region: region}
 		},
 	)
 }
@@ -129,35 +384,132 @@ func (m *ParameterListModel) SetRecents(entries []cfg.RecentEntry) {
 func (m ParameterListModel) Update(msg tea.Msg) (ParameterListModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case types.ParametersLoadedMsg:
+		// Compare with the previous load so a refresh highlights what changed
+		if m.snapshot != nil {
+			m.changes.kinds, m.changes.removed = diffSnapshot(m.snapshot, msg.Parameters)
+			m.changes.until = time.Now().Add(changeHighlightDuration)
+		}
+		m.snapshot = snapshotVersions(msg.Parameters)
 		m.parameters = msg.Parameters
-		m.filtered = msg.Parameters
 		m.loading = false
-		m.updateList()
-		m.updateListTitle()
+		// Forget marks of parameters that are gone, e.g. after a bulk delete
+		for name := range m.marked {
+			if _, ok := m.snapshot[name]; !ok {
+				delete(m.marked, name)
+			}
+		}
+		m.filterValid = false
+		m.filterParameters()
+		return m, m.LoadVisibleValues()
+
+	case filterTickMsg:
+		if msg.seq != m.filterSeq || !m.filterStale() {
+			return m, nil
+		}
+		m.filterParameters()
+		return m, m.LoadVisibleValues()
+
+	case types.PeekLoadedMsg:
+		if m.PeekActive {
+			m.peek = msg.Parameter
+			m.peekErr = msg.Err
+		}
 		return m, nil
 
+	case types.ValuesLoadedMsg:
+		b, ok := m.valueBatches[msg.Batch]
+		if !ok {
+			// Canceled after the cursor moved away; the names are queued again when near
+			return m, nil
+		}
+		b.cancel()
+		delete(m.valueBatches, msg.Batch)
+		for _, name := range msg.Names {
+			delete(m.pendingValues, name)
+			// Names that failed or vanished show an empty preview instead of retrying
+			m.values[name] = msg.Values[name]
+		}
+		return m, m.LoadVisibleValues()
+
 	case types.ErrorMsg:
 		m.loading = false
 		m.err = msg.Err
 		return m, nil
 
 	case tea.WindowSizeMsg:
-		m.list.SetWidth(msg.Width)
-		h := msg.Height - 7 // Leave space for help text, search and recents (5 lines)
-		if m.SearchActive {
-			h -= 2
+		m.SetSize(msg.Width, msg.Height)
+		return m, nil
+
+	case types.ActionRepeatedMsg:
+		m.status = msg.Status
+		m.statusErr = msg.Err != nil
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Repeat failed: %v", msg.Err)
 		}
-		// Additional space for recents if present
-		if len(m.recents) > 0 {
-			h -= 7 // 1 label line + 5 recent entries + 1 spacing
+		return m, nil
+
+	case types.MirroredMsg:
+		m.status = msg.Status
+		m.statusErr = msg.Err != nil
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Git mirror failed: %v", msg.Err)
+		}
+		return m, nil
+
+	case types.ParametersTaggedMsg:
+		m.status = fmt.Sprintf("Tagged %d parameters %s=%s", len(msg.Names), msg.Tag.Key, msg.Tag.Value)
+		m.statusErr = msg.Err != nil
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Tagged %d parameters, then failed: %v", len(msg.Names), msg.Err)
+		}
+		return m, nil
+
+	case types.SubshellExitedMsg:
+		m.status = fmt.Sprintf("Subshell with %d parameters exited", msg.Count)
+		m.statusErr = msg.Err != nil
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Subshell failed: %v", msg.Err)
 		}
-		m.list.SetHeight(h)
 		return m, nil
 
 	case tea.KeyMsg:
 		if m.loading {
 			return m, nil
 		}
+		m.status = ""
+
+		// Any key closes the value peek
+		if m.PeekActive {
+			m.closePeek()
+			return m, nil
+		}
+
+		if m.OpenActive {
+			switch msg.String() {
+			case "esc":
+				m.OpenActive = false
+				m.openInput.Blur()
+				return m, nil
+			case "enter":
+				m.OpenActive = false
+				m.openInput.Blur()
+				name := strings.TrimSpace(m.openInput.Value())
+				if name == "" {
+					return m, nil
+				}
+				return m, func() tea.Msg {
+					return types.ViewParameterMsg{Parameter: m.listedOrNew(name)}
+				}
+			default:
+				var cmd tea.Cmd
+				m.openInput, cmd = m.openInput.Update(msg)
+				return m, cmd
+			}
+		}
+
+		if m.BulkActive {
+			return m.updateBulk(msg)
+		}
 
 		// Handle search mode - escape exits search, doesn't go back
 		if m.SearchActive {
@@ -166,18 +518,28 @@ func (m ParameterListModel) Update(msg tea.Msg) (ParameterListModel, tea.Cmd) {
 				m.SearchActive = false
 				m.searchInput.Blur()
 				m.searchInput.SetValue("")
-				m.filtered = m.parameters
-				m.updateList()
+				m.filterParameters()
 				return m, nil
 			case "enter":
 				m.SearchActive = false
 				m.searchInput.Blur()
+				if m.filterStale() {
+					m.filterParameters()
+					return m, m.LoadVisibleValues()
+				}
 				return m, nil
 			default:
 				var cmd tea.Cmd
 				m.searchInput, cmd = m.searchInput.Update(msg)
+				if len(m.parameters) >= filterDebounceThreshold {
+					m.filterSeq++
+					seq := m.filterSeq
+					return m, tea.Batch(cmd, tea.Tick(filterDebounce, func(time.Time) tea.Msg {
+						return filterTickMsg{seq: seq}
+					}))
+				}
 				m.filterParameters()
-				return m, cmd
+				return m, tea.Batch(cmd, m.LoadVisibleValues())
 			}
 		}
 
@@ -193,8 +555,6 @@ func (m ParameterListModel) Update(msg tea.Msg) (ParameterListModel, tea.Cmd) {
 			return m, tea.Quit
 		}
 
-
-
 		// Regular navigation
 		switch msg.String() {
 		case "esc":
@@ -213,9 +573,139 @@ func (m ParameterListModel) Update(msg tea.Msg) (ParameterListModel, tea.Cmd) {
 					return types.ViewParameterMsg{Parameter: item.param}
 				}
 			}
+		case "o":
+			// Open a parameter by name or ARN, e.g. one shared from another account
+			m.OpenActive = true
+			m.openInput.SetValue("")
+			return m, m.openInput.Focus()
+		case "H":
+			// Switch to the hierarchical view
+			return m, func() tea.Msg { return types.ShowTreeMsg{} }
+		case "C":
+			// Browse AppConfig configurations stored next to the parameters
+			return m, func() tea.Msg { return types.ShowAppConfigMsg{} }
+		case "n":
+			// Create a new parameter
+			return m, func() tea.Msg { return types.CreateParameterMsg{} }
+		case "R":
+			// Reload, highlighting what changed since the last load
+			if m.client != nil {
+				return m, m.LoadParameters(m.client)
+			}
+		case "A":
+			// Toggle advanced-tier filter
+			m.advancedOnly = !m.advancedOnly
+			m.filterParameters()
+			return m, m.LoadVisibleValues()
+		case "D":
+			// Toggle global dry-run mode
+			return m, func() tea.Msg { return types.ToggleDryRunMsg{} }
+		case "m":
+			// Toggle compact/detailed display (persisted by the root model)
+			return m, func() tea.Msg { return types.ToggleListModeMsg{} }
+		case "v":
+			// Peek at the selected value without leaving the list
+			if item, ok := m.list.SelectedItem().(parameterItem); ok && m.client != nil {
+				return m, m.openPeek(item.param)
+			}
+		case "L":
+			// List the listed parameters by value size
+			if params := m.Parameters(); len(params) > 0 {
+				return m, func() tea.Msg {
+					return types.ShowReportMsg{Kind: types.ReportLargest, Parameters: params, Scope: m.pathPrefix}
+				}
+			}
+		case "O":
+			// List the listed parameters nobody changed in a long time
+			if params := m.Parameters(); len(params) > 0 {
+				return m, func() tea.Msg {
+					return types.ShowReportMsg{Kind: types.ReportStale, Parameters: params, Scope: m.pathPrefix}
+				}
+			}
+		case "F":
+			// Compare the listed parameters with a snapshot directory
+			return m, func() tea.Msg { return types.ShowDriftMsg{} }
+		case "S":
+			// Show counts by type, tier, path and age
+			return m, func() tea.Msg { return types.ShowStatsMsg{} }
+		case "a":
+			// Audit the listed parameters for blank and placeholder values
+			if params := m.Parameters(); len(params) > 0 {
+				return m, func() tea.Msg {
+					return types.ShowReportMsg{Kind: types.ReportPlaceholders, Parameters: params, Scope: m.pathPrefix}
+				}
+			}
+		case "I":
+			// Show the IAM policy ps9s needs
+			return m, func() tea.Msg { return types.ShowIAMPolicyMsg{} }
+		case "!":
+			// Open a shell with the marked parameters, or the selected one, exported
+			params := m.Marked()
+			if len(params) == 0 {
+				if item, ok := m.list.SelectedItem().(parameterItem); ok {
+					params = []*aws.Parameter{item.param}
+				}
+			}
+			if len(params) > 0 {
+				return m, func() tea.Msg { return types.SubshellMsg{Parameters: params} }
+			}
+		case ".":
+			// Repeat the last action on the selected parameter
+			if item, ok := m.list.SelectedItem().(parameterItem); ok {
+				return m, func() tea.Msg { return types.RepeatActionMsg{Parameter: item.param} }
+			}
+		case " ":
+			// Mark or unmark the selected parameter and move on
+			if item, ok := m.list.SelectedItem().(parameterItem); ok {
+				if m.marked[item.param.Name] {
+					delete(m.marked, item.param.Name)
+				} else {
+					m.marked[item.param.Name] = true
+				}
+				m.list.CursorDown()
+				m.updateListTitle()
+			}
+			return m, m.LoadVisibleValues()
+		case "b":
+			// Pick an action for all the marked parameters
+			if len(m.Marked()) == 0 {
+				m.status = "Mark parameters with space first"
+				m.statusErr = true
+				return m, nil
+			}
+			m.BulkActive = true
+			return m, nil
+		case "x":
+			// Export the marked parameters, or the selected one
+			params := m.Marked()
+			if len(params) == 0 {
+				if item, ok := m.list.SelectedItem().(parameterItem); ok {
+					params = []*aws.Parameter{item.param}
+				}
+			}
+			if len(params) > 0 {
+				return m, func() tea.Msg { return types.ExportParametersMsg{Parameters: params} }
+			}
+		case "X":
+			// Export every parameter the search and filters leave listed
+			if m.filterStale() {
+				m.filterParameters()
+			}
+			if params := m.filtered; len(params) > 0 {
+				return m, func() tea.Msg { return types.ExportParametersMsg{Parameters: params} }
+			}
+		case "V":
+			// Toggle the value preview column (persisted by the root model)
+			return m, func() tea.Msg { return types.ToggleValuePreviewMsg{} }
+		case "t":
+			// Toggle relative/absolute timestamps everywhere
+			return m, func() tea.Msg { return types.ToggleTimestampsMsg{} }
 		case "p":
 			// Jump to profile selection
 			return m, func() tea.Msg { return types.GoToProfileSelectionMsg{} }
+		case "r":
+			// Pick another region for the current profile
+			return m, func() tea.Msg { return types.SwitchRegionMsg{} }
 		case "1", "2", "3", "4", "5":
 			// Switch to a recent entry if present
 			idx := int(msg.String()[0] - '1')
@@ -239,16 +729,217 @@ func (m ParameterListModel) Update(msg tea.Msg) (ParameterListModel, tea.Cmd) {
 		return m, cmd
 	}
 
-	// Update list for navigation keys
+	// Update list for navigation keys, fetching values for a newly shown page
 	var cmd tea.Cmd
 	m.list, cmd = m.list.Update(msg)
-	return m, cmd
+	return m, tea.Batch(cmd, m.LoadVisibleValues())
+}
+
+// updateBulk handles keys while the bulk action menu is open
+func (m ParameterListModel) updateBulk(msg tea.KeyMsg) (ParameterListModel, tea.Cmd) {
+	params := m.Marked()
+	bulk := func(action types.BulkAction, tag aws.Tag) tea.Cmd {
+		return func() tea.Msg { return types.BulkActionMsg{Action: action, Parameters: params, Tag: tag} }
+	}
+
+	if m.tagging {
+		switch msg.String() {
+		case "esc":
+			m.tagging = false
+			m.tagInput.Blur()
+			return m, nil
+		case "enter":
+			key, value, _ := strings.Cut(m.tagInput.Value(), "=")
+			if key = strings.TrimSpace(key); key == "" {
+				m.status = "Enter the tag as key=value"
+				m.statusErr = true
+				return m, nil
+			}
+			m.tagging = false
+			m.BulkActive = false
+			m.tagInput.Blur()
+			return m, bulk(types.BulkTag, aws.Tag{Key: key, Value: strings.TrimSpace(value)})
+		}
+		m.status = ""
+		var cmd tea.Cmd
+		m.tagInput, cmd = m.tagInput.Update(msg)
+		return m, cmd
+	}
+
+	m.BulkActive = false
+	switch msg.String() {
+	case "d":
+		return m, bulk(types.BulkDelete, aws.Tag{})
+	case "t":
+		m.BulkActive = true
+		m.tagging = true
+		m.tagInput.SetValue("")
+		return m, m.tagInput.Focus()
+	case "c":
+		return m, bulk(types.BulkCopy, aws.Tag{})
+	case "x":
+		return m, bulk(types.BulkExport, aws.Tag{})
+	}
+	return m, nil
+}
+
+// openPeek fetches the decrypted value of param for the peek popup, reusing a
+// prefetched value when it needs no decryption
+func (m *ParameterListModel) openPeek(param *aws.Parameter) tea.Cmd {
+	m.PeekActive = true
+	m.peek = nil
+	m.peekErr = nil
+	if v, ok := m.values[param.Name]; ok && param.Type != "SecureString" {
+		m.peek = &aws.Parameter{Name: param.Name, Type: param.Type, Value: v}
+		return nil
+	}
+	name := param.Name
+	client := m.client
+	return func() tea.Msg {
+		p, err := client.GetParameter(context.Background(), name)
+		return types.PeekLoadedMsg{Parameter: p, Err: err}
+	}
+}
+
+// closePeek hides the peek popup
+func (m *ParameterListModel) closePeek() {
+	m.PeekActive = false
+	m.peek = nil
+	m.peekErr = nil
+}
+
+// maxPeekLines limits how much of a value the peek popup shows
+const maxPeekLines = 8
+
+// renderPeek renders the peek popup shown in place of the help line
+func (m ParameterListModel) renderPeek() string {
+	var content string
+	switch {
+	case m.peekErr != nil:
+		content = styles.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.peekErr))
+	case m.peek == nil:
+		content = "Loading value..."
+	default:
+		lines := strings.Split(indentJSON(m.peek.Value), "\n")
+		if len(lines) > maxPeekLines {
+			more := len(lines) - maxPeekLines
+			lines = append(lines[:maxPeekLines], styles.HelpStyle.UnsetMarginTop().Render(fmt.Sprintf("… %d more lines", more)))
+		}
+		content = styles.LabelStyle.Render(m.peek.Name) + "\n\n" + strings.Join(lines, "\n")
+	}
+
+	return lipgloss.NewStyle().
+		Border(styles.Border).
+		BorderForeground(styles.Subtle).
+		Padding(0, 1).
+		Width(m.list.Width()-4).
+		Render(content) + "\n" +
+		styles.HelpStyle.UnsetMarginTop().Render("press any key to close")
+}
+
+// LoadVisibleValues queues value fetches for the rows near the cursor when the
+// value or size column is shown. Requests whose rows have all moved out of
+// reach are canceled, and the queue continues as batches complete.
+func (m *ParameterListModel) LoadVisibleValues() tea.Cmd {
+	if (!m.showValues && !slices.Contains(m.delegate.columns, "size")) || m.client == nil || m.loading {
+		return nil
+	}
+
+	near := m.prefetchNames()
+	inReach := make(map[string]bool, len(near))
+	for _, name := range near {
+		inReach[name] = true
+	}
+	for id, b := range m.valueBatches {
+		if !slices.ContainsFunc(b.names, func(name string) bool { return inReach[name] }) {
+			b.cancel()
+			delete(m.valueBatches, id)
+			for _, name := range b.names {
+				delete(m.pendingValues, name)
+			}
+		}
+	}
+
+	var queue []string
+	for _, name := range near {
+		if _, ok := m.values[name]; !ok && !m.pendingValues[name] {
+			queue = append(queue, name)
+		}
+	}
+
+	var cmds []tea.Cmd
+	inFlight := max(maxValueBatchesInFlight, m.client.Concurrency())
+	for len(queue) > 0 && len(m.valueBatches) < inFlight {
+		n := min(maxValueBatch, len(queue))
+		cmds = append(cmds, m.fetchValues(queue[:n]))
+		queue = queue[n:]
+	}
+	return tea.Batch(cmds...)
+}
+
+// prefetchNames lists the previewable rows on the current page or within
+// valuePrefetchRadius of the cursor, nearest first
+func (m ParameterListModel) prefetchNames() []string {
+	items := m.list.Items()
+	if len(items) == 0 {
+		return nil
+	}
+	cursor := m.list.Index()
+	start, end := m.list.Paginator.GetSliceBounds(len(items))
+	start = max(0, min(start, cursor-valuePrefetchRadius))
+	end = min(len(items), max(end, cursor+valuePrefetchRadius+1))
+
+	var names []string
+	add := func(i int) {
+		if p := items[i].(parameterItem).param; p.Type != "SecureString" {
+			names = append(names, p.Name)
+		}
+	}
+	add(cursor)
+	for d := 1; cursor-d >= start || cursor+d < end; d++ {
+		if cursor+d < end {
+			add(cursor + d)
+		}
+		if cursor-d >= start {
+			add(cursor - d)
+		}
+	}
+	return names
+}
+
+// fetchValues starts a batch fetching the undecrypted values of names
+func (m *ParameterListModel) fetchValues(names []string) tea.Cmd {
+	id := m.nextValueBatch
+	m.nextValueBatch++
+	ctx, cancel := context.WithCancel(context.Background())
+	m.valueBatches[id] = &valueBatch{names: names, cancel: cancel}
+	for _, name := range names {
+		m.pendingValues[name] = true
+	}
+
+	client, profile, region := m.client, m.currentProfile, m.currentRegion
+	return func() tea.Msg {
+		// The preview is best effort, so errors leave the values empty
+		values, _ := client.GetParameterValues(ctx, names)
+		return types.ValuesLoadedMsg{Batch: id, Names: names, Values: values, Profile: profile, Region: region}
+	}
+}
+
+// cancelValueBatches stops every value request in flight
+func (m *ParameterListModel) cancelValueBatches() {
+	for id, b := range m.valueBatches {
+		b.cancel()
+		delete(m.valueBatches, id)
+		for _, name := range b.names {
+			delete(m.pendingValues, name)
+		}
+	}
 }
 
 // View renders the parameter list
 func (m ParameterListModel) View() string {
 	if m.loading {
-		return fmt.Sprintf("\n  %s Loading parameters...\n\n", m.spinner.View())
+		return fmt.Sprintf("\n  %s %s\n\n", m.spinner.View(), progressText("Loading parameters..."))
 	}
 
 	if m.err != nil {
@@ -261,7 +952,31 @@ func (m ParameterListModel) View() string {
 	b.WriteString(m.list.View())
 	b.WriteString("\n")
 
-	if m.SearchActive {
+	if m.PeekActive {
+		b.WriteString("\n")
+		b.WriteString(m.renderPeek())
+	} else if m.BulkActive {
+		b.WriteString("\n")
+		if m.tagging {
+			b.WriteString(styles.LabelStyle.Render(fmt.Sprintf("Tag %d marked parameters: ", len(m.Marked()))))
+			b.WriteString(m.tagInput.View())
+			b.WriteString("\n")
+			if m.status != "" {
+				b.WriteString(styles.ErrorStyle.Render(m.status) + "\n")
+			}
+			b.WriteString(styles.HelpStyle.Render("enter: add the tag • esc: cancel"))
+		} else {
+			b.WriteString(styles.LabelStyle.Render(fmt.Sprintf("%d marked parameters: ", len(m.Marked()))))
+			b.WriteString("\n")
+			b.WriteString(styles.HelpStyle.Render("d: delete • t: tag • c: copy to another prefix or context • x: export • esc: cancel"))
+		}
+	} else if m.OpenActive {
+		b.WriteString("\n")
+		b.WriteString(styles.LabelStyle.Render("Open: "))
+		b.WriteString(m.openInput.View())
+		b.WriteString("\n")
+		b.WriteString(styles.HelpStyle.Render("enter: open • esc: cancel"))
+	} else if m.SearchActive {
 		b.WriteString("\n")
 		b.WriteString(styles.LabelStyle.Render("Search: "))
 		b.WriteString(m.searchInput.View())
@@ -269,10 +984,22 @@ func (m ParameterListModel) View() string {
 		b.WriteString(styles.HelpStyle.Render("esc: cancel • enter: apply"))
 	} else {
 		// Integrated help with navigation and custom keys
-		help := "↑/↓: navigate • enter: view • /: search • p: profile • esc: back • q: quit"
+		help := "↑/↓: navigate • enter: view • /: search • o: open name/ARN • R: refresh • H: tree • C: AppConfig • I: IAM policy • a: audit placeholders • S: stats • L: largest values • O: stale • F: drift • n: new • A: advanced only • m: mode • v: peek • V: values • space: mark • b: bulk actions on marked • .: repeat • x: export • X: export listed • !: subshell • t: times • D: dry run • p: profile • r: region • esc: back • q: quit"
 		if len(m.recents) > 0 {
 			help += " • 1-5: switch"
 		}
+		if m.changes.active() && len(m.changes.removed) > 0 {
+			b.WriteString(styles.ErrorStyle.Render("- removed since last refresh: " + removedSummary(m.changes.removed)))
+			b.WriteString("\n")
+		}
+		if m.status != "" {
+			style := styles.SuccessStyle
+			if m.statusErr {
+				style = styles.ErrorStyle
+			}
+			b.WriteString(style.Render(m.status))
+			b.WriteString("\n")
+		}
 		b.WriteString(styles.HelpStyle.Render(help))
 	}
 
@@ -288,7 +1015,7 @@ func (m ParameterListModel) View() string {
 			line := fmt.Sprintf(" %d) %s : %s", i+1, r.Profile, r.Region)
 			// Mark current context as inactive
 			if r.Profile == m.currentProfile && r.Region == m.currentRegion {
-				line = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(line + " (current)")
+				line = lipgloss.NewStyle().Foreground(styles.Subtle).Render(line + " (current)")
 			}
 			b.WriteString(line + "\n")
 		}
@@ -297,15 +1024,106 @@ func (m ParameterListModel) View() string {
 	return b.String()
 }
 
-// SetContext sets profile/region context for the list
+// Parameters returns all loaded parameters, ignoring filters
+func (m ParameterListModel) Parameters() []*aws.Parameter {
+	return m.parameters
+}
+
+// Context returns the profile and region the list was loaded for
+func (m ParameterListModel) Context() (string, string) {
+	return m.currentProfile, m.currentRegion
+}
+
+// Marked returns the marked parameters in list order
+func (m ParameterListModel) Marked() []*aws.Parameter {
+	var params []*aws.Parameter
+	for _, p := range m.parameters {
+		if m.marked[p.Name] {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// SetContext sets profile/region context for the list, clearing marks when it changes
 func (m *ParameterListModel) SetContext(profile, region string) {
+	if profile != m.currentProfile || region != m.currentRegion {
+		m.marked = make(map[string]bool)
+		m.delegate.marked = m.marked
+		m.list.SetDelegate(m.delegate)
+		// Versions from another context are not comparable
+		m.snapshot = nil
+		*m.changes = listChanges{}
+	}
 	m.currentProfile = profile
 	m.currentRegion = region
 	m.updateListTitle()
 }
 
+// SetTimestampFormat changes how the modified column is rendered
+func (m *ParameterListModel) SetTimestampFormat(f TimestampFormat) {
+	m.delegate.times = f
+	m.list.SetDelegate(m.delegate)
+}
+
+// SetDetailed switches between compact one-line and detailed two-line items
+func (m *ParameterListModel) SetDetailed(on bool) {
+	m.delegate.detailed = on
+	m.list.SetDelegate(m.delegate)
+	if m.height > 0 {
+		m.SetSize(m.list.Width(), m.height)
+	}
+}
+
+// SetShowValues shows or hides the value preview column
+func (m *ParameterListModel) SetShowValues(on bool) {
+	m.showValues = on
+	m.delegate.showValues = on
+	m.list.SetDelegate(m.delegate)
+}
+
+// SetTerraform marks the parameters Terraform manages
+func (m *ParameterListModel) SetTerraform(resources terraform.Resources) {
+	m.delegate.terraform = resources
+	m.list.SetDelegate(m.delegate)
+}
+
+// SetNaming flags parameters whose names break the naming convention; nil
+// flags none
+func (m *ParameterListModel) SetNaming(naming *regexp.Regexp) {
+	m.delegate.naming = naming
+	m.list.SetDelegate(m.delegate)
+}
+
+// SetColumns sets the metadata columns shown right of each name, in order
+func (m *ParameterListModel) SetColumns(columns []string) {
+	m.delegate.columns = columns
+	m.list.SetDelegate(m.delegate)
+}
+
+// SetReadOnly updates the read-only indicator in the title
+func (m *ParameterListModel) SetReadOnly(on bool) {
+	m.readOnly = on
+	m.updateListTitle()
+}
+
+// SetDryRun updates the dry-run indicator in the title
+func (m *ParameterListModel) SetDryRun(on bool) {
+	m.dryRun = on
+	m.updateListTitle()
+}
+
+// SetPageSize limits the number of rows shown per page (0 fills the screen)
+func (m *ParameterListModel) SetPageSize(n int) {
+	m.pageSize = n
+	if m.height > 0 {
+		m.SetSize(m.list.Width(), m.height)
+	}
+}
+
 // SetSize updates the dimensions of the parameter list
 func (m *ParameterListModel) SetSize(width, height int) {
+	m.height = height
 	m.list.SetWidth(width)
 	h := height - 7 // Leave space for help text, search and recents (5 lines)
 	if m.SearchActive {
@@ -316,25 +1134,65 @@ func (m *ParameterListModel) SetSize(width, height int) {
 		h -= 7 // 1 label line + 5 recent entries + 1 spacing
 	}
 	m.list.SetHeight(h)
+
+	// Shrink the list so a page holds at most pageSize items
+	if m.pageSize > 0 && m.list.Paginator.PerPage > m.pageSize {
+		m.list.SetHeight(h - (m.list.Paginator.PerPage-m.pageSize)*m.delegate.Height())
+	}
 }
 
-// filterParameters filters the parameter list based on search input
+// filterParameters filters the parameter list based on search input. When the
+// query only grew since the last pass, the previous matches are narrowed
+// instead of scanning every parameter again.
 func (m *ParameterListModel) filterParameters() {
 	query := strings.ToLower(m.searchInput.Value())
-	if query == "" {
+	candidates := m.parameters
+	if m.filterValid && m.filterAdvanced == m.advancedOnly && strings.HasPrefix(query, m.filterQuery) {
+		candidates = m.filtered
+	}
+	m.filterQuery = query
+	m.filterAdvanced = m.advancedOnly
+	m.filterValid = true
+
+	if query == "" && !m.advancedOnly {
 		m.filtered = m.parameters
 	} else {
-		m.filtered = []*aws.Parameter{}
-		for _, p := range m.parameters {
+		filtered := []*aws.Parameter{}
+		for _, p := range candidates {
+			if m.advancedOnly && p.Tier != "Advanced" {
+				continue
+			}
 			if strings.Contains(strings.ToLower(p.Name), query) {
-				m.filtered = append(m.filtered, p)
+				filtered = append(filtered, p)
 			}
 		}
+		m.filtered = filtered
 	}
 	m.updateList()
 	m.updateListTitle()
 }
 
+// Capturing reports whether the list is taking keys for a prompt or popup,
+// so esc and global shortcuts must be forwarded to it
+func (m ParameterListModel) Capturing() bool {
+	return m.SearchActive || m.PeekActive || m.OpenActive || m.BulkActive
+}
+
+// listedOrNew returns the listed parameter called name, or a bare one to load
+func (m ParameterListModel) listedOrNew(name string) *aws.Parameter {
+	for _, p := range m.parameters {
+		if p.Name == name {
+			return p
+		}
+	}
+	return &aws.Parameter{Name: name}
+}
+
+// filterStale reports whether the search input changed since the last filter
+func (m ParameterListModel) filterStale() bool {
+	return !m.filterValid || strings.ToLower(m.searchInput.Value()) != m.filterQuery
+}
+
 // updateList updates the list items with filtered parameters
 func (m *ParameterListModel) updateList() {
 	items := make([]list.Item, len(m.filtered))
@@ -356,10 +1214,30 @@ func (m *ParameterListModel) updateListTitle() {
 		region = "-"
 	}
 
+	label := "Parameters"
+	if m.advancedOnly {
+		label = "Advanced parameters"
+	}
+	if m.pathPrefix != "" {
+		label += " under " + m.pathPrefix
+	}
+
 	if len(m.filtered) != len(m.parameters) {
-		m.list.Title = fmt.Sprintf("%s : %s : Parameters (%d/%d)", profile, region, len(m.filtered), len(m.parameters))
-		return
+		m.list.Title = fmt.Sprintf("%s : %s : %s (%d/%d)", profile, region, label, len(m.filtered), len(m.parameters))
+	} else {
+		m.list.Title = fmt.Sprintf("%s : %s : %s (%d)", profile, region, label, len(m.parameters))
+	}
+	if len(m.marked) > 0 {
+		m.list.Title += fmt.Sprintf(" [%d marked]", len(m.marked))
 	}
 
-	m.list.Title = fmt.Sprintf("%s : %s : Parameters (%d)", profile, region, len(m.parameters))
+	if m.endpoint != "" {
+		m.list.Title += " [" + m.endpoint + "]"
+	}
+	if m.readOnly {
+		m.list.Title += " [READ ONLY]"
+	}
+	if m.dryRun {
+		m.list.Title += " [DRY RUN]"
+	}
 }
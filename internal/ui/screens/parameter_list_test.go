@@ -0,0 +1,407 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+func TestParameterList_AdvancedOnlyFilter(t *testing.T) {
+	m := NewParameterList()
+	m, _ = m.Update(types.ParametersLoadedMsg{Parameters: []*aws.Parameter{
+		{Name: "/app/a", Tier: "Standard"},
+		{Name: "/app/b", Tier: "Advanced"},
+		{Name: "/app/c", Tier: "Intelligent-Tiering"},
+	}})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+	if len(m.filtered) != 1 || m.filtered[0].Name != "/app/b" {
+		t.Fatalf("expected only /app/b with advanced filter, got %+v", m.filtered)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+	if len(m.filtered) != 3 {
+		t.Fatalf("expected all parameters after toggling filter off, got %d", len(m.filtered))
+	}
+}
+
+func TestParameterList_PageSize(t *testing.T) {
+	params := make([]*aws.Parameter, 40)
+	for i := range params {
+		params[i] = &aws.Parameter{Name: fmt.Sprintf("/app/p%02d", i)}
+	}
+
+	m := NewParameterList()
+	m.SetPageSize(10)
+	m.SetSize(100, 50)
+	m, _ = m.Update(types.ParametersLoadedMsg{Parameters: params})
+
+	if got := m.list.Paginator.PerPage; got != 10 {
+		t.Fatalf("expected 10 rows per page, got %d", got)
+	}
+	if got := m.list.Paginator.TotalPages; got != 4 {
+		t.Fatalf("expected 4 pages, got %d", got)
+	}
+}
+
+func TestTypeBadge_FixedWidth(t *testing.T) {
+	for _, typ := range []string{"String", "SecureString", "StringList", ""} {
+		if w := lipgloss.Width(typeBadge(typ)); w != 4 {
+			t.Errorf("badge for %q has width %d, want 4", typ, w)
+		}
+	}
+	if !strings.Contains(typeBadge("SecureString"), "[SS]") {
+		t.Error("SecureString badge should read [SS]")
+	}
+}
+
+func TestParameterList_DetailedModeKeepsPageSize(t *testing.T) {
+	params := make([]*aws.Parameter, 40)
+	for i := range params {
+		params[i] = &aws.Parameter{Name: fmt.Sprintf("/app/p%02d", i), Type: "String", Version: 3}
+	}
+
+	m := NewParameterList()
+	m.SetPageSize(10)
+	m.SetDetailed(true)
+	m.SetSize(100, 60)
+	m, _ = m.Update(types.ParametersLoadedMsg{Parameters: params})
+
+	if got := m.list.Paginator.PerPage; got != 10 {
+		t.Fatalf("expected 10 items per page in detailed mode, got %d", got)
+	}
+	if !strings.Contains(m.View(), "v3 • String") {
+		t.Fatalf("expected metadata line in detailed view")
+	}
+}
+
+func TestValuePreview(t *testing.T) {
+	values := map[string]string{
+		"/app/flag":  "true",
+		"/app/multi": "line one\nline two",
+		"/app/long":  strings.Repeat("x", 100),
+	}
+
+	tests := []struct {
+		param *aws.Parameter
+		want  string
+	}{
+		{&aws.Parameter{Name: "/app/flag", Type: "String"}, "true"},
+		{&aws.Parameter{Name: "/app/multi", Type: "String"}, "line one line two"},
+		{&aws.Parameter{Name: "/app/secret", Type: "SecureString"}, "••••••"},
+		{&aws.Parameter{Name: "/app/pending", Type: "String"}, "…"},
+	}
+	for _, tt := range tests {
+		if got := valuePreview(tt.param, values); got != tt.want {
+			t.Errorf("valuePreview(%s) = %q, want %q", tt.param.Name, got, tt.want)
+		}
+	}
+
+	long := valuePreview(&aws.Parameter{Name: "/app/long", Type: "String"}, values)
+	if w := lipgloss.Width(long); w != valuePreviewWidth {
+		t.Errorf("long value preview has width %d, want %d", w, valuePreviewWidth)
+	}
+}
+
+func TestParamDelegate_Columns(t *testing.T) {
+	d := paramDelegate{values: map[string]string{"/app/flag": "true"}}
+	p := &aws.Parameter{Name: "/app/flag", Type: "String", Version: 12, LastModifiedUser: "arn:aws:iam::123456789012:user/alice"}
+
+	tests := []struct {
+		column string
+		want   string
+	}{
+		{"type", "String"},
+		{"version", "v12"},
+		{"user", "alice"},
+		{"size", "4 B"},
+	}
+	for _, tt := range tests {
+		if got, width := d.column(tt.column, p); got != tt.want || width == 0 {
+			t.Errorf("column %s = %q (width %d), want %q", tt.column, got, width, tt.want)
+		}
+	}
+	if got, _ := d.column("size", &aws.Parameter{Name: "/app/secret", Type: "SecureString"}); got != "" {
+		t.Errorf("expected no size before the value is known, got %q", got)
+	}
+}
+
+func TestParameterList_ValuesLoaded(t *testing.T) {
+	m := NewParameterList()
+	m.SetShowValues(true)
+	m, _ = m.Update(types.ParametersLoadedMsg{Parameters: []*aws.Parameter{
+		{Name: "/app/a", Type: "String"},
+		{Name: "/app/gone", Type: "String"},
+	}})
+	m.pendingValues["/app/a"] = true
+	m.pendingValues["/app/gone"] = true
+	m.valueBatches[7] = &valueBatch{names: []string{"/app/a", "/app/gone"}, cancel: func() {}}
+
+	// Results of a canceled batch are dropped
+	m, _ = m.Update(types.ValuesLoadedMsg{Batch: 3, Names: []string{"/app/a"}, Values: map[string]string{"/app/a": "stale"}})
+	if _, ok := m.values["/app/a"]; ok {
+		t.Fatal("expected values of an unknown batch to be ignored")
+	}
+
+	m, _ = m.Update(types.ValuesLoadedMsg{
+		Batch:  7,
+		Names:  []string{"/app/a", "/app/gone"},
+		Values: map[string]string{"/app/a": "on"},
+	})
+	if len(m.pendingValues) != 0 {
+		t.Fatalf("expected no pending values, got %v", m.pendingValues)
+	}
+	if v, ok := m.values["/app/gone"]; !ok || v != "" {
+		t.Fatalf("expected an empty value for a missing parameter, got %q (ok=%v)", v, ok)
+	}
+	if !strings.Contains(m.View(), "/app/a  on") {
+		t.Fatalf("expected the value in the list view, got:\n%s", m.View())
+	}
+}
+
+func TestParameterList_PeekClosesOnAnyKey(t *testing.T) {
+	m := NewParameterList()
+	m, _ = m.Update(types.ParametersLoadedMsg{Parameters: []*aws.Parameter{{Name: "/app/a", Type: "String"}}})
+
+	m.PeekActive = true
+	m, _ = m.Update(types.PeekLoadedMsg{Parameter: &aws.Parameter{Name: "/app/a", Value: `{"enabled":true}`}})
+	view := m.View()
+	if !strings.Contains(view, `"enabled": true`) {
+		t.Fatalf("expected the indented value in the peek, got:\n%s", view)
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	if m.PeekActive || cmd != nil {
+		t.Fatalf("expected the key to only close the peek")
+	}
+}
+
+func TestParameterList_MarkAndExport(t *testing.T) {
+	m := NewParameterList()
+	m, _ = m.Update(types.ParametersLoadedMsg{Parameters: []*aws.Parameter{
+		{Name: "/app/a"}, {Name: "/app/b"}, {Name: "/app/c"},
+	}})
+
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	m, _ = m.Update(space) // marks /app/a, moves to /app/b
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(space) // marks /app/c
+
+	if !strings.Contains(m.list.Title, "[2 marked]") {
+		t.Fatalf("expected marked count in title, got %q", m.list.Title)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if cmd == nil {
+		t.Fatalf("expected export cmd")
+	}
+	msg, ok := cmd().(types.ExportParametersMsg)
+	if !ok || len(msg.Parameters) != 2 || msg.Parameters[0].Name != "/app/a" || msg.Parameters[1].Name != "/app/c" {
+		t.Fatalf("expected the marked parameters, got %#v", cmd())
+	}
+
+	// Marks are cleared when the context changes
+	m.SetContext("other", "us-east-1")
+	if len(m.Marked()) != 0 {
+		t.Fatalf("expected marks to be cleared")
+	}
+}
+
+func TestParameterList_ExportListed(t *testing.T) {
+	m := NewParameterList()
+	m, _ = m.Update(types.ParametersLoadedMsg{Parameters: []*aws.Parameter{
+		{Name: "/app/prod/a"}, {Name: "/app/prod/b"}, {Name: "/app/staging/a"},
+	}})
+	m.searchInput.SetValue("prod")
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}})
+	if cmd == nil {
+		t.Fatal("expected export cmd")
+	}
+	msg, ok := cmd().(types.ExportParametersMsg)
+	if !ok || len(msg.Parameters) != 2 || msg.Parameters[1].Name != "/app/prod/b" {
+		t.Fatalf("expected the two parameters matching the search, got %#v", cmd())
+	}
+}
+
+func TestParameterList_BulkActions(t *testing.T) {
+	m := NewParameterList()
+	m, _ = m.Update(types.ParametersLoadedMsg{Parameters: []*aws.Parameter{
+		{Name: "/app/a"}, {Name: "/app/b"}, {Name: "/app/c"},
+	}})
+
+	key := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+	if m, _ = m.Update(key('b')); m.BulkActive {
+		t.Fatal("expected the menu to need marked parameters")
+	}
+
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	m, _ = m.Update(space)
+	m, _ = m.Update(space)
+	m, _ = m.Update(key('b'))
+	if !m.Capturing() {
+		t.Fatal("expected the bulk menu to take the keys")
+	}
+	m, cmd := m.Update(key('d'))
+	msg, ok := cmd().(types.BulkActionMsg)
+	if !ok || msg.Action != types.BulkDelete || len(msg.Parameters) != 2 {
+		t.Fatalf("expected a bulk delete of the marked parameters, got %#v", cmd())
+	}
+
+	m, _ = m.Update(key('b'))
+	m, _ = m.Update(key('t'))
+	m.tagInput.SetValue("owner=platform")
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	msg, ok = cmd().(types.BulkActionMsg)
+	if !ok || msg.Action != types.BulkTag || msg.Tag != (aws.Tag{Key: "owner", Value: "platform"}) {
+		t.Fatalf("expected a bulk tag, got %#v", cmd())
+	}
+	if m.Capturing() {
+		t.Fatal("expected the menu to close")
+	}
+
+	// Marks of parameters gone from the next load are forgotten
+	m, _ = m.Update(types.ParametersLoadedMsg{Parameters: []*aws.Parameter{{Name: "/app/b"}, {Name: "/app/c"}}})
+	if got := m.Marked(); len(got) != 1 || got[0].Name != "/app/b" {
+		t.Fatalf("expected only /app/b to stay marked, got %v", got)
+	}
+}
+
+func TestParameterList_RefreshHighlightsChanges(t *testing.T) {
+	m := NewParameterList()
+	m.SetSize(100, 40)
+	m, _ = m.Update(types.ParametersLoadedMsg{Parameters: []*aws.Parameter{
+		{Name: "/app/a", Version: 1},
+		{Name: "/app/b", Version: 1},
+		{Name: "/app/gone", Version: 3},
+	}})
+	if m.changes.active() {
+		t.Fatalf("expected no highlighting after the first load")
+	}
+
+	m, _ = m.Update(types.ParametersLoadedMsg{Parameters: []*aws.Parameter{
+		{Name: "/app/a", Version: 1},
+		{Name: "/app/b", Version: 2},
+		{Name: "/app/c", Version: 1},
+	}})
+	if !m.changes.active() {
+		t.Fatalf("expected refresh changes to be highlighted")
+	}
+	if m.changes.kinds["/app/b"] != changeUpdated || m.changes.kinds["/app/c"] != changeNew {
+		t.Fatalf("unexpected change kinds: %v", m.changes.kinds)
+	}
+	if _, ok := m.changes.kinds["/app/a"]; ok {
+		t.Fatalf("unchanged parameter should not be marked")
+	}
+	if !strings.Contains(m.View(), "removed since last refresh: /app/gone") {
+		t.Fatalf("expected removed parameter in view:\n%s", m.View())
+	}
+
+	// Switching context drops the snapshot
+	m.SetContext("other", "eu-west-1")
+	if m.changes.active() || m.snapshot != nil {
+		t.Fatalf("expected changes to be cleared on context switch")
+	}
+}
+
+func TestParameterList_DebouncedFilter(t *testing.T) {
+	params := make([]*aws.Parameter, filterDebounceThreshold)
+	for i := range params {
+		params[i] = &aws.Parameter{Name: fmt.Sprintf("/app/p%05d", i)}
+	}
+
+	m := NewParameterList()
+	m, _ = m.Update(types.ParametersLoadedMsg{Parameters: params})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'9'}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'9'}})
+	if len(m.filtered) != len(params) {
+		t.Fatalf("expected filtering to wait for the debounce, got %d matches", len(m.filtered))
+	}
+
+	// A tick scheduled before the last key is ignored
+	m, _ = m.Update(filterTickMsg{seq: m.filterSeq - 1})
+	if len(m.filtered) != len(params) {
+		t.Fatalf("expected stale tick to be ignored, got %d matches", len(m.filtered))
+	}
+
+	m, _ = m.Update(filterTickMsg{seq: m.filterSeq})
+	if len(m.filtered) != 95 {
+		t.Fatalf("expected 95 matches for \"99\", got %d", len(m.filtered))
+	}
+
+	// Enter applies a pending filter right away, narrowing the previous matches
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'9'}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(m.filtered) != 5 {
+		t.Fatalf("expected 5 matches for \"999\", got %d", len(m.filtered))
+	}
+}
+
+func TestParameterList_PrefetchFollowsCursor(t *testing.T) {
+	params := make([]*aws.Parameter, 200)
+	for i := range params {
+		params[i] = &aws.Parameter{Name: fmt.Sprintf("/app/p%03d", i), Type: "String"}
+	}
+	params[1].Type = "SecureString"
+
+	m := NewParameterList()
+	m.SetShowValues(true)
+	m.SetSize(100, 30)
+	m, _ = m.Update(types.ParametersLoadedMsg{Parameters: params})
+	m.client = aws.NewDemoClient("demo", "us-east-1")
+
+	if cmd := m.LoadVisibleValues(); cmd == nil {
+		t.Fatal("expected value requests")
+	}
+	if len(m.valueBatches) != maxValueBatchesInFlight {
+		t.Fatalf("expected %d batches in flight, got %d", maxValueBatchesInFlight, len(m.valueBatches))
+	}
+	if !m.pendingValues["/app/p000"] || m.pendingValues["/app/p001"] {
+		t.Fatal("expected the cursor row first and SecureStrings skipped")
+	}
+
+	// Moving far away cancels the old batches and queues the new rows
+	m.list.Select(150)
+	m.LoadVisibleValues()
+	if m.pendingValues["/app/p000"] || !m.pendingValues["/app/p150"] {
+		t.Fatalf("expected requests to follow the cursor, pending: %v", m.pendingValues)
+	}
+	if len(m.valueBatches) != maxValueBatchesInFlight {
+		t.Fatalf("expected %d batches in flight, got %d", maxValueBatchesInFlight, len(m.valueBatches))
+	}
+}
+
+func TestParameterList_OpenByARN(t *testing.T) {
+	m := NewParameterList()
+	m, _ = m.Update(types.ParametersLoadedMsg{Parameters: []*aws.Parameter{{Name: "/app/a", Tier: "Advanced"}}})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if !m.OpenActive || !m.Capturing() {
+		t.Fatal("expected the open prompt")
+	}
+	const arn = "arn:aws:ssm:us-east-1:210987654321:parameter/platform/vpc-id"
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(arn)})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.OpenActive || cmd == nil {
+		t.Fatal("expected enter to close the prompt and open the parameter")
+	}
+	msg, ok := cmd().(types.ViewParameterMsg)
+	if !ok || msg.Parameter.Name != arn {
+		t.Fatalf("expected ViewParameterMsg for the ARN, got %#v", cmd())
+	}
+
+	// Listed names keep their listing metadata
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/app/a")})
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg := cmd().(types.ViewParameterMsg); msg.Parameter.Tier != "Advanced" {
+		t.Fatalf("expected the listed parameter, got %+v", msg.Parameter)
+	}
+}
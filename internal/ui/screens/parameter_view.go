@@ -2,6 +2,7 @@ package screens
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"sort"
@@ -10,11 +11,15 @@ import (
 
 	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/export"
+	"github.com/ilia/ps9s/internal/snapshot"
 	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/terraform"
 	"github.com/ilia/ps9s/internal/types"
 )
 
@@ -31,8 +36,14 @@ type clearStatusMsg struct{}
 
 // copyResultMsg is sent from the async copy command to report result
 type copyResultMsg struct {
-	Err  error
-	Text string
+	Err   error
+	Text  string
+	Label string // What was copied, for the status line; empty for the value
+}
+
+// snapshotStatusMsg reports a snapshot that could not be recorded or diffed
+type snapshotStatusMsg struct {
+	Status string
 }
 
 // ParameterViewModel represents the parameter view screen
@@ -47,10 +58,63 @@ type ParameterViewModel struct {
 	status         string
 	isJSON         bool
 	jsonKeys       []jsonKeyItem
+	listItems      []string // Items of a StringList value, shown as rows like JSON keys
 	currentProfile string
 	currentRegion  string
 	selectedIndex  int
 	cancelLoad     context.CancelFunc
+	kmsKeyInput    textinput.Model
+	converting     bool
+	times          TimestampFormat
+	followed       []*aws.Parameter // Parameters left by following references, for esc
+	terraform      terraform.Resources
+	tfWarned       string // Parameter whose Terraform warning was shown, so the next key goes ahead
+	// PromptActive is exported so the root model can let esc cancel the prompt
+	PromptActive bool
+	// Environment variable preview of JSON values: the variable name of each
+	// JSON key, shown instead of the key while envView is on
+	envView  bool
+	envStyle envStyle
+	envNames []string
+	// Snapshots of viewed parameters; changedSince is the previous snapshot
+	// when the value changed since it was taken
+	snapshots    *snapshot.Store
+	changedSince *snapshot.Entry
+	saved        bool // The load follows a save made here, which is not flagged
+	// The conversion prompt turns a SecureString back into a plain String
+	toString bool
+}
+
+// Nested reports whether a reference was followed, so esc returns to the
+// referring parameter instead of leaving
+func (m ParameterViewModel) Nested() bool {
+	return len(m.followed) > 0 && !m.PromptActive
+}
+
+// rowCount returns the number of selectable rows: JSON keys or StringList items
+func (m ParameterViewModel) rowCount() int {
+	if m.isJSON {
+		return len(m.jsonKeys)
+	}
+	return len(m.listItems)
+}
+
+// selectedReference returns the parameter the selected JSON value, or the
+// whole value, refers to: a dynamic reference, ARN or /path
+func (m ParameterViewModel) selectedReference() string {
+	value := m.parameter.Value
+	if m.isJSON && len(m.jsonKeys) > 0 {
+		value = m.jsonKeys[m.selectedIndex].value
+	} else if len(m.listItems) > 0 {
+		value = m.listItems[m.selectedIndex]
+	}
+	refs := aws.ParameterReferences(strings.TrimSpace(value), func(string) bool { return true })
+	for _, ref := range refs {
+		if ref != m.parameter.Name {
+			return ref
+		}
+	}
+	return ""
 }
 
 // SetContext sets the profile and region context for the view screen
@@ -59,18 +123,29 @@ func (m *ParameterViewModel) SetContext(profile, region string) {
 	m.currentRegion = region
 }
 
+// Parameter returns the parameter currently shown, or nil
+func (m ParameterViewModel) Parameter() *aws.Parameter {
+	return m.parameter
+}
+
 // NewParameterView creates a new parameter view screen
 func NewParameterView() ParameterViewModel {
 	vp := viewport.New(80, 20)
 	vp.Style = lipgloss.NewStyle().Padding(1, 2)
 
 	s := spinner.New()
-	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	s.Spinner = styles.Spinner
+	s.Style = lipgloss.NewStyle().Foreground(styles.Secondary)
+
+	ki := textinput.New()
+	ki.Placeholder = "alias/aws/ssm"
+	ki.CharLimit = 2048
+	ki.Width = 60
 
 	return ParameterViewModel{
-		viewport: vp,
-		spinner:  s,
+		viewport:    vp,
+		spinner:     s,
+		kmsKeyInput: ki,
 	}
 }
 
@@ -91,8 +166,14 @@ func (m *ParameterViewModel) LoadParameter(param *aws.Parameter, client *aws.Cli
 	m.client = client
 	m.parameter = param
 	m.loading = true
+	m.converting = false
 	m.err = nil
 	m.status = ""
+	m.tfWarned = ""
+	m.PromptActive = false
+	m.followed = nil
+	m.changedSince = nil
+	m.saved = false
 
 	return tea.Batch(
 		m.spinner.Tick,
@@ -101,6 +182,10 @@ func (m *ParameterViewModel) LoadParameter(param *aws.Parameter, client *aws.Cli
 			if err != nil {
 				return types.ErrorMsg{Err: err}
 			}
+			// GetParameter doesn't return describe-only metadata, keep it from the listing
+			fullParam.Tier = param.Tier
+			fullParam.KeyID = param.KeyID
+			fullParam.LastModifiedUser = param.LastModifiedUser
 			return types.ParameterValueLoadedMsg{Parameter: fullParam}
 		},
 	)
@@ -114,21 +199,35 @@ func (m ParameterViewModel) Update(msg tea.Msg) (ParameterViewModel, tea.Cmd) {
 		m.loading = false
 		m.selectedIndex = 0
 
-		// Check if value is JSON
-		m.isJSON = isValidJSON(msg.Parameter.Value)
+		// StringLists are split into items; other values are checked for JSON
+		m.listItems = nil
+		m.isJSON = false
+		if msg.Parameter.Type == "StringList" {
+			m.listItems = strings.Split(msg.Parameter.Value, ",")
+		} else {
+			m.isJSON = isValidJSON(msg.Parameter.Value)
+		}
 		if m.isJSON {
 			var data interface{}
 			if err := json.Unmarshal([]byte(msg.Parameter.Value), &data); err == nil {
-				m.jsonKeys = m.flattenJSONForView(data, "")
+				m.jsonKeys = flattenJSONForView(data, "")
+				m.envNames = flattenJSONForEnv(data, nil, m.envStyle)
 			}
 		}
 
 		content := m.formatParameterDetails(msg.Parameter)
 		m.viewport.SetContent(content)
-		return m, nil
+		return m, m.checkSnapshot()
+
+	case snapshotStatusMsg:
+		m.status = msg.Status
+		return m, tea.Tick(3*time.Second, func(t time.Time) tea.Msg {
+			return clearStatusMsg{}
+		})
 
 	case types.ErrorMsg:
 		m.loading = false
+		m.converting = false
 		m.err = msg.Err
 		return m, nil
 
@@ -137,6 +236,9 @@ func (m ParameterViewModel) Update(msg tea.Msg) (ParameterViewModel, tea.Cmd) {
 			m.status = fmt.Sprintf("Copy failed: %v", msg.Err)
 		} else {
 			m.status = "Copied to clipboard"
+			if msg.Label != "" {
+				m.status = "Copied " + msg.Label + " to clipboard"
+			}
 		}
 		return m, tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
 			return clearStatusMsg{}
@@ -146,6 +248,33 @@ func (m ParameterViewModel) Update(msg tea.Msg) (ParameterViewModel, tea.Cmd) {
 		m.status = ""
 		return m, nil
 
+	case types.ActionRepeatedMsg:
+		m.status = msg.Status
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Repeat failed: %v", msg.Err)
+		}
+		return m, tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
+			return clearStatusMsg{}
+		})
+
+	case types.MirroredMsg:
+		m.status = msg.Status
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Git mirror failed: %v", msg.Err)
+		}
+		if m.status == "" {
+			return m, nil
+		}
+		return m, tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
+			return clearStatusMsg{}
+		})
+
+	case pagerClosedMsg:
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Pager failed: %v", msg.Err)
+		}
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		if !m.ready {
 			m.viewport = viewport.New(msg.Width-4, msg.Height-10)
@@ -161,10 +290,23 @@ func (m ParameterViewModel) Update(msg tea.Msg) (ParameterViewModel, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
-		if m.loading {
+		if msg.String() == "esc" && m.Nested() {
+			// Return to the parameter the reference was followed from
+			prev := m.followed[len(m.followed)-1]
+			followed := m.followed[:len(m.followed)-1]
+			cmd := m.LoadParameter(prev, m.client)
+			m.followed = followed
+			return m, cmd
+		}
+
+		if m.loading || m.converting {
 			return m, nil
 		}
 
+		if m.PromptActive {
+			return m.updateConvertPrompt(msg)
+		}
+
 		if msg.String() == "esc" {
 			if m.cancelLoad != nil {
 				m.cancelLoad()
@@ -177,6 +319,25 @@ func (m ParameterViewModel) Update(msg tea.Msg) (ParameterViewModel, tea.Cmd) {
 			return m, tea.Quit
 		}
 
+		// Shared parameters belong to another account and cannot be changed
+		if m.parameter != nil && aws.IsShared(m.parameter.Name) {
+			switch msg.String() {
+			case "e", "a", "S", "T":
+				m.status = "Shared parameters are read-only"
+				return m, nil
+			}
+		}
+
+		// The next Terraform apply reverts manual changes, so warn once first
+		if address, ok := m.terraformAddress(); ok && m.tfWarned != m.parameter.Name {
+			switch msg.String() {
+			case "e", "a", "S", "T":
+				m.tfWarned = m.parameter.Name
+				m.status = fmt.Sprintf("Managed by Terraform (%s): the next apply reverts manual changes. Press '%s' again to continue", address, msg.String())
+				return m, nil
+			}
+		}
+
 		switch msg.String() {
 		case "e":
 			// Edit parameter or selected JSON key
@@ -189,6 +350,15 @@ func (m ParameterViewModel) Update(msg tea.Msg) (ParameterViewModel, tea.Cmd) {
 						JSONKey:   selectedKey,
 					}
 				}
+			} else if len(m.listItems) > 0 {
+				// Edit selected StringList item
+				item := m.selectedIndex + 1
+				return m, func() tea.Msg {
+					return types.EditParameterMsg{
+						Parameter: m.parameter,
+						ListItem:  item,
+					}
+				}
 			} else {
 				// Edit entire parameter value
 				return m, func() tea.Msg {
@@ -202,24 +372,132 @@ func (m ParameterViewModel) Update(msg tea.Msg) (ParameterViewModel, tea.Cmd) {
 					return types.AddJSONKeyMsg{Parameter: m.parameter}
 				}
 			}
-		case "c":
-			// Copy selected value (either JSON key value or whole parameter)
+		case "S":
+			// Convert a plain String parameter to SecureString, or back
 			if m.parameter == nil {
 				return m, nil
 			}
-			var toCopy string
+			switch m.parameter.Type {
+			case "String":
+				m.toString = false
+			case "SecureString":
+				// Decrypting needs a confirmation, not a key
+				m.toString = true
+				m.PromptActive = true
+				return m, nil
+			default:
+				m.status = fmt.Sprintf("Only String and SecureString parameters can be converted (this is %s)", m.parameter.Type)
+				return m, nil
+			}
+			m.PromptActive = true
+			m.kmsKeyInput.SetValue("")
+			m.kmsKeyInput.Focus()
+			return m, textinput.Blink
+		case "E":
+			// Show JSON keys as the environment variables a config loader would read
 			if m.isJSON && len(m.jsonKeys) > 0 {
-				toCopy = m.jsonKeys[m.selectedIndex].value
-			} else {
-				toCopy = m.parameter.Value
+				m.envView = !m.envView
+				m.viewport.SetContent(m.formatParameterDetails(m.parameter))
 			}
-
-			return m, func() tea.Msg {
-				err := clipboard.WriteAll(toCopy)
-				return copyResultMsg{Err: err, Text: toCopy}
+			return m, nil
+		case "P":
+			// Read the value in $PAGER
+			if m.parameter != nil {
+				return m, openInPager(m.parameter.Value)
+			}
+		case "t":
+			// Toggle relative/absolute timestamps everywhere
+			return m, func() tea.Msg { return types.ToggleTimestampsMsg{} }
+		case "h":
+			// Show version history
+			if m.parameter != nil {
+				return m, func() tea.Msg {
+					return types.ViewHistoryMsg{Parameter: m.parameter}
+				}
+			}
+		case "v":
+			// Compare with the same parameter in another context
+			if m.parameter != nil {
+				param := m.parameter
+				return m, func() tea.Msg { return types.CompareContextsMsg{Parameter: param} }
+			}
+		case "C":
+			// Copy to another context, e.g. to promote staging config to prod
+			if m.parameter != nil {
+				param := m.parameter
+				return m, func() tea.Msg { return types.CopyParameterMsg{Parameter: param} }
+			}
+		case "u":
+			// Create a new parameter using this one as a template
+			if m.parameter != nil {
+				return m, func() tea.Msg {
+					return types.CreateParameterMsg{Template: m.parameter}
+				}
+			}
+		case "T":
+			// View and edit tags
+			if m.parameter != nil {
+				return m, func() tea.Msg {
+					return types.ViewTagsMsg{Parameter: m.parameter}
+				}
+			}
+		case "g":
+			// Go to the parameter the selected JSON value, or the value, refers to
+			if m.parameter == nil {
+				return m, nil
 			}
+			ref := m.selectedReference()
+			if ref == "" {
+				m.status = "No parameter reference here"
+				return m, nil
+			}
+			followed := append(m.followed, m.parameter)
+			cmd := m.LoadParameter(&aws.Parameter{Name: ref}, m.client)
+			m.followed = followed
+			return m, cmd
+		case "r":
+			// Show which parameters this one refers to and which refer to it
+			if m.parameter != nil {
+				param := m.parameter
+				return m, func() tea.Msg { return types.ViewReferencesMsg{Parameter: param} }
+			}
+		case "N":
+			// Get notified of changes through EventBridge
+			if m.parameter != nil {
+				name := m.parameter.Name
+				return m, func() tea.Msg { return types.NotifyChangesMsg{Name: name} }
+			}
+		case "L":
+			// Copy the AWS console link for sharing
+			if m.parameter == nil {
+				return m, nil
+			}
+			link := aws.ConsoleURL(m.currentRegion, m.parameter.Name)
+			return m, tea.Batch(
+				func() tea.Msg {
+					err := clipboard.WriteAll(link)
+					return copyResultMsg{Err: err, Text: link, Label: "console link"}
+				},
+				performed(types.RepeatableAction{Kind: types.ActionCopyLink}),
+			)
+		case "c":
+			// Copy selected value (either JSON key value, list item or whole parameter)
+			return m, m.copySelected(false)
+		case "D":
+			// Diff against the value seen last time, when it changed since
+			return m, m.diffSnapshot()
+		case "B":
+			// Copy the selected value base64-encoded, e.g. for Kubernetes Secret data
+			return m, m.copySelected(true)
+		case ".":
+			// Repeat the last action on this parameter
+			if m.parameter == nil {
+				return m, nil
+			}
+			param := m.parameter
+			return m, func() tea.Msg { return types.RepeatActionMsg{Parameter: param} }
 		case "up", "k":
-			if m.isJSON && len(m.jsonKeys) > 0 {
+			if m.rowCount() > 0 {
 				if m.selectedIndex > 0 {
 					m.selectedIndex--
 					m.viewport.SetContent(m.formatParameterDetails(m.parameter))
@@ -231,8 +509,8 @@ func (m ParameterViewModel) Update(msg tea.Msg) (ParameterViewModel, tea.Cmd) {
 			m.viewport, cmd = m.viewport.Update(msg)
 			return m, cmd
 		case "down", "j":
-			if m.isJSON && len(m.jsonKeys) > 0 {
-				if m.selectedIndex < len(m.jsonKeys)-1 {
+			if m.rowCount() > 0 {
+				if m.selectedIndex < m.rowCount()-1 {
 					m.selectedIndex++
 					m.viewport.SetContent(m.formatParameterDetails(m.parameter))
 				}
@@ -251,7 +529,7 @@ func (m ParameterViewModel) Update(msg tea.Msg) (ParameterViewModel, tea.Cmd) {
 	}
 
 	// Update spinner if loading
-	if m.loading {
+	if m.loading || m.converting {
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
 		return m, cmd
@@ -261,10 +539,136 @@ func (m ParameterViewModel) Update(msg tea.Msg) (ParameterViewModel, tea.Cmd) {
 	return m, nil
 }
 
+// copySelected copies the selected JSON key value, list item or the whole
+// value, base64-encoded if asked
+func (m ParameterViewModel) copySelected(encode bool) tea.Cmd {
+	if m.parameter == nil {
+		return nil
+	}
+	label := ""
+	if encode {
+		label = "base64 value"
+	}
+	if len(m.listItems) > 0 {
+		item := m.listItems[m.selectedIndex]
+		label = fmt.Sprintf("item %d", m.selectedIndex+1)
+		if encode {
+			item = base64.StdEncoding.EncodeToString([]byte(item))
+			label += " as base64"
+		}
+		return func() tea.Msg {
+			err := clipboard.WriteAll(item)
+			return copyResultMsg{Err: err, Text: item, Label: label}
+		}
+	}
+	var toCopy string
+	action := types.RepeatableAction{Kind: types.ActionCopyValue, Base64: encode}
+	if m.isJSON && len(m.jsonKeys) > 0 {
+		toCopy = m.jsonKeys[m.selectedIndex].value
+		action.JSONKey = m.jsonKeys[m.selectedIndex].key
+	} else {
+		toCopy = m.parameter.Value
+	}
+	if encode {
+		toCopy = base64.StdEncoding.EncodeToString([]byte(toCopy))
+	}
+
+	return tea.Batch(
+		func() tea.Msg {
+			err := clipboard.WriteAll(toCopy)
+			return copyResultMsg{Err: err, Text: toCopy, Label: label}
+		},
+		performed(action),
+	)
+}
+
+// updateConvertPrompt handles keys while the SecureString conversion prompt is open
+func (m ParameterViewModel) updateConvertPrompt(msg tea.KeyMsg) (ParameterViewModel, tea.Cmd) {
+	if m.toString {
+		switch msg.String() {
+		case "y":
+			m.PromptActive = false
+			return m, m.convertToString()
+		case "esc", "n":
+			m.PromptActive = false
+		case "ctrl+c":
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.PromptActive = false
+		m.kmsKeyInput.Blur()
+		return m, nil
+	case "enter":
+		m.PromptActive = false
+		m.kmsKeyInput.Blur()
+		return m, m.convertToSecureString(strings.TrimSpace(m.kmsKeyInput.Value()))
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+
+	var cmd tea.Cmd
+	m.kmsKeyInput, cmd = m.kmsKeyInput.Update(msg)
+	return m, cmd
+}
+
+// convertToSecureString re-puts the current value as a SecureString encrypted with keyID
+func (m *ParameterViewModel) convertToSecureString(keyID string) tea.Cmd {
+	m.converting = true
+	m.err = nil
+
+	client := m.client
+	param := *m.parameter
+
+	return tea.Batch(
+		m.spinner.Tick,
+		func() tea.Msg {
+			if err := client.PutSecureParameter(context.Background(), param.Name, param.Value, keyID); err != nil {
+				return types.ErrorMsg{Err: err}
+			}
+			param.Type = "SecureString"
+			param.KeyID = keyID
+			return types.SaveSuccessMsg{Parameter: &param}
+		},
+	)
+}
+
+// convertToString re-puts the current SecureString value as a plain String
+func (m *ParameterViewModel) convertToString() tea.Cmd {
+	m.converting = true
+	m.err = nil
+
+	client := m.client
+	param := *m.parameter
+
+	return tea.Batch(
+		m.spinner.Tick,
+		func() tea.Msg {
+			if err := client.PutParameter(context.Background(), param.Name, param.Value, "String"); err != nil {
+				return types.ErrorMsg{Err: err}
+			}
+			param.Type = "String"
+			param.KeyID = ""
+			return types.SaveSuccessMsg{Parameter: &param}
+		},
+	)
+}
+
 // View renders the parameter view
 func (m ParameterViewModel) View() string {
 	if m.loading {
-		return fmt.Sprintf("\n  %s Loading parameter value...\n", m.spinner.View())
+		return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), progressText("Loading parameter value..."))
+	}
+
+	if m.converting {
+		target := "SecureString"
+		if m.toString {
+			target = "String"
+		}
+		return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), progressText("Converting to "+target+"..."))
 	}
 
 	if m.err != nil {
@@ -288,16 +692,61 @@ func (m ParameterViewModel) View() string {
 		region = "-"
 	}
 	title := fmt.Sprintf("%s : %s : %s", profile, region, m.parameter.Name)
+	shared := aws.IsShared(m.parameter.Name)
+	if shared {
+		title += " [shared, read-only]"
+	}
+	if address, ok := m.terraformAddress(); ok {
+		title += " [terraform: " + address + "]"
+	}
 	b.WriteString("  " + styles.TitleStyle.Render(title))
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if prev := m.changedSince; prev != nil {
+		b.WriteString("  " + styles.WarningStyle.Render(fmt.Sprintf("Changed since you last looked (%s, version %d → %d) • 'D' to diff",
+			m.times.Format(prev.Viewed), prev.Version, m.parameter.Version)))
+	}
+	b.WriteString("\n")
 	b.WriteString(m.viewport.View())
 	b.WriteString("\n\n")
 
+	if m.PromptActive && m.toString {
+		b.WriteString("  " + styles.WarningStyle.Render("Convert to a plain String? The value will be stored unencrypted and readable by anyone allowed ssm:GetParameter"))
+		b.WriteString("\n  " + styles.HelpStyle.Render("y: convert • esc: cancel"))
+		b.WriteString("\n")
+		return b.String()
+	}
+	if m.PromptActive {
+		b.WriteString("  " + styles.LabelStyle.Render("Convert to SecureString — KMS key (blank for default): "))
+		b.WriteString(m.kmsKeyInput.View())
+		b.WriteString("\n  " + styles.HelpStyle.Render("enter: convert • esc: cancel"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
 	helpText := "Press 'e' to edit"
 	if m.isJSON && len(m.jsonKeys) > 0 {
 		helpText += " selected key • 'a' to add key • ↑/↓ to select"
+		if m.envView {
+			helpText += " • 'E' for JSON keys"
+		} else {
+			helpText += " • 'E' for env vars"
+		}
+	} else if len(m.listItems) > 0 {
+		helpText += " selected item • ↑/↓ to select"
+	}
+	if m.parameter.Type == "String" {
+		helpText += " • 'S' to make SecureString"
+	} else if m.parameter.Type == "SecureString" {
+		helpText += " • 'S' to make String"
+	}
+	helpText += " • 'T' for tags"
+	if shared {
+		helpText = "Shared from another account"
+		if m.rowCount() > 0 {
+			helpText += " • ↑/↓ to select"
+		}
 	}
-	helpText += " • 'c' to copy • 'esc' to go back • 'q' to quit"
+	helpText += " • 'h' for history • 'u' to use as template • 'v' to compare with another context • 'C' to copy to another context • 'P' for pager • 't' for times • 'c' to copy • 'B' to copy as base64 • 'L' for console link • 'N' to notify on changes • 'r' for references • 'g' to go to referenced parameter • '.' to repeat last action • 'esc' to go back • 'q' to quit"
 	b.WriteString("  " + styles.HelpStyle.Render(helpText))
 
 	// Always reserve a line for status message
@@ -309,6 +758,103 @@ func (m ParameterViewModel) View() string {
 	return b.String()
 }
 
+// terraformAddress returns the Terraform resource managing the parameter, if any
+func (m ParameterViewModel) terraformAddress() (string, bool) {
+	if m.parameter == nil {
+		return "", false
+	}
+	address, ok := m.terraform[m.parameter.Name]
+	return address, ok
+}
+
+// SetTerraform sets the parameters Terraform manages
+func (m *ParameterViewModel) SetTerraform(resources terraform.Resources) {
+	m.terraform = resources
+}
+
+// showEnv reports whether JSON keys are shown as environment variables
+func (m ParameterViewModel) showEnv() bool {
+	return m.envView && m.isJSON && len(m.envNames) == len(m.jsonKeys) && len(m.jsonKeys) > 0
+}
+
+// SetEnvStyle sets how the environment variable preview names JSON keys:
+// separator joins nesting levels and casing is upper, lower or preserve
+func (m *ParameterViewModel) SetEnvStyle(separator, casing string) {
+	m.envStyle = envStyle{separator: separator, casing: casing}
+}
+
+// SetSnapshots sets the store viewed parameters are remembered in, nil to
+// stop remembering them
+func (m *ParameterViewModel) SetSnapshots(store *snapshot.Store) {
+	m.snapshots = store
+}
+
+// MarkSaved tells the view the parameter being loaded was just saved from
+// ps9s, so the new value is remembered without being flagged as a change
+func (m *ParameterViewModel) MarkSaved() {
+	m.saved = true
+}
+
+// checkSnapshot flags a value that changed since the parameter was last
+// viewed and remembers the value now shown
+func (m *ParameterViewModel) checkSnapshot() tea.Cmd {
+	m.changedSince = nil
+	if m.snapshots == nil || m.parameter == nil {
+		return nil
+	}
+	if prev, ok := m.snapshots.Get(m.currentProfile, m.currentRegion, m.parameter.Name); ok && !m.saved && prev.SHA256 != snapshot.Digest(m.parameter.Value) {
+		m.changedSince = &prev
+	}
+	store, profile, region, param := m.snapshots, m.currentProfile, m.currentRegion, m.parameter
+	return func() tea.Msg {
+		if err := store.Record(profile, region, param); err != nil {
+			return snapshotStatusMsg{Status: fmt.Sprintf("Snapshot failed: %v", err)}
+		}
+		return nil
+	}
+}
+
+// diffSnapshot compares the value seen last time with the current one, using
+// the stored value or else the version from the parameter's history
+func (m ParameterViewModel) diffSnapshot() tea.Cmd {
+	prev := m.changedSince
+	if prev == nil {
+		return nil
+	}
+	store, client, current := m.snapshots, m.client, m.parameter
+	return func() tea.Msg {
+		older := *current
+		older.Version = prev.Version
+		older.LastModifiedDate = prev.LastModified
+		value, ok, err := store.Value(*prev)
+		if err != nil {
+			return snapshotStatusMsg{Status: fmt.Sprintf("Diff failed: %v", err)}
+		}
+		if ok {
+			older.Value = value
+			return types.CompareVersionsMsg{Older: &older, Newer: current}
+		}
+		history, err := client.GetParameterHistory(context.Background(), current.Name)
+		if err != nil {
+			return snapshotStatusMsg{Status: fmt.Sprintf("Diff failed: %v", err)}
+		}
+		for _, v := range history {
+			if v.Version == prev.Version && snapshot.Digest(v.Value) == prev.SHA256 {
+				return types.CompareVersionsMsg{Older: v, Newer: current}
+			}
+		}
+		return snapshotStatusMsg{Status: fmt.Sprintf("Version %d is no longer in the history; set snapshots to \"value\" to keep values to diff", prev.Version)}
+	}
+}
+
+// SetTimestampFormat changes how the modified time is rendered
+func (m *ParameterViewModel) SetTimestampFormat(f TimestampFormat) {
+	m.times = f
+	if m.parameter != nil && !m.loading {
+		m.viewport.SetContent(m.formatParameterDetails(m.parameter))
+	}
+}
+
 // SetSize updates the dimensions of the parameter view
 func (m *ParameterViewModel) SetSize(width, height int) {
 	m.viewport.Width = width - 4
@@ -322,7 +868,7 @@ func isValidJSON(s string) bool {
 }
 
 // flattenJSONForView flattens JSON for viewing with selection
-func (m *ParameterViewModel) flattenJSONForView(data interface{}, prefix string) []jsonKeyItem {
+func flattenJSONForView(data interface{}, prefix string) []jsonKeyItem {
 	var result []jsonKeyItem
 
 	switch v := data.(type) {
@@ -340,12 +886,12 @@ func (m *ParameterViewModel) flattenJSONForView(data interface{}, prefix string)
 			if prefix != "" {
 				newPrefix = prefix + "." + key
 			}
-			result = append(result, m.flattenJSONForView(value, newPrefix)...)
+			result = append(result, flattenJSONForView(value, newPrefix)...)
 		}
 	case []interface{}:
 		for i, value := range v {
 			newPrefix := fmt.Sprintf("%s[%d]", prefix, i)
-			result = append(result, m.flattenJSONForView(value, newPrefix)...)
+			result = append(result, flattenJSONForView(value, newPrefix)...)
 		}
 	default:
 		// Leaf node
@@ -364,6 +910,53 @@ func (m *ParameterViewModel) flattenJSONForView(data interface{}, prefix string)
 	return result
 }
 
+// rowChrome is the height of the metadata, "Value:" label and box around the
+// rows of a JSON or StringList value
+const rowChrome = 8
+
+// rowWindow returns the rows that fit the viewport around the selection, so
+// moving the selection renders a screenful of rows instead of every key
+func (m ParameterViewModel) rowWindow() (start, end int) {
+	rows := m.rowCount()
+	visible := m.viewport.Height - rowChrome
+	if m.viewport.Height <= 0 || rows <= visible {
+		return 0, rows
+	}
+	visible = max(1, visible-2) // Room for the "more" lines
+	start = min(max(0, m.selectedIndex-visible/2), rows-visible)
+	return start, start + visible
+}
+
+// formatRows renders the rows in the window, one line each, with the
+// selected row highlighted; text returns the text of a row
+func (m ParameterViewModel) formatRows(text func(i int) string) string {
+	start, end := m.rowWindow()
+	width := m.viewport.Width - 12 // Inside the box border and padding, after the cursor
+
+	var lines []string
+	if start > 0 {
+		lines = append(lines, styles.HelpStyle.UnsetMarginTop().Render(fmt.Sprintf("  ↑ %d more", start)))
+	}
+	for i := start; i < end; i++ {
+		line := text(i)
+		if width > 0 {
+			line = truncateToWidth(line, width)
+		}
+		if i == m.selectedIndex {
+			line = lipgloss.NewStyle().
+				Foreground(styles.Primary).
+				Bold(true).
+				Render(styles.Cursor + " " + line)
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+	if more := m.rowCount() - end; more > 0 {
+		lines = append(lines, styles.HelpStyle.UnsetMarginTop().Render(fmt.Sprintf("  ↓ %d more", more)))
+	}
+	return strings.Join(lines, "\n")
+}
 
 // formatParameterDetails formats the parameter details for display
 func (m ParameterViewModel) formatParameterDetails(p *aws.Parameter) string {
@@ -371,30 +964,47 @@ func (m ParameterViewModel) formatParameterDetails(p *aws.Parameter) string {
 
 	b.WriteString(styles.LabelStyle.Render("Type: "))
 	b.WriteString(p.Type)
+	if p.Tier != "" {
+		b.WriteString("   ")
+		b.WriteString(styles.LabelStyle.Render("Tier: "))
+		b.WriteString(p.Tier)
+	}
+	if modified := m.times.Format(p.LastModifiedDate); modified != "" {
+		b.WriteString("   ")
+		b.WriteString(styles.LabelStyle.Render("Modified: "))
+		b.WriteString(modified)
+		if p.LastModifiedUser != "" {
+			b.WriteString(" by " + p.LastModifiedUser)
+		}
+	}
+	b.WriteString("   ")
+	b.WriteString(styles.LabelStyle.Render("SHA-256: "))
+	b.WriteString(export.Digest(p.Value))
 	b.WriteString("\n\n")
 
-	b.WriteString(styles.LabelStyle.Render("Value:"))
+	if m.showEnv() {
+		b.WriteString(styles.LabelStyle.Render("Value as environment variables:"))
+	} else {
+		b.WriteString(styles.LabelStyle.Render("Value:"))
+	}
 	b.WriteString("\n\n")
 
 	// Check if value is valid JSON and format accordingly
 	var valueContent string
-	if m.isJSON && len(m.jsonKeys) > 0 {
-		// Display JSON with selection highlighting
-		var lines []string
-		for i, item := range m.jsonKeys {
-			line := fmt.Sprintf("%s: %s", item.key, item.value)
-			if i == m.selectedIndex {
-				// Highlight selected line
-				line = lipgloss.NewStyle().
-					Foreground(lipgloss.Color("86")).
-					Bold(true).
-					Render("▸ " + line)
-			} else {
-				line = "  " + line
-			}
-			lines = append(lines, line)
-		}
-		valueContent = strings.Join(lines, "\n")
+	if m.showEnv() {
+		valueContent = m.formatRows(func(i int) string {
+			return m.envNames[i] + "=" + m.jsonKeys[i].value
+		})
+	} else if m.isJSON && len(m.jsonKeys) > 0 {
+		// Display JSON keys with selection highlighting
+		valueContent = m.formatRows(func(i int) string {
+			return fmt.Sprintf("%s: %s", m.jsonKeys[i].key, m.jsonKeys[i].value)
+		})
+	} else if len(m.listItems) > 0 {
+		// Display StringList items as rows with selection highlighting
+		valueContent = m.formatRows(func(i int) string {
+			return fmt.Sprintf("%d. %s", i+1, m.listItems[i])
+		})
 	} else {
 		// Not JSON, display as-is
 		valueContent = p.Value
@@ -402,8 +1012,8 @@ func (m ParameterViewModel) formatParameterDetails(p *aws.Parameter) string {
 
 	// Display value in a styled box
 	valueBox := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		Border(styles.Border).
+		BorderForeground(styles.Subtle).
 		Padding(1, 2).
 		Width(m.viewport.Width - 6).
 		Render(valueContent)
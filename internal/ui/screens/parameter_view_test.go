@@ -0,0 +1,143 @@
+package screens
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/snapshot"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+func TestParameterView_RendersVisibleKeysOnly(t *testing.T) {
+	data := make(map[string]string)
+	for i := range 5000 {
+		data[fmt.Sprintf("k%04d", i)] = "v"
+	}
+	value, _ := json.Marshal(data)
+
+	m := NewParameterView()
+	m.SetSize(80, 40)
+	m, _ = m.Update(types.ParameterValueLoadedMsg{Parameter: &aws.Parameter{Name: "/app/big", Type: "String", Value: string(value)}})
+
+	for range 100 {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	content := m.formatParameterDetails(m.parameter)
+	if lines := strings.Count(content, "\n"); lines > m.viewport.Height {
+		t.Fatalf("expected the key list to fit the viewport of %d lines, got %d", m.viewport.Height, lines)
+	}
+	if !strings.Contains(content, "k0100: v") || strings.Contains(content, "k0000: v") {
+		t.Fatalf("expected the window to follow the selection:\n%s", content)
+	}
+	if !strings.Contains(content, "more") {
+		t.Fatalf("expected the hidden keys to be counted:\n%s", content)
+	}
+}
+
+func TestParameterView_EnvPreview(t *testing.T) {
+	value := `{"server": {"host": "db", "ports": [80, 443]}, "log-level": "info"}`
+
+	m := NewParameterView()
+	m.SetSize(100, 40)
+	m.SetEnvStyle("__", "")
+	m, _ = m.Update(types.ParameterValueLoadedMsg{Parameter: &aws.Parameter{Name: "/app/cfg", Type: "String", Value: value}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'E'}})
+
+	content := m.formatParameterDetails(m.parameter)
+	for _, want := range []string{"LOG-LEVEL=info", "SERVER__HOST=db", "SERVER__PORTS__0=80", "SERVER__PORTS__1=443"} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("expected %q in the env preview:\n%s", want, content)
+		}
+	}
+
+	m.SetEnvStyle(":", "preserve")
+	m, _ = m.Update(types.ParameterValueLoadedMsg{Parameter: m.parameter})
+	if content := m.formatParameterDetails(m.parameter); !strings.Contains(content, "server:ports:1=443") {
+		t.Fatalf("expected the configured separator and case:\n%s", content)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'E'}})
+	if content := m.formatParameterDetails(m.parameter); !strings.Contains(content, "server.host: db") {
+		t.Fatalf("expected E to switch back to JSON keys:\n%s", content)
+	}
+}
+
+func TestParameterView_FlagsChangesSinceLastLook(t *testing.T) {
+	store, err := snapshot.Open(t.TempDir(), true)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if err := store.Record("prod", "eu-west-1", &aws.Parameter{Name: "/app/mode", Version: 1, Value: "blue"}); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	m := NewParameterView()
+	m.SetSize(100, 40)
+	m.SetContext("prod", "eu-west-1")
+	m.SetSnapshots(store)
+	m, cmd := m.Update(types.ParameterValueLoadedMsg{Parameter: &aws.Parameter{Name: "/app/mode", Type: "String", Version: 2, Value: "green"}})
+	if !strings.Contains(m.View(), "Changed since you last looked") {
+		t.Fatalf("expected the change to be flagged:\n%s", m.View())
+	}
+	if cmd != nil {
+		cmd()
+	}
+	if e, _ := store.Get("prod", "eu-west-1", "/app/mode"); e.Version != 2 {
+		t.Fatalf("expected the new value to be remembered, got version %d", e.Version)
+	}
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	msg, ok := cmd().(types.CompareVersionsMsg)
+	if !ok || msg.Older.Value != "blue" || msg.Newer.Value != "green" {
+		t.Fatalf("expected D to compare the remembered value, got %#v", msg)
+	}
+
+	m, _ = m.Update(types.ParameterValueLoadedMsg{Parameter: m.parameter})
+	if strings.Contains(m.View(), "Changed since you last looked") {
+		t.Fatal("an unchanged value must not be flagged")
+	}
+}
+
+func TestParameterView_ConvertSecureStringToString(t *testing.T) {
+	t.Cleanup(aws.ResetDemo)
+	ctx := context.Background()
+	client := aws.NewDemoClient("convert-test", "eu-west-1")
+	if err := client.CreateParameter(ctx, "/app/flag", "on", "SecureString", "", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	param, err := client.GetParameter(ctx, "/app/flag")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewParameterView()
+	m.SetSize(100, 40)
+	m.client = client
+	m, _ = m.Update(types.ParameterValueLoadedMsg{Parameter: param})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	if !m.PromptActive || !strings.Contains(m.View(), "Convert to a plain String?") {
+		t.Fatalf("expected a confirmation before decrypting:\n%s", m.View())
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Fatal("expected the conversion to run")
+	}
+	var saved *aws.Parameter
+	for _, c := range cmd().(tea.BatchMsg) {
+		if msg, ok := c().(types.SaveSuccessMsg); ok {
+			saved = msg.Parameter
+		}
+	}
+	if saved == nil || saved.Type != "String" {
+		t.Fatalf("expected the parameter saved as a String, got %+v", saved)
+	}
+	if p, _ := client.GetParameter(ctx, "/app/flag"); p == nil || p.Type != "String" || p.Value != "on" {
+		t.Fatalf("expected a String with the same value, got %+v", p)
+	}
+}
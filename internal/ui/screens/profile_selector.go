@@ -3,17 +3,21 @@ package screens
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/aws"
+	cfg "github.com/ilia/ps9s/internal/config"
 	"github.com/ilia/ps9s/internal/styles"
 	"github.com/ilia/ps9s/internal/types"
 )
 
 // profileItem represents a profile in the list
 type profileItem struct {
-	profile string
+	profile   string
+	roleChain string // Roles assumed by the profile, from its base credentials
 }
 
 func (i profileItem) FilterValue() string { return i.profile }
@@ -30,15 +34,21 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 	}
 
 	str := fmt.Sprintf("%d. %s", index+1, i.profile)
+	if degraded, _ := aws.ProfileDegraded(i.profile); degraded {
+		str += " " + styles.WarningStyle.Render("[degraded]")
+	}
+	if i.roleChain != "" {
+		str += " " + styles.HelpStyle.UnsetMarginTop().Render("("+i.roleChain+")")
+	}
 
 	fn := lipgloss.NewStyle().PaddingLeft(2).Render
 	if index == m.Index() {
 		fn = func(s ...string) string {
 			return lipgloss.NewStyle().
-				Foreground(lipgloss.Color("86")).
+				Foreground(styles.Primary).
 				Bold(true).
 				PaddingLeft(2).
-				Render("▸ " + s[0])
+				Render(styles.Cursor + " " + s[0])
 		}
 	}
 
@@ -47,8 +57,13 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 
 // ProfileSelectorModel represents the profile selection screen
 type ProfileSelectorModel struct {
-	list   list.Model
-	choice string
+	list      list.Model
+	choice    string
+	profiles  []string
+	favorites []cfg.RecentEntry // Pinned contexts, selected with alt+1-9
+	status    string            // Result of the last retry
+	height    int
+	jump      string // Number typed to jump to, confirmed with enter or g
 }
 
 // NewProfileSelector creates a new profile selector screen
@@ -70,8 +85,34 @@ func NewProfileSelector(profiles []string) ProfileSelectorModel {
 	l.Styles.HelpStyle = list.DefaultStyles().HelpStyle.PaddingLeft(4).PaddingBottom(1)
 
 	return ProfileSelectorModel{
-		list: l,
+		list:     l,
+		profiles: profiles,
+	}
+}
+
+// SetFavorites sets the pinned contexts, skipping profiles that no longer exist
+func (m *ProfileSelectorModel) SetFavorites(favorites []cfg.RecentEntry) {
+	known := make(map[string]bool, len(m.profiles))
+	for _, p := range m.profiles {
+		known[p] = true
+	}
+
+	m.favorites = nil
+	for _, f := range favorites {
+		if known[f.Profile] && len(m.favorites) < cfg.MaxFavorites {
+			m.favorites = append(m.favorites, f)
+		}
+	}
+	m.resize()
+}
+
+// SetRoleChains shows the role chain next to each profile that assumes a role
+func (m *ProfileSelectorModel) SetRoleChains(chains map[string]string) {
+	items := make([]list.Item, len(m.profiles))
+	for i, p := range m.profiles {
+		items[i] = profileItem{profile: p, roleChain: chains[p]}
 	}
+	m.list.SetItems(items)
 }
 
 // Init initializes the profile selector
@@ -83,11 +124,14 @@ func (m ProfileSelectorModel) Init() tea.Cmd {
 func (m ProfileSelectorModel) Update(msg tea.Msg) (ProfileSelectorModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		m.list.SetWidth(msg.Width)
-		m.list.SetHeight(msg.Height - 2)
+		m.SetSize(msg.Width, msg.Height)
 		return m, nil
 
 	case tea.KeyMsg:
+		m.status = ""
+		if updateJump(&m.jump, msg.String(), &m.list) {
+			return m, nil
+		}
 		switch msg.String() {
 		case "esc":
 			return m, func() tea.Msg { return types.BackMsg{} }
@@ -102,6 +146,26 @@ func (m ProfileSelectorModel) Update(msg tea.Msg) (ProfileSelectorModel, tea.Cmd
 			}
 		case "q", "ctrl+c":
 			return m, tea.Quit
+		case "R":
+			// Let a degraded profile call AWS again
+			if item, ok := m.list.SelectedItem().(profileItem); ok {
+				if degraded, _ := aws.ProfileDegraded(item.profile); degraded {
+					aws.ResetProfile(item.profile)
+					m.status = fmt.Sprintf("Profile %s will be retried", item.profile)
+				}
+			}
+			return m, nil
+		case "alt+1", "alt+2", "alt+3", "alt+4", "alt+5", "alt+6", "alt+7", "alt+8", "alt+9":
+			// Open a favorite context directly, skipping region selection
+			idx := int(msg.String()[len("alt+")] - '1')
+			if idx < len(m.favorites) {
+				f := m.favorites[idx]
+				m.choice = f.Profile
+				return m, func() tea.Msg {
+					return types.ProfileSelectedMsg{Profile: f.Profile, Region: f.Region}
+				}
+			}
+			return m, nil
 		}
 	}
 
@@ -110,13 +174,68 @@ func (m ProfileSelectorModel) Update(msg tea.Msg) (ProfileSelectorModel, tea.Cmd
 	return m, cmd
 }
 
+// Jumping reports whether a number to jump to is being typed
+func (m ProfileSelectorModel) Jumping() bool {
+	return m.jump != ""
+}
+
 // View renders the profile selector
 func (m ProfileSelectorModel) View() string {
-	return m.list.View()
+	view := m.list.View()
+	if len(m.favorites) > 0 {
+		view = m.renderFavorites() + view
+	}
+	if m.jump != "" {
+		return view + jumpPrompt(m.jump)
+	}
+	if m.status != "" {
+		return view + "\n  " + styles.SuccessStyle.Render(m.status)
+	}
+	if m.anyDegraded() {
+		return view + "\n  " + styles.WarningStyle.Render("Degraded profiles stopped calling AWS after repeated credential failures • R: retry selected")
+	}
+	return view
+}
+
+// anyDegraded reports whether a listed profile is degraded
+func (m ProfileSelectorModel) anyDegraded() bool {
+	for _, p := range m.profiles {
+		if degraded, _ := aws.ProfileDegraded(p); degraded {
+			return true
+		}
+	}
+	return false
+}
+
+// renderFavorites lists the pinned contexts above the profiles
+func (m ProfileSelectorModel) renderFavorites() string {
+	var b strings.Builder
+	b.WriteString("  " + styles.LabelStyle.Render("Favorites") + "\n")
+	for i, f := range m.favorites {
+		fmt.Fprintf(&b, "    %s %s : %s\n", styles.LabelStyle.Render(fmt.Sprintf("alt+%d", i+1)), f.Profile, f.Region)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// favoritesHeight is the number of lines the favorites section takes
+func (m ProfileSelectorModel) favoritesHeight() int {
+	if len(m.favorites) == 0 {
+		return 0
+	}
+	return len(m.favorites) + 2
 }
 
 // SetSize updates the dimensions of the profile selector
 func (m *ProfileSelectorModel) SetSize(width, height int) {
+	m.height = height
 	m.list.SetWidth(width)
-	m.list.SetHeight(height - 2)
+	m.resize()
+}
+
+// resize fits the profile list below the favorites
+func (m *ProfileSelectorModel) resize() {
+	if m.height > 0 {
+		m.list.SetHeight(m.height - 3 - m.favoritesHeight())
+	}
 }
@@ -0,0 +1,68 @@
+package screens
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	cfg "github.com/ilia/ps9s/internal/config"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+func TestProfileSelector_FavoritesSkipUnknownProfiles(t *testing.T) {
+	m := NewProfileSelector([]string{"prod", "staging"})
+	m.SetFavorites([]cfg.RecentEntry{
+		{Profile: "gone", Region: "us-east-1"},
+		{Profile: "prod", Region: "eu-west-1"},
+	})
+
+	if len(m.favorites) != 1 || m.favorites[0].Profile != "prod" {
+		t.Fatalf("expected only the prod favorite, got %#v", m.favorites)
+	}
+	if view := m.View(); !strings.Contains(view, "prod : eu-west-1") {
+		t.Fatalf("expected favorite in view, got:\n%s", view)
+	}
+}
+
+func TestProfileSelector_FavoriteKeySelectsContext(t *testing.T) {
+	m := NewProfileSelector([]string{"prod"})
+	m.SetFavorites([]cfg.RecentEntry{{Profile: "prod", Region: "eu-west-1"}})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1"), Alt: true})
+	if cmd == nil {
+		t.Fatalf("expected cmd for favorite key, got nil")
+	}
+	msg, ok := cmd().(types.ProfileSelectedMsg)
+	if !ok || msg.Profile != "prod" || msg.Region != "eu-west-1" {
+		t.Fatalf("expected ProfileSelectedMsg for prod/eu-west-1, got %#v", cmd())
+	}
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2"), Alt: true})
+	if cmd != nil {
+		t.Fatalf("expected no cmd for an unset favorite")
+	}
+
+	// Plain digits are left to the profile list
+	if _, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")}); cmd != nil {
+		if _, ok := cmd().(types.ProfileSelectedMsg); ok {
+			t.Fatalf("expected 1 not to open a favorite")
+		}
+	}
+}
+
+func TestProfileSelector_JumpToNumber(t *testing.T) {
+	m := NewProfileSelector([]string{"dev", "prod", "staging"})
+	m.SetFavorites([]cfg.RecentEntry{{Profile: "prod", Region: "eu-west-1"}})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	if !m.Jumping() {
+		t.Fatal("expected a typed number to start a jump")
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || m.Jumping() || m.list.Index() != 2 {
+		t.Fatalf("expected enter to jump to profile 3, got index %d", m.list.Index())
+	}
+	if view := m.View(); !strings.Contains(view, "3. staging") {
+		t.Fatalf("expected numbered profiles, got:\n%s", view)
+	}
+}
@@ -0,0 +1,26 @@
+package screens
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+// progressText is the label shown next to a spinner: text normally, or the
+// retry progress while a throttled or failed call is being retried
+func progressText(text string) string {
+	if s := aws.Activity().Retrying; s != nil {
+		return formatRetry(*s, time.Now())
+	}
+	return text
+}
+
+// formatRetry renders a retry status, e.g. "retrying (attempt 2/5, waiting 4s)…"
+func formatRetry(s aws.RetryStatus, now time.Time) string {
+	wait := s.Until.Sub(now).Round(time.Second)
+	if wait < time.Second {
+		return fmt.Sprintf("retrying (attempt %d/%d)…", s.Attempt, s.MaxAttempts)
+	}
+	return fmt.Sprintf("retrying (attempt %d/%d, waiting %s)…", s.Attempt, s.MaxAttempts, wait)
+}
@@ -0,0 +1,21 @@
+package screens
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+func TestFormatRetry(t *testing.T) {
+	now := time.Now()
+	s := aws.RetryStatus{Attempt: 2, MaxAttempts: 5, Until: now.Add(4 * time.Second)}
+	if got, want := formatRetry(s, now), "retrying (attempt 2/5, waiting 4s)…"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// Once the backoff is over the attempt is in flight
+	if got, want := formatRetry(s, now.Add(5*time.Second)), "retrying (attempt 2/5)…"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
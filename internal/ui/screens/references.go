@@ -0,0 +1,290 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+// referenceRow is a parameter listed on the references screen
+type referenceRow struct {
+	name     string
+	incoming bool // Refers to the focused parameter, rather than referred to by it
+}
+
+// ReferencesModel shows which parameters the focused parameter refers to and
+// which refer to it; enter moves the focus along a reference
+type ReferencesModel struct {
+	client         *aws.Client
+	graph          *aws.ReferenceGraph
+	graphClient    *aws.Client               // Client the graph was built with
+	params         map[string]*aws.Parameter // Listed parameters, by name
+	focus          string
+	trail          []string // Previously focused names, for esc
+	rows           []referenceRow
+	cursor         int
+	spinner        spinner.Model
+	loading        bool
+	err            error
+	height         int
+	currentProfile string
+	currentRegion  string
+	cancelLoad     context.CancelFunc
+}
+
+// NewReferences creates the reference graph screen
+func NewReferences() ReferencesModel {
+	s := spinner.New()
+	s.Spinner = styles.Spinner
+	s.Style = lipgloss.NewStyle().Foreground(styles.Secondary)
+	return ReferencesModel{spinner: s}
+}
+
+// Init initializes the reference graph screen
+func (m ReferencesModel) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+// Load focuses name, building the graph from the values of params first
+// unless it was already built for client
+func (m *ReferencesModel) Load(client *aws.Client, params []*aws.Parameter, name string) tea.Cmd {
+	m.client = client
+	m.trail = nil
+	m.err = nil
+	m.params = make(map[string]*aws.Parameter, len(params))
+	for _, p := range params {
+		m.params[p.Name] = p
+	}
+	if m.graph != nil && m.graphClient == client {
+		m.setFocus(name)
+		return nil
+	}
+	m.focus = name
+	return m.build(params)
+}
+
+// build fetches the values of params (SecureStrings stay encrypted) and
+// finds the references between them
+func (m *ReferencesModel) build(params []*aws.Parameter) tea.Cmd {
+	if m.cancelLoad != nil {
+		m.cancelLoad()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelLoad = cancel
+	m.loading = true
+	m.graph = nil
+
+	client := m.client
+	return tea.Batch(m.spinner.Tick, func() tea.Msg {
+		if len(params) == 0 {
+			var err error
+			if params, err = client.ListParameters(ctx); err != nil {
+				return types.ErrorMsg{Err: err}
+			}
+		}
+		names := make([]string, len(params))
+		for i, p := range params {
+			names[i] = p.Name
+		}
+		values, err := client.GetParameterValues(ctx, names)
+		if err != nil {
+			return types.ErrorMsg{Err: err}
+		}
+		graph := aws.BuildReferenceGraph(values)
+		return types.ReferenceGraphLoadedMsg{Graph: &graph, Parameters: params}
+	})
+}
+
+// setFocus shows the references of name
+func (m *ReferencesModel) setFocus(name string) {
+	m.focus = name
+	m.cursor = 0
+	m.rows = nil
+	if m.graph == nil {
+		return
+	}
+	for _, ref := range m.graph.Refs[name] {
+		m.rows = append(m.rows, referenceRow{name: ref})
+	}
+	for _, ref := range m.graph.ReferencedBy[name] {
+		m.rows = append(m.rows, referenceRow{name: ref, incoming: true})
+	}
+}
+
+// Nested reports whether a reference was followed, so esc steps back instead of leaving
+func (m ReferencesModel) Nested() bool {
+	return len(m.trail) > 0 && !m.loading
+}
+
+// Update handles messages for the reference graph screen
+func (m ReferencesModel) Update(msg tea.Msg) (ReferencesModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case types.ReferenceGraphLoadedMsg:
+		m.loading = false
+		m.graph = msg.Graph
+		m.graphClient = m.client
+		for _, p := range msg.Parameters {
+			m.params[p.Name] = p
+		}
+		m.setFocus(m.focus)
+		return m, nil
+
+	case types.ErrorMsg:
+		m.loading = false
+		m.err = msg.Err
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading && msg.String() != "esc" {
+			return m, nil
+		}
+		switch msg.String() {
+		case "esc":
+			// Step back along the followed references before leaving
+			if m.Nested() {
+				prev := m.trail[len(m.trail)-1]
+				m.trail = m.trail[:len(m.trail)-1]
+				m.setFocus(prev)
+				return m, nil
+			}
+			if m.cancelLoad != nil {
+				m.cancelLoad()
+			}
+			m.loading = false
+			return m, func() tea.Msg { return types.BackMsg{} }
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.rows)-1 {
+				m.cursor++
+			}
+		case "enter":
+			// Follow the selected reference
+			if m.cursor < len(m.rows) {
+				m.trail = append(m.trail, m.focus)
+				m.setFocus(m.rows[m.cursor].name)
+			}
+		case "v":
+			// Open the selected parameter, or the focused one, in the viewer
+			name := m.focus
+			if m.cursor < len(m.rows) {
+				name = m.rows[m.cursor].name
+			}
+			param := m.params[name]
+			if param == nil {
+				param = &aws.Parameter{Name: name}
+			}
+			return m, func() tea.Msg { return types.ViewParameterMsg{Parameter: param} }
+		case "R":
+			// Rebuild the graph from current values
+			params := make([]*aws.Parameter, 0, len(m.params))
+			for _, p := range m.params {
+				params = append(params, p)
+			}
+			return m, m.build(params)
+		}
+		return m, nil
+	}
+
+	if m.loading {
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// View renders the reference graph screen
+func (m ReferencesModel) View() string {
+	if m.loading {
+		return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), progressText("Reading values to find references..."))
+	}
+
+	var b strings.Builder
+
+	profile := m.currentProfile
+	region := m.currentRegion
+	if profile == "" {
+		profile = "-"
+	}
+	if region == "" {
+		region = "-"
+	}
+	title := fmt.Sprintf("%s : %s : References : %s", profile, region, m.focus)
+	b.WriteString("  " + styles.TitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	if m.err != nil {
+		b.WriteString("  " + styles.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)) + "\n")
+		b.WriteString("  " + styles.HelpStyle.Render("R: retry • esc: back"))
+		return b.String()
+	}
+
+	if len(m.trail) > 0 {
+		b.WriteString("  " + styles.HelpStyle.UnsetMarginTop().Render("via "+strings.Join(m.trail, " → ")) + "\n\n")
+	}
+
+	outgoing, incoming := 0, 0
+	for _, r := range m.rows {
+		if r.incoming {
+			incoming++
+		} else {
+			outgoing++
+		}
+	}
+
+	// Keep the cursor visible on long lists
+	visible := max(1, m.height-10)
+	start := max(0, m.cursor-visible+1)
+
+	section := func(label string, count int, incomingRows bool) {
+		b.WriteString("  " + styles.LabelStyle.Render(fmt.Sprintf("%s (%d)", label, count)) + "\n")
+		if count == 0 {
+			b.WriteString("    " + styles.HelpStyle.UnsetMarginTop().Render("none") + "\n")
+		}
+		for i, r := range m.rows {
+			if r.incoming != incomingRows || i < start || i >= start+visible {
+				continue
+			}
+			line := r.name
+			if _, ok := m.params[r.name]; !ok {
+				line += styles.WarningStyle.Render(" (not found)")
+			}
+			if i == m.cursor {
+				b.WriteString("  " + lipgloss.NewStyle().Foreground(styles.Primary).Bold(true).Render(styles.Cursor+" ") + line + "\n")
+			} else {
+				b.WriteString("    " + line + "\n")
+			}
+		}
+	}
+	section("References", outgoing, false)
+	b.WriteString("\n")
+	section("Referenced by", incoming, true)
+
+	b.WriteString("  " + styles.HelpStyle.Render("↑/↓: select • enter: follow • v: view • R: rebuild • esc: back • q: quit"))
+	b.WriteString("\n  " + styles.HelpStyle.UnsetMarginTop().Render("References in SecureString values are not detected"))
+
+	return b.String()
+}
+
+// SetContext sets the profile and region context for the reference graph screen
+func (m *ReferencesModel) SetContext(profile, region string) {
+	m.currentProfile = profile
+	m.currentRegion = region
+}
+
+// SetSize updates the dimensions of the reference graph screen
+func (m *ReferencesModel) SetSize(width, height int) {
+	m.height = height
+}
@@ -0,0 +1,60 @@
+package screens
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+func TestReferences_FollowAndStepBack(t *testing.T) {
+	m := NewReferences()
+	m.Load(nil, nil, "/app/a")
+	graph := aws.BuildReferenceGraph(map[string]string{
+		"/app/a":     "{{ssm:/shared/db}}",
+		"/app/b":     "/shared/db",
+		"/shared/db": "db.internal",
+	})
+	m, _ = m.Update(types.ReferenceGraphLoadedMsg{Graph: &graph})
+
+	if len(m.rows) != 1 || m.rows[0].name != "/shared/db" {
+		t.Fatalf("expected /app/a to refer to /shared/db, got %+v", m.rows)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.focus != "/shared/db" || len(m.rows) != 2 || !m.rows[0].incoming {
+		t.Fatalf("expected /shared/db with two referrers, got %s %+v", m.focus, m.rows)
+	}
+	if !m.Nested() {
+		t.Fatal("expected esc to step back after following a reference")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.focus != "/app/a" || m.Nested() {
+		t.Fatalf("expected esc to return to /app/a, got %s", m.focus)
+	}
+}
+
+func TestParameterView_FollowReference(t *testing.T) {
+	m := NewParameterView()
+	m.LoadParameter(&aws.Parameter{Name: "/app/config"}, nil)
+	m, _ = m.Update(types.ParameterValueLoadedMsg{Parameter: &aws.Parameter{
+		Name:  "/app/config",
+		Value: `{"db": "{{resolve:ssm:/shared/db}}", "name": "app"}`,
+	}})
+
+	if got := m.selectedReference(); got != "/shared/db" {
+		t.Fatalf("expected the selected JSON value to refer to /shared/db, got %q", got)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	if m.Parameter().Name != "/shared/db" || !m.Nested() {
+		t.Fatalf("expected /shared/db to be loaded with a way back, got %s", m.Parameter().Name)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Parameter().Name != "/app/config" || m.Nested() {
+		t.Fatalf("expected esc to return to /app/config, got %s", m.Parameter().Name)
+	}
+}
@@ -3,6 +3,7 @@ package screens
 import (
 	"fmt"
 	"io"
+	"strconv"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -47,10 +48,10 @@ func (d regionDelegate) Render(w io.Writer, m list.Model, index int, listItem li
 	if index == m.Index() {
 		fn = func(s ...string) string {
 			return lipgloss.NewStyle().
-				Foreground(lipgloss.Color("86")).
+				Foreground(styles.Primary).
 				Bold(true).
 				PaddingLeft(2).
-				Render("▸ " + s[0])
+				Render(styles.Cursor + " " + s[0])
 		}
 	}
 
@@ -61,6 +62,7 @@ func (d regionDelegate) Render(w io.Writer, m list.Model, index int, listItem li
 type RegionSelectorModel struct {
 	list   list.Model
 	choice string
+	jump   string // Number typed to jump to, confirmed with enter or g
 }
 
 // NewRegionSelector creates a new region selector screen
@@ -100,6 +102,9 @@ func (m RegionSelectorModel) Update(msg tea.Msg) (RegionSelectorModel, tea.Cmd)
 		return m, nil
 
 	case tea.KeyMsg:
+		if updateJump(&m.jump, msg.String(), &m.list) {
+			return m, nil
+		}
 		switch msg.String() {
 		case "esc":
 			return m, func() tea.Msg { return types.BackMsg{} }
@@ -122,11 +127,55 @@ func (m RegionSelectorModel) Update(msg tea.Msg) (RegionSelectorModel, tea.Cmd)
 	return m, cmd
 }
 
+// Jumping reports whether a number to jump to is being typed
+func (m RegionSelectorModel) Jumping() bool {
+	return m.jump != ""
+}
+
 // View renders the region selector
 func (m RegionSelectorModel) View() string {
+	if m.jump != "" {
+		return m.list.View() + jumpPrompt(m.jump)
+	}
 	return m.list.View()
 }
 
+// updateJump moves the cursor of l to a row number typed one digit at a time
+// and confirmed with enter or g, reporting whether key was used
+func updateJump(jump *string, key string, l *list.Model) bool {
+	if *jump != "" {
+		switch key {
+		case "esc":
+			*jump = ""
+			return true
+		case "backspace":
+			*jump = (*jump)[:len(*jump)-1]
+			return true
+		case "enter", "g":
+			if n, err := strconv.Atoi(*jump); err == nil && n >= 1 && n <= len(l.Items()) {
+				l.Select(n - 1)
+			}
+			*jump = ""
+			return true
+		}
+	}
+	if len(key) == 1 && key[0] >= '0' && key[0] <= '9' {
+		// Row numbers start at 1
+		if *jump != "" || key != "0" {
+			*jump += key
+		}
+		return true
+	}
+	*jump = ""
+	return false
+}
+
+// jumpPrompt shows the row number being typed below a list
+func jumpPrompt(jump string) string {
+	return "\n  " + styles.InfoStyle.Render("Go to: "+jump) + " " +
+		styles.HelpStyle.UnsetMarginTop().Render("(enter/g: jump • esc: cancel)")
+}
+
 // SetSize updates the dimensions of the region selector
 func (m *RegionSelectorModel) SetSize(width, height int) {
 	m.list.SetWidth(width)
@@ -0,0 +1,38 @@
+package screens
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func typeKeys(m RegionSelectorModel, keys string) RegionSelectorModel {
+	for _, r := range keys {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m
+}
+
+func TestRegionSelector_JumpToNumber(t *testing.T) {
+	m := NewRegionSelector()
+
+	m = typeKeys(m, "6")
+	if !m.Jumping() {
+		t.Fatal("expected a typed number to start a jump")
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || m.Jumping() || m.list.Index() != 5 {
+		t.Fatalf("expected enter to jump to region 6, got index %d", m.list.Index())
+	}
+
+	m = typeKeys(m, "2g")
+	if m.list.Index() != 1 {
+		t.Fatalf("expected g to jump to region 2, got index %d", m.list.Index())
+	}
+
+	// Numbers past the end leave the selection alone
+	m = typeKeys(m, "42g")
+	if m.list.Index() != 1 || m.Jumping() {
+		t.Fatalf("expected an unknown number to be ignored, got index %d", m.list.Index())
+	}
+}
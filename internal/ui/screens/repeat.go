@@ -0,0 +1,124 @@
+package screens
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+// DescribeAction returns a short description of a repeatable action, e.g. "add tags team=payments"
+func DescribeAction(action types.RepeatableAction) string {
+	switch action.Kind {
+	case types.ActionCopyValue:
+		desc := "copy value"
+		if action.JSONKey != "" {
+			desc = "copy JSON key " + action.JSONKey
+		}
+		if action.Base64 {
+			desc += " as base64"
+		}
+		return desc
+	case types.ActionCopyLink:
+		return "copy console link"
+	case types.ActionAddTags:
+		tags := make([]string, len(action.Tags))
+		for i, t := range action.Tags {
+			tags[i] = t.Key + "=" + t.Value
+		}
+		return "add tags " + strings.Join(tags, ", ")
+	}
+	return "unknown action"
+}
+
+// RepeatAction applies action to param, reporting the outcome with an ActionRepeatedMsg
+func RepeatAction(client *aws.Client, region string, action types.RepeatableAction, param *aws.Parameter) tea.Cmd {
+	name := param.Name
+	return func() tea.Msg {
+		ctx := context.Background()
+		switch action.Kind {
+		case types.ActionCopyValue:
+			// List entries may lack the (decrypted) value, so fetch it
+			p, err := client.GetParameter(ctx, name)
+			if err != nil {
+				return types.ActionRepeatedMsg{Err: err}
+			}
+			value := p.Value
+			if action.JSONKey != "" {
+				var ok bool
+				if value, ok = jsonKeyValue(p.Value, action.JSONKey); !ok {
+					return types.ActionRepeatedMsg{Err: fmt.Errorf("%s has no JSON key %s", name, action.JSONKey)}
+				}
+			}
+			if action.Base64 {
+				value = base64.StdEncoding.EncodeToString([]byte(value))
+			}
+			if err := clipboard.WriteAll(value); err != nil {
+				return types.ActionRepeatedMsg{Err: fmt.Errorf("failed to copy: %w", err)}
+			}
+			return types.ActionRepeatedMsg{Status: fmt.Sprintf("Repeated %s for %s", DescribeAction(action), name)}
+
+		case types.ActionCopyLink:
+			if err := clipboard.WriteAll(aws.ConsoleURL(region, name)); err != nil {
+				return types.ActionRepeatedMsg{Err: fmt.Errorf("failed to copy: %w", err)}
+			}
+			return types.ActionRepeatedMsg{Status: fmt.Sprintf("Repeated %s for %s", DescribeAction(action), name)}
+
+		case types.ActionAddTags:
+			old, err := client.ListTags(ctx, name)
+			if err != nil {
+				return types.ActionRepeatedMsg{Err: err}
+			}
+			updated := mergeTags(old, action.Tags)
+			if err := client.UpdateTags(ctx, name, old, updated); err != nil {
+				return types.ActionRepeatedMsg{Err: err}
+			}
+			return types.ActionRepeatedMsg{Status: fmt.Sprintf("Repeated %s for %s", DescribeAction(action), name)}
+		}
+		return types.ActionRepeatedMsg{Err: fmt.Errorf("cannot repeat %s", DescribeAction(action))}
+	}
+}
+
+// jsonKeyValue returns the value at a flattened key (as shown on the view
+// screen) of a JSON document
+func jsonKeyValue(value, key string) (string, bool) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(value), &data); err != nil {
+		return "", false
+	}
+	for _, item := range flattenJSONForView(data, "") {
+		if item.key == key {
+			return item.value, true
+		}
+	}
+	return "", false
+}
+
+// mergeTags sets tags on top of existing ones, replacing values of the same key
+func mergeTags(existing, tags []aws.Tag) []aws.Tag {
+	merged := append([]aws.Tag(nil), existing...)
+	for _, t := range tags {
+		replaced := false
+		for i := range merged {
+			if merged[i].Key == t.Key {
+				merged[i].Value = t.Value
+				replaced = true
+			}
+		}
+		if !replaced {
+			merged = append(merged, t)
+		}
+	}
+	return merged
+}
+
+// performed records action as the one '.' repeats
+func performed(action types.RepeatableAction) tea.Cmd {
+	return func() tea.Msg { return types.ActionPerformedMsg{Action: action} }
+}
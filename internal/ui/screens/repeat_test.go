@@ -0,0 +1,41 @@
+package screens
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+func TestMergeTags(t *testing.T) {
+	existing := []aws.Tag{{Key: "team", Value: "core"}, {Key: "env", Value: "prod"}}
+	got := mergeTags(existing, []aws.Tag{{Key: "team", Value: "payments"}, {Key: "owner", Value: "ops"}})
+	want := []aws.Tag{{Key: "team", Value: "payments"}, {Key: "env", Value: "prod"}, {Key: "owner", Value: "ops"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeTags() = %v, want %v", got, want)
+	}
+	if existing[0].Value != "core" {
+		t.Errorf("mergeTags() modified the existing tags")
+	}
+}
+
+func TestJSONKeyValue(t *testing.T) {
+	value := `{"db":{"host":"localhost","port":5432}}`
+	if got, ok := jsonKeyValue(value, "db.host"); !ok || got != "localhost" {
+		t.Errorf("jsonKeyValue(db.host) = %q, %v", got, ok)
+	}
+	if _, ok := jsonKeyValue(value, "db.user"); ok {
+		t.Errorf("jsonKeyValue(db.user) found a missing key")
+	}
+	if _, ok := jsonKeyValue("not json", "db.host"); ok {
+		t.Errorf("jsonKeyValue() found a key in a non-JSON value")
+	}
+}
+
+func TestDescribeAction_Base64(t *testing.T) {
+	action := types.RepeatableAction{Kind: types.ActionCopyValue, JSONKey: "db.password", Base64: true}
+	if got := DescribeAction(action); got != "copy JSON key db.password as base64" {
+		t.Errorf("DescribeAction() = %q", got)
+	}
+}
@@ -0,0 +1,514 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/aws"
+	cfg "github.com/ilia/ps9s/internal/config"
+	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+// ReportModel lists the parameters a report flags, e.g. placeholder values
+// under a path, as a review list with bulk cleanup actions
+type ReportModel struct {
+	client         *aws.Client
+	kind           types.ReportKind
+	scope          string
+	params         []*aws.Parameter // Parameters the report ran over
+	rows           []types.ReportRow
+	cursor         int
+	marked         map[string]bool // Names marked with space for bulk actions
+	staleDays      int
+	confirmDelete  bool
+	spinner        spinner.Model
+	loading        bool
+	working        bool // A bulk action is running
+	loadSeq        int
+	err            error
+	status         string
+	statusErr      bool
+	height         int
+	currentProfile string
+	currentRegion  string
+	cancelLoad     context.CancelFunc
+}
+
+// NewReport creates the report screen
+func NewReport() ReportModel {
+	s := spinner.New()
+	s.Spinner = styles.Spinner
+	s.Style = lipgloss.NewStyle().Foreground(styles.Secondary)
+	return ReportModel{spinner: s, staleDays: cfg.DefaultStaleDays}
+}
+
+// Init initializes the report screen
+func (m ReportModel) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+// reportTitle names a report
+func reportTitle(kind types.ReportKind) string {
+	switch kind {
+	case types.ReportPlaceholders:
+		return "Placeholder audit"
+	case types.ReportLargest:
+		return "Largest values"
+	case types.ReportStale:
+		return "Stale parameters"
+	}
+	return "Report"
+}
+
+// Open runs the report kind over params
+func (m *ReportModel) Open(client *aws.Client, kind types.ReportKind, params []*aws.Parameter, scope string) tea.Cmd {
+	m.client = client
+	m.kind = kind
+	m.params = params
+	m.scope = scope
+	m.marked = make(map[string]bool)
+	m.confirmDelete = false
+	m.status = ""
+	return m.run()
+}
+
+// SetStaleDays sets the age in days from which the stale report flags parameters
+func (m *ReportModel) SetStaleDays(days int) {
+	m.staleDays = days
+}
+
+// Prompting reports whether the delete confirmation is shown, so esc cancels it
+func (m ReportModel) Prompting() bool {
+	return m.confirmDelete
+}
+
+// run (re)computes the report rows
+func (m *ReportModel) run() tea.Cmd {
+	if m.cancelLoad != nil {
+		m.cancelLoad()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelLoad = cancel
+	m.loadSeq++
+	m.loading = true
+	m.err = nil
+	m.rows = nil
+	m.cursor = 0
+
+	seq, client, kind, params, days := m.loadSeq, m.client, m.kind, m.params, m.staleDays
+	return tea.Batch(m.spinner.Tick, func() tea.Msg {
+		rows, err := runReport(ctx, client, kind, params, days)
+		if err != nil {
+			return types.ErrorMsg{Err: err}
+		}
+		return types.ReportLoadedMsg{Seq: seq, Rows: rows}
+	})
+}
+
+// runReport flags the parameters of params the report kind applies to, in
+// the order they are best reviewed; staleDays is the threshold of ReportStale
+func runReport(ctx context.Context, client *aws.Client, kind types.ReportKind, params []*aws.Parameter, staleDays int) ([]types.ReportRow, error) {
+	switch kind {
+	case types.ReportPlaceholders:
+		// Placeholders in SecureStrings matter most, so values are decrypted
+		values, err := client.GetParameters(ctx, parameterNames(params), true)
+		if err != nil {
+			return nil, err
+		}
+		var rows []types.ReportRow
+		for _, p := range values {
+			if reason, ok := aws.PlaceholderReason(p.Value); ok {
+				rows = append(rows, types.ReportRow{Parameter: p, Note: reason, Warn: true})
+			}
+		}
+		sort.Slice(rows, func(i, j int) bool { return rows[i].Parameter.Name < rows[j].Parameter.Name })
+		return rows, nil
+
+	case types.ReportLargest:
+		// Decrypted, since the limits apply to the plaintext
+		values, err := client.GetParameters(ctx, parameterNames(params), true)
+		if err != nil {
+			return nil, err
+		}
+		// GetParameters does not return the tier, so take it from the listing
+		tiers := make(map[string]string, len(params))
+		for _, p := range params {
+			tiers[p.Name] = p.Tier
+		}
+		rows := make([]types.ReportRow, len(values))
+		for i, p := range values {
+			p.Tier = tiers[p.Name]
+			limit := aws.MaxValueSize(p.Tier)
+			tier := p.Tier
+			if tier == "" {
+				tier = "Standard"
+			}
+			rows[i] = types.ReportRow{
+				Parameter: p,
+				Note:      fmt.Sprintf("%s of %s (%s), %d%%", formatBytes(len(p.Value)), formatBytes(limit), tier, len(p.Value)*100/limit),
+				Warn:      len(p.Value)*100 >= limit*largeValuePercent,
+			}
+		}
+		sort.SliceStable(rows, func(i, j int) bool { return len(rows[i].Parameter.Value) > len(rows[j].Parameter.Value) })
+		return rows, nil
+
+	case types.ReportStale:
+		// The listing has the modification dates already
+		return staleRows(params, staleDays, time.Now()), nil
+	}
+	return nil, fmt.Errorf("unknown report %d", kind)
+}
+
+// staleRows returns the parameters of params not modified in more than days
+// days before now, oldest first
+func staleRows(params []*aws.Parameter, days int, now time.Time) []types.ReportRow {
+	cutoff := now.AddDate(0, 0, -days)
+	var rows []types.ReportRow
+	for _, p := range params {
+		if p.LastModifiedDate.IsZero() || !p.LastModifiedDate.Before(cutoff) {
+			continue
+		}
+		age := int(now.Sub(p.LastModifiedDate).Hours() / 24)
+		rows = append(rows, types.ReportRow{
+			Parameter: p,
+			Note:      fmt.Sprintf("not modified for %d days", age),
+			Warn:      age >= 2*days,
+		})
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].Parameter.LastModifiedDate.Before(rows[j].Parameter.LastModifiedDate)
+	})
+	return rows
+}
+
+// deprecatedTag is added by the report's 'T' action, valued with the date
+const deprecatedTag = "deprecated"
+
+// targets returns the parameters of the marked rows, or of every row when
+// none is marked
+func (m ReportModel) targets() []*aws.Parameter {
+	var params []*aws.Parameter
+	for _, r := range m.rows {
+		if len(m.marked) == 0 || m.marked[r.Parameter.Name] {
+			params = append(params, r.Parameter)
+		}
+	}
+	return params
+}
+
+// tagDeprecated adds the deprecated tag, valued with today's date, to params
+func (m *ReportModel) tagDeprecated(params []*aws.Parameter) tea.Cmd {
+	m.working = true
+	tag := aws.Tag{Key: deprecatedTag, Value: time.Now().Format(time.DateOnly)}
+	return tea.Batch(m.spinner.Tick, TagParameters(m.client, params, tag))
+}
+
+// TagParameters adds tag to params, keeping their other tags, and stops at
+// the first failure
+func TagParameters(client *aws.Client, params []*aws.Parameter, tag aws.Tag) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		var names []string
+		for _, p := range params {
+			old, err := client.ListTags(ctx, p.Name)
+			if err == nil {
+				err = client.UpdateTags(ctx, p.Name, old, mergeTags(old, []aws.Tag{tag}))
+			}
+			if err != nil {
+				return types.ParametersTaggedMsg{Tag: tag, Names: names, Err: err}
+			}
+			names = append(names, p.Name)
+		}
+		return types.ParametersTaggedMsg{Tag: tag, Names: names}
+	}
+}
+
+// deleteParameters deletes params
+func (m *ReportModel) deleteParameters(params []*aws.Parameter) tea.Cmd {
+	m.working = true
+	client := m.client
+	names := parameterNames(params)
+	return tea.Batch(m.spinner.Tick, func() tea.Msg {
+		deleted, err := client.DeleteParameters(context.Background(), names)
+		return types.ParametersDeletedMsg{Names: deleted, Err: err}
+	})
+}
+
+// removeDeleted drops deleted parameters from the rows and the report's scope
+func (m *ReportModel) removeDeleted(names []string) {
+	deleted := make(map[string]bool, len(names))
+	for _, name := range names {
+		deleted[name] = true
+		delete(m.marked, name)
+	}
+	rows := m.rows[:0]
+	for _, r := range m.rows {
+		if !deleted[r.Parameter.Name] {
+			rows = append(rows, r)
+		}
+	}
+	m.rows = rows
+	params := make([]*aws.Parameter, 0, len(m.params))
+	for _, p := range m.params {
+		if !deleted[p.Name] {
+			params = append(params, p)
+		}
+	}
+	m.params = params
+	m.cursor = max(0, min(m.cursor, len(m.rows)-1))
+}
+
+// largeValuePercent is the share of its tier's limit from which a value is highlighted
+const largeValuePercent = 80
+
+// formatBytes renders a size, e.g. "512 B" or "3.9 KB"
+func formatBytes(n int) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+	return fmt.Sprintf("%.1f KB", float64(n)/1024)
+}
+
+// parameterNames returns the names of params
+func parameterNames(params []*aws.Parameter) []string {
+	names := make([]string, len(params))
+	for i, p := range params {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// Update handles messages for the report screen
+func (m ReportModel) Update(msg tea.Msg) (ReportModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case types.ReportLoadedMsg:
+		if msg.Seq != m.loadSeq {
+			return m, nil
+		}
+		m.loading = false
+		m.rows = msg.Rows
+		m.marked = make(map[string]bool)
+		return m, nil
+
+	case types.ParametersTaggedMsg:
+		m.working = false
+		m.status = fmt.Sprintf("Tagged %d parameters %s=%s", len(msg.Names), msg.Tag.Key, msg.Tag.Value)
+		m.statusErr = false
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Tagged %d parameters, then failed: %v", len(msg.Names), msg.Err)
+			m.statusErr = true
+		}
+		return m, nil
+
+	case types.ParametersDeletedMsg:
+		m.working = false
+		m.removeDeleted(msg.Names)
+		m.status = fmt.Sprintf("Deleted %d parameters", len(msg.Names))
+		m.statusErr = false
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Deleted %d parameters, then failed: %v", len(msg.Names), msg.Err)
+			m.statusErr = true
+		}
+		return m, nil
+
+	case types.MirroredMsg:
+		// Keep the outcome of the action itself unless the mirror failed
+		if msg.Err != nil {
+			m.status = fmt.Sprintf("Git mirror failed: %v", msg.Err)
+			m.statusErr = true
+		}
+		return m, nil
+
+	case types.ErrorMsg:
+		m.loading = false
+		m.working = false
+		m.err = msg.Err
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.confirmDelete {
+			m.confirmDelete = false
+			if msg.String() == "y" {
+				return m, m.deleteParameters(m.targets())
+			}
+			m.status = "Delete canceled"
+			m.statusErr = false
+			return m, nil
+		}
+		switch msg.String() {
+		case "esc":
+			if m.cancelLoad != nil {
+				m.cancelLoad()
+			}
+			return m, func() tea.Msg { return types.BackMsg{} }
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		}
+		if m.loading || m.working {
+			return m, nil
+		}
+		m.status = ""
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.rows)-1 {
+				m.cursor++
+			}
+		case "enter":
+			if m.cursor < len(m.rows) {
+				param := m.rows[m.cursor].Parameter
+				return m, func() tea.Msg { return types.ViewParameterMsg{Parameter: param} }
+			}
+		case " ":
+			// Mark or unmark the selected row and move on
+			if m.cursor < len(m.rows) {
+				name := m.rows[m.cursor].Parameter.Name
+				if m.marked[name] {
+					delete(m.marked, name)
+				} else {
+					m.marked[name] = true
+				}
+				if m.cursor < len(m.rows)-1 {
+					m.cursor++
+				}
+			}
+		case "x":
+			// Export the marked rows, or all flagged parameters, for review
+			if params := m.targets(); len(params) > 0 {
+				return m, func() tea.Msg { return types.ExportParametersMsg{Parameters: params} }
+			}
+		case "T":
+			if params := m.targets(); len(params) > 0 {
+				return m, m.tagDeprecated(params)
+			}
+		case "d":
+			if len(m.targets()) > 0 {
+				m.confirmDelete = true
+			}
+		case "R":
+			return m, m.run()
+		}
+		return m, nil
+	}
+
+	if m.loading || m.working {
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// View renders the report screen
+func (m ReportModel) View() string {
+	if m.loading {
+		return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), progressText(fmt.Sprintf("Checking %d parameters...", len(m.params))))
+	}
+
+	var b strings.Builder
+
+	profile := m.currentProfile
+	region := m.currentRegion
+	if profile == "" {
+		profile = "-"
+	}
+	if region == "" {
+		region = "-"
+	}
+	title := fmt.Sprintf("%s : %s : %s", profile, region, reportTitle(m.kind))
+	if m.scope != "" {
+		title += " : " + m.scope
+	}
+	b.WriteString("  " + styles.TitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	if m.err != nil {
+		b.WriteString("  " + styles.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)) + "\n")
+		b.WriteString("  " + styles.HelpStyle.Render("R: retry • esc: back"))
+		return b.String()
+	}
+
+	if len(m.rows) == 0 {
+		if m.status != "" {
+			b.WriteString("  " + styles.SuccessStyle.Render(m.status) + "\n")
+		}
+		b.WriteString("  " + styles.SuccessStyle.Render(fmt.Sprintf("✓ Nothing to review in %d parameters", len(m.params))) + "\n")
+		b.WriteString("  " + styles.HelpStyle.Render("R: run again • esc: back • q: quit"))
+		return b.String()
+	}
+
+	summary := fmt.Sprintf("%d of %d parameters to review", len(m.rows), len(m.params))
+	switch m.kind {
+	case types.ReportLargest:
+		summary = fmt.Sprintf("%d parameters, largest value first", len(m.rows))
+	case types.ReportStale:
+		summary = fmt.Sprintf("%d of %d parameters not modified in %d days, oldest first", len(m.rows), len(m.params), m.staleDays)
+	}
+	if len(m.marked) > 0 {
+		summary += fmt.Sprintf(" (%d marked)", len(m.marked))
+	}
+	b.WriteString("  " + styles.LabelStyle.Render(summary) + "\n\n")
+
+	width := 0
+	for _, r := range m.rows {
+		width = max(width, len(r.Parameter.Name))
+	}
+	visible := max(1, m.height-8)
+	start := max(0, m.cursor-visible+1)
+	for i := start; i < min(len(m.rows), start+visible); i++ {
+		r := m.rows[i]
+		note := styles.HelpStyle.UnsetMarginTop().Render(r.Note)
+		if r.Warn {
+			note = styles.WarningStyle.Render(r.Note)
+		}
+		line := fmt.Sprintf("%-*s  ", width, r.Parameter.Name) + note
+		if len(m.marked) > 0 {
+			mark := "  "
+			if m.marked[r.Parameter.Name] {
+				mark = lipgloss.NewStyle().Foreground(styles.Secondary).Render("● ")
+			}
+			line = mark + line
+		}
+		if i == m.cursor {
+			b.WriteString("  " + lipgloss.NewStyle().Foreground(styles.Primary).Bold(true).Render(styles.Cursor+" ") + line + "\n")
+		} else {
+			b.WriteString("    " + line + "\n")
+		}
+	}
+
+	switch {
+	case m.working:
+		b.WriteString("\n  " + m.spinner.View() + " " + progressText("Updating parameters...") + "\n")
+	case m.confirmDelete:
+		count := len(m.targets())
+		b.WriteString("\n  " + styles.WarningStyle.Render(fmt.Sprintf("Delete %d parameters? This cannot be undone. (y/n)", count)) + "\n")
+	case m.status != "" && m.statusErr:
+		b.WriteString("\n  " + styles.ErrorStyle.Render(m.status) + "\n")
+	case m.status != "":
+		b.WriteString("\n  " + styles.SuccessStyle.Render(m.status) + "\n")
+	}
+
+	b.WriteString("  " + styles.HelpStyle.Render("↑/↓: select • enter: view • space: mark • x: export • T: tag deprecated • d: delete • R: run again • esc: back • q: quit"))
+	return b.String()
+}
+
+// SetContext sets the profile and region context for the report screen
+func (m *ReportModel) SetContext(profile, region string) {
+	m.currentProfile = profile
+	m.currentRegion = region
+}
+
+// SetSize updates the dimensions of the report screen
+func (m *ReportModel) SetSize(width, height int) {
+	m.height = height
+}
@@ -0,0 +1,124 @@
+package screens
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+func TestRunReport_Placeholders(t *testing.T) {
+	ctx := context.Background()
+	client := aws.NewDemoClient("report-test", "eu-west-1")
+	if err := client.PutParameter(ctx, "/report/todo", "CHANGEME", "SecureString"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.PutParameter(ctx, "/report/ok", "db.internal", "String"); err != nil {
+		t.Fatal(err)
+	}
+
+	params := []*aws.Parameter{{Name: "/report/todo"}, {Name: "/report/ok"}}
+	rows, err := runReport(ctx, client, types.ReportPlaceholders, params, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].Parameter.Name != "/report/todo" || rows[0].Note != `placeholder "CHANGEME"` {
+		t.Fatalf("expected only /report/todo to be flagged, got %+v", rows)
+	}
+}
+
+func TestRunReport_Largest(t *testing.T) {
+	ctx := context.Background()
+	client := aws.NewDemoClient("report-test", "eu-west-1")
+	if err := client.PutParameter(ctx, "/report/big", strings.Repeat("x", 3500), "String"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.PutParameter(ctx, "/report/small", "v", "String"); err != nil {
+		t.Fatal(err)
+	}
+
+	params := []*aws.Parameter{{Name: "/report/small"}, {Name: "/report/big", Tier: "Standard"}}
+	rows, err := runReport(ctx, client, types.ReportLargest, params, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0].Parameter.Name != "/report/big" || !rows[0].Warn || rows[1].Warn {
+		t.Fatalf("expected /report/big first and highlighted, got %+v", rows)
+	}
+	if rows[0].Note != "3.4 KB of 4.0 KB (Standard), 85%" {
+		t.Errorf("unexpected note %q", rows[0].Note)
+	}
+}
+
+func TestReport_IgnoresStaleResults(t *testing.T) {
+	m := NewReport()
+	m.loadSeq = 2
+	m.loading = true
+
+	m, _ = m.Update(types.ReportLoadedMsg{Seq: 1, Rows: []types.ReportRow{{Parameter: &aws.Parameter{Name: "/old"}}}})
+	if !m.loading || len(m.rows) != 0 {
+		t.Fatal("expected results of an earlier run to be ignored")
+	}
+}
+
+func TestStaleRows(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	params := []*aws.Parameter{
+		{Name: "/fresh", LastModifiedDate: now.AddDate(0, 0, -10)},
+		{Name: "/old", LastModifiedDate: now.AddDate(0, 0, -200)},
+		{Name: "/ancient", LastModifiedDate: now.AddDate(0, 0, -400)},
+	}
+
+	rows := staleRows(params, 180, now)
+	if len(rows) != 2 || rows[0].Parameter.Name != "/ancient" || rows[1].Parameter.Name != "/old" {
+		t.Fatalf("expected /ancient then /old, got %+v", rows)
+	}
+	if rows[1].Note != "not modified for 200 days" || rows[1].Warn || !rows[0].Warn {
+		t.Errorf("unexpected rows %+v", rows)
+	}
+}
+
+func TestReport_DeleteMarked(t *testing.T) {
+	ctx := context.Background()
+	client := aws.NewDemoClient("report-test", "eu-west-1")
+	for _, name := range []string{"/report/a", "/report/b"} {
+		if err := client.PutParameter(ctx, name, "v", "String"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := NewReport()
+	m.client = client
+	m.params = []*aws.Parameter{{Name: "/report/a"}, {Name: "/report/b"}}
+	m.marked = make(map[string]bool)
+	m.rows = []types.ReportRow{{Parameter: m.params[0]}, {Parameter: m.params[1]}}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if !m.Prompting() {
+		t.Fatal("expected a confirmation prompt")
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	var deleted types.ParametersDeletedMsg
+	for _, msg := range cmd().(tea.BatchMsg) {
+		if d, ok := msg().(types.ParametersDeletedMsg); ok {
+			deleted = d
+			break
+		}
+	}
+	if deleted.Err != nil || len(deleted.Names) != 1 || deleted.Names[0] != "/report/a" {
+		t.Fatalf("expected only the marked parameter to be deleted, got %+v", deleted)
+	}
+
+	m, _ = m.Update(deleted)
+	if len(m.rows) != 1 || m.rows[0].Parameter.Name != "/report/b" || len(m.params) != 1 {
+		t.Fatalf("expected the deleted row to be removed, got %+v", m.rows)
+	}
+	if _, err := client.GetParameter(ctx, "/report/a"); err == nil {
+		t.Error("expected /report/a to be gone")
+	}
+}
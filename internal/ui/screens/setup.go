@@ -0,0 +1,255 @@
+package screens
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/styles"
+)
+
+// setupStep is a page of the first-run setup wizard
+type setupStep int
+
+const (
+	setupProfiles setupStep = iota
+	setupRegions
+	setupReview
+)
+
+// SetupResult is what the setup wizard chose
+type SetupResult struct {
+	Skipped            bool              // Nothing was chosen; keep the defaults
+	Profiles           []string          // Profiles to manage, nil for all of them
+	Regions            map[string]string // Default region of each managed profile
+	SkipRegionSelector bool
+}
+
+// SetupModel is the first-run wizard that picks the profiles to manage and
+// their default regions
+type SetupModel struct {
+	profiles           []string
+	selected           []bool
+	choices            [][]string // Regions offered for each profile
+	region             []int      // Index of each profile's region in choices
+	skipRegionSelector bool
+	step               setupStep
+	cursor             int
+	done               bool
+	result             SetupResult
+	configPath         string
+	width              int
+	height             int
+}
+
+// NewSetup creates the setup wizard for the discovered profiles. known holds
+// regions already associated with profiles (remembered or from the AWS
+// config), which are preselected.
+func NewSetup(profiles []string, known map[string]string, configPath string) SetupModel {
+	m := SetupModel{
+		profiles:   profiles,
+		selected:   make([]bool, len(profiles)),
+		choices:    make([][]string, len(profiles)),
+		region:     make([]int, len(profiles)),
+		configPath: configPath,
+	}
+	for i, p := range profiles {
+		m.selected[i] = true
+		m.choices[i] = defaultRegions
+		if r := known[p]; r != "" {
+			if !slices.Contains(defaultRegions, r) {
+				m.choices[i] = append([]string{r}, defaultRegions...)
+			}
+			m.region[i] = slices.Index(m.choices[i], r)
+		}
+	}
+	return m
+}
+
+// Init initializes the setup wizard
+func (m SetupModel) Init() tea.Cmd {
+	return nil
+}
+
+// Done reports whether the wizard was finished or skipped
+func (m SetupModel) Done() bool {
+	return m.done
+}
+
+// Result returns the choices once Done
+func (m SetupModel) Result() SetupResult {
+	return m.result
+}
+
+// managed returns the indexes of the selected profiles
+func (m SetupModel) managed() []int {
+	var idx []int
+	for i, on := range m.selected {
+		if on {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// finish records the choices and ends the wizard
+func (m SetupModel) finish() SetupModel {
+	m.done = true
+	m.result = SetupResult{Regions: make(map[string]string), SkipRegionSelector: m.skipRegionSelector}
+	managed := m.managed()
+	for _, i := range managed {
+		m.result.Regions[m.profiles[i]] = m.choices[i][m.region[i]]
+	}
+	// Keep new profiles visible when every profile was chosen
+	if len(managed) < len(m.profiles) {
+		for _, i := range managed {
+			m.result.Profiles = append(m.result.Profiles, m.profiles[i])
+		}
+	}
+	return m
+}
+
+// Update handles messages for the setup wizard
+func (m SetupModel) Update(msg tea.Msg) (SetupModel, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	if key.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	switch m.step {
+	case setupProfiles:
+		switch key.String() {
+		case "up", "k":
+			m.cursor = max(0, m.cursor-1)
+		case "down", "j":
+			m.cursor = min(len(m.profiles)-1, m.cursor+1)
+		case " ", "x":
+			m.selected[m.cursor] = !m.selected[m.cursor]
+		case "a":
+			all := len(m.managed()) < len(m.profiles)
+			for i := range m.selected {
+				m.selected[i] = all
+			}
+		case "enter":
+			if managed := m.managed(); len(managed) > 0 {
+				m.step = setupRegions
+				m.cursor = 0
+			}
+		case "esc", "q":
+			m.done = true
+			m.result = SetupResult{Skipped: true}
+			return m, tea.Quit
+		}
+
+	case setupRegions:
+		managed := m.managed()
+		i := managed[m.cursor]
+		switch key.String() {
+		case "up", "k":
+			m.cursor = max(0, m.cursor-1)
+		case "down", "j":
+			m.cursor = min(len(managed)-1, m.cursor+1)
+		case "left", "h":
+			m.region[i] = (m.region[i] + len(m.choices[i]) - 1) % len(m.choices[i])
+		case "right", "l", " ":
+			m.region[i] = (m.region[i] + 1) % len(m.choices[i])
+		case "enter":
+			m.step = setupReview
+		case "esc":
+			m.step = setupProfiles
+			m.cursor = 0
+		}
+
+	case setupReview:
+		switch key.String() {
+		case "s":
+			m.skipRegionSelector = !m.skipRegionSelector
+		case "enter":
+			m = m.finish()
+			return m, tea.Quit
+		case "esc":
+			m.step = setupRegions
+			m.cursor = 0
+		}
+	}
+	return m, nil
+}
+
+// View renders the setup wizard
+func (m SetupModel) View() string {
+	var b strings.Builder
+	cursor := lipgloss.NewStyle().Foreground(styles.Primary).Bold(true).Render(styles.Cursor + " ")
+	row := func(selected bool, text string) {
+		if selected {
+			b.WriteString("  " + cursor + text + "\n")
+		} else {
+			b.WriteString("    " + text + "\n")
+		}
+	}
+
+	b.WriteString("  " + styles.TitleStyle.Render("Welcome to ps9s") + "\n\n")
+
+	switch m.step {
+	case setupProfiles:
+		b.WriteString("  " + styles.InfoStyle.Render(fmt.Sprintf("Found %d AWS profiles. Which should ps9s show?", len(m.profiles))) + "\n\n")
+		start, end := m.window(len(m.profiles))
+		for i := start; i < end; i++ {
+			box := "[ ]"
+			if m.selected[i] {
+				box = "[x]"
+			}
+			row(i == m.cursor, box+" "+m.profiles[i])
+		}
+		if len(m.managed()) == 0 {
+			b.WriteString("\n  " + styles.WarningStyle.Render("Select at least one profile") + "\n")
+		}
+		b.WriteString("\n  " + styles.HelpStyle.Render("space: toggle • a: all/none • enter: next • esc: skip setup"))
+
+	case setupRegions:
+		b.WriteString("  " + styles.InfoStyle.Render("Default region of each profile:") + "\n\n")
+		managed := m.managed()
+		start, end := m.window(len(managed))
+		for c := start; c < end; c++ {
+			i := managed[c]
+			row(c == m.cursor, fmt.Sprintf("%-30s ‹ %s ›", m.profiles[i], m.choices[i][m.region[i]]))
+		}
+		b.WriteString("\n  " + styles.HelpStyle.Render("←/→: change region • enter: next • esc: back"))
+
+	case setupReview:
+		managed := m.managed()
+		profiles := fmt.Sprintf("%d of %d", len(managed), len(m.profiles))
+		if len(managed) == len(m.profiles) {
+			profiles = "all, including profiles added later"
+		}
+		skip := "no"
+		if m.skipRegionSelector {
+			skip = "yes"
+		}
+		b.WriteString("  " + styles.LabelStyle.Render("Profiles: ") + profiles + "\n")
+		b.WriteString("  " + styles.LabelStyle.Render("Open the default region directly: ") + skip + "\n\n")
+		b.WriteString("  " + styles.InfoStyle.Render("Settings will be written to "+m.configPath) + "\n")
+		b.WriteString("\n  " + styles.HelpStyle.Render("s: toggle opening the default region • enter: save • esc: back"))
+	}
+	return b.String()
+}
+
+// window returns the rows of an n-row list that fit around the cursor
+func (m SetupModel) window(n int) (int, int) {
+	visible := n
+	if m.height > 0 {
+		visible = max(1, m.height-10)
+	}
+	start := max(0, m.cursor-visible+1)
+	return start, min(n, start+visible)
+}
+
+// SetSize updates the dimensions of the setup wizard
+func (m *SetupModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
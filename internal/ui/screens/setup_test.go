@@ -0,0 +1,67 @@
+package screens
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func setupKeys(m SetupModel, keys ...string) SetupModel {
+	for _, k := range keys {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "right":
+			msg = tea.KeyMsg{Type: tea.KeyRight}
+		case " ":
+			msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		m, _ = m.Update(msg)
+	}
+	return m
+}
+
+func TestSetup_PicksProfilesAndRegions(t *testing.T) {
+	m := NewSetup([]string{"default", "dev", "prod"}, map[string]string{"prod": "sa-east-1"}, "/tmp/config.json")
+
+	// Drop default, move dev one region on, keep prod's known region
+	m = setupKeys(m, " ", "enter", "right", "enter", "s", "enter")
+	if !m.Done() {
+		t.Fatal("expected the wizard to finish")
+	}
+	r := m.Result()
+	if r.Skipped || !r.SkipRegionSelector {
+		t.Fatalf("unexpected result %+v", r)
+	}
+	if len(r.Profiles) != 2 || r.Profiles[0] != "dev" || r.Profiles[1] != "prod" {
+		t.Fatalf("expected dev and prod to be managed, got %v", r.Profiles)
+	}
+	if r.Regions["dev"] != defaultRegions[1] || r.Regions["prod"] != "sa-east-1" || r.Regions["default"] != "" {
+		t.Fatalf("unexpected regions %v", r.Regions)
+	}
+}
+
+func TestSetup_AllProfilesAndSkip(t *testing.T) {
+	m := NewSetup([]string{"default", "dev"}, nil, "")
+	m = setupKeys(m, "enter", "enter", "enter")
+	if r := m.Result(); r.Profiles != nil || len(r.Regions) != 2 {
+		t.Fatalf("choosing every profile should leave the list open, got %+v", r)
+	}
+
+	m = NewSetup([]string{"default", "dev"}, nil, "")
+	m = setupKeys(m, "a", "enter")
+	if m.Done() || m.step != setupProfiles {
+		t.Fatal("expected the wizard to require a profile")
+	}
+	m = setupKeys(m, "esc")
+	if !m.Done() || !m.Result().Skipped {
+		t.Fatal("expected esc to skip the wizard")
+	}
+}
@@ -0,0 +1,224 @@
+package screens
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+// maxStatsPrefixes is the number of path prefixes listed, largest first
+const maxStatsPrefixes = 15
+
+// statCount is one bar of a stats section
+type statCount struct {
+	label string
+	count int
+}
+
+// ageBuckets are the last-modified age ranges counted, oldest last
+var ageBuckets = []struct {
+	label string
+	max   time.Duration
+}{
+	{"< 1 week", 7 * 24 * time.Hour},
+	{"1 week – 1 month", 30 * 24 * time.Hour},
+	{"1 – 3 months", 90 * 24 * time.Hour},
+	{"3 – 12 months", 365 * 24 * time.Hour},
+	{"> 1 year", 1<<63 - 1},
+}
+
+// parameterStats summarizes listing metadata
+type parameterStats struct {
+	total    int
+	types    []statCount
+	tiers    []statCount
+	prefixes []statCount // Two-level path prefixes, largest first
+	ages     []statCount // In ageBuckets order, then parameters without a date
+}
+
+// computeStats counts params by type, tier, path prefix and age at now
+func computeStats(params []*aws.Parameter, now time.Time) parameterStats {
+	byType, byTier, byPrefix := map[string]int{}, map[string]int{}, map[string]int{}
+	ages := make([]int, len(ageBuckets)+1)
+	for _, p := range params {
+		byType[p.Type]++
+		tier := p.Tier
+		if tier == "" {
+			tier = "unknown"
+		}
+		byTier[tier]++
+		byPrefix[statsPrefix(p.Name)]++
+
+		if p.LastModifiedDate.IsZero() {
+			ages[len(ageBuckets)]++
+			continue
+		}
+		age := now.Sub(p.LastModifiedDate)
+		for i, b := range ageBuckets {
+			if age < b.max {
+				ages[i]++
+				break
+			}
+		}
+	}
+
+	s := parameterStats{
+		total:    len(params),
+		types:    sortedCounts(byType),
+		tiers:    sortedCounts(byTier),
+		prefixes: sortedCounts(byPrefix),
+	}
+	if len(s.prefixes) > maxStatsPrefixes {
+		s.prefixes = s.prefixes[:maxStatsPrefixes]
+	}
+	for i, b := range ageBuckets {
+		s.ages = append(s.ages, statCount{b.label, ages[i]})
+	}
+	if n := ages[len(ageBuckets)]; n > 0 {
+		s.ages = append(s.ages, statCount{"unknown", n})
+	}
+	return s
+}
+
+// statsPrefix groups a name by its first two path levels, e.g. "/app/prod/"
+func statsPrefix(name string) string {
+	if !strings.HasPrefix(name, "/") {
+		return "(no path)"
+	}
+	segments := strings.Split(strings.TrimPrefix(name, "/"), "/")
+	segments = segments[:len(segments)-1]
+	if len(segments) == 0 {
+		return "/"
+	}
+	return "/" + strings.Join(segments[:min(2, len(segments))], "/") + "/"
+}
+
+// sortedCounts orders counts largest first, then by label
+func sortedCounts(counts map[string]int) []statCount {
+	out := make([]statCount, 0, len(counts))
+	for label, n := range counts {
+		out = append(out, statCount{label, n})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].count != out[j].count {
+			return out[i].count > out[j].count
+		}
+		return out[i].label < out[j].label
+	})
+	return out
+}
+
+// StatsModel shows counts of the listed parameters by type, tier, path prefix
+// and age, from the listing metadata
+type StatsModel struct {
+	stats          parameterStats
+	viewport       viewport.Model
+	width          int
+	currentProfile string
+	currentRegion  string
+}
+
+// NewStats creates the statistics screen
+func NewStats() StatsModel {
+	return StatsModel{viewport: viewport.New(80, 20)}
+}
+
+// Init initializes the statistics screen
+func (m StatsModel) Init() tea.Cmd {
+	return nil
+}
+
+// Load computes the statistics of params
+func (m *StatsModel) Load(params []*aws.Parameter) {
+	m.stats = computeStats(params, time.Now())
+	m.refresh()
+	m.viewport.GotoTop()
+}
+
+// Update handles messages for the statistics screen
+func (m StatsModel) Update(msg tea.Msg) (StatsModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg { return types.BackMsg{} }
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// refresh renders the sections into the viewport
+func (m *StatsModel) refresh() {
+	var b strings.Builder
+	section := func(title string, counts []statCount) {
+		b.WriteString("  " + styles.LabelStyle.Render(title) + "\n")
+		labelWidth := 0
+		for _, c := range counts {
+			labelWidth = max(labelWidth, len([]rune(c.label)))
+		}
+		barWidth := max(10, min(40, m.width-labelWidth-20))
+		for _, c := range counts {
+			bar := 0
+			if m.stats.total > 0 {
+				bar = c.count * barWidth / m.stats.total
+			}
+			if c.count > 0 && bar == 0 {
+				bar = 1
+			}
+			pad := strings.Repeat(" ", labelWidth-len([]rune(c.label)))
+			b.WriteString(fmt.Sprintf("    %s%s  %s %d\n", c.label, pad, styles.InfoStyle.Render(strings.Repeat("█", bar)), c.count))
+		}
+		b.WriteString("\n")
+	}
+	section("By type", m.stats.types)
+	section("By tier", m.stats.tiers)
+	section("By last modified", m.stats.ages)
+	section(fmt.Sprintf("By path (top %d)", maxStatsPrefixes), m.stats.prefixes)
+	m.viewport.SetContent(strings.TrimSuffix(b.String(), "\n"))
+}
+
+// View renders the statistics screen
+func (m StatsModel) View() string {
+	var b strings.Builder
+
+	profile := m.currentProfile
+	region := m.currentRegion
+	if profile == "" {
+		profile = "-"
+	}
+	if region == "" {
+		region = "-"
+	}
+	title := fmt.Sprintf("%s : %s : Statistics (%d parameters)", profile, region, m.stats.total)
+	b.WriteString("  " + styles.TitleStyle.Render(title))
+	b.WriteString("\n\n")
+	b.WriteString(m.viewport.View())
+	b.WriteString("\n")
+	b.WriteString("  " + styles.HelpStyle.Render("↑/↓: scroll • esc: back • q: quit"))
+	return b.String()
+}
+
+// SetContext sets the profile and region context for the statistics screen
+func (m *StatsModel) SetContext(profile, region string) {
+	m.currentProfile = profile
+	m.currentRegion = region
+}
+
+// SetSize updates the dimensions of the statistics screen
+func (m *StatsModel) SetSize(width, height int) {
+	m.width = width
+	m.viewport.Width = width
+	m.viewport.Height = max(1, height-5)
+	m.refresh()
+}
@@ -0,0 +1,34 @@
+package screens
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+func TestComputeStats(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	params := []*aws.Parameter{
+		{Name: "/app/prod/db", Type: "SecureString", Tier: "Standard", LastModifiedDate: now.Add(-2 * 24 * time.Hour)},
+		{Name: "/app/prod/api/key", Type: "SecureString", Tier: "Advanced", LastModifiedDate: now.Add(-400 * 24 * time.Hour)},
+		{Name: "/app/dev/db", Type: "String", Tier: "Standard", LastModifiedDate: now.Add(-40 * 24 * time.Hour)},
+		{Name: "legacy", Type: "String"},
+	}
+	s := computeStats(params, now)
+
+	if want := []statCount{{"SecureString", 2}, {"String", 2}}; !reflect.DeepEqual(s.types, want) {
+		t.Errorf("types = %v, want %v", s.types, want)
+	}
+	if want := []statCount{{"/app/prod/", 2}, {"(no path)", 1}, {"/app/dev/", 1}}; !reflect.DeepEqual(s.prefixes, want) {
+		t.Errorf("prefixes = %v, want %v", s.prefixes, want)
+	}
+	ages := []int{}
+	for _, a := range s.ages {
+		ages = append(ages, a.count)
+	}
+	if want := []int{1, 0, 1, 0, 1, 1}; !reflect.DeepEqual(ages, want) {
+		t.Errorf("ages = %v, want %v", ages, want)
+	}
+}
@@ -0,0 +1,49 @@
+package screens
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"runtime"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/export"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+// subshellCommand builds the user's shell with env added to the environment
+func subshellCommand(env []string) *exec.Cmd {
+	shell := os.Getenv("SHELL")
+	if runtime.GOOS == "windows" {
+		shell = os.Getenv("COMSPEC")
+	}
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+
+	c := exec.Command(shell)
+	c.Env = append(os.Environ(), env...)
+	return c
+}
+
+// OpenSubshell fetches the decrypted values of params and suspends the TUI
+// for a shell with them exported, e.g. to run a service against real config.
+// PS9S_CONTEXT holds the profile and region, for use in shell prompts.
+func OpenSubshell(client *aws.Client, profile, region string, params []*aws.Parameter) tea.Cmd {
+	names := make([]string, len(params))
+	for i, p := range params {
+		names[i] = p.Name
+	}
+	return func() tea.Msg {
+		fetched, err := client.GetParameters(context.Background(), names, true)
+		if err != nil {
+			return types.SubshellExitedMsg{Err: err}
+		}
+		env := append(export.Environ(fetched), "PS9S_CONTEXT="+profile+":"+region)
+		// Run the exec command here so it starts once the values are known
+		return tea.ExecProcess(subshellCommand(env), func(err error) tea.Msg {
+			return types.SubshellExitedMsg{Count: len(fetched), Err: err}
+		})()
+	}
+}
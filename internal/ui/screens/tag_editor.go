@@ -0,0 +1,221 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/styles"
+)
+
+// Parameter Store tag limits
+const (
+	maxTagKeyLength   = 128
+	maxTagValueLength = 256
+)
+
+// TagEditor is a reusable widget for editing key/value tags. It is embedded by
+// screens, which forward key messages while it has focus.
+type TagEditor struct {
+	tags       []aws.Tag
+	cursor     int
+	editing    bool
+	editIndex  int // Tag being edited, or -1 for a new tag
+	keyInput   textinput.Model
+	valueInput textinput.Model
+	focusValue bool // Value input has focus while editing
+	err        error
+}
+
+// NewTagEditor creates an empty tag editor
+func NewTagEditor() TagEditor {
+	keyInput := textinput.New()
+	keyInput.Placeholder = "key"
+	keyInput.CharLimit = maxTagKeyLength
+	keyInput.Width = 30
+
+	valueInput := textinput.New()
+	valueInput.Placeholder = "value"
+	valueInput.CharLimit = maxTagValueLength
+	valueInput.Width = 40
+
+	return TagEditor{keyInput: keyInput, valueInput: valueInput, editIndex: -1}
+}
+
+// SetTags replaces the edited tags
+func (e *TagEditor) SetTags(tags []aws.Tag) {
+	e.tags = append([]aws.Tag(nil), tags...)
+	e.cursor = 0
+	e.cancelEdit()
+}
+
+// Tags returns the edited tags
+func (e TagEditor) Tags() []aws.Tag {
+	return append([]aws.Tag(nil), e.tags...)
+}
+
+// Editing reports whether a tag is being typed; the parent must then forward
+// every key, including esc, enter and tab
+func (e TagEditor) Editing() bool {
+	return e.editing
+}
+
+// Update handles a key for the tag editor
+func (e TagEditor) Update(msg tea.KeyMsg) (TagEditor, tea.Cmd) {
+	if e.editing {
+		switch msg.String() {
+		case "esc":
+			e.cancelEdit()
+			return e, nil
+		case "enter":
+			e.commitEdit()
+			return e, nil
+		case "tab", "shift+tab":
+			e.focusValue = !e.focusValue
+			if e.focusValue {
+				e.keyInput.Blur()
+				return e, e.valueInput.Focus()
+			}
+			e.valueInput.Blur()
+			return e, e.keyInput.Focus()
+		}
+
+		var cmd tea.Cmd
+		if e.focusValue {
+			e.valueInput, cmd = e.valueInput.Update(msg)
+		} else {
+			e.keyInput, cmd = e.keyInput.Update(msg)
+		}
+		return e, cmd
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		if e.cursor > 0 {
+			e.cursor--
+		}
+	case "down", "j":
+		if e.cursor < len(e.tags)-1 {
+			e.cursor++
+		}
+	case "a":
+		if len(e.tags) >= aws.MaxTags {
+			e.err = fmt.Errorf("a parameter can have at most %d tags", aws.MaxTags)
+			return e, nil
+		}
+		return e, e.startEdit(-1)
+	case "e", "enter":
+		if e.cursor < len(e.tags) {
+			return e, e.startEdit(e.cursor)
+		}
+	case "d", "delete":
+		if e.cursor < len(e.tags) {
+			e.tags = append(e.tags[:e.cursor], e.tags[e.cursor+1:]...)
+			if e.cursor > 0 && e.cursor >= len(e.tags) {
+				e.cursor--
+			}
+			e.err = nil
+		}
+	}
+	return e, nil
+}
+
+// startEdit opens the inputs for tag i, or for a new tag when i is -1
+func (e *TagEditor) startEdit(i int) tea.Cmd {
+	e.editing = true
+	e.editIndex = i
+	e.focusValue = false
+	e.err = nil
+	if i >= 0 {
+		e.keyInput.SetValue(e.tags[i].Key)
+		e.valueInput.SetValue(e.tags[i].Value)
+	} else {
+		e.keyInput.SetValue("")
+		e.valueInput.SetValue("")
+	}
+	e.valueInput.Blur()
+	return e.keyInput.Focus()
+}
+
+// commitEdit validates the inputs and stores the tag
+func (e *TagEditor) commitEdit() {
+	tag := aws.Tag{
+		Key:   strings.TrimSpace(e.keyInput.Value()),
+		Value: e.valueInput.Value(),
+	}
+	if tag.Key == "" {
+		e.err = fmt.Errorf("tag key is required")
+		return
+	}
+	if strings.HasPrefix(strings.ToLower(tag.Key), "aws:") {
+		e.err = fmt.Errorf("tag keys starting with aws: are reserved")
+		return
+	}
+	for i, t := range e.tags {
+		if i != e.editIndex && t.Key == tag.Key {
+			e.err = fmt.Errorf("tag %q already exists", tag.Key)
+			return
+		}
+	}
+
+	if e.editIndex >= 0 {
+		e.tags[e.editIndex] = tag
+	} else {
+		e.tags = append(e.tags, tag)
+		e.cursor = len(e.tags) - 1
+	}
+	e.cancelEdit()
+}
+
+// cancelEdit closes the inputs without changing the tags
+func (e *TagEditor) cancelEdit() {
+	e.editing = false
+	e.editIndex = -1
+	e.err = nil
+	e.keyInput.Blur()
+	e.valueInput.Blur()
+}
+
+// View renders the tags; the cursor is only shown when focused
+func (e TagEditor) View(focused bool) string {
+	var b strings.Builder
+
+	if len(e.tags) == 0 && !e.editing {
+		b.WriteString("  " + styles.HelpStyle.UnsetMarginTop().Render("no tags") + "\n")
+	}
+	for i, t := range e.tags {
+		if e.editing && i == e.editIndex {
+			b.WriteString(e.inputsView())
+			continue
+		}
+		line := fmt.Sprintf("%s = %s", t.Key, t.Value)
+		if focused && !e.editing && i == e.cursor {
+			b.WriteString("  " + lipgloss.NewStyle().Foreground(styles.Primary).Bold(true).Render(styles.Cursor+" "+line) + "\n")
+		} else {
+			b.WriteString("    " + line + "\n")
+		}
+	}
+	if e.editing && e.editIndex < 0 {
+		b.WriteString(e.inputsView())
+	}
+
+	if e.err != nil {
+		b.WriteString("  " + styles.ErrorStyle.Render("✗ "+e.err.Error()) + "\n")
+	}
+	if focused {
+		help := "a: add tag • e: edit • d: delete"
+		if e.editing {
+			help = "tab: key/value • enter: save tag • esc: cancel"
+		}
+		b.WriteString("  " + styles.HelpStyle.UnsetMarginTop().Render(help) + "\n")
+	}
+	return b.String()
+}
+
+// inputsView renders the key and value inputs on one line
+func (e TagEditor) inputsView() string {
+	return "  " + styles.Cursor + " " + e.keyInput.View() + " = " + e.valueInput.View() + "\n"
+}
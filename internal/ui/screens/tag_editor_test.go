@@ -0,0 +1,104 @@
+package screens
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ilia/ps9s/internal/aws"
+)
+
+func editorKeys(e TagEditor, keys ...tea.KeyMsg) TagEditor {
+	for _, k := range keys {
+		e, _ = e.Update(k)
+	}
+	return e
+}
+
+func editorType(e TagEditor, s string) TagEditor {
+	for _, r := range s {
+		e, _ = e.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return e
+}
+
+var (
+	keyA     = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}}
+	keyE     = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}}
+	keyD     = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}}
+	keyTab   = tea.KeyMsg{Type: tea.KeyTab}
+	keyEnter = tea.KeyMsg{Type: tea.KeyEnter}
+	keyEsc   = tea.KeyMsg{Type: tea.KeyEsc}
+)
+
+func TestTagEditor_AddEditDelete(t *testing.T) {
+	e := NewTagEditor()
+
+	e = editorKeys(e, keyA)
+	e = editorType(e, "env")
+	e = editorKeys(e, keyTab)
+	e = editorType(e, "prod")
+	e = editorKeys(e, keyEnter)
+	if e.Editing() {
+		t.Fatalf("expected enter to save the tag")
+	}
+	if got := e.Tags(); len(got) != 1 || got[0] != (aws.Tag{Key: "env", Value: "prod"}) {
+		t.Fatalf("unexpected tags after add: %v", got)
+	}
+
+	// Edit the value in place
+	e = editorKeys(e, keyE, keyTab)
+	e = editorType(e, "2")
+	e = editorKeys(e, keyEnter)
+	if got := e.Tags(); got[0].Value != "prod2" {
+		t.Fatalf("expected edited value, got %v", got)
+	}
+
+	e = editorKeys(e, keyD)
+	if len(e.Tags()) != 0 {
+		t.Fatalf("expected tag to be deleted, got %v", e.Tags())
+	}
+}
+
+func TestTagEditor_RejectsDuplicateAndEmptyKeys(t *testing.T) {
+	e := NewTagEditor()
+	e.SetTags([]aws.Tag{{Key: "env", Value: "prod"}})
+
+	e = editorKeys(e, keyA, keyEnter)
+	if !e.Editing() || e.err == nil {
+		t.Fatalf("expected empty key to be rejected")
+	}
+
+	e = editorType(e, "env")
+	e = editorKeys(e, keyEnter)
+	if !e.Editing() || e.err == nil {
+		t.Fatalf("expected duplicate key to be rejected")
+	}
+
+	e = editorKeys(e, keyEsc)
+	if e.Editing() || len(e.Tags()) != 1 {
+		t.Fatalf("expected esc to cancel without changes, got %v", e.Tags())
+	}
+}
+
+func TestParameterCreate_TagsFocus(t *testing.T) {
+	m := NewParameterCreate()
+	m.Reset(nil, nil, "/app/db")
+
+	// Name -> value -> tags
+	m, _ = m.Update(keyTab)
+	m, _ = m.Update(keyTab)
+	m, _ = m.Update(keyA)
+	if !m.TagEditing() {
+		t.Fatalf("expected 'a' on the tags field to start a new tag")
+	}
+	for _, r := range "team" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m, _ = m.Update(keyEnter)
+	if got := m.tagEditor.Tags(); len(got) != 1 || got[0].Key != "team" {
+		t.Fatalf("expected tag to be added, got %v", got)
+	}
+	if m.nameInput.Value() != "/app/db" {
+		t.Fatalf("expected name untouched, got %q", m.nameInput.Value())
+	}
+}
@@ -0,0 +1,231 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+// TagsModel represents the panel for viewing and editing a parameter's tags
+type TagsModel struct {
+	client         *aws.Client
+	parameter      *aws.Parameter
+	original       []aws.Tag // Tags as last loaded from or saved to AWS
+	editor         TagEditor
+	spinner        spinner.Model
+	loading        bool
+	saving         bool
+	err            error
+	status         string
+	currentProfile string
+	currentRegion  string
+	cancelLoad     context.CancelFunc
+}
+
+// NewTags creates a new tags panel
+func NewTags() TagsModel {
+	s := spinner.New()
+	s.Spinner = styles.Spinner
+	s.Style = lipgloss.NewStyle().Foreground(styles.Secondary)
+
+	return TagsModel{
+		editor:  NewTagEditor(),
+		spinner: s,
+	}
+}
+
+// Init initializes the tags panel
+func (m TagsModel) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+// Load starts loading the tags of param
+func (m *TagsModel) Load(client *aws.Client, param *aws.Parameter) tea.Cmd {
+	if m.cancelLoad != nil {
+		m.cancelLoad()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelLoad = cancel
+	m.client = client
+	m.parameter = param
+	m.original = nil
+	m.editor.SetTags(nil)
+	m.loading = true
+	m.saving = false
+	m.err = nil
+	m.status = ""
+
+	return tea.Batch(
+		m.spinner.Tick,
+		func() tea.Msg {
+			tags, err := client.ListTags(ctx, param.Name)
+			if err != nil {
+				return types.ErrorMsg{Err: err}
+			}
+			return types.TagsLoadedMsg{Tags: tags}
+		},
+	)
+}
+
+// Editing reports whether a tag is being typed, so esc cancels the tag
+// instead of leaving the panel
+func (m TagsModel) Editing() bool {
+	return m.editor.Editing()
+}
+
+// Dirty reports whether the edited tags differ from the saved ones
+func (m TagsModel) Dirty() bool {
+	add, remove := aws.DiffTags(m.original, m.editor.Tags())
+	return len(add) > 0 || len(remove) > 0
+}
+
+// Update handles messages for the tags panel
+func (m TagsModel) Update(msg tea.Msg) (TagsModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case types.TagsLoadedMsg:
+		m.loading = false
+		m.original = msg.Tags
+		m.editor.SetTags(msg.Tags)
+		return m, nil
+
+	case types.TagsSavedMsg:
+		m.saving = false
+		add, _ := aws.DiffTags(m.original, msg.Tags)
+		m.original = msg.Tags
+		m.status = "Tags saved"
+		if len(add) > 0 {
+			return m, performed(types.RepeatableAction{Kind: types.ActionAddTags, Tags: add})
+		}
+		return m, nil
+
+	case types.ErrorMsg:
+		m.loading = false
+		m.saving = false
+		m.err = msg.Err
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading || m.saving {
+			return m, nil
+		}
+
+		if !m.editor.Editing() {
+			switch msg.String() {
+			case "esc":
+				if m.cancelLoad != nil {
+					m.cancelLoad()
+				}
+				return m, func() tea.Msg { return types.BackMsg{} }
+			case "q", "ctrl+c":
+				return m, tea.Quit
+			case "ctrl+s":
+				if !m.Dirty() {
+					m.status = "No changes to save"
+					return m, nil
+				}
+				return m, m.save()
+			}
+		}
+
+		m.status = ""
+		m.err = nil
+		var cmd tea.Cmd
+		m.editor, cmd = m.editor.Update(msg)
+		return m, cmd
+	}
+
+	if m.loading || m.saving {
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+// save sends the tag changes to AWS
+func (m *TagsModel) save() tea.Cmd {
+	m.saving = true
+	m.err = nil
+
+	client := m.client
+	name := m.parameter.Name
+	old := m.original
+	updated := m.editor.Tags()
+
+	return tea.Batch(
+		m.spinner.Tick,
+		func() tea.Msg {
+			if err := client.UpdateTags(context.Background(), name, old, updated); err != nil {
+				return types.ErrorMsg{Err: err}
+			}
+			return types.TagsSavedMsg{Tags: updated}
+		},
+	)
+}
+
+// View renders the tags panel
+func (m TagsModel) View() string {
+	if m.loading {
+		return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), progressText("Loading tags..."))
+	}
+	if m.saving {
+		return fmt.Sprintf("\n  %s %s\n", m.spinner.View(), progressText("Saving tags..."))
+	}
+
+	var b strings.Builder
+
+	profile := m.currentProfile
+	region := m.currentRegion
+	if profile == "" {
+		profile = "-"
+	}
+	if region == "" {
+		region = "-"
+	}
+	name := "-"
+	if m.parameter != nil {
+		name = m.parameter.Name
+	}
+	title := fmt.Sprintf("%s : %s : %s : Tags (%d)", profile, region, name, len(m.editor.Tags()))
+	b.WriteString("  " + styles.TitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	if m.err != nil {
+		b.WriteString("  " + styles.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(m.editor.View(true))
+	b.WriteString("\n")
+
+	if m.status != "" {
+		b.WriteString("  " + styles.SuccessStyle.Render(m.status))
+		b.WriteString("\n")
+	} else if m.Dirty() {
+		b.WriteString("  " + styles.WarningStyle.Render("Unsaved changes"))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("  " + styles.HelpStyle.Render("ctrl+s: save tags • esc: back • q: quit"))
+
+	return b.String()
+}
+
+// SetContext sets the profile and region context for the tags panel
+func (m *TagsModel) SetContext(profile, region string) {
+	m.currentProfile = profile
+	m.currentRegion = region
+}
+
+// SetSize updates the dimensions of the tags panel
+func (m *TagsModel) SetSize(width, height int) {
+}
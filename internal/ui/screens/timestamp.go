@@ -0,0 +1,67 @@
+package screens
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultTimeLayout is used for absolute timestamps when no layout is configured
+const DefaultTimeLayout = "2006-01-02 15:04"
+
+// TimestampFormat controls how modification times are rendered
+type TimestampFormat struct {
+	Absolute bool           // Show absolute times instead of "3 days ago"
+	Layout   string         // Go time layout for absolute times
+	Location *time.Location // Time zone for absolute times (nil means local)
+}
+
+// Format renders t relative to now, or absolute when toggled
+func (f TimestampFormat) Format(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	if !f.Absolute {
+		return relativeTime(t, time.Now())
+	}
+
+	layout := f.Layout
+	if layout == "" {
+		layout = DefaultTimeLayout
+	}
+	loc := f.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	return t.In(loc).Format(layout)
+}
+
+// relativeTime describes how long before now t happened, e.g. "3 days ago"
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	if d < 0 {
+		return "in the future"
+	}
+
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return plural(int(d/time.Minute), "minute")
+	case d < 24*time.Hour:
+		return plural(int(d/time.Hour), "hour")
+	case d < 30*24*time.Hour:
+		return plural(int(d/(24*time.Hour)), "day")
+	case d < 365*24*time.Hour:
+		return plural(int(d/(30*24*time.Hour)), "month")
+	default:
+		return plural(int(d/(365*24*time.Hour)), "year")
+	}
+}
+
+// plural formats "1 day ago" / "3 days ago"
+func plural(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s ago", unit)
+	}
+	return fmt.Sprintf("%d %ss ago", n, unit)
+}
@@ -0,0 +1,45 @@
+package screens
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{10 * time.Second, "just now"},
+		{time.Minute, "1 minute ago"},
+		{5 * time.Hour, "5 hours ago"},
+		{3 * 24 * time.Hour, "3 days ago"},
+		{65 * 24 * time.Hour, "2 months ago"},
+		{800 * 24 * time.Hour, "2 years ago"},
+	}
+	for _, tt := range tests {
+		if got := relativeTime(now.Add(-tt.ago), now); got != tt.want {
+			t.Errorf("relativeTime(-%v) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+}
+
+func TestTimestampFormat_Absolute(t *testing.T) {
+	ts := time.Date(2024, 6, 15, 12, 30, 0, 0, time.UTC)
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	f := TimestampFormat{Absolute: true, Location: tokyo}
+	if got := f.Format(ts); got != "2024-06-15 21:30" {
+		t.Errorf("default layout = %q", got)
+	}
+
+	f.Layout = time.RFC3339
+	if got := f.Format(ts); got != "2024-06-15T21:30:00+09:00" {
+		t.Errorf("custom layout = %q", got)
+	}
+
+	if got := f.Format(time.Time{}); got != "" {
+		t.Errorf("zero time should render empty, got %q", got)
+	}
+}
@@ -0,0 +1,580 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/export"
+	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+// treeNode is a path segment (directory) or a parameter in the hierarchy
+type treeNode struct {
+	name     string         // Last path segment
+	path     string         // Full path; directories end with "/"
+	param    *aws.Parameter // nil for directories
+	children []*treeNode
+	depth    int
+}
+
+func (n *treeNode) isDir() bool { return n.param == nil }
+
+// child returns the directory child named name, creating it if needed
+func (n *treeNode) child(name string) *treeNode {
+	for _, c := range n.children {
+		if c.isDir() && c.name == name {
+			return c
+		}
+	}
+	c := &treeNode{name: name, path: n.path + name + "/", depth: n.depth + 1}
+	n.children = append(n.children, c)
+	return c
+}
+
+// sortTree orders directories before parameters, each alphabetically
+func sortTree(n *treeNode) {
+	sort.Slice(n.children, func(i, j int) bool {
+		a, b := n.children[i], n.children[j]
+		if a.isDir() != b.isDir() {
+			return a.isDir()
+		}
+		return a.name < b.name
+	})
+	for _, c := range n.children {
+		sortTree(c)
+	}
+}
+
+// buildTree arranges parameters by their slash-separated path segments
+func buildTree(params []*aws.Parameter) *treeNode {
+	root := &treeNode{path: "/", depth: -1}
+	for _, p := range params {
+		segments := strings.Split(strings.TrimPrefix(p.Name, "/"), "/")
+		dir := root
+		for _, seg := range segments[:len(segments)-1] {
+			dir = dir.child(seg)
+		}
+		dir.children = append(dir.children, &treeNode{
+			name:  segments[len(segments)-1],
+			path:  p.Name,
+			param: p,
+			depth: dir.depth + 1,
+		})
+	}
+	sortTree(root)
+	return root
+}
+
+// countParams returns the number of parameters below n
+func countParams(n *treeNode) int {
+	if !n.isDir() {
+		return 1
+	}
+	total := 0
+	for _, c := range n.children {
+		total += countParams(c)
+	}
+	return total
+}
+
+// treeItem is a visible row in the tree
+type treeItem struct {
+	node     *treeNode
+	expanded bool
+}
+
+func (i treeItem) FilterValue() string { return i.node.path }
+
+type treeDelegate struct{}
+
+func (d treeDelegate) Height() int                             { return 1 }
+func (d treeDelegate) Spacing() int                            { return 0 }
+func (d treeDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+func (d treeDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(treeItem)
+	if !ok {
+		return
+	}
+
+	indent := strings.Repeat("  ", i.node.depth)
+	var str string
+	if i.node.isDir() {
+		marker := styles.Cursor
+		if i.expanded {
+			marker = styles.Expanded
+		}
+		str = fmt.Sprintf("%s%s %s/ (%d)", indent, marker, i.node.name, countParams(i.node))
+	} else {
+		str = indent + "  " + typeBadge(i.node.param.Type) + " " + i.node.name
+	}
+
+	if index == m.Index() {
+		str = lipgloss.NewStyle().
+			Foreground(styles.Primary).
+			Bold(true).
+			Render(styles.Cursor + " " + str)
+	} else {
+		str = lipgloss.NewStyle().
+			PaddingLeft(2).
+			Render(str)
+	}
+
+	fmt.Fprint(w, str)
+}
+
+// TreeModel represents the hierarchical parameter browser
+type TreeModel struct {
+	root           *treeNode
+	expanded       map[string]bool // Expanded directory paths, kept across reloads
+	list           list.Model
+	currentProfile string
+	currentRegion  string
+	// Subtree browsed with GetParametersByPath instead of the listing, if any
+	browsePath string
+	listed     []*aws.Parameter // Parameters of the listing, shown again when browsing ends
+	client     *aws.Client
+	pathInput  textinput.Model
+	prompting  bool
+	loading    bool
+}
+
+// treePathLoadedMsg carries the parameters found under a browsed path
+type treePathLoadedMsg struct {
+	Path   string
+	Params []*aws.Parameter
+	Err    error
+}
+
+// NewTree creates a new hierarchical browser screen
+func NewTree() TreeModel {
+	const defaultWidth = 80
+	const defaultHeight = 20
+
+	l := list.New([]list.Item{}, treeDelegate{}, defaultWidth, defaultHeight)
+	l.Title = "Tree"
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+	l.Styles.Title = styles.TitleStyle
+	l.Styles.PaginationStyle = list.DefaultStyles().PaginationStyle.PaddingLeft(4)
+
+	pathInput := textinput.New()
+	pathInput.Placeholder = "/app/prod/ (empty for the whole listing)"
+	pathInput.CharLimit = 2048
+	pathInput.Width = 60
+
+	return TreeModel{
+		root:      buildTree(nil),
+		expanded:  make(map[string]bool),
+		list:      l,
+		pathInput: pathInput,
+	}
+}
+
+// Init initializes the tree screen
+func (m TreeModel) Init() tea.Cmd {
+	return nil
+}
+
+// Load rebuilds the tree from params, keeping expanded directories open.
+// While a path is browsed, params are kept for when browsing ends.
+func (m *TreeModel) Load(params []*aws.Parameter) {
+	m.listed = params
+	if m.browsePath != "" {
+		return
+	}
+	m.root = buildTree(params)
+	m.refresh()
+	m.updateTitle()
+}
+
+// SetClient sets the client paths are browsed with
+func (m *TreeModel) SetClient(client *aws.Client) {
+	m.client = client
+}
+
+// Prompting reports whether the path prompt is open, so esc closes it
+// instead of leaving
+func (m TreeModel) Prompting() bool {
+	return m.prompting
+}
+
+// browse loads the parameters under path with GetParametersByPath, which
+// only reads that subtree instead of the whole account
+func (m *TreeModel) browse(path string) tea.Cmd {
+	m.loading = true
+	client := m.client
+	return func() tea.Msg {
+		params, err := client.GetParametersByPath(context.Background(), path, true)
+		return treePathLoadedMsg{Path: path, Params: params, Err: err}
+	}
+}
+
+// updatePrompt handles keys while the path prompt is open
+func (m TreeModel) updatePrompt(msg tea.KeyMsg) (TreeModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.prompting = false
+		m.pathInput.Blur()
+		return m, nil
+	case "ctrl+c":
+		return m, tea.Quit
+	case "enter":
+		m.prompting = false
+		m.pathInput.Blur()
+		path := strings.TrimSpace(m.pathInput.Value())
+		if path == "" {
+			// Back to the tree of the listing
+			m.browsePath = ""
+			m.Load(m.listed)
+			return m, nil
+		}
+		if !strings.HasPrefix(path, "/") {
+			return m, m.list.NewStatusMessage(styles.ErrorStyle.Render("Paths start with /"))
+		}
+		if m.client == nil {
+			return m, nil
+		}
+		return m, m.browse(path)
+	}
+	var cmd tea.Cmd
+	m.pathInput, cmd = m.pathInput.Update(msg)
+	return m, cmd
+}
+
+// refresh rebuilds the visible rows from the expansion state
+func (m *TreeModel) refresh() {
+	var items []list.Item
+	var walk func(n *treeNode)
+	walk = func(n *treeNode) {
+		for _, c := range n.children {
+			open := c.isDir() && m.expanded[c.path]
+			items = append(items, treeItem{node: c, expanded: open})
+			if open {
+				walk(c)
+			}
+		}
+	}
+	walk(m.root)
+
+	index := m.list.Index()
+	m.list.SetItems(items)
+	if index >= len(items) {
+		index = len(items) - 1
+	}
+	if index >= 0 {
+		m.list.Select(index)
+	}
+}
+
+// selected returns the node under the cursor, or nil
+func (m TreeModel) selected() *treeNode {
+	if item, ok := m.list.SelectedItem().(treeItem); ok {
+		return item.node
+	}
+	return nil
+}
+
+// selectPath moves the cursor to the visible row for path
+func (m *TreeModel) selectPath(path string) {
+	for i, item := range m.list.Items() {
+		if item.(treeItem).node.path == path {
+			m.list.Select(i)
+			return
+		}
+	}
+}
+
+// SelectedPrefix is the path new parameters are created under: the selected
+// directory, or the directory containing the selected parameter
+func (m TreeModel) SelectedPrefix() string {
+	n := m.selected()
+	if n == nil {
+		return "/"
+	}
+	if n.isDir() {
+		return n.path
+	}
+	if i := strings.LastIndex(n.path, "/"); i >= 0 {
+		return n.path[:i+1]
+	}
+	return ""
+}
+
+// SelectedParams returns the parameters below the selected directory, or the
+// selected parameter, in tree order
+func (m TreeModel) SelectedParams() []*aws.Parameter {
+	var params []*aws.Parameter
+	var walk func(n *treeNode)
+	walk = func(n *treeNode) {
+		if !n.isDir() {
+			params = append(params, n.param)
+			return
+		}
+		for _, c := range n.children {
+			walk(c)
+		}
+	}
+	if n := m.selected(); n != nil {
+		walk(n)
+	}
+	return params
+}
+
+// Update handles messages for the tree screen
+func (m TreeModel) Update(msg tea.Msg) (TreeModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+		return m, nil
+
+	case types.MirroredMsg:
+		status := msg.Status
+		if msg.Err != nil {
+			status = styles.ErrorStyle.Render(fmt.Sprintf("Git mirror failed: %v", msg.Err))
+		}
+		if status == "" {
+			return m, nil
+		}
+		return m, m.list.NewStatusMessage(status)
+
+	case treePathLoadedMsg:
+		m.loading = false
+		if msg.Err != nil {
+			return m, m.list.NewStatusMessage(styles.ErrorStyle.Render(fmt.Sprintf("Error: %v", msg.Err)))
+		}
+		m.browsePath = msg.Path
+		m.root = buildTree(msg.Params)
+		// Open the directories down to the browsed path
+		dir := "/"
+		for _, seg := range strings.Split(strings.Trim(msg.Path, "/"), "/") {
+			if seg == "" {
+				continue
+			}
+			dir += seg + "/"
+			m.expanded[dir] = true
+		}
+		m.refresh()
+		m.selectPath(dir)
+		m.updateTitle()
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading {
+			// Only quitting is possible until the path has loaded
+			if k := msg.String(); k == "q" || k == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+		if m.prompting {
+			return m.updatePrompt(msg)
+		}
+
+		switch msg.String() {
+		case "p":
+			// Browse a path with GetParametersByPath, starting from the selection
+			m.prompting = true
+			path := m.browsePath
+			if m.selected() != nil {
+				path = m.SelectedPrefix()
+			}
+			m.pathInput.SetValue(path)
+			m.pathInput.CursorEnd()
+			return m, m.pathInput.Focus()
+		case "esc", "H":
+			return m, func() tea.Msg { return types.BackMsg{} }
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "enter", "right", "l":
+			n := m.selected()
+			if n == nil {
+				return m, nil
+			}
+			if !n.isDir() {
+				return m, func() tea.Msg { return types.ViewParameterMsg{Parameter: n.param} }
+			}
+			if msg.String() == "enter" {
+				m.expanded[n.path] = !m.expanded[n.path]
+			} else {
+				m.expanded[n.path] = true
+			}
+			m.refresh()
+			return m, nil
+		case "left", "h":
+			// Collapse the selected directory, or jump to the parent
+			n := m.selected()
+			if n == nil {
+				return m, nil
+			}
+			if n.isDir() && m.expanded[n.path] {
+				m.expanded[n.path] = false
+				m.refresh()
+				return m, nil
+			}
+			parent := strings.TrimSuffix(n.path, "/")
+			if i := strings.LastIndex(parent, "/"); i > 0 {
+				m.selectPath(parent[:i+1])
+			}
+			return m, nil
+		case "n":
+			// New parameter under the selected path
+			prefix := m.SelectedPrefix()
+			return m, func() tea.Msg { return types.CreateParameterMsg{Prefix: prefix} }
+		case "N":
+			// Get notified of changes to the selected parameter or subtree
+			n := m.selected()
+			if n == nil {
+				return m, nil
+			}
+			if n.isDir() {
+				prefix := m.SelectedPrefix()
+				return m, func() tea.Msg { return types.NotifyChangesMsg{Name: prefix, Prefix: true} }
+			}
+			name := n.param.Name
+			return m, func() tea.Msg { return types.NotifyChangesMsg{Name: name} }
+		case "a":
+			// Audit the selected subtree for blank and placeholder values
+			if params := m.SelectedParams(); len(params) > 0 {
+				prefix := m.SelectedPrefix()
+				return m, func() tea.Msg {
+					return types.ShowReportMsg{Kind: types.ReportPlaceholders, Parameters: params, Scope: prefix}
+				}
+			}
+			return m, nil
+		case "O":
+			// List the parameters of the selected subtree nobody changed in a long time
+			if params := m.SelectedParams(); len(params) > 0 {
+				prefix := m.SelectedPrefix()
+				return m, func() tea.Msg {
+					return types.ShowReportMsg{Kind: types.ReportStale, Parameters: params, Scope: prefix}
+				}
+			}
+			return m, nil
+		case "!":
+			// Open a shell with the selected subtree exported
+			if params := m.SelectedParams(); len(params) > 0 {
+				return m, func() tea.Msg { return types.SubshellMsg{Parameters: params} }
+			}
+			return m, nil
+		case "G":
+			// Mirror the selected subtree into the git mirror, one file per parameter.
+			// Directories only, since a sync removes files of other parameters.
+			if n := m.selected(); n != nil && n.isDir() {
+				params := m.SelectedParams()
+				prefix := m.SelectedPrefix()
+				return m, tea.Batch(
+					m.list.NewStatusMessage("Mirroring "+prefix+"..."),
+					func() tea.Msg { return types.MirrorSubtreeMsg{Prefix: prefix, Parameters: params} },
+				)
+			}
+			return m, nil
+		case "M":
+			// Move the selected subtree to another prefix
+			if n := m.selected(); n != nil && n.isDir() {
+				params := m.SelectedParams()
+				prefix := m.SelectedPrefix()
+				return m, func() tea.Msg { return types.MoveSubtreeMsg{Prefix: prefix, Parameters: params} }
+			}
+			return m, nil
+		case "C":
+			// Copy the selected subtree to another prefix or context
+			if n := m.selected(); n != nil && n.isDir() {
+				params := m.SelectedParams()
+				prefix := m.SelectedPrefix()
+				return m, func() tea.Msg { return types.CopySubtreeMsg{Prefix: prefix, Parameters: params} }
+			}
+			return m, nil
+		case "D":
+			// Delete everything under the selected directory
+			if n := m.selected(); n != nil && n.isDir() {
+				params := m.SelectedParams()
+				prefix := m.SelectedPrefix()
+				return m, func() tea.Msg { return types.DeleteSubtreeMsg{Prefix: prefix, Parameters: params} }
+			}
+			return m, nil
+		case "x":
+			// Document the selected subtree, defaulting to Markdown
+			if params := m.SelectedParams(); len(params) > 0 {
+				return m, func() tea.Msg {
+					return types.ExportParametersMsg{Parameters: params, Format: string(export.FormatMarkdown)}
+				}
+			}
+			return m, nil
+		case "X":
+			// Back up the selected subtree with its metadata
+			if params := m.SelectedParams(); len(params) > 0 {
+				return m, func() tea.Msg {
+					return types.ExportParametersMsg{Parameters: params, Format: string(export.FormatBackup)}
+				}
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+// View renders the tree screen
+func (m TreeModel) View() string {
+	var b strings.Builder
+	b.WriteString(m.list.View())
+	b.WriteString("\n")
+	if m.loading {
+		b.WriteString(progressText("Loading parameters by path..."))
+		return b.String()
+	}
+	if m.prompting {
+		b.WriteString(styles.LabelStyle.Render("Browse path: "))
+		b.WriteString(m.pathInput.View())
+		b.WriteString("\n" + styles.HelpStyle.Render("enter: load the subtree • empty: whole listing • esc: cancel"))
+		return b.String()
+	}
+	b.WriteString(styles.HelpStyle.Render("↑/↓: navigate • enter: expand/view • ←/→: collapse/expand • p: browse a path • n: new parameter here • x: document subtree • X: back up subtree • !: subshell • a: audit placeholders • O: stale • G: git mirror • M: move subtree • C: copy subtree • D: delete subtree • N: notify on changes • H/esc: flat list • q: quit"))
+	return b.String()
+}
+
+// updateTitle updates the list title with profile, region and parameter count
+func (m *TreeModel) updateTitle() {
+	profile := m.currentProfile
+	region := m.currentRegion
+	if profile == "" {
+		profile = "-"
+	}
+	if region == "" {
+		region = "-"
+	}
+	if m.browsePath != "" {
+		m.list.Title = fmt.Sprintf("%s : %s : Tree of %s (%d)", profile, region, m.browsePath, countParams(m.root))
+		return
+	}
+	m.list.Title = fmt.Sprintf("%s : %s : Tree (%d)", profile, region, countParams(m.root))
+}
+
+// SetContext sets the profile and region context for the tree screen
+func (m *TreeModel) SetContext(profile, region string) {
+	if profile != m.currentProfile || region != m.currentRegion {
+		m.expanded = make(map[string]bool)
+		m.browsePath = ""
+	}
+	m.currentProfile = profile
+	m.currentRegion = region
+	m.updateTitle()
+}
+
+// SetSize updates the dimensions of the tree screen
+func (m *TreeModel) SetSize(width, height int) {
+	m.list.SetWidth(width)
+	m.list.SetHeight(height - 3)
+}
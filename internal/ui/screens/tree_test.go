@@ -0,0 +1,135 @@
+package screens
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+func treeParams() []*aws.Parameter {
+	return []*aws.Parameter{
+		{Name: "/app/prod/db/host", Type: "String"},
+		{Name: "/app/prod/db/password", Type: "SecureString"},
+		{Name: "/app/staging/db/host", Type: "String"},
+		{Name: "standalone", Type: "String"},
+	}
+}
+
+func TestTree_ExpandAndCollapse(t *testing.T) {
+	m := NewTree()
+	m.Load(treeParams())
+
+	// Top level: the /app directory, then the root-level parameter
+	if got := len(m.list.Items()); got != 2 {
+		t.Fatalf("expected 2 top-level rows, got %d", got)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := len(m.list.Items()); got != 4 {
+		t.Fatalf("expected /app expanded to prod and staging, got %d rows", got)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if got := len(m.list.Items()); got != 2 {
+		t.Fatalf("expected /app collapsed again, got %d rows", got)
+	}
+}
+
+func TestTree_NewParameterHerePrefillsPrefix(t *testing.T) {
+	m := NewTree()
+	m.Load(treeParams())
+	m.expanded["/app/"] = true
+	m.expanded["/app/prod/"] = true
+	m.expanded["/app/prod/db/"] = true
+	m.refresh()
+
+	m.selectPath("/app/prod/db/")
+	if got := m.SelectedPrefix(); got != "/app/prod/db/" {
+		t.Fatalf("directory prefix = %q", got)
+	}
+
+	m.selectPath("/app/prod/db/password")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if cmd == nil {
+		t.Fatal("expected create command")
+	}
+	msg, ok := cmd().(types.CreateParameterMsg)
+	if !ok || msg.Prefix != "/app/prod/db/" {
+		t.Fatalf("expected CreateParameterMsg with parent prefix, got %+v", msg)
+	}
+}
+
+func TestTree_ExportSubtree(t *testing.T) {
+	m := NewTree()
+	m.Load(treeParams())
+	m.expanded["/app/"] = true
+	m.refresh()
+
+	m.selectPath("/app/prod/")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if cmd == nil {
+		t.Fatal("expected export command")
+	}
+	msg, ok := cmd().(types.ExportParametersMsg)
+	if !ok || len(msg.Parameters) != 2 || msg.Format != "markdown" {
+		t.Fatalf("expected the two /app/prod/ parameters as markdown, got %+v", msg)
+	}
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}})
+	if msg, ok := cmd().(types.ExportParametersMsg); !ok || len(msg.Parameters) != 2 || msg.Format != "backup" {
+		t.Fatalf("expected the two /app/prod/ parameters as a backup, got %+v", msg)
+	}
+}
+
+func TestTree_BrowsePath(t *testing.T) {
+	client := aws.NewDemoClient("tree-test", "eu-west-1")
+	want, err := client.GetParametersByPath(context.Background(), "/api", true)
+	if err != nil || len(want) == 0 {
+		t.Fatalf("expected demo parameters under /api, got %d (%v)", len(want), err)
+	}
+
+	m := NewTree()
+	m.SetClient(client)
+	m.SetContext("tree-test", "eu-west-1")
+	m.Load(treeParams())
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	if !m.Prompting() {
+		t.Fatal("expected the path prompt to open")
+	}
+	m.pathInput.SetValue("/api")
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a load command")
+	}
+	// Quitting still works while the path loads
+	if _, quit := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}}); quit == nil {
+		t.Fatal("expected q to quit while loading")
+	} else if _, ok := quit().(tea.QuitMsg); !ok {
+		t.Fatal("expected a quit command")
+	}
+	m, _ = m.Update(cmd())
+	if got := countParams(m.root); got != len(want) {
+		t.Fatalf("expected the %d parameters under /api, got %d", len(want), got)
+	}
+	if !m.expanded["/api/"] || !strings.Contains(m.list.Title, "Tree of /api") {
+		t.Fatalf("expected /api/ open and titled, got %q", m.list.Title)
+	}
+
+	// A refreshed listing waits until browsing ends
+	m.Load(treeParams())
+	if got := countParams(m.root); got != len(want) {
+		t.Fatalf("expected the browsed subtree kept, got %d", got)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	m.pathInput.SetValue("")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := countParams(m.root); got != len(treeParams()) {
+		t.Fatalf("expected the listing back, got %d parameters", got)
+	}
+}
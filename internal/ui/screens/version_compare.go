@@ -0,0 +1,194 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/types"
+)
+
+// VersionCompareModel shows two parameter versions in adjacent panes with synchronized scrolling
+type VersionCompareModel struct {
+	older          *aws.Parameter
+	newer          *aws.Parameter
+	left           viewport.Model
+	right          viewport.Model
+	currentProfile string
+	currentRegion  string
+}
+
+// NewVersionCompare creates a new side-by-side compare screen
+func NewVersionCompare() VersionCompareModel {
+	return VersionCompareModel{
+		left:  viewport.New(38, 20),
+		right: viewport.New(38, 20),
+	}
+}
+
+// Init initializes the compare screen
+func (m VersionCompareModel) Init() tea.Cmd {
+	return nil
+}
+
+// Load sets the two versions to compare
+func (m *VersionCompareModel) Load(older, newer *aws.Parameter) {
+	m.older = older
+	m.newer = newer
+	m.refresh()
+	m.left.GotoTop()
+	m.right.GotoTop()
+}
+
+// Update handles messages for the compare screen
+func (m VersionCompareModel) Update(msg tea.Msg) (VersionCompareModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg { return types.BackMsg{} }
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+
+	// Scroll the left pane and mirror its offset on the right
+	var cmd tea.Cmd
+	m.left, cmd = m.left.Update(msg)
+	m.right.SetYOffset(m.left.YOffset)
+	return m, cmd
+}
+
+// View renders the compare screen
+func (m VersionCompareModel) View() string {
+	if m.older == nil || m.newer == nil {
+		return "No versions selected"
+	}
+
+	var b strings.Builder
+
+	profile := m.currentProfile
+	region := m.currentRegion
+	if profile == "" {
+		profile = "-"
+	}
+	if region == "" {
+		region = "-"
+	}
+	title := fmt.Sprintf("%s : %s : %s : v%d ↔ v%d", profile, region, m.newer.Name, m.older.Version, m.newer.Version)
+	b.WriteString("  " + styles.TitleStyle.Render(title))
+	b.WriteString("\n")
+
+	header := func(p *aws.Parameter) string {
+		return styles.LabelStyle.Width(m.left.Width).Render(fmt.Sprintf("v%d (%s, %s)",
+			p.Version, p.Type, p.LastModifiedDate.Local().Format("2006-01-02 15:04")))
+	}
+	b.WriteString("  " + lipgloss.JoinHorizontal(lipgloss.Top, header(m.older), " │ ", header(m.newer)))
+	b.WriteString("\n")
+
+	sep := strings.TrimSuffix(strings.Repeat(" │ \n", m.left.Height), "\n")
+	b.WriteString("  " + lipgloss.JoinHorizontal(lipgloss.Top, m.left.View(), sep, m.right.View()))
+	b.WriteString("\n\n")
+	b.WriteString("  " + styles.HelpStyle.Render("↑/↓/pgup/pgdn: scroll both panes • esc: back • q: quit"))
+
+	return b.String()
+}
+
+// refresh aligns both values line by line and fills the panes
+func (m *VersionCompareModel) refresh() {
+	if m.older == nil || m.newer == nil {
+		return
+	}
+	left, right := sideBySideLines(diffLines(m.older.Value, m.newer.Value))
+
+	removed := lipgloss.NewStyle().Foreground(styles.Error)
+	added := lipgloss.NewStyle().Foreground(styles.Success)
+	render := func(lines []diffLine, style lipgloss.Style, width int) string {
+		out := make([]string, len(lines))
+		for i, l := range lines {
+			text := truncateToWidth(l.text, width)
+			if l.op != ' ' {
+				text = style.Render(text)
+			}
+			out[i] = text
+		}
+		return strings.Join(out, "\n")
+	}
+
+	m.left.SetContent(render(left, removed, m.left.Width))
+	m.right.SetContent(render(right, added, m.right.Width))
+}
+
+// sideBySideLines splits a diff into aligned left/right rows, pairing removed
+// and added runs and padding the shorter side with blank rows
+func sideBySideLines(diff []diffLine) (left, right []diffLine) {
+	var removed, added []diffLine
+	flush := func() {
+		n := max(len(removed), len(added))
+		for i := 0; i < n; i++ {
+			l := diffLine{op: ' '}
+			r := diffLine{op: ' '}
+			if i < len(removed) {
+				l = removed[i]
+			}
+			if i < len(added) {
+				r = added[i]
+			}
+			left = append(left, l)
+			right = append(right, r)
+		}
+		removed, added = nil, nil
+	}
+
+	for _, d := range diff {
+		switch d.op {
+		case '-':
+			removed = append(removed, d)
+		case '+':
+			added = append(added, d)
+		default:
+			flush()
+			left = append(left, d)
+			right = append(right, d)
+		}
+	}
+	flush()
+
+	return left, right
+}
+
+// truncateToWidth cuts s to at most width display cells, marking the cut with …
+func truncateToWidth(s string, width int) string {
+	if width <= 0 || lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+// SetContext sets the profile and region context for the compare screen
+func (m *VersionCompareModel) SetContext(profile, region string) {
+	m.currentProfile = profile
+	m.currentRegion = region
+}
+
+// SetSize updates the dimensions of both panes
+func (m *VersionCompareModel) SetSize(width, height int) {
+	paneWidth := (width - 7) / 2
+	m.left.Width = paneWidth
+	m.right.Width = paneWidth
+	m.left.Height = height - 6
+	m.right.Height = height - 6
+	m.refresh()
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ilia/ps9s/internal/config"
+	"github.com/ilia/ps9s/internal/ui/screens"
+)
+
+// ErrSetupCancelled is returned by RunSetup when the wizard is quit with ctrl+c
+var ErrSetupCancelled = errors.New("setup cancelled")
+
+// setupProgram runs the setup wizard as a program of its own, before the
+// main model exists
+type setupProgram struct {
+	wizard screens.SetupModel
+}
+
+func (p setupProgram) Init() tea.Cmd {
+	return p.wizard.Init()
+}
+
+func (p setupProgram) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		p.wizard.SetSize(msg.Width, msg.Height)
+		return p, nil
+	}
+	var cmd tea.Cmd
+	p.wizard, cmd = p.wizard.Update(msg)
+	return p, cmd
+}
+
+func (p setupProgram) View() string {
+	return p.wizard.View()
+}
+
+// RunSetup runs the first-run wizard over profiles and saves the chosen
+// profiles and default regions. Skipping it still writes config.json, so it
+// is not offered again.
+func RunSetup(profiles []string, opts ...tea.ProgramOption) error {
+	mapping, err := config.LoadRegionMapping()
+	if err != nil {
+		mapping = &config.RegionMapping{ProfileRegions: make(map[string]string)}
+	}
+	known := make(map[string]string, len(profiles))
+	for _, p := range profiles {
+		if r := mapping.ProfileRegions[p]; r != "" {
+			known[p] = r
+		} else if r, _ := config.GetProfileRegion(p); r != "" {
+			known[p] = r
+		}
+	}
+	path, err := config.SettingsPath()
+	if err != nil {
+		return err
+	}
+
+	final, err := tea.NewProgram(setupProgram{wizard: screens.NewSetup(profiles, known, path)}, opts...).Run()
+	if err != nil {
+		return err
+	}
+	wizard := final.(setupProgram).wizard
+	if !wizard.Done() {
+		return ErrSetupCancelled
+	}
+
+	result := wizard.Result()
+	if err := config.UpdateSettings(func(s *config.Settings) {
+		if result.Skipped {
+			return
+		}
+		s.Profiles = result.Profiles
+		s.SkipRegionSelector = result.SkipRegionSelector
+	}); err != nil {
+		return err
+	}
+	if result.Skipped {
+		return nil
+	}
+	for profile, region := range result.Regions {
+		mapping.ProfileRegions[profile] = region
+	}
+	return config.SaveRegionMapping(mapping)
+}
@@ -0,0 +1,202 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ilia/ps9s/internal/aws"
+	"github.com/ilia/ps9s/internal/config"
+	"github.com/ilia/ps9s/internal/styles"
+	"github.com/ilia/ps9s/internal/ui/screens"
+)
+
+// maxTabs caps the number of open contexts (alt+1..alt+9)
+const maxTabs = 9
+
+// contextTab is an open profile/region context with its own parameter list state.
+// The active tab's state lives in the root model fields; tabs[activeTab] is only
+// refreshed when switching away from it.
+type contextTab struct {
+	profile string
+	region  string
+	client  *aws.Client
+	list    screens.ParameterListModel
+}
+
+// newParameterList creates a parameter list screen with the shared root state applied
+func (m Model) newParameterList() screens.ParameterListModel {
+	pl := screens.NewParameterList()
+	pl.SetRecents(m.recents)
+	pl.SetDryRun(m.dryRun)
+	pl.SetPageSize(m.settings.ListPageSize)
+	pl.SetTimestampFormat(m.timestamps)
+	pl.SetDetailed(m.settings.ListMode == config.ListModeDetailed)
+	pl.SetReadOnly(m.settings.ReadOnly)
+	pl.SetShowValues(m.settings.ShowValues)
+	pl.SetColumns(m.settings.ListColumnNames())
+	pl.SetTerraform(m.terraform)
+	naming, _ := m.settings.NamingPattern()
+	pl.SetNaming(naming)
+	pl.SetSize(m.width, m.listHeight())
+	return pl
+}
+
+// saveActiveTab stores the live list state into the active tab
+func (m *Model) saveActiveTab() {
+	if m.activeTab >= len(m.tabs) {
+		return
+	}
+	m.tabs[m.activeTab] = contextTab{
+		profile: m.currentProfile,
+		region:  m.currentRegion,
+		client:  m.awsClients[m.currentProfile],
+		list:    m.parameterList,
+	}
+}
+
+// activateTab saves the current tab and restores tab i into the live state
+func (m *Model) activateTab(i int) {
+	if i < 0 || i >= len(m.tabs) || i == m.activeTab {
+		return
+	}
+	m.saveActiveTab()
+	m.activeTab = i
+	m.restoreActiveTab()
+	m.parameterList.SetSize(m.width, m.listHeight())
+}
+
+// restoreActiveTab loads the active tab into the live state without saving
+// the live state first
+func (m *Model) restoreActiveTab() {
+	t := m.tabs[m.activeTab]
+	m.currentProfile = t.profile
+	m.currentRegion = t.region
+	m.parameterList = t.list
+	if t.client != nil {
+		m.awsClients = copyClientMap(m.awsClients, t.profile, t.client)
+	}
+}
+
+// cancelOpeningTab abandons a new tab before its context was picked and
+// returns to the tab it was requested from
+func (m *Model) cancelOpeningTab() {
+	m.openingTab = false
+	m.restoreActiveTab()
+	m.parameterList.SetSize(m.width, m.listHeight())
+	m.currentScreen = ParameterListScreen
+}
+
+// openTab appends a new empty tab and makes it active. The previous tab was
+// already saved when the new tab was requested, before its profile changed.
+func (m *Model) openTab() {
+	m.tabs = append(m.tabs, contextTab{})
+	m.activeTab = len(m.tabs) - 1
+	m.parameterList = m.newParameterList()
+	m.resizeTabs()
+}
+
+// closeActiveTab closes the active tab, keeping at least one open
+func (m *Model) closeActiveTab() {
+	if len(m.tabs) < 2 {
+		return
+	}
+	m.tabs = append(m.tabs[:m.activeTab], m.tabs[m.activeTab+1:]...)
+	if m.activeTab >= len(m.tabs) {
+		m.activeTab = len(m.tabs) - 1
+	}
+
+	// Restore directly: saving the closed tab's state over the neighbour must be avoided
+	m.restoreActiveTab()
+	m.resizeTabs()
+}
+
+// handleTabKey handles tab keys on the parameter list, reporting whether the key was used
+func (m *Model) handleTabKey(msg tea.KeyMsg) bool {
+	switch key := msg.String(); key {
+	case "ctrl+right":
+		if len(m.tabs) > 1 {
+			m.activateTab((m.activeTab + 1) % len(m.tabs))
+		}
+		return true
+	case "ctrl+left":
+		if len(m.tabs) > 1 {
+			m.activateTab((m.activeTab - 1 + len(m.tabs)) % len(m.tabs))
+		}
+		return true
+	case "T":
+		if len(m.tabs) >= maxTabs {
+			return true
+		}
+		m.saveActiveTab()
+		m.openingTab = true
+		m.currentScreen = ProfileSelectorScreen
+		return true
+	case "ctrl+w":
+		m.closeActiveTab()
+		return true
+	case "alt+1", "alt+2", "alt+3", "alt+4", "alt+5", "alt+6", "alt+7", "alt+8", "alt+9":
+		m.activateTab(int(key[len(key)-1] - '1'))
+		return true
+	}
+	return false
+}
+
+// routeToTab delivers a list result for profile/region to the inactive tab it
+// belongs to, reporting whether such a tab was found
+func (m *Model) routeToTab(profile, region string, msg tea.Msg) bool {
+	for i := range m.tabs {
+		if i == m.activeTab {
+			continue
+		}
+		if m.tabs[i].profile == profile && m.tabs[i].region == region {
+			m.tabs[i].list, _ = m.tabs[i].list.Update(msg)
+			return true
+		}
+	}
+	return false
+}
+
+// listHeight is the height available to the parameter list between the tab bar and API indicator
+func (m Model) listHeight() int {
+	h := m.screenHeight()
+	if len(m.tabs) > 1 {
+		h -= 2
+	}
+	return h
+}
+
+// resizeTabs reapplies list sizes after the tab bar appears or disappears
+func (m *Model) resizeTabs() {
+	m.parameterList.SetSize(m.width, m.listHeight())
+	for i := range m.tabs {
+		// The active tab's stored state is stale (or empty) until it is saved
+		if i != m.activeTab {
+			m.tabs[i].list.SetSize(m.width, m.listHeight())
+		}
+	}
+}
+
+// renderTabBar renders the open contexts, highlighting the active one
+func (m Model) renderTabBar() string {
+	active := lipgloss.NewStyle().Foreground(styles.Primary).Bold(true)
+	inactive := lipgloss.NewStyle().Foreground(styles.Subtle)
+
+	parts := make([]string, len(m.tabs))
+	for i, t := range m.tabs {
+		profile, region := t.profile, t.region
+		if i == m.activeTab {
+			profile, region = m.currentProfile, m.currentRegion
+		}
+		label := fmt.Sprintf(" %d %s : %s ", i+1, profile, region)
+		if i == m.activeTab {
+			parts[i] = active.Render("[" + label + "]")
+		} else {
+			parts[i] = inactive.Render(" " + label + " ")
+		}
+	}
+
+	return strings.Join(parts, "") + "\n" +
+		styles.HelpStyle.UnsetMarginTop().Render("ctrl+←/→ or alt+1-9: switch tab • T: new tab • ctrl+w: close tab")
+}
@@ -0,0 +1,18 @@
+package ui
+
+// windowTitle returns the terminal title: "ps9s", then the context and the
+// parameter being worked on, if any
+func (m Model) windowTitle() string {
+	if m.currentProfile == "" || m.currentRegion == "" ||
+		m.currentScreen == ProfileSelectorScreen || m.currentScreen == RegionSelectorScreen {
+		return "ps9s"
+	}
+	title := "ps9s: " + m.currentProfile + " : " + m.currentRegion
+	switch m.currentScreen {
+	case ParameterViewScreen, ParameterEditScreen, HistoryScreen, VersionCompareScreen, TagsScreen:
+		if p := m.parameterView.Parameter(); p != nil {
+			title += " : " + p.Name
+		}
+	}
+	return title
+}